@@ -21,6 +21,11 @@ import (
 
 // @host		localhost:8080
 // @BasePath	/api/v1
+
+// @securityDefinitions.apikey	BearerAuth
+// @in							header
+// @name						Authorization
+// @description				A JWT signed with AUTH_JWT_SECRET, as "Bearer <token>"
 func main() {
 	if err := cmd.RootCmd().Execute(); err != nil {
 		os.Exit(1)
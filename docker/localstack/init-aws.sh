@@ -4,6 +4,9 @@ echo "SQS setup start!"
 echo "Creating SQS Queue..."
 
 aws  --endpoint-url=http://localstack:4566  sqs create-queue --queue-name sample_queue
+aws  --endpoint-url=http://localstack:4566  sqs create-queue --queue-name moderation_queue
+aws  --endpoint-url=http://localstack:4566  sqs create-queue --queue-name mail_queue
+aws  --endpoint-url=http://localstack:4566  sqs create-queue --queue-name notification_queue
 
 echo 'queue created!'
 
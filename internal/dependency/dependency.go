@@ -3,34 +3,63 @@ package dependency
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/caarlos0/env/v10"
 
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/service"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/environment"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/aws"
+	fileRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/file/repository"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/migration"
 	mysqlRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/schema"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/redis"
 	redisRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/redis/repository"
+	smtpRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/smtp/repository"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/sqs"
 	sqsRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/sqs/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/audit"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/event"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/interactor"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/errorreport"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/token"
 )
 
 type Dependency struct {
-	Environment        *environment.Environment
-	HealthInteractor   interactor.HealthInteractor
-	UserInteractor     interactor.UserInteractor
-	MatchingInteractor interactor.MatchingInteractor
+	Environment              *environment.Environment
+	Clock                    clock.Clock
+	ErrorReporter            errorreport.Reporter
+	HealthInteractor         interactor.HealthInteractor
+	UserInteractor           port.UserUsecase
+	MatchingInteractor       port.MatchingUsecase
+	UserBlockInteractor      port.UserBlockUsecase
+	ReportInteractor         port.ReportUsecase
+	RecommendationInteractor interactor.RecommendationInteractor
+	MailInteractor           interactor.MailInteractor
+	OutboxInteractor         interactor.OutboxInteractor
+	AuditLogInteractor       interactor.AuditLogInteractor
+	ConversationInteractor   interactor.ConversationInteractor
+	NotificationInteractor   interactor.NotificationInteractor
 }
 
-func Inject(ctx context.Context) (*Dependency, error) {
+// Inject wires the dependencies. Every time read by the domain, the interactors and the gateways comes from clk,
+// so that a fake clock can freeze or shift the time of a whole run.
+func Inject(ctx context.Context, clk clock.Clock) (*Dependency, error) {
 	e := &environment.Environment{}
 	if err := env.Parse(e); err != nil {
 		return nil, fmt.Errorf("failed to parse environment variables: %w", err)
 	}
+	// TENANT_IDS="a, b" lists the tenants a and b
+	for i, id := range e.TenantIDs {
+		e.TenantIDs[i] = strings.TrimSpace(id)
+	}
 
 	mysqlClient, err := mysql.InitDB(ctx, mysql.DBConfig{
 		Environment: e.Environment,
@@ -43,6 +72,15 @@ func Inject(ctx context.Context) (*Dependency, error) {
 	if err != nil {
 		return nil, err
 	}
+	if e.DBSchemaCheck {
+		migrator, err := migration.NewMigrator(mysqlClient, schema.FS)
+		if err != nil {
+			return nil, err
+		}
+		if err := migrator.Check(ctx); err != nil {
+			return nil, fmt.Errorf("failed to check the schema: %w", err)
+		}
+	}
 	redisClient, err := redis.InitRedis(ctx, redis.RedisConfig{
 		Environment: e.Environment,
 		Host:        e.RedisHost,
@@ -58,37 +96,193 @@ func Inject(ctx context.Context) (*Dependency, error) {
 		Endpoint:    e.AWSEndpoint,
 	}, sqs.SQSConfig{
 		QueueNames: map[sqs.Key]string{
-			sqs.SQSKeySample: e.SQSQueueNameSample,
+			sqs.SQSKeySample:       e.SQSQueueNameSample,
+			sqs.SQSKeyModeration:   e.SQSQueueNameModeration,
+			sqs.SQSKeyMail:         e.SQSQueueNameMail,
+			sqs.SQSKeyNotification: e.SQSQueueNameNotification,
 		},
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	mysqlTxManager := transaction.NewMySQLTransactionManager(mysqlClient)
-
 	// Initialize repository
 	mysqlHealthRepository := mysqlRepo.NewHealthMySQLRepository(mysqlClient)
 	redisHealthRepository := redisRepo.NewHealthRedisRepository(redisClient)
 
 	mysqlUserRepository := mysqlRepo.NewUserMySQLRepository(mysqlClient)
 	redisUserRepository := redisRepo.NewUserRedisRepository(redisClient)
-	sqsUserRepository := sqsRepo.NewSQSRepository(sqsClient.Client, e.SQSQueueNameSample)
+	sqsUserRepository := sqsRepo.NewSQSRepository(sqsClient.Client, e.SQSQueueNameSample, clk)
+	mysqlOutboxRepository := mysqlRepo.NewOutboxMySQLRepository(mysqlClient)
+	mysqlEmailVerificationRepository := mysqlRepo.NewEmailVerificationMySQLRepository(mysqlClient)
+	mysqlEmailChangeRepository := mysqlRepo.NewEmailChangeMySQLRepository(mysqlClient)
+
+	sqsMailRepository := sqsRepo.NewSQSRepository(sqsClient.Client, e.SQSQueueNameMail, clk)
+	mailerRepository, err := newMailer(e.Environment, e.MailerEnvironment, clk)
+	if err != nil {
+		return nil, err
+	}
 
 	mysqlMatchingRepository := mysqlRepo.NewMatchingMySQLRepository(mysqlClient)
+	mysqlMatchingHistoryRepository := mysqlRepo.NewMatchingHistoryMySQLRepository(mysqlClient)
+	mysqlUserBlockRepository := mysqlRepo.NewUserBlockMySQLRepository(mysqlClient)
+
+	mysqlEntitlementRepository := mysqlRepo.NewEntitlementMySQLRepository(mysqlClient, model.PlanLimits{
+		model.PlanFree:    e.MatchingDailyLimitFree,
+		model.PlanPremium: e.MatchingDailyLimitPremium,
+	}, clk)
+	mysqlMatchingQuotaUsageRepository := mysqlRepo.NewMatchingQuotaUsageMySQLRepository(mysqlClient, clk)
+	redisMatchingQuotaRepository := redisRepo.NewMatchingQuotaRedisRepository(redisClient)
+
+	redisRecommendationRepository := redisRepo.NewRecommendationRedisRepository(redisClient)
+
+	mysqlReportRepository := mysqlRepo.NewReportMySQLRepository(mysqlClient)
+	sqsModerationRepository := sqsRepo.NewSQSRepository(sqsClient.Client, e.SQSQueueNameModeration, clk)
+
+	mysqlConversationRepository := mysqlRepo.NewConversationMySQLRepository(mysqlClient)
+	mysqlChatMessageRepository := mysqlRepo.NewChatMessageMySQLRepository(mysqlClient)
+	redisChatPublisher := redisRepo.NewChatRedisPublisher(redisClient)
+
+	mysqlAuditLogRepository := mysqlRepo.NewAuditLogMySQLRepository(mysqlClient)
+
+	mysqlNotificationRepository := mysqlRepo.NewNotificationMySQLRepository(mysqlClient)
+	mysqlNotificationPreferenceRepository := mysqlRepo.NewNotificationPreferenceMySQLRepository(mysqlClient)
+	mysqlNotificationDeliveryRepository := mysqlRepo.NewNotificationDeliveryMySQLRepository(mysqlClient)
+	sqsNotificationRepository := sqsRepo.NewSQSRepository(sqsClient.Client, e.SQSQueueNameNotification, clk)
+	pushRepository, err := fileRepo.NewNotificationPushFileRepository(e.NotificationPushFilePath, clk)
+	if err != nil {
+		return nil, err
+	}
+
+	errorReporter, err := newErrorReporter(e.ErrorReporterEnvironment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize domain event subscribers, which run after the transaction commits, or before it commits when subscribed in the transaction
+	eventDispatcher := event.NewDispatcher()
+	eventDispatcher.Subscribe(interactor.NewUserCacheInvalidator(redisUserRepository), model.UserEventNames...)
+	eventDispatcher.Subscribe(interactor.NewConversationArchiver(mysqlConversationRepository), model.EventMatchingUnmatched)
+	eventDispatcher.SubscribeInTransaction(interactor.NewMatchingNotifier(mysqlOutboxRepository, clk), model.EventMatchingCreated, model.EventMatchingAccepted)
+	txManager := event.NewTransactionManager(transaction.NewMySQLTransactionManager(mysqlClient), eventDispatcher)
 
 	// Initialize domain service
 	matchingDomainService := &service.MatchingDomainService{}
+	recommendationDomainService := service.NewRecommendationDomainService(service.DefaultCandidateScorers()...)
 
 	// Initialize interactor
 	healthInteractor := interactor.NewHealthInteractor(mysqlHealthRepository, redisHealthRepository)
-	userInteractor := interactor.NewUserInteractor(mysqlTxManager, mysqlUserRepository, redisUserRepository, sqsUserRepository)
-	matchingInteractor := interactor.NewMatchingInteractor(mysqlTxManager, mysqlMatchingRepository, mysqlUserRepository, matchingDomainService)
+	emailSigner := token.NewSigner(e.UserEmailTokenSecret)
+	userInteractor := interactor.NewUserInteractor(
+		txManager,
+		mysqlUserRepository,
+		redisUserRepository,
+		sqsUserRepository,
+		mysqlOutboxRepository,
+		mysqlEmailVerificationRepository,
+		mysqlEmailChangeRepository,
+		interactor.UserEmailConfig{
+			Signer:                  emailSigner,
+			VerificationTTL:         e.UserEmailVerificationTTL,
+			ChangeTTL:               e.UserEmailChangeTTL,
+			ChangeUndoPeriod:        e.UserEmailChangeUndoPeriod,
+			VerificationLimit:       e.UserEmailVerificationLimit,
+			VerificationLimitWindow: e.UserEmailVerificationLimitWindow,
+		},
+		e.UserWithdrawalGracePeriod,
+		clk,
+	)
+	matchingInteractor := interactor.NewMatchingInteractor(txManager, mysqlMatchingRepository, mysqlMatchingHistoryRepository, mysqlUserRepository, mysqlUserBlockRepository, mysqlEntitlementRepository, mysqlMatchingQuotaUsageRepository, redisMatchingQuotaRepository, matchingDomainService, clk)
+	userBlockInteractor := interactor.NewUserBlockInteractor(txManager, mysqlUserBlockRepository, mysqlUserRepository, mysqlMatchingRepository, mysqlMatchingHistoryRepository, clk)
+	recommendationInteractor := interactor.NewRecommendationInteractor(mysqlUserRepository, recommendationDomainService, redisRecommendationRepository, e.RecommendationCacheTTL, clk)
+	mailInteractor := interactor.NewMailInteractor(sqsMailRepository, mailerRepository)
+	outboxInteractor := interactor.NewOutboxInteractor(txManager, mysqlOutboxRepository, map[model.OutboxDestination]repository.MessageQueueRepository{
+		model.OutboxDestinationUserDeletion: sqsUserRepository,
+		model.OutboxDestinationMail:         sqsMailRepository,
+		model.OutboxDestinationModeration:   sqsModerationRepository,
+		model.OutboxDestinationNotification: sqsNotificationRepository,
+	}, clk)
+	reportInteractor := interactor.NewReportInteractor(txManager, mysqlReportRepository, mysqlUserRepository, mysqlOutboxRepository, clk)
+	conversationInteractor := interactor.NewConversationInteractor(txManager, mysqlConversationRepository, mysqlChatMessageRepository, mysqlMatchingRepository, mysqlUserBlockRepository, mysqlUserRepository, redisChatPublisher, clk)
+	auditLogInteractor := interactor.NewAuditLogInteractor(mysqlAuditLogRepository)
+	notificationInteractor := interactor.NewNotificationInteractor(
+		mysqlNotificationRepository,
+		mysqlNotificationPreferenceRepository,
+		mysqlNotificationDeliveryRepository,
+		mysqlUserRepository,
+		sqsNotificationRepository,
+		clk,
+		interactor.NewInAppNotificationDeliverer(mysqlNotificationRepository),
+		interactor.NewEmailNotificationDeliverer(mailerRepository),
+		pushRepository,
+	)
+
+	// Decorate the interactors to record an audit log of the changes in the same transaction
+	auditedUserInteractor := audit.NewUserInteractor(
+		userInteractor,
+		txManager,
+		mysqlAuditLogRepository,
+		mysqlUserRepository,
+		mysqlEmailVerificationRepository,
+		mysqlEmailChangeRepository,
+		emailSigner,
+		clk,
+	)
+	auditedMatchingInteractor := audit.NewMatchingInteractor(matchingInteractor, txManager, mysqlAuditLogRepository, mysqlMatchingRepository, clk)
+	auditedUserBlockInteractor := audit.NewUserBlockInteractor(userBlockInteractor, txManager, mysqlAuditLogRepository, mysqlUserBlockRepository, mysqlMatchingRepository, clk)
+	auditedReportInteractor := audit.NewReportInteractor(reportInteractor, txManager, mysqlAuditLogRepository, mysqlReportRepository, mysqlUserRepository, clk)
 
 	return &Dependency{
-		Environment:        e,
-		HealthInteractor:   healthInteractor,
-		UserInteractor:     userInteractor,
-		MatchingInteractor: matchingInteractor,
+		Environment:              e,
+		Clock:                    clk,
+		ErrorReporter:            errorReporter,
+		HealthInteractor:         healthInteractor,
+		UserInteractor:           auditedUserInteractor,
+		MatchingInteractor:       auditedMatchingInteractor,
+		UserBlockInteractor:      auditedUserBlockInteractor,
+		ReportInteractor:         auditedReportInteractor,
+		RecommendationInteractor: recommendationInteractor,
+		MailInteractor:           mailInteractor,
+		OutboxInteractor:         outboxInteractor,
+		AuditLogInteractor:       auditLogInteractor,
+		ConversationInteractor:   conversationInteractor,
+		NotificationInteractor:   notificationInteractor,
 	}, nil
 }
+
+func newMailer(environmentName string, e environment.MailerEnvironment, clk clock.Clock) (repository.Mailer, error) {
+	driver := e.MailerDriver
+	if driver == "" {
+		switch environmentName {
+		case "local", "test":
+			driver = "file"
+		default:
+			return nil, fmt.Errorf("MAILER_DRIVER is required in %s", environmentName)
+		}
+	}
+	switch driver {
+	case "smtp":
+		return smtpRepo.NewMailerSMTPRepository(smtpRepo.SMTPConfig{
+			Host:     e.SMTPHost,
+			Port:     e.SMTPPort,
+			Username: e.SMTPUsername,
+			Password: e.SMTPPassword,
+			From:     e.MailerFrom,
+		}, clk), nil
+	case "file":
+		return fileRepo.NewMailerFileRepository(e.MailerFilePath, clk)
+	default:
+		return nil, fmt.Errorf("unknown mailer driver: %s", driver)
+	}
+}
+
+func newErrorReporter(e environment.ErrorReporterEnvironment) (errorreport.Reporter, error) {
+	switch e.ErrorReporterDriver {
+	case "stdout":
+		return errorreport.NewStdoutReporter(), nil
+	case "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown error reporter driver: %s", e.ErrorReporterDriver)
+	}
+}
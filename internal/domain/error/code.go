@@ -0,0 +1,123 @@
+package error
+
+// codes are the category of the errors of each reason, which decides their status on every transport.
+var codes = map[Reason]ErrorCode{
+	ReasonInternal: Critical,
+
+	ReasonRequestBodyInvalid:     InvalidArgument,
+	ReasonParameterInvalid:       InvalidArgument,
+	ReasonParameterRequired:      InvalidArgument,
+	ReasonIncludePathUnsupported: InvalidArgument,
+
+	ReasonAuthRequired:     Unauthorized,
+	ReasonAuthTokenInvalid: Unauthorized,
+	ReasonAuthRoleDenied:   PermissionDenied,
+	ReasonAuthUserDenied:   PermissionDenied,
+	ReasonTenantRequired:   InvalidArgument,
+	ReasonTenantUnknown:    InvalidArgument,
+	ReasonTenantMismatch:   PermissionDenied,
+
+	ReasonUserNotFound:              NotFound,
+	ReasonUsersBatchSizeInvalid:     InvalidArgument,
+	ReasonUserCannotMatch:           PreconditionFailed,
+	ReasonUserCannotBeReactivated:   PreconditionFailed,
+	ReasonUserNotActive:             PreconditionFailed,
+	ReasonUserSuspended:             PreconditionFailed,
+	ReasonUserNotSuspended:          PreconditionFailed,
+	ReasonUserWithdrawn:             PreconditionFailed,
+	ReasonUserNotWithdrawn:          PreconditionFailed,
+	ReasonUserGracePeriodExpired:    PreconditionFailed,
+	ReasonUserEmailVerified:         PreconditionFailed,
+	ReasonUserEmailEmpty:            InvalidArgument,
+	ReasonUserEmailUnchanged:        InvalidArgument,
+	ReasonUserEmailChangeNotPending: PreconditionFailed,
+	ReasonEmailAlreadyUsed:          AlreadyExists,
+	ReasonEmailCannotBeChanged:      InvalidArgument,
+	ReasonEmailTokenInvalid:         InvalidArgument,
+
+	ReasonEmailVerificationNotFound:      NotFound,
+	ReasonEmailVerificationUnusable:      PreconditionFailed,
+	ReasonEmailVerificationUsed:          PreconditionFailed,
+	ReasonEmailVerificationExpired:       PreconditionFailed,
+	ReasonEmailVerificationEmailMismatch: PreconditionFailed,
+	ReasonEmailVerificationTooFrequent:   ResourceExhausted,
+
+	ReasonEmailChangeNotFound:      NotFound,
+	ReasonEmailChangeUnconfirmable: PreconditionFailed,
+	ReasonEmailChangeUnrevertable:  PreconditionFailed,
+	ReasonEmailChangeSuperseded:    PreconditionFailed,
+	ReasonEmailChangeConfirmed:     PreconditionFailed,
+	ReasonEmailChangeReverted:      PreconditionFailed,
+	ReasonEmailChangeExpired:       PreconditionFailed,
+	ReasonEmailChangeUndoExpired:   PreconditionFailed,
+
+	ReasonMatchingNotFound:              NotFound,
+	ReasonMatchingAlreadyExists:         AlreadyExists,
+	ReasonMatchingNotAllowed:            PermissionDenied,
+	ReasonMatchingMeOrPartnerIDRequired: InvalidArgument,
+	ReasonMatchingStatusRequired:        InvalidArgument,
+	ReasonMatchingStatusInvalid:         InvalidArgument,
+	ReasonMatchingNotPending:            PreconditionFailed,
+	ReasonMatchingExpired:               PreconditionFailed,
+	ReasonMatchingNotOverdue:            PreconditionFailed,
+	ReasonMatchingTransitionNotAllowed:  PreconditionFailed,
+	ReasonMatchingActorNotAllowed:       PermissionDenied,
+	ReasonMatchingQuotaExhausted:        ResourceExhausted,
+
+	ReasonUserBlockNotFound:      NotFound,
+	ReasonUserBlockAlreadyExists: AlreadyExists,
+	ReasonUserBlockSelf:          InvalidArgument,
+
+	ReasonReportNotFound:          NotFound,
+	ReasonReportInvalid:           InvalidArgument,
+	ReasonReportNotChangeable:     PreconditionFailed,
+	ReasonReportSelf:              InvalidArgument,
+	ReasonReportResolved:          PreconditionFailed,
+	ReasonReportNotAssigned:       PreconditionFailed,
+	ReasonReportAssigneeInvalid:   InvalidArgument,
+	ReasonReportResolutionInvalid: InvalidArgument,
+
+	ReasonConversationNotFound:       NotFound,
+	ReasonConversationArchived:       PreconditionFailed,
+	ReasonConversationNotMutual:      PreconditionFailed,
+	ReasonConversationNotParticipant: PermissionDenied,
+	ReasonMessagingNotAllowed:        PermissionDenied,
+	ReasonChatMessageNotFound:        NotFound,
+	ReasonChatMessageInvalid:         InvalidArgument,
+	ReasonChatMessageCursorInvalid:   InvalidArgument,
+
+	ReasonNotificationNotFound:       NotFound,
+	ReasonNotificationTypeUnknown:    InvalidArgument,
+	ReasonNotificationChannelUnknown: InvalidArgument,
+}
+
+// Reasons returns every reason with a category.
+func Reasons() []Reason {
+	reasons := make([]Reason, 0, len(codes))
+	for reason := range codes {
+		reasons = append(reasons, reason)
+	}
+	return reasons
+}
+
+// New returns the error of the reason with its category. The reason is the message, the presentation layer
+// replaces it with the template of the client's language.
+// A reason without a category is a programming error, so it is reported as critical.
+func New(reason Reason, cause error, details map[string]interface{}) *DomainError {
+	code, ok := codes[reason]
+	if !ok {
+		code = Critical
+	}
+	err := NewDomainError(code, string(reason), cause, details)
+	err.Reason = reason
+	return err
+}
+
+// Wrap returns the error of the reason of the sentinel in err, or of the fallback when err has none.
+func Wrap(err error, fallback Reason, details map[string]interface{}) *DomainError {
+	reason, ok := ReasonOf(err)
+	if !ok {
+		reason = fallback
+	}
+	return New(reason, err, details)
+}
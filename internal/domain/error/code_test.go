@@ -0,0 +1,42 @@
+package error
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	err := New(ReasonMatchingQuotaExhausted, nil, map[string]interface{}{"limit": 10})
+	if err.Code != ResourceExhausted || err.Reason != ReasonMatchingQuotaExhausted {
+		t.Errorf("New() code = %s, reason = %s", err.Code, err.Reason)
+	}
+
+	unknown := New(Reason("UNKNOWN"), nil, nil)
+	if unknown.Code != Critical {
+		t.Errorf("New() of a reason without a category code = %s, want %s", unknown.Code, Critical)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	sentinel := NewSentinel(ReasonMatchingNotPending, "matching status is not pending")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantReason Reason
+		wantCode   ErrorCode
+	}{
+		{name: "Sentinel", err: sentinel, wantReason: ReasonMatchingNotPending, wantCode: PreconditionFailed},
+		{name: "WrappedSentinel", err: fmt.Errorf("accept: %w", sentinel), wantReason: ReasonMatchingNotPending, wantCode: PreconditionFailed},
+		{name: "Fallback", err: errors.New("unknown"), wantReason: ReasonMatchingTransitionNotAllowed, wantCode: PreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Wrap(tt.err, ReasonMatchingTransitionNotAllowed, nil)
+			if got.Reason != tt.wantReason || got.Code != tt.wantCode || !errors.Is(got, tt.err) {
+				t.Errorf("Wrap() = %v, want reason %s and code %s", got, tt.wantReason, tt.wantCode)
+			}
+		})
+	}
+}
@@ -14,12 +14,14 @@ const (
 	Unauthorized       ErrorCode = "UNAUTHORIZED"
 	PermissionDenied   ErrorCode = "PERMISSION_DENIED"
 	PreconditionFailed ErrorCode = "PRECONDITION_FAILED"
+	ResourceExhausted  ErrorCode = "RESOURCE_EXHAUSTED"
 	Critical           ErrorCode = "CRITICAL"
 )
 
 type DomainError struct {
 	Message string                 `json:"message"`
 	Code    ErrorCode              `json:"code"`
+	Reason  Reason                 `json:"reason,omitempty"`
 	Cause   error                  `json:"cause,omitempty"`
 	Details map[string]interface{} `json:"details,omitempty"`
 }
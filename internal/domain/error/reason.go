@@ -0,0 +1,123 @@
+package error
+
+import "errors"
+
+// Reason is the stable machine readable code of an error. Clients switch on it, so a reason is never renamed or reused.
+type Reason string
+
+const (
+	ReasonInternal Reason = "INTERNAL"
+
+	ReasonRequestBodyInvalid     Reason = "REQUEST_BODY_INVALID"
+	ReasonParameterInvalid       Reason = "PARAMETER_INVALID"
+	ReasonParameterRequired      Reason = "PARAMETER_REQUIRED"
+	ReasonIncludePathUnsupported Reason = "INCLUDE_PATH_UNSUPPORTED"
+
+	ReasonAuthRequired     Reason = "AUTH_REQUIRED"
+	ReasonAuthTokenInvalid Reason = "AUTH_TOKEN_INVALID"
+	ReasonAuthRoleDenied   Reason = "AUTH_ROLE_DENIED"
+	ReasonAuthUserDenied   Reason = "AUTH_USER_DENIED"
+	ReasonTenantRequired   Reason = "TENANT_REQUIRED"
+	ReasonTenantUnknown    Reason = "TENANT_UNKNOWN"
+	ReasonTenantMismatch   Reason = "TENANT_MISMATCH"
+
+	ReasonUserNotFound              Reason = "USER_NOT_FOUND"
+	ReasonUsersBatchSizeInvalid     Reason = "USERS_BATCH_SIZE_INVALID"
+	ReasonUserCannotMatch           Reason = "USER_CANNOT_MATCH"
+	ReasonUserCannotBeReactivated   Reason = "USER_CANNOT_BE_REACTIVATED"
+	ReasonUserNotActive             Reason = "USER_NOT_ACTIVE"
+	ReasonUserSuspended             Reason = "USER_SUSPENDED"
+	ReasonUserNotSuspended          Reason = "USER_NOT_SUSPENDED"
+	ReasonUserWithdrawn             Reason = "USER_WITHDRAWN"
+	ReasonUserNotWithdrawn          Reason = "USER_NOT_WITHDRAWN"
+	ReasonUserGracePeriodExpired    Reason = "USER_GRACE_PERIOD_EXPIRED"
+	ReasonUserEmailVerified         Reason = "USER_EMAIL_VERIFIED"
+	ReasonUserEmailEmpty            Reason = "USER_EMAIL_EMPTY"
+	ReasonUserEmailUnchanged        Reason = "USER_EMAIL_UNCHANGED"
+	ReasonUserEmailChangeNotPending Reason = "USER_EMAIL_CHANGE_NOT_PENDING"
+	ReasonEmailAlreadyUsed          Reason = "EMAIL_ALREADY_USED"
+	ReasonEmailCannotBeChanged      Reason = "EMAIL_CANNOT_BE_CHANGED"
+	ReasonEmailTokenInvalid         Reason = "EMAIL_TOKEN_INVALID"
+
+	ReasonEmailVerificationNotFound      Reason = "EMAIL_VERIFICATION_NOT_FOUND"
+	ReasonEmailVerificationUnusable      Reason = "EMAIL_VERIFICATION_UNUSABLE"
+	ReasonEmailVerificationUsed          Reason = "EMAIL_VERIFICATION_USED"
+	ReasonEmailVerificationExpired       Reason = "EMAIL_VERIFICATION_EXPIRED"
+	ReasonEmailVerificationEmailMismatch Reason = "EMAIL_VERIFICATION_EMAIL_MISMATCH"
+	ReasonEmailVerificationTooFrequent   Reason = "EMAIL_VERIFICATION_TOO_FREQUENT"
+
+	ReasonEmailChangeNotFound      Reason = "EMAIL_CHANGE_NOT_FOUND"
+	ReasonEmailChangeUnconfirmable Reason = "EMAIL_CHANGE_UNCONFIRMABLE"
+	ReasonEmailChangeUnrevertable  Reason = "EMAIL_CHANGE_UNREVERTABLE"
+	ReasonEmailChangeSuperseded    Reason = "EMAIL_CHANGE_SUPERSEDED"
+	ReasonEmailChangeConfirmed     Reason = "EMAIL_CHANGE_CONFIRMED"
+	ReasonEmailChangeReverted      Reason = "EMAIL_CHANGE_REVERTED"
+	ReasonEmailChangeExpired       Reason = "EMAIL_CHANGE_EXPIRED"
+	ReasonEmailChangeUndoExpired   Reason = "EMAIL_CHANGE_UNDO_EXPIRED"
+
+	ReasonMatchingNotFound              Reason = "MATCHING_NOT_FOUND"
+	ReasonMatchingAlreadyExists         Reason = "MATCHING_ALREADY_EXISTS"
+	ReasonMatchingNotAllowed            Reason = "MATCHING_NOT_ALLOWED"
+	ReasonMatchingMeOrPartnerIDRequired Reason = "MATCHING_ME_OR_PARTNER_ID_REQUIRED"
+	ReasonMatchingStatusRequired        Reason = "MATCHING_STATUS_REQUIRED"
+	ReasonMatchingStatusInvalid         Reason = "MATCHING_STATUS_INVALID"
+	ReasonMatchingNotPending            Reason = "MATCHING_NOT_PENDING"
+	ReasonMatchingExpired               Reason = "MATCHING_EXPIRED"
+	ReasonMatchingNotOverdue            Reason = "MATCHING_NOT_OVERDUE"
+	ReasonMatchingTransitionNotAllowed  Reason = "MATCHING_TRANSITION_NOT_ALLOWED"
+	ReasonMatchingActorNotAllowed       Reason = "MATCHING_ACTOR_NOT_ALLOWED"
+	ReasonMatchingQuotaExhausted        Reason = "MATCHING_QUOTA_EXHAUSTED"
+
+	ReasonUserBlockNotFound      Reason = "USER_BLOCK_NOT_FOUND"
+	ReasonUserBlockAlreadyExists Reason = "USER_BLOCK_ALREADY_EXISTS"
+	ReasonUserBlockSelf          Reason = "USER_BLOCK_SELF"
+
+	ReasonReportNotFound          Reason = "REPORT_NOT_FOUND"
+	ReasonReportInvalid           Reason = "REPORT_INVALID"
+	ReasonReportNotChangeable     Reason = "REPORT_NOT_CHANGEABLE"
+	ReasonReportSelf              Reason = "REPORT_SELF"
+	ReasonReportResolved          Reason = "REPORT_RESOLVED"
+	ReasonReportNotAssigned       Reason = "REPORT_NOT_ASSIGNED"
+	ReasonReportAssigneeInvalid   Reason = "REPORT_ASSIGNEE_INVALID"
+	ReasonReportResolutionInvalid Reason = "REPORT_RESOLUTION_INVALID"
+
+	ReasonConversationNotFound       Reason = "CONVERSATION_NOT_FOUND"
+	ReasonConversationArchived       Reason = "CONVERSATION_ARCHIVED"
+	ReasonConversationNotMutual      Reason = "CONVERSATION_NOT_MUTUAL"
+	ReasonConversationNotParticipant Reason = "CONVERSATION_NOT_PARTICIPANT"
+	ReasonMessagingNotAllowed        Reason = "MESSAGING_NOT_ALLOWED"
+	ReasonChatMessageNotFound        Reason = "CHAT_MESSAGE_NOT_FOUND"
+	ReasonChatMessageInvalid         Reason = "CHAT_MESSAGE_INVALID"
+	ReasonChatMessageCursorInvalid   Reason = "CHAT_MESSAGE_CURSOR_INVALID"
+
+	ReasonNotificationNotFound       Reason = "NOTIFICATION_NOT_FOUND"
+	ReasonNotificationTypeUnknown    Reason = "NOTIFICATION_TYPE_UNKNOWN"
+	ReasonNotificationChannelUnknown Reason = "NOTIFICATION_CHANNEL_UNKNOWN"
+)
+
+// Sentinel is an error of the domain model identified by its reason. It is compared by identity like errors.New.
+type Sentinel struct {
+	reason  Reason
+	message string
+}
+
+func NewSentinel(reason Reason, message string) *Sentinel {
+	return &Sentinel{reason: reason, message: message}
+}
+
+func (s *Sentinel) Error() string {
+	return s.message
+}
+
+func (s *Sentinel) Reason() Reason {
+	return s.reason
+}
+
+// ReasonOf returns the reason of the first sentinel in the chain of err.
+func ReasonOf(err error) (Reason, bool) {
+	var sentinel *Sentinel
+	if !errors.As(err, &sentinel) {
+		return "", false
+	}
+	return sentinel.reason, true
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// AuditSource is where the change that an audit log records came from.
+type AuditSource string
+
+const (
+	AuditSourceHTTP       AuditSource = "http"
+	AuditSourceTask       AuditSource = "task"
+	AuditSourceSubscriber AuditSource = "subscriber"
+)
+
+// AuditTargetType is the kind of the aggregate that an audit log records a change of.
+type AuditTargetType string
+
+const (
+	AuditTargetUser      AuditTargetType = "user"
+	AuditTargetMatching  AuditTargetType = "matching"
+	AuditTargetUserBlock AuditTargetType = "user_block"
+	AuditTargetReport    AuditTargetType = "report"
+)
+
+var AuditTargetTypes = map[AuditTargetType]struct{}{
+	AuditTargetUser:      {},
+	AuditTargetMatching:  {},
+	AuditTargetUserBlock: {},
+	AuditTargetReport:    {},
+}
+
+// AuditSnapshot is the audited fields of a target at a point in time. Nil means the target does not exist.
+// The values must be comparable as JSON, e.g. strings, booleans and string slices.
+type AuditSnapshot map[string]interface{}
+
+// AuditChange is the value of a field before and after the change. Nil means the field was absent.
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditDiff is the changed fields of the target, keyed by the field name.
+type AuditDiff map[string]AuditChange
+
+// NewAuditDiff returns the fields that differ between the snapshots.
+func NewAuditDiff(before, after AuditSnapshot) AuditDiff {
+	diff := AuditDiff{}
+	for key, value := range before {
+		if afterValue, ok := after[key]; !ok || !reflect.DeepEqual(value, afterValue) {
+			diff[key] = AuditChange{Before: value, After: after[key]}
+		}
+	}
+	for key, value := range after {
+		if _, ok := before[key]; !ok {
+			diff[key] = AuditChange{Before: nil, After: value}
+		}
+	}
+	return diff
+}
+
+// AuditLog records who changed what, when and from where. It is append-only and never updated.
+type AuditLog struct {
+	ID uuid.UUID
+	// ActorID is the user who made the change. Nil means the system, or an anonymous caller.
+	ActorID    uuid.UUID
+	Action     string
+	TargetType AuditTargetType
+	TargetID   string
+	Diff       AuditDiff
+	// RequestID correlates the log with the request that made the change, and is empty outside HTTP.
+	RequestID string
+	Source    AuditSource
+	CreatedAt time.Time
+}
+
+type InputAuditLogParams struct {
+	ActorID    uuid.UUID
+	Action     string
+	TargetType AuditTargetType
+	TargetID   string
+	Before     AuditSnapshot
+	After      AuditSnapshot
+	RequestID  string
+	Source     AuditSource
+}
+
+func NewAuditLog(params InputAuditLogParams, now time.Time) *AuditLog {
+	return &AuditLog{
+		ID:         uuid.New(),
+		ActorID:    params.ActorID,
+		Action:     params.Action,
+		TargetType: params.TargetType,
+		TargetID:   params.TargetID,
+		Diff:       NewAuditDiff(params.Before, params.After),
+		RequestID:  params.RequestID,
+		Source:     params.Source,
+		CreatedAt:  now,
+	}
+}
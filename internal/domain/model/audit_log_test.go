@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewAuditDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before AuditSnapshot
+		after  AuditSnapshot
+		want   AuditDiff
+	}{
+		{
+			name:   "created",
+			before: nil,
+			after:  AuditSnapshot{"status": "active"},
+			want:   AuditDiff{"status": {Before: nil, After: "active"}},
+		},
+		{
+			name:   "deleted",
+			before: AuditSnapshot{"status": "active"},
+			after:  nil,
+			want:   AuditDiff{"status": {Before: "active", After: nil}},
+		},
+		{
+			name:   "only changed fields",
+			before: AuditSnapshot{"status": "active", "bio": "old", "interests": []string{"go"}},
+			after:  AuditSnapshot{"status": "active", "bio": "new", "interests": []string{"go"}},
+			want:   AuditDiff{"bio": {Before: "old", After: "new"}},
+		},
+		{
+			name:   "unchanged",
+			before: AuditSnapshot{"interests": []string{"go"}},
+			after:  AuditSnapshot{"interests": []string{"go"}},
+			want:   AuditDiff{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, NewAuditDiff(tt.before, tt.after)); diff != "" {
+				t.Errorf("NewAuditDiff() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+	"github.com/go-playground/validator/v10"
+)
+
+var ErrChatMessageCursorInvalid = domainerr.NewSentinel(domainerr.ReasonChatMessageCursorInvalid, "chat message cursor is invalid")
+
+// ChatMessage is a message posted by a participant to a conversation.
+type ChatMessage struct {
+	ID             uuid.UUID `validate:"required"`
+	ConversationID uuid.UUID `validate:"required"`
+	SenderID       uuid.UUID `validate:"required"`
+	Body           string    `validate:"required,max=2000"`
+	// ReadAt is set once the recipient reads the message, and zero until then.
+	ReadAt    time.Time
+	CreatedAt time.Time `validate:"required"`
+}
+
+type InputChatMessageParams struct {
+	ConversationID uuid.UUID
+	SenderID       uuid.UUID
+	Body           string
+}
+
+func NewChatMessage(params InputChatMessageParams, now time.Time) *ChatMessage {
+	return &ChatMessage{
+		ID:             uuid.New(),
+		ConversationID: params.ConversationID,
+		SenderID:       params.SenderID,
+		Body:           strings.TrimSpace(params.Body),
+		// The database keeps microseconds, and the cursor must match the stored time
+		CreatedAt: now.Truncate(time.Microsecond),
+	}
+}
+
+func (m *ChatMessage) Validate() error {
+	return validator.New().Struct(m)
+}
+
+func (m *ChatMessage) IsRead() bool {
+	return !m.ReadAt.IsZero()
+}
+
+// ChatMessageReceipt tells the sender that the reader has read the messages of the conversation up to UpTo.
+type ChatMessageReceipt struct {
+	ConversationID uuid.UUID
+	ReaderID       uuid.UUID
+	UpTo           time.Time
+	ReadAt         time.Time
+	Count          int
+}
+
+// ChatMessageCursor points at a message of a conversation to page the older messages from it.
+// Messages are ordered by CreatedAt, and by ID among the ones created at the same time.
+type ChatMessageCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func NewChatMessageCursor(message *ChatMessage) *ChatMessageCursor {
+	return &ChatMessageCursor{CreatedAt: message.CreatedAt, ID: message.ID}
+}
+
+// String encodes the cursor into an opaque token for the clients.
+func (c *ChatMessageCursor) String() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixMicro(), 10) + ":" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseChatMessageCursor decodes a token returned by ChatMessageCursor.String.
+func ParseChatMessageCursor(token string) (*ChatMessageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrChatMessageCursorInvalid
+	}
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrChatMessageCursorInvalid
+	}
+	unixMicro, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, ErrChatMessageCursorInvalid
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrChatMessageCursorInvalid
+	}
+	return &ChatMessageCursor{CreatedAt: time.UnixMicro(unixMicro), ID: parsedID}, nil
+}
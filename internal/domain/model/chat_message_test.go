@@ -0,0 +1,30 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func TestChatMessageCursor(t *testing.T) {
+	message := NewChatMessage(InputChatMessageParams{ConversationID: uuid.New(), SenderID: uuid.New(), Body: "  hi  "}, time.Now())
+	if message.Body != "hi" {
+		t.Errorf("NewChatMessage() body = %q, want trimmed", message.Body)
+	}
+
+	cursor, err := ParseChatMessageCursor(NewChatMessageCursor(message).String())
+	if err != nil {
+		t.Fatalf("ParseChatMessageCursor() error = %v", err)
+	}
+	if !cursor.CreatedAt.Equal(message.CreatedAt) || cursor.ID != message.ID {
+		t.Errorf("ParseChatMessageCursor() = %+v, want %v %v", cursor, message.CreatedAt, message.ID)
+	}
+
+	for _, token := range []string{"", "not base64!", "bm8tY29sb24", "MTpub3QtdXVpZA"} {
+		if _, err := ParseChatMessageCursor(token); !errors.Is(err, ErrChatMessageCursorInvalid) {
+			t.Errorf("ParseChatMessageCursor(%q) error = %v, want %v", token, err, ErrChatMessageCursorInvalid)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+var (
+	ErrConversationIsArchived     = domainerr.NewSentinel(domainerr.ReasonConversationArchived, "conversation is archived")
+	ErrConversationIsNotMutual    = domainerr.NewSentinel(domainerr.ReasonConversationNotMutual, "conversation requires an accepted matching")
+	ErrConversationNotParticipant = domainerr.NewSentinel(domainerr.ReasonConversationNotParticipant, "user is not a participant of the conversation")
+)
+
+// ConversationStatus is the state of a conversation: active <-> archived.
+type ConversationStatus string
+
+const (
+	ConversationStatusActive   ConversationStatus = "active"
+	ConversationStatusArchived ConversationStatus = "archived"
+)
+
+// Conversation is where the two users of an accepted matching exchange messages.
+// It is archived when the matching is no longer accepted, and restored when the matching is accepted again.
+type Conversation struct {
+	ID         uuid.UUID
+	MatchingID uuid.UUID
+	// User1ID and User2ID are the participants, in the order of MeID and PartnerID of the matching.
+	User1ID uuid.UUID
+	User2ID uuid.UUID
+	Status  ConversationStatus
+	// ArchivedAt is set while the conversation is archived, and zero otherwise.
+	ArchivedAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewConversation opens the conversation of the matching, which must be accepted.
+func NewConversation(matching *Matching, now time.Time) (*Conversation, error) {
+	if !matching.IsMutual() {
+		return nil, ErrConversationIsNotMutual
+	}
+	return &Conversation{
+		ID:         uuid.New(),
+		MatchingID: matching.ID,
+		User1ID:    matching.MeID,
+		User2ID:    matching.PartnerID,
+		Status:     ConversationStatusActive,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+func (c *Conversation) IsArchived() bool {
+	return c.Status == ConversationStatusArchived
+}
+
+func (c *Conversation) IsParticipant(userID uuid.UUID) bool {
+	return userID == c.User1ID || userID == c.User2ID
+}
+
+// Partner returns the other participant of the user.
+func (c *Conversation) Partner(userID uuid.UUID) uuid.UUID {
+	if userID == c.User1ID {
+		return c.User2ID
+	}
+	return c.User1ID
+}
+
+// CanPost checks that the user can send a message to the conversation.
+func (c *Conversation) CanPost(userID uuid.UUID) error {
+	if !c.IsParticipant(userID) {
+		return ErrConversationNotParticipant
+	}
+	if c.IsArchived() {
+		return ErrConversationIsArchived
+	}
+	return nil
+}
+
+// Archive closes the conversation. It reports whether the status changed.
+func (c *Conversation) Archive(now time.Time) bool {
+	if c.IsArchived() {
+		return false
+	}
+	c.Status = ConversationStatusArchived
+	c.ArchivedAt = now
+	c.UpdatedAt = now
+	return true
+}
+
+// Restore reopens the archived conversation of a matching accepted again. It reports whether the status changed.
+func (c *Conversation) Restore(now time.Time) bool {
+	if !c.IsArchived() {
+		return false
+	}
+	c.Status = ConversationStatusActive
+	c.ArchivedAt = time.Time{}
+	c.UpdatedAt = now
+	return true
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func TestNewConversation(t *testing.T) {
+	now := time.Now()
+	matching := NewMatching(InputMatchingParams{MeID: uuid.New(), PartnerID: uuid.New(), Status: string(MatchingStatusPending)}, now)
+	if _, err := NewConversation(matching, now); !errors.Is(err, ErrConversationIsNotMutual) {
+		t.Errorf("NewConversation() error = %v, want %v", err, ErrConversationIsNotMutual)
+	}
+
+	matching.Status = MatchingStatusAccepted
+	conversation, err := NewConversation(matching, now)
+	if err != nil {
+		t.Fatalf("NewConversation() error = %v", err)
+	}
+	if conversation.MatchingID != matching.ID || conversation.IsArchived() {
+		t.Errorf("NewConversation() = %+v", conversation)
+	}
+	if conversation.Partner(matching.MeID) != matching.PartnerID || conversation.Partner(matching.PartnerID) != matching.MeID {
+		t.Error("Partner() does not return the other participant")
+	}
+}
+
+func TestConversation_CanPost(t *testing.T) {
+	me, partner := uuid.New(), uuid.New()
+	now := time.Now()
+	conversation, err := NewConversation(&Matching{ID: uuid.New(), MeID: me, PartnerID: partner, Status: MatchingStatusAccepted}, now)
+	if err != nil {
+		t.Fatalf("NewConversation() error = %v", err)
+	}
+
+	if err := conversation.CanPost(partner); err != nil {
+		t.Errorf("CanPost() error = %v", err)
+	}
+	if err := conversation.CanPost(uuid.New()); !errors.Is(err, ErrConversationNotParticipant) {
+		t.Errorf("CanPost() by another user error = %v, want %v", err, ErrConversationNotParticipant)
+	}
+
+	if !conversation.Archive(now) || conversation.Archive(now) {
+		t.Error("Archive() does not report the change once")
+	}
+	if err := conversation.CanPost(me); !errors.Is(err, ErrConversationIsArchived) {
+		t.Errorf("CanPost() to archived error = %v, want %v", err, ErrConversationIsArchived)
+	}
+
+	if !conversation.Restore(now) || conversation.Restore(now) {
+		t.Error("Restore() does not report the change once")
+	}
+	if err := conversation.CanPost(me); err != nil || !conversation.ArchivedAt.IsZero() {
+		t.Errorf("CanPost() after Restore() error = %v, archivedAt = %v", err, conversation.ArchivedAt)
+	}
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+var (
+	ErrEmailChangeIsConfirmed   = domainerr.NewSentinel(domainerr.ReasonEmailChangeConfirmed, "email change is already confirmed")
+	ErrEmailChangeIsReverted    = domainerr.NewSentinel(domainerr.ReasonEmailChangeReverted, "email change is already reverted")
+	ErrEmailChangeIsExpired     = domainerr.NewSentinel(domainerr.ReasonEmailChangeExpired, "email change is expired")
+	ErrEmailChangeUndoIsExpired = domainerr.NewSentinel(domainerr.ReasonEmailChangeUndoExpired, "email change can no longer be undone")
+)
+
+// EmailChange is a request to change the email of the user from OldEmail to NewEmail.
+// It is confirmed from the new email before ExpiresAt, and can be undone from the old email until UndoExpiresAt.
+type EmailChange struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	OldEmail      string
+	NewEmail      string
+	ExpiresAt     time.Time
+	UndoExpiresAt time.Time
+	// ConfirmedAt and RevertedAt are set once the change is confirmed or undone, and zero until then.
+	ConfirmedAt time.Time
+	RevertedAt  time.Time
+	CreatedAt   time.Time
+}
+
+func NewEmailChange(user *User, newEmail string, ttl, undoPeriod time.Duration, now time.Time) *EmailChange {
+	return &EmailChange{
+		ID:            uuid.New(),
+		UserID:        user.ID,
+		OldEmail:      user.Email,
+		NewEmail:      newEmail,
+		ExpiresAt:     now.Add(ttl),
+		UndoExpiresAt: now.Add(undoPeriod),
+		CreatedAt:     now,
+	}
+}
+
+func (c *EmailChange) IsConfirmed() bool {
+	return !c.ConfirmedAt.IsZero()
+}
+
+func (c *EmailChange) IsReverted() bool {
+	return !c.RevertedAt.IsZero()
+}
+
+func (c *EmailChange) Confirm(now time.Time) error {
+	if c.IsReverted() {
+		return ErrEmailChangeIsReverted
+	}
+	if c.IsConfirmed() {
+		return ErrEmailChangeIsConfirmed
+	}
+	if !now.Before(c.ExpiresAt) {
+		return ErrEmailChangeIsExpired
+	}
+	c.ConfirmedAt = now
+	return nil
+}
+
+// Revert undoes the change, before or after it is confirmed.
+func (c *EmailChange) Revert(now time.Time) error {
+	if c.IsReverted() {
+		return ErrEmailChangeIsReverted
+	}
+	if !now.Before(c.UndoExpiresAt) {
+		return ErrEmailChangeUndoIsExpired
+	}
+	c.RevertedAt = now
+	return nil
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEmailChange(t *testing.T) {
+	now := time.Now()
+	newUser := func() *User {
+		user := NewUser(InputUserParams{Email: "old@example.com"}, now)
+		if err := user.RequestEmailChange("new@example.com", now); err != nil {
+			t.Fatalf("RequestEmailChange() error = %v", err)
+		}
+		return user
+	}
+
+	t.Run("OK: confirm swaps to the new email", func(t *testing.T) {
+		user := newUser()
+		change := NewEmailChange(user, user.PendingEmail, time.Hour, 2*time.Hour, now)
+		if err := change.Confirm(now); err != nil {
+			t.Fatalf("Confirm() error = %v", err)
+		}
+		if err := user.ConfirmEmailChange(change.NewEmail, now); err != nil {
+			t.Fatalf("ConfirmEmailChange() error = %v", err)
+		}
+		if user.Email != "new@example.com" || user.PendingEmail != "" || !user.IsEmailVerified() {
+			t.Errorf("user = %+v, want the verified new email", user)
+		}
+		if err := change.Confirm(now); !errors.Is(err, ErrEmailChangeIsConfirmed) {
+			t.Errorf("Confirm() error = %v, wantErr %v", err, ErrEmailChangeIsConfirmed)
+		}
+	})
+
+	t.Run("OK: undo after confirmation restores the old email", func(t *testing.T) {
+		user := newUser()
+		change := NewEmailChange(user, user.PendingEmail, time.Hour, 2*time.Hour, now)
+		if err := user.ConfirmEmailChange(change.NewEmail, now); err != nil {
+			t.Fatalf("ConfirmEmailChange() error = %v", err)
+		}
+		if err := change.Revert(now); err != nil {
+			t.Fatalf("Revert() error = %v", err)
+		}
+		if err := user.RevertEmailChange(change.OldEmail, change.NewEmail, now); err != nil {
+			t.Fatalf("RevertEmailChange() error = %v", err)
+		}
+		if user.Email != "old@example.com" {
+			t.Errorf("email = %v, want %v", user.Email, "old@example.com")
+		}
+		if err := change.Revert(now); !errors.Is(err, ErrEmailChangeIsReverted) {
+			t.Errorf("Revert() error = %v, wantErr %v", err, ErrEmailChangeIsReverted)
+		}
+	})
+
+	t.Run("OK: undo before confirmation drops the pending email", func(t *testing.T) {
+		user := newUser()
+		change := NewEmailChange(user, user.PendingEmail, time.Hour, 2*time.Hour, now)
+		if err := user.RevertEmailChange(change.OldEmail, change.NewEmail, now); err != nil {
+			t.Fatalf("RevertEmailChange() error = %v", err)
+		}
+		if user.Email != "old@example.com" || user.PendingEmail != "" {
+			t.Errorf("user = %+v, want the old email without pending", user)
+		}
+		if err := user.ConfirmEmailChange(change.NewEmail, now); !errors.Is(err, ErrUserEmailChangeIsNotPending) {
+			t.Errorf("ConfirmEmailChange() error = %v, wantErr %v", err, ErrUserEmailChangeIsNotPending)
+		}
+	})
+
+	t.Run("NG: confirm a superseded change", func(t *testing.T) {
+		user := newUser()
+		if err := user.RequestEmailChange("newer@example.com", now); err != nil {
+			t.Fatalf("RequestEmailChange() error = %v", err)
+		}
+		if err := user.ConfirmEmailChange("new@example.com", now); !errors.Is(err, ErrUserEmailChangeIsNotPending) {
+			t.Errorf("ConfirmEmailChange() error = %v, wantErr %v", err, ErrUserEmailChangeIsNotPending)
+		}
+	})
+
+	t.Run("NG: request the current email", func(t *testing.T) {
+		user := newUser()
+		if err := user.RequestEmailChange(user.Email, now); !errors.Is(err, ErrUserEmailIsUnchanged) {
+			t.Errorf("RequestEmailChange() error = %v, wantErr %v", err, ErrUserEmailIsUnchanged)
+		}
+	})
+
+	t.Run("NG: expired", func(t *testing.T) {
+		user := newUser()
+		change := NewEmailChange(user, user.PendingEmail, time.Hour, 2*time.Hour, now)
+		if err := change.Confirm(now.Add(time.Hour)); !errors.Is(err, ErrEmailChangeIsExpired) {
+			t.Errorf("Confirm() error = %v, wantErr %v", err, ErrEmailChangeIsExpired)
+		}
+		if err := change.Revert(now.Add(2 * time.Hour)); !errors.Is(err, ErrEmailChangeUndoIsExpired) {
+			t.Errorf("Revert() error = %v, wantErr %v", err, ErrEmailChangeUndoIsExpired)
+		}
+	})
+}
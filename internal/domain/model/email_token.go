@@ -0,0 +1,32 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// The purpose is signed into the email tokens, so that a token mailed for one purpose cannot be used for another.
+const (
+	EmailTokenPurposeVerification = "email_verification"
+	EmailTokenPurposeChange       = "email_change"
+	EmailTokenPurposeChangeUndo   = "email_change_undo"
+)
+
+// EmailTokenPayload is what an email token signs, the purpose and the ID of the verification or the change.
+func EmailTokenPayload(purpose string, id uuid.UUID) string {
+	return purpose + ":" + id.String()
+}
+
+// ParseEmailTokenPayload returns the ID in a payload signed for the purpose.
+func ParseEmailTokenPayload(payload, purpose string) (uuid.UUID, bool) {
+	signedPurpose, id, ok := strings.Cut(payload, ":")
+	if !ok || signedPurpose != purpose {
+		return uuid.Nil(), false
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil(), false
+	}
+	return parsed, true
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+var (
+	ErrEmailVerificationIsUsed        = domainerr.NewSentinel(domainerr.ReasonEmailVerificationUsed, "email verification is already used")
+	ErrEmailVerificationIsExpired     = domainerr.NewSentinel(domainerr.ReasonEmailVerificationExpired, "email verification is expired")
+	ErrEmailVerificationEmailMismatch = domainerr.NewSentinel(domainerr.ReasonEmailVerificationEmailMismatch, "email verification was issued for another email")
+)
+
+// EmailVerification is issued for the email of the user, and can be used once before it expires.
+type EmailVerification struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Email     string
+	ExpiresAt time.Time
+	// UsedAt is set once the verification is used, and zero until then.
+	UsedAt    time.Time
+	CreatedAt time.Time
+}
+
+func NewEmailVerification(user *User, ttl time.Duration, now time.Time) *EmailVerification {
+	return &EmailVerification{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Email:     user.Email,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
+
+func (v *EmailVerification) IsUsed() bool {
+	return !v.UsedAt.IsZero()
+}
+
+func (v *EmailVerification) IsExpired(now time.Time) bool {
+	return !now.Before(v.ExpiresAt)
+}
+
+// Use consumes the verification for the current email of the user.
+// It is rejected when the email has changed since the verification was issued.
+func (v *EmailVerification) Use(email string, now time.Time) error {
+	if v.IsUsed() {
+		return ErrEmailVerificationIsUsed
+	}
+	if v.IsExpired(now) {
+		return ErrEmailVerificationIsExpired
+	}
+	if v.Email != email {
+		return ErrEmailVerificationEmailMismatch
+	}
+	v.UsedAt = now
+	return nil
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEmailVerification_Use(t *testing.T) {
+	now := time.Now()
+	user := NewUser(InputUserParams{Email: "test@example.com"}, now)
+
+	tests := []struct {
+		name         string
+		verification func() *EmailVerification
+		email        string
+		wantErr      error
+	}{
+		{
+			name:         "OK",
+			verification: func() *EmailVerification { return NewEmailVerification(user, time.Hour, now) },
+			email:        user.Email,
+		},
+		{
+			name: "NG: already used",
+			verification: func() *EmailVerification {
+				v := NewEmailVerification(user, time.Hour, now)
+				v.UsedAt = now
+				return v
+			},
+			email:   user.Email,
+			wantErr: ErrEmailVerificationIsUsed,
+		},
+		{
+			name:         "NG: expired",
+			verification: func() *EmailVerification { return NewEmailVerification(user, -time.Second, now) },
+			email:        user.Email,
+			wantErr:      ErrEmailVerificationIsExpired,
+		},
+		{
+			name:         "NG: email has changed",
+			verification: func() *EmailVerification { return NewEmailVerification(user, time.Hour, now) },
+			email:        "changed@example.com",
+			wantErr:      ErrEmailVerificationEmailMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.verification()
+			err := v.Use(tt.email, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Use() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !v.IsUsed() {
+				t.Error("IsUsed() = false after Use()")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// Plan is the subscription plan of a user, which decides the entitlements.
+type Plan string
+
+const (
+	PlanFree    Plan = "free"
+	PlanPremium Plan = "premium"
+)
+
+var Plans = map[Plan]struct{}{
+	PlanFree:    {},
+	PlanPremium: {},
+}
+
+// PlanLimits are the daily matching limits by plan.
+type PlanLimits map[Plan]int
+
+// Entitlement is what the user is allowed to do under the plan.
+type Entitlement struct {
+	UserID             uuid.UUID
+	Plan               Plan
+	DailyMatchingLimit int
+}
+
+// NewEntitlement falls back to the free plan for an unknown plan.
+func NewEntitlement(userID uuid.UUID, plan Plan, limits PlanLimits) *Entitlement {
+	if _, ok := Plans[plan]; !ok {
+		plan = PlanFree
+	}
+	return &Entitlement{
+		UserID:             userID,
+		Plan:               plan,
+		DailyMatchingLimit: limits[plan],
+	}
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// EventName identifies the kind of a domain event. Subscribers subscribe by name.
+type EventName string
+
+// DomainEvent is a fact recorded by an aggregate when it changes.
+// It is delivered to the subscribers once the transaction saving the aggregate commits.
+type DomainEvent interface {
+	EventName() EventName
+	AggregateID() uuid.UUID
+	OccurredAt() time.Time
+}
+
+// EventRecorder is an aggregate that records domain events.
+type EventRecorder interface {
+	PullEvents() []DomainEvent
+}
+
+// events records the domain events of an aggregate until they are pulled.
+type events struct {
+	recorded []DomainEvent
+}
+
+func (e *events) record(event DomainEvent) {
+	e.recorded = append(e.recorded, event)
+}
+
+// PullEvents returns the recorded events and forgets them, so that each event is dispatched once.
+func (e *events) PullEvents() []DomainEvent {
+	pulled := e.recorded
+	e.recorded = nil
+	return pulled
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Mail is a plain text email.
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// NewEmailVerificationMail asks the user to verify the email with the token.
+func NewEmailVerificationMail(user *User, token string) *Mail {
+	return &Mail{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Please verify your email address with the following token:\n\n%s\n\nIf you did not sign up, you can ignore this email.\n",
+			token,
+		),
+	}
+}
+
+// NewEmailChangeConfirmationMail asks the user to confirm the new email with the token.
+func NewEmailChangeConfirmationMail(change *EmailChange, token string) *Mail {
+	return &Mail{
+		To:      change.NewEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf(
+			"Please confirm your new email address with the following token:\n\n%s\n\nThe token expires at %s. If you did not request the change, you can ignore this email.\n",
+			token, change.ExpiresAt.UTC().Format(time.RFC1123),
+		),
+	}
+}
+
+// NewEmailChangeNoticeMail tells the old email about the change, with the token to undo it.
+func NewEmailChangeNoticeMail(change *EmailChange, token string) *Mail {
+	return &Mail{
+		To:      change.OldEmail,
+		Subject: "Your email address is being changed",
+		Body: fmt.Sprintf(
+			"A change of your email address to %s was requested.\n\nIf you did not request it, undo the change with the following token:\n\n%s\n\nThe token can be used until %s.\n",
+			change.NewEmail, token, change.UndoExpiresAt.UTC().Format(time.RFC1123),
+		),
+	}
+}
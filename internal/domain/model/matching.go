@@ -1,32 +1,45 @@
 package model
 
 import (
-	"errors"
 	"time"
 
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
 	"github.com/go-playground/validator/v10"
 )
 
 var (
-	ErrMatchingMeOrPartnerIDIsRequired = errors.New("matching me or partner id is required")
-	ErrMatchingStatusIsRequired        = errors.New("matching status is required")
-	ErrMatchingStatusIsInvalid         = errors.New("matching status is invalid")
-	ErrMatchingStatusIsNotPending      = errors.New("matching status is not pending")
+	ErrMatchingMeOrPartnerIDIsRequired = domainerr.NewSentinel(domainerr.ReasonMatchingMeOrPartnerIDRequired, "matching me or partner id is required")
+	ErrMatchingStatusIsRequired        = domainerr.NewSentinel(domainerr.ReasonMatchingStatusRequired, "matching status is required")
+	ErrMatchingStatusIsInvalid         = domainerr.NewSentinel(domainerr.ReasonMatchingStatusInvalid, "matching status is invalid")
+	ErrMatchingStatusIsNotPending      = domainerr.NewSentinel(domainerr.ReasonMatchingNotPending, "matching status is not pending")
+	ErrMatchingIsExpired               = domainerr.NewSentinel(domainerr.ReasonMatchingExpired, "matching is expired")
+	ErrMatchingIsNotOverdue            = domainerr.NewSentinel(domainerr.ReasonMatchingNotOverdue, "matching is not overdue")
+	ErrMatchingTransitionIsNotAllowed  = domainerr.NewSentinel(domainerr.ReasonMatchingTransitionNotAllowed, "matching status transition is not allowed")
+	ErrMatchingActorIsNotAllowed       = domainerr.NewSentinel(domainerr.ReasonMatchingActorNotAllowed, "matching actor is not allowed")
 )
 
+// MatchingPendingTTL is how long a pending matching waits for an answer before it expires.
+const MatchingPendingTTL = 7 * 24 * time.Hour
+
 type MatchingStatus string
 
 const (
-	MatchingStatusPending  MatchingStatus = "pending"
-	MatchingStatusAccepted MatchingStatus = "accepted"
-	MatchingStatusRejected MatchingStatus = "rejected"
+	MatchingStatusPending   MatchingStatus = "pending"
+	MatchingStatusAccepted  MatchingStatus = "accepted"
+	MatchingStatusRejected  MatchingStatus = "rejected"
+	MatchingStatusExpired   MatchingStatus = "expired"
+	MatchingStatusCancelled MatchingStatus = "cancelled"
+	MatchingStatusUnmatched MatchingStatus = "unmatched"
 )
 
 var MatchingStatuses = map[MatchingStatus]struct{}{
-	MatchingStatusPending:  {},
-	MatchingStatusAccepted: {},
-	MatchingStatusRejected: {},
+	MatchingStatusPending:   {},
+	MatchingStatusAccepted:  {},
+	MatchingStatusRejected:  {},
+	MatchingStatusExpired:   {},
+	MatchingStatusCancelled: {},
+	MatchingStatusUnmatched: {},
 }
 
 type Matching struct {
@@ -34,8 +47,12 @@ type Matching struct {
 	MeID      uuid.UUID      `validate:"required"`
 	PartnerID uuid.UUID      `validate:"required"`
 	Status    MatchingStatus `validate:"required,matching_status"`
-	CreatedAt time.Time      `validate:"required"`
-	UpdatedAt time.Time      `validate:"required"`
+	// ExpiresAt is when the matching expires unless answered. Zero means it never expires.
+	ExpiresAt time.Time
+	CreatedAt time.Time `validate:"required"`
+	UpdatedAt time.Time `validate:"required"`
+
+	events
 }
 
 type InputMatchingParams struct {
@@ -45,18 +62,44 @@ type InputMatchingParams struct {
 	Status    string
 }
 
-func NewMatching(params InputMatchingParams) *Matching {
+func NewMatching(params InputMatchingParams, now time.Time) *Matching {
 	if params.ID == uuid.Nil() {
 		params.ID = uuid.New()
 	}
-	return &Matching{
+	matching := &Matching{
 		ID:        params.ID,
 		MeID:      params.MeID,
 		PartnerID: params.PartnerID,
 		Status:    MatchingStatus(params.Status),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ExpiresAt: now.Add(MatchingPendingTTL),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	matching.recordEvent(EventMatchingCreated, "")
+	return matching
+}
+
+// MatchingPairKey returns the key of the unordered pair of users, which is the same for A->B and B->A.
+func MatchingPairKey(a, b uuid.UUID) string {
+	x, y := a.String(), b.String()
+	if x > y {
+		x, y = y, x
 	}
+	return x + ":" + y
+}
+
+func (m *Matching) PairKey() string {
+	return MatchingPairKey(m.MeID, m.PartnerID)
+}
+
+// IsMutual reports whether both users like each other.
+func (m *Matching) IsMutual() bool {
+	return m.Status == MatchingStatusAccepted
+}
+
+// IsAnswerableBy reports whether the user is the partner who can answer the pending matching.
+func (m *Matching) IsAnswerableBy(userID uuid.UUID, now time.Time) bool {
+	return m.CanTransition(MatchingActionAccept, userID, now) == nil
 }
 
 func (m *Matching) Validate() error {
@@ -79,18 +122,35 @@ func validateMatchingStatus(fl validator.FieldLevel) bool {
 	return exists
 }
 
-func (m *Matching) Accept() error {
-	if m.Status != MatchingStatusPending {
-		return ErrMatchingStatusIsNotPending
+// IsExpired reports whether the matching can no longer be answered,
+// including a pending matching that is overdue but not yet expired by the batch.
+func (m *Matching) IsExpired(now time.Time) bool {
+	if m.Status == MatchingStatusExpired {
+		return true
 	}
-	m.Status = MatchingStatusAccepted
-	return nil
+	return m.IsOverdue(now)
 }
 
-func (m *Matching) Reject() error {
-	if m.Status != MatchingStatusPending {
-		return ErrMatchingStatusIsNotPending
-	}
-	m.Status = MatchingStatusRejected
-	return nil
+func (m *Matching) IsOverdue(now time.Time) bool {
+	return m.Status == MatchingStatusPending && !m.ExpiresAt.IsZero() && !now.Before(m.ExpiresAt)
+}
+
+func (m *Matching) Accept(now time.Time) error {
+	return m.transition(MatchingActionAccept, now)
+}
+
+func (m *Matching) Reject(now time.Time) error {
+	return m.transition(MatchingActionReject, now)
+}
+
+func (m *Matching) Cancel(now time.Time) error {
+	return m.transition(MatchingActionCancel, now)
+}
+
+func (m *Matching) Unmatch(now time.Time) error {
+	return m.transition(MatchingActionUnmatch, now)
+}
+
+func (m *Matching) Expire(now time.Time) error {
+	return m.transition(MatchingActionExpire, now)
 }
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+const (
+	EventMatchingCreated   EventName = "matching.created"
+	EventMatchingAccepted  EventName = "matching.accepted"
+	EventMatchingRejected  EventName = "matching.rejected"
+	EventMatchingCancelled EventName = "matching.cancelled"
+	EventMatchingUnmatched EventName = "matching.unmatched"
+	EventMatchingReopened  EventName = "matching.reopened"
+	EventMatchingExpired   EventName = "matching.expired"
+	EventMatchingBlocked   EventName = "matching.blocked"
+)
+
+// matchingActionEvents is the event recorded for each action of the transition table.
+var matchingActionEvents = map[MatchingAction]EventName{
+	MatchingActionAccept:  EventMatchingAccepted,
+	MatchingActionReject:  EventMatchingRejected,
+	MatchingActionCancel:  EventMatchingCancelled,
+	MatchingActionUnmatch: EventMatchingUnmatched,
+	MatchingActionReopen:  EventMatchingReopened,
+	MatchingActionExpire:  EventMatchingExpired,
+	MatchingActionBlock:   EventMatchingBlocked,
+}
+
+type MatchingEvent struct {
+	Name       EventName
+	MatchingID uuid.UUID
+	MeID       uuid.UUID
+	PartnerID  uuid.UUID
+	// From is empty for a created matching.
+	From MatchingStatus
+	To   MatchingStatus
+	At   time.Time
+}
+
+func (e MatchingEvent) EventName() EventName {
+	return e.Name
+}
+
+func (e MatchingEvent) AggregateID() uuid.UUID {
+	return e.MatchingID
+}
+
+func (e MatchingEvent) OccurredAt() time.Time {
+	return e.At
+}
+
+func (m *Matching) recordEvent(name EventName, from MatchingStatus) {
+	m.record(MatchingEvent{
+		Name:       name,
+		MatchingID: m.ID,
+		MeID:       m.MeID,
+		PartnerID:  m.PartnerID,
+		From:       from,
+		To:         m.Status,
+		At:         m.UpdatedAt,
+	})
+}
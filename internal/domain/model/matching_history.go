@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// MatchingActionCreate is recorded for the first like, which is not a transition of the table.
+const MatchingActionCreate MatchingAction = "create"
+
+// MatchingHistory records a status change of a matching. It is append-only.
+type MatchingHistory struct {
+	ID         uuid.UUID
+	MatchingID uuid.UUID
+	// ActorID is uuid.Nil when the system performed the action.
+	ActorID   uuid.UUID
+	Action    MatchingAction
+	From      MatchingStatus
+	To        MatchingStatus
+	Reason    string
+	CreatedAt time.Time
+}
+
+type InputMatchingHistoryParams struct {
+	MatchingID uuid.UUID
+	ActorID    uuid.UUID
+	Action     MatchingAction
+	From       MatchingStatus
+	To         MatchingStatus
+	Reason     string
+}
+
+func NewMatchingHistory(params InputMatchingHistoryParams, now time.Time) *MatchingHistory {
+	return &MatchingHistory{
+		ID:         uuid.New(),
+		MatchingID: params.MatchingID,
+		ActorID:    params.ActorID,
+		Action:     params.Action,
+		From:       params.From,
+		To:         params.To,
+		Reason:     params.Reason,
+		CreatedAt:  now,
+	}
+}
+
+// NewMatchingCreatedHistory records the creation of a pending matching by its requester.
+func NewMatchingCreatedHistory(m *Matching) *MatchingHistory {
+	return NewMatchingHistory(InputMatchingHistoryParams{
+		MatchingID: m.ID,
+		ActorID:    m.MeID,
+		Action:     MatchingActionCreate,
+		To:         m.Status,
+	}, m.CreatedAt)
+}
+
+func (h *MatchingHistory) IsBySystem() bool {
+	return h.ActorID == uuid.Nil()
+}
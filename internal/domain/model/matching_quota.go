@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+var (
+	ErrMatchingQuotaExhausted = domainerr.NewSentinel(domainerr.ReasonMatchingQuotaExhausted, "daily matching quota is exhausted")
+)
+
+// MatchingQuota is how many new matching requests the user can send on the day.
+// Days are in UTC, so every quota resets at midnight UTC.
+type MatchingQuota struct {
+	UserID uuid.UUID
+	Day    time.Time
+	Limit  int
+	// Used includes the request being checked.
+	Used int
+}
+
+// MatchingQuotaDay returns the day of the quota the time belongs to.
+func MatchingQuotaDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func NewMatchingQuota(entitlement *Entitlement, now time.Time) *MatchingQuota {
+	return &MatchingQuota{
+		UserID: entitlement.UserID,
+		Day:    MatchingQuotaDay(now),
+		Limit:  entitlement.DailyMatchingLimit,
+	}
+}
+
+func (q *MatchingQuota) ResetAt() time.Time {
+	return q.Day.AddDate(0, 0, 1)
+}
+
+func (q *MatchingQuota) Remaining() int {
+	if q.Used >= q.Limit {
+		return 0
+	}
+	return q.Limit - q.Used
+}
+
+// Check returns ErrMatchingQuotaExhausted when the request being checked goes over the limit.
+func (q *MatchingQuota) Check() error {
+	if q.Used > q.Limit {
+		return ErrMatchingQuotaExhausted
+	}
+	return nil
+}
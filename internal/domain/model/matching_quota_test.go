@@ -0,0 +1,51 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func TestMatchingQuota(t *testing.T) {
+	limits := PlanLimits{PlanFree: 2, PlanPremium: 10}
+	now := time.Date(2024, 1, 31, 23, 30, 0, 0, time.FixedZone("JST", 9*60*60))
+
+	tests := []struct {
+		name          string
+		plan          Plan
+		used          int
+		wantLimit     int
+		wantRemaining int
+		wantErr       error
+	}{
+		{name: "OK: within the free limit", plan: PlanFree, used: 2, wantLimit: 2, wantRemaining: 0},
+		{name: "NG: over the free limit", plan: PlanFree, used: 3, wantLimit: 2, wantRemaining: 0, wantErr: ErrMatchingQuotaExhausted},
+		{name: "OK: premium has more", plan: PlanPremium, used: 3, wantLimit: 10, wantRemaining: 7},
+		{name: "OK: unknown plan falls back to free", plan: "gold", used: 1, wantLimit: 2, wantRemaining: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quota := NewMatchingQuota(NewEntitlement(uuid.New(), tt.plan, limits), now)
+			quota.Used = tt.used
+			if quota.Limit != tt.wantLimit {
+				t.Errorf("Limit = %d, want %d", quota.Limit, tt.wantLimit)
+			}
+			if got := quota.Remaining(); got != tt.wantRemaining {
+				t.Errorf("Remaining() = %d, want %d", got, tt.wantRemaining)
+			}
+			if err := quota.Check(); err != tt.wantErr {
+				t.Errorf("Check() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	quota := NewMatchingQuota(NewEntitlement(uuid.New(), PlanFree, limits), now)
+	if want := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC); !quota.Day.Equal(want) {
+		t.Errorf("Day = %v, want %v", quota.Day, want)
+	}
+	if want := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC); !quota.ResetAt().Equal(want) {
+		t.Errorf("ResetAt() = %v, want %v", quota.ResetAt(), want)
+	}
+}
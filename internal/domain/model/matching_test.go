@@ -41,22 +41,23 @@ func TestNewMatching(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := NewMatching(tt.args.params)
+			now := time.Now()
+			got := NewMatching(tt.args.params, now)
 
 			diff := cmp.Diff(
 				got, tt.want,
-				cmpopts.IgnoreFields(Matching{}, "ID", "CreatedAt", "UpdatedAt"),
+				cmpopts.IgnoreFields(Matching{}, "ID", "ExpiresAt", "CreatedAt", "UpdatedAt"),
+				cmpopts.IgnoreUnexported(Matching{}),
 			)
 			if diff != "" {
 				t.Errorf("NewMatching() mismatching (-got +want):\n%s", diff)
 			}
 
-			now := time.Now()
-			if got.CreatedAt.Sub(now) > time.Second {
-				t.Error("CreatedAt should be close to current time")
+			if !got.CreatedAt.Equal(now) || !got.UpdatedAt.Equal(now) {
+				t.Errorf("CreatedAt = %v, UpdatedAt = %v, want %v", got.CreatedAt, got.UpdatedAt, now)
 			}
-			if got.UpdatedAt.Sub(now) > time.Second {
-				t.Error("UpdatedAt should be close to current time")
+			if !got.ExpiresAt.Equal(now.Add(MatchingPendingTTL)) {
+				t.Errorf("ExpiresAt = %v, want %v", got.ExpiresAt, now.Add(MatchingPendingTTL))
 			}
 		})
 	}
@@ -144,6 +145,7 @@ func TestValidateMatching(t *testing.T) {
 }
 
 func TestMatching_Accept(t *testing.T) {
+	now := time.Now()
 	tests := []struct {
 		name     string
 		matching *Matching
@@ -163,11 +165,19 @@ func TestMatching_Accept(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "NG: matching is overdue",
+			matching: &Matching{
+				Status:    MatchingStatusPending,
+				ExpiresAt: now.Add(-time.Minute),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.matching.Accept()
+			err := tt.matching.Accept(now)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Accept() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -176,6 +186,7 @@ func TestMatching_Accept(t *testing.T) {
 }
 
 func TestMatching_Reject(t *testing.T) {
+	now := time.Now()
 	tests := []struct {
 		name     string
 		matching *Matching
@@ -195,14 +206,124 @@ func TestMatching_Reject(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "NG: matching is expired",
+			matching: &Matching{
+				Status: MatchingStatusExpired,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.matching.Reject()
+			err := tt.matching.Reject(now)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Reject() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestMatching_Expire(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		matching *Matching
+		wantErr  bool
+	}{
+		{
+			name: "OK: pending matching is overdue",
+			matching: &Matching{
+				Status:    MatchingStatusPending,
+				ExpiresAt: now.Add(-time.Minute),
+			},
+			wantErr: false,
+		},
+		{
+			name: "NG: pending matching is not overdue",
+			matching: &Matching{
+				Status:    MatchingStatusPending,
+				ExpiresAt: now.Add(time.Hour),
+			},
+			wantErr: true,
+		},
+		{
+			name: "NG: matching status is not pending",
+			matching: &Matching{
+				Status:    MatchingStatusAccepted,
+				ExpiresAt: now.Add(-time.Minute),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.matching.Expire(now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expire() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.matching.Status != MatchingStatusExpired {
+				t.Errorf("Expire() status = %v, want %v", tt.matching.Status, MatchingStatusExpired)
+			}
+		})
+	}
+}
+
+func TestMatchingPairKey(t *testing.T) {
+	a := uuid.New()
+	b := uuid.New()
+	if MatchingPairKey(a, b) != MatchingPairKey(b, a) {
+		t.Errorf("MatchingPairKey() differs by direction: %v, %v", MatchingPairKey(a, b), MatchingPairKey(b, a))
+	}
+	if MatchingPairKey(a, b) == MatchingPairKey(a, uuid.New()) {
+		t.Error("MatchingPairKey() is the same for different pairs")
+	}
+}
+
+func TestMatching_IsAnswerableBy(t *testing.T) {
+	now := time.Now()
+	meID := uuid.New()
+	partnerID := uuid.New()
+
+	tests := []struct {
+		name     string
+		matching *Matching
+		userID   uuid.UUID
+		want     bool
+	}{
+		{
+			name:     "OK: partner answers pending matching",
+			matching: &Matching{MeID: meID, PartnerID: partnerID, Status: MatchingStatusPending},
+			userID:   partnerID,
+			want:     true,
+		},
+		{
+			name:     "NG: requester cannot answer own matching",
+			matching: &Matching{MeID: meID, PartnerID: partnerID, Status: MatchingStatusPending},
+			userID:   meID,
+			want:     false,
+		},
+		{
+			name:     "NG: overdue matching",
+			matching: &Matching{MeID: meID, PartnerID: partnerID, Status: MatchingStatusPending, ExpiresAt: now.Add(-time.Minute)},
+			userID:   partnerID,
+			want:     false,
+		},
+		{
+			name:     "NG: accepted matching",
+			matching: &Matching{MeID: meID, PartnerID: partnerID, Status: MatchingStatusAccepted},
+			userID:   partnerID,
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matching.IsAnswerableBy(tt.userID, now); got != tt.want {
+				t.Errorf("IsAnswerableBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
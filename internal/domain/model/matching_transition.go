@@ -0,0 +1,157 @@
+package model
+
+import (
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type MatchingAction string
+
+const (
+	MatchingActionAccept  MatchingAction = "accept"
+	MatchingActionReject  MatchingAction = "reject"
+	MatchingActionCancel  MatchingAction = "cancel"
+	MatchingActionUnmatch MatchingAction = "unmatch"
+	MatchingActionReopen  MatchingAction = "reopen"
+	MatchingActionExpire  MatchingAction = "expire"
+	MatchingActionBlock   MatchingAction = "block"
+)
+
+// MatchingActor is the role of the user who is allowed to perform an action.
+type MatchingActor string
+
+const (
+	// MatchingActorRequester is the user who liked first (MeID).
+	MatchingActorRequester MatchingActor = "requester"
+	// MatchingActorPartner is the user who was liked (PartnerID).
+	MatchingActorPartner MatchingActor = "partner"
+	// MatchingActorParticipant is either of the users.
+	MatchingActorParticipant MatchingActor = "participant"
+	// MatchingActorSystem is a batch or another process, not a user.
+	MatchingActorSystem MatchingActor = "system"
+)
+
+type MatchingTransition struct {
+	To    MatchingStatus
+	Actor MatchingActor
+}
+
+// MatchingTransitions is the transition table of a matching: action -> from status -> transition.
+// A pair not listed in the table is not allowed.
+var MatchingTransitions = map[MatchingAction]map[MatchingStatus]MatchingTransition{
+	MatchingActionAccept: {
+		MatchingStatusPending: {To: MatchingStatusAccepted, Actor: MatchingActorPartner},
+	},
+	MatchingActionReject: {
+		MatchingStatusPending: {To: MatchingStatusRejected, Actor: MatchingActorPartner},
+	},
+	MatchingActionCancel: {
+		MatchingStatusPending: {To: MatchingStatusCancelled, Actor: MatchingActorRequester},
+	},
+	MatchingActionUnmatch: {
+		MatchingStatusAccepted: {To: MatchingStatusUnmatched, Actor: MatchingActorParticipant},
+	},
+	// Only the user who rejected can reopen, so that a rejected requester cannot keep asking again
+	MatchingActionReopen: {
+		MatchingStatusRejected:  {To: MatchingStatusPending, Actor: MatchingActorPartner},
+		MatchingStatusExpired:   {To: MatchingStatusPending, Actor: MatchingActorParticipant},
+		MatchingStatusCancelled: {To: MatchingStatusPending, Actor: MatchingActorParticipant},
+		MatchingStatusUnmatched: {To: MatchingStatusPending, Actor: MatchingActorParticipant},
+	},
+	MatchingActionExpire: {
+		MatchingStatusPending: {To: MatchingStatusExpired, Actor: MatchingActorSystem},
+	},
+	// Blocking either user rejects the pending matching, even an overdue one
+	MatchingActionBlock: {
+		MatchingStatusPending: {To: MatchingStatusRejected, Actor: MatchingActorParticipant},
+	},
+}
+
+// CanTransition checks that the actor can perform the action on the matching.
+// The actor is uuid.Nil for the system.
+func (m *Matching) CanTransition(action MatchingAction, actorID uuid.UUID, now time.Time) error {
+	t, err := m.lookupTransition(action, now)
+	if err != nil {
+		return err
+	}
+	if !m.isActor(t.Actor, actorID) {
+		return ErrMatchingActorIsNotAllowed
+	}
+	return nil
+}
+
+// Transition performs the action as the actor and returns the history entry to record.
+// On reopen, the actor becomes the requester of the new pending matching.
+func (m *Matching) Transition(action MatchingAction, actorID uuid.UUID, reason string, now time.Time) (*MatchingHistory, error) {
+	if err := m.CanTransition(action, actorID, now); err != nil {
+		return nil, err
+	}
+	from := m.Status
+	if action == MatchingActionReopen && m.MeID != actorID {
+		m.MeID, m.PartnerID = m.PartnerID, m.MeID
+	}
+	if err := m.transition(action, now); err != nil {
+		return nil, err
+	}
+	return NewMatchingHistory(InputMatchingHistoryParams{
+		MatchingID: m.ID,
+		ActorID:    actorID,
+		Action:     action,
+		From:       from,
+		To:         m.Status,
+		Reason:     reason,
+	}, now), nil
+}
+
+// transition applies the action without checking the actor, and records the event of the action.
+func (m *Matching) transition(action MatchingAction, now time.Time) error {
+	t, err := m.lookupTransition(action, now)
+	if err != nil {
+		return err
+	}
+	from := m.Status
+	m.Status = t.To
+	m.UpdatedAt = now
+	if t.To == MatchingStatusPending {
+		m.ExpiresAt = now.Add(MatchingPendingTTL)
+	}
+	m.recordEvent(matchingActionEvents[action], from)
+	return nil
+}
+
+func (m *Matching) lookupTransition(action MatchingAction, now time.Time) (MatchingTransition, error) {
+	switch action {
+	case MatchingActionExpire:
+		if m.Status == MatchingStatusPending && !m.IsOverdue(now) {
+			return MatchingTransition{}, ErrMatchingIsNotOverdue
+		}
+	case MatchingActionAccept, MatchingActionReject, MatchingActionCancel:
+		if m.IsExpired(now) {
+			return MatchingTransition{}, ErrMatchingIsExpired
+		}
+	}
+	t, ok := MatchingTransitions[action][m.Status]
+	if !ok {
+		if m.Status != MatchingStatusPending && (action == MatchingActionAccept || action == MatchingActionReject) {
+			return MatchingTransition{}, ErrMatchingStatusIsNotPending
+		}
+		return MatchingTransition{}, ErrMatchingTransitionIsNotAllowed
+	}
+	return t, nil
+}
+
+func (m *Matching) isActor(actor MatchingActor, actorID uuid.UUID) bool {
+	switch actor {
+	case MatchingActorRequester:
+		return actorID == m.MeID
+	case MatchingActorPartner:
+		return actorID == m.PartnerID
+	case MatchingActorParticipant:
+		return actorID == m.MeID || actorID == m.PartnerID
+	case MatchingActorSystem:
+		return actorID == uuid.Nil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,183 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func TestMatching_Transition(t *testing.T) {
+	now := time.Now()
+	meID := uuid.New()
+	partnerID := uuid.New()
+	otherID := uuid.New()
+
+	tests := []struct {
+		name          string
+		status        MatchingStatus
+		expiresAt     time.Time
+		action        MatchingAction
+		actorID       uuid.UUID
+		want          MatchingStatus
+		wantRequester uuid.UUID
+		wantErr       error
+	}{
+		{
+			name:          "OK: partner accepts pending matching",
+			status:        MatchingStatusPending,
+			action:        MatchingActionAccept,
+			actorID:       partnerID,
+			want:          MatchingStatusAccepted,
+			wantRequester: meID,
+		},
+		{
+			name:    "NG: requester cannot accept own matching",
+			status:  MatchingStatusPending,
+			action:  MatchingActionAccept,
+			actorID: meID,
+			want:    MatchingStatusPending,
+			wantErr: ErrMatchingActorIsNotAllowed,
+		},
+		{
+			name:          "OK: requester cancels pending matching",
+			status:        MatchingStatusPending,
+			action:        MatchingActionCancel,
+			actorID:       meID,
+			want:          MatchingStatusCancelled,
+			wantRequester: meID,
+		},
+		{
+			name:    "NG: partner cannot cancel",
+			status:  MatchingStatusPending,
+			action:  MatchingActionCancel,
+			actorID: partnerID,
+			want:    MatchingStatusPending,
+			wantErr: ErrMatchingActorIsNotAllowed,
+		},
+		{
+			name:    "NG: cancel overdue matching",
+			status:  MatchingStatusPending,
+			action:  MatchingActionCancel,
+			actorID: meID,
+			want:    MatchingStatusPending,
+			// The matching is already expired, even if the batch has not run yet
+			expiresAt: now.Add(-time.Minute),
+			wantErr:   ErrMatchingIsExpired,
+		},
+		{
+			name:          "OK: partner unmatches accepted matching",
+			status:        MatchingStatusAccepted,
+			action:        MatchingActionUnmatch,
+			actorID:       partnerID,
+			want:          MatchingStatusUnmatched,
+			wantRequester: meID,
+		},
+		{
+			name:    "NG: other user cannot unmatch",
+			status:  MatchingStatusAccepted,
+			action:  MatchingActionUnmatch,
+			actorID: otherID,
+			want:    MatchingStatusAccepted,
+			wantErr: ErrMatchingActorIsNotAllowed,
+		},
+		{
+			name:    "NG: unmatch pending matching",
+			status:  MatchingStatusPending,
+			action:  MatchingActionUnmatch,
+			actorID: meID,
+			want:    MatchingStatusPending,
+			wantErr: ErrMatchingTransitionIsNotAllowed,
+		},
+		{
+			name:          "OK: rejecter reopens and becomes requester",
+			status:        MatchingStatusRejected,
+			action:        MatchingActionReopen,
+			actorID:       partnerID,
+			want:          MatchingStatusPending,
+			wantRequester: partnerID,
+		},
+		{
+			name:    "NG: rejected requester cannot reopen",
+			status:  MatchingStatusRejected,
+			action:  MatchingActionReopen,
+			actorID: meID,
+			want:    MatchingStatusRejected,
+			wantErr: ErrMatchingActorIsNotAllowed,
+		},
+		{
+			name:          "OK: requester reopens unmatched matching",
+			status:        MatchingStatusUnmatched,
+			action:        MatchingActionReopen,
+			actorID:       meID,
+			want:          MatchingStatusPending,
+			wantRequester: meID,
+		},
+		{
+			name:          "OK: block rejects overdue pending matching",
+			status:        MatchingStatusPending,
+			expiresAt:     now.Add(-time.Minute),
+			action:        MatchingActionBlock,
+			actorID:       meID,
+			want:          MatchingStatusRejected,
+			wantRequester: meID,
+		},
+		{
+			name:    "NG: block does not change accepted matching",
+			status:  MatchingStatusAccepted,
+			action:  MatchingActionBlock,
+			actorID: partnerID,
+			want:    MatchingStatusAccepted,
+			wantErr: ErrMatchingTransitionIsNotAllowed,
+		},
+		{
+			name:    "NG: user cannot expire",
+			status:  MatchingStatusPending,
+			action:  MatchingActionExpire,
+			actorID: meID,
+			want:    MatchingStatusPending,
+			// Checked before the actor, since the matching is not overdue
+			wantErr: ErrMatchingIsNotOverdue,
+		},
+		{
+			name:          "OK: system expires overdue matching",
+			status:        MatchingStatusPending,
+			expiresAt:     now.Add(-time.Minute),
+			action:        MatchingActionExpire,
+			actorID:       uuid.Nil(),
+			want:          MatchingStatusExpired,
+			wantRequester: meID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Matching{ID: uuid.New(), MeID: meID, PartnerID: partnerID, Status: tt.status, ExpiresAt: tt.expiresAt}
+			history, err := m.Transition(tt.action, tt.actorID, "reason", now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Transition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if m.Status != tt.want {
+				t.Errorf("Transition() status = %v, want %v", m.Status, tt.want)
+			}
+			events := m.PullEvents()
+			if tt.wantErr != nil {
+				if len(events) != 0 {
+					t.Errorf("Transition() events = %+v, want none", events)
+				}
+				return
+			}
+			if m.MeID != tt.wantRequester {
+				t.Errorf("Transition() requester = %v, want %v", m.MeID, tt.wantRequester)
+			}
+			if history.From != tt.status || history.To != tt.want || history.ActorID != tt.actorID || history.Action != tt.action {
+				t.Errorf("Transition() history = %+v", history)
+			}
+			want := MatchingEvent{Name: matchingActionEvents[tt.action], MatchingID: m.ID, MeID: m.MeID, PartnerID: m.PartnerID, From: tt.status, To: tt.want, At: m.UpdatedAt}
+			if len(events) != 1 || events[0] != want {
+				t.Errorf("Transition() events = %+v, want %+v", events, want)
+			}
+		})
+	}
+}
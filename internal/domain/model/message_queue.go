@@ -21,12 +21,12 @@ type MessageDTO struct {
 	MessageAttributes MessageAttributes
 }
 
-func NewMessage(body string, attributes MessageAttributes) *Message {
+func NewMessage(body string, attributes MessageAttributes, now time.Time) *Message {
 	return &Message{
 		ID:         uuid.New(),
 		Body:       body,
 		Attributes: attributes,
-		CreatedAt:  time.Now(),
+		CreatedAt:  now,
 	}
 }
 
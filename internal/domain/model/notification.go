@@ -0,0 +1,168 @@
+package model
+
+import (
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+var (
+	ErrNotificationTypeUnknown    = domainerr.NewSentinel(domainerr.ReasonNotificationTypeUnknown, "notification type is unknown")
+	ErrNotificationChannelUnknown = domainerr.NewSentinel(domainerr.ReasonNotificationChannelUnknown, "notification channel is unknown")
+)
+
+type NotificationType string
+
+const (
+	NotificationTypeMatchingCreated  NotificationType = "matching_created"
+	NotificationTypeMatchingAccepted NotificationType = "matching_accepted"
+)
+
+// notificationTemplates is the title and body of each type of notification.
+var notificationTemplates = map[NotificationType]struct{ title, body string }{
+	NotificationTypeMatchingCreated: {
+		title: "Someone likes you",
+		body:  "You received a new like. Check it out and like them back to match.",
+	},
+	NotificationTypeMatchingAccepted: {
+		title: "It's a match!",
+		body:  "Your like was accepted. Say hello to your new match.",
+	},
+}
+
+// NotificationChannel is how a notification reaches the user.
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp NotificationChannel = "in_app"
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelPush  NotificationChannel = "push"
+)
+
+var NotificationChannels = map[NotificationChannel]struct{}{
+	NotificationChannelInApp: {},
+	NotificationChannelEmail: {},
+	NotificationChannelPush:  {},
+}
+
+// Notification tells the user about something that happened to them. The in-app channel keeps it in the inbox of the user.
+type Notification struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	Type   NotificationType
+	Title  string
+	Body   string
+	// Data is what a client needs to open the subject of the notification, such as the ID of the matching.
+	Data map[string]string
+	// ReadAt is set once the user reads the notification in the inbox, and zero until then.
+	ReadAt    time.Time
+	CreatedAt time.Time
+}
+
+// NewNotification renders the notification of the type for the user.
+// The ID is given by the producer, so that delivering the same notification twice keeps a single entry in the inbox.
+func NewNotification(id, userID uuid.UUID, typ NotificationType, data map[string]string, now time.Time) (*Notification, error) {
+	template, ok := notificationTemplates[typ]
+	if !ok {
+		return nil, ErrNotificationTypeUnknown
+	}
+	if data == nil {
+		data = map[string]string{}
+	}
+	return &Notification{
+		ID:        id,
+		UserID:    userID,
+		Type:      typ,
+		Title:     template.title,
+		Body:      template.body,
+		Data:      data,
+		CreatedAt: now.Truncate(time.Microsecond),
+	}, nil
+}
+
+func (n *Notification) IsRead() bool {
+	return !n.ReadAt.IsZero()
+}
+
+// NotificationPreference is the channels the user receives notifications through.
+type NotificationPreference struct {
+	UserID uuid.UUID
+	// Channels turns the channels on or off. A channel missing from the map is on.
+	Channels  map[NotificationChannel]bool
+	UpdatedAt time.Time
+}
+
+// NewNotificationPreference is the preference of a user who has not chosen any, with every channel on.
+func NewNotificationPreference(userID uuid.UUID, now time.Time) *NotificationPreference {
+	return &NotificationPreference{
+		UserID:    userID,
+		Channels:  map[NotificationChannel]bool{},
+		UpdatedAt: now,
+	}
+}
+
+func (p *NotificationPreference) Allows(channel NotificationChannel) bool {
+	enabled, ok := p.Channels[channel]
+	return !ok || enabled
+}
+
+// Set turns the channels on or off, leaving the others as they are.
+func (p *NotificationPreference) Set(channels map[NotificationChannel]bool, now time.Time) error {
+	for channel := range channels {
+		if _, ok := NotificationChannels[channel]; !ok {
+			return ErrNotificationChannelUnknown
+		}
+	}
+	for channel, enabled := range channels {
+		p.Channels[channel] = enabled
+	}
+	p.UpdatedAt = now
+	return nil
+}
+
+// NotificationDeliveryMaxAttempts is how many times a notification is delivered to a channel before it is given up on that channel.
+const NotificationDeliveryMaxAttempts = 5
+
+// NotificationDelivery is the delivery of a notification to one channel.
+// It is kept so that a notification failed on a channel is delivered again to that channel only.
+type NotificationDelivery struct {
+	NotificationID uuid.UUID
+	Channel        NotificationChannel
+	Attempts       int
+	// LastError is the error of the last failed attempt, and empty otherwise.
+	LastError string
+	// DeliveredAt is set once the notification is delivered, and zero until then.
+	DeliveredAt time.Time
+	UpdatedAt   time.Time
+}
+
+func NewNotificationDelivery(notificationID uuid.UUID, channel NotificationChannel, now time.Time) *NotificationDelivery {
+	return &NotificationDelivery{
+		NotificationID: notificationID,
+		Channel:        channel,
+		UpdatedAt:      now,
+	}
+}
+
+func (d *NotificationDelivery) IsDelivered() bool {
+	return !d.DeliveredAt.IsZero()
+}
+
+// IsGivenUp tells whether the delivery failed NotificationDeliveryMaxAttempts times and is not to be retried.
+func (d *NotificationDelivery) IsGivenUp() bool {
+	return !d.IsDelivered() && d.Attempts >= NotificationDeliveryMaxAttempts
+}
+
+func (d *NotificationDelivery) MarkDelivered(now time.Time) {
+	d.Attempts++
+	d.LastError = ""
+	d.DeliveredAt = now
+	d.UpdatedAt = now
+}
+
+func (d *NotificationDelivery) MarkFailed(err error, now time.Time) {
+	d.Attempts++
+	d.LastError = err.Error()
+	d.UpdatedAt = now
+}
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func TestNewNotification(t *testing.T) {
+	id, userID := uuid.New(), uuid.New()
+	notification, err := NewNotification(id, userID, NotificationTypeMatchingAccepted, nil, time.Now())
+	if err != nil {
+		t.Fatalf("NewNotification() error = %v", err)
+	}
+	if notification.ID != id || notification.UserID != userID || notification.Title == "" || notification.Data == nil || notification.IsRead() {
+		t.Errorf("NewNotification() = %+v", notification)
+	}
+
+	if _, err := NewNotification(id, userID, NotificationType("unknown"), nil, time.Now()); !errors.Is(err, ErrNotificationTypeUnknown) {
+		t.Errorf("NewNotification() error = %v, want %v", err, ErrNotificationTypeUnknown)
+	}
+}
+
+func TestNotificationPreference_Set(t *testing.T) {
+	tests := []struct {
+		name     string
+		channels map[NotificationChannel]bool
+		want     map[NotificationChannel]bool
+		wantErr  error
+	}{
+		{
+			name:     "OK_Default",
+			channels: map[NotificationChannel]bool{},
+			want:     map[NotificationChannel]bool{NotificationChannelInApp: true, NotificationChannelEmail: true, NotificationChannelPush: true},
+		},
+		{
+			name:     "OK_EmailOff",
+			channels: map[NotificationChannel]bool{NotificationChannelEmail: false},
+			want:     map[NotificationChannel]bool{NotificationChannelInApp: true, NotificationChannelEmail: false, NotificationChannelPush: true},
+		},
+		{
+			name:     "NG_UnknownChannel",
+			channels: map[NotificationChannel]bool{NotificationChannelPush: false, "sms": false},
+			want:     map[NotificationChannel]bool{NotificationChannelInApp: true, NotificationChannelEmail: true, NotificationChannelPush: true},
+			wantErr:  ErrNotificationChannelUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			preference := NewNotificationPreference(uuid.New(), now)
+			if err := preference.Set(tt.channels, now); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Set() error = %v, want %v", err, tt.wantErr)
+			}
+			got := map[NotificationChannel]bool{}
+			for channel := range NotificationChannels {
+				got[channel] = preference.Allows(channel)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Allows() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNotificationDelivery(t *testing.T) {
+	now := time.Now()
+	d := NewNotificationDelivery(uuid.New(), NotificationChannelEmail, now)
+
+	for i := 0; i < NotificationDeliveryMaxAttempts-1; i++ {
+		d.MarkFailed(errors.New("mailer is down"), now)
+	}
+	if d.IsDelivered() || d.IsGivenUp() || d.LastError != "mailer is down" {
+		t.Errorf("MarkFailed() = %+v, want retried", d)
+	}
+
+	d.MarkDelivered(now)
+	if !d.IsDelivered() || d.IsGivenUp() || d.LastError != "" {
+		t.Errorf("MarkDelivered() = %+v", d)
+	}
+
+	d = NewNotificationDelivery(uuid.New(), NotificationChannelEmail, now)
+	for i := 0; i < NotificationDeliveryMaxAttempts; i++ {
+		d.MarkFailed(errors.New("mailer is down"), now)
+	}
+	if !d.IsGivenUp() {
+		t.Errorf("MarkFailed() = %+v, want given up after %d attempts", d, NotificationDeliveryMaxAttempts)
+	}
+}
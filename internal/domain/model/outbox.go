@@ -0,0 +1,70 @@
+package model
+
+import (
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// OutboxMaxAttempts is how many times the relay tries to publish a message before leaving it for an operator.
+const OutboxMaxAttempts = 10
+
+// OutboxDestination is the queue an outbox message is published to.
+type OutboxDestination string
+
+const (
+	OutboxDestinationUserDeletion OutboxDestination = "user_deletion"
+	OutboxDestinationMail         OutboxDestination = "mail"
+	OutboxDestinationModeration   OutboxDestination = "moderation"
+	OutboxDestinationNotification OutboxDestination = "notification"
+)
+
+// OutboxMessage is a message written in the same transaction as the state change it announces,
+// and published to its destination by the relay afterwards, at least once.
+type OutboxMessage struct {
+	ID          uuid.UUID
+	Destination OutboxDestination
+	Body        string
+	Attributes  MessageAttributes
+	Attempts    int
+	// LastError is the error of the last failed attempt to publish, and empty otherwise.
+	LastError string
+	CreatedAt time.Time
+	// SentAt is set once the message is published, and zero until then.
+	SentAt time.Time
+}
+
+func NewOutboxMessage(destination OutboxDestination, message *Message, now time.Time) *OutboxMessage {
+	return &OutboxMessage{
+		ID:          uuid.New(),
+		Destination: destination,
+		Body:        message.Body,
+		Attributes:  message.Attributes,
+		CreatedAt:   now,
+	}
+}
+
+func (m *OutboxMessage) IsSent() bool {
+	return !m.SentAt.IsZero()
+}
+
+// Message returns the message to publish. The outbox ID is attached so that consumers can drop a redelivery.
+func (m *OutboxMessage) Message() *Message {
+	attributes := MessageAttributes{"outboxId": m.ID.String()}
+	for k, v := range m.Attributes {
+		attributes[k] = v
+	}
+	return &Message{Body: m.Body, Attributes: attributes}
+}
+
+func (m *OutboxMessage) MarkSent(now time.Time) {
+	m.Attempts++
+	m.LastError = ""
+	m.SentAt = now
+}
+
+// MarkFailed records a failed attempt. The message is retried until it reaches OutboxMaxAttempts.
+func (m *OutboxMessage) MarkFailed(err error) {
+	m.Attempts++
+	m.LastError = err.Error()
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOutboxMessage(t *testing.T) {
+	now := time.Now()
+	m := NewOutboxMessage(OutboxDestinationUserDeletion, &Message{
+		Body:       "body",
+		Attributes: MessageAttributes{"messageType": "user_deletion"},
+	}, now)
+
+	msg := m.Message()
+	if msg.Body != "body" || msg.Attributes["messageType"] != "user_deletion" || msg.Attributes["outboxId"] != m.ID.String() {
+		t.Errorf("Message() = %+v", msg)
+	}
+
+	m.MarkFailed(errors.New("queue is down"))
+	if m.IsSent() || m.Attempts != 1 || m.LastError != "queue is down" {
+		t.Errorf("MarkFailed() = %+v", m)
+	}
+
+	m.MarkSent(now)
+	if !m.IsSent() || m.Attempts != 2 || m.LastError != "" {
+		t.Errorf("MarkSent() = %+v", m)
+	}
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// Recommendation is a candidate partner recommended to the user.
+type Recommendation struct {
+	UserID      uuid.UUID
+	CandidateID uuid.UUID
+	// Score is the weighted score of the candidate from 0 to 1. Higher is better.
+	Score float64
+	// Scores are the unweighted scores by scorer name, which explain the Score.
+	Scores      map[string]float64
+	GeneratedAt time.Time
+}
@@ -0,0 +1,198 @@
+package model
+
+import (
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	ErrReportSelf              = domainerr.NewSentinel(domainerr.ReasonReportSelf, "user cannot report themselves")
+	ErrReportIsResolved        = domainerr.NewSentinel(domainerr.ReasonReportResolved, "report is already resolved")
+	ErrReportIsNotAssigned     = domainerr.NewSentinel(domainerr.ReasonReportNotAssigned, "report is not assigned")
+	ErrReportAssigneeIsInvalid = domainerr.NewSentinel(domainerr.ReasonReportAssigneeInvalid, "report assignee is invalid")
+	ErrReportResolutionInvalid = domainerr.NewSentinel(domainerr.ReasonReportResolutionInvalid, "report resolution is invalid")
+)
+
+type ReportReason string
+
+const (
+	ReportReasonSpam                 ReportReason = "spam"
+	ReportReasonHarassment           ReportReason = "harassment"
+	ReportReasonInappropriateContent ReportReason = "inappropriate_content"
+	ReportReasonFakeProfile          ReportReason = "fake_profile"
+	ReportReasonUnderage             ReportReason = "underage"
+	ReportReasonOther                ReportReason = "other"
+)
+
+var ReportReasons = map[ReportReason]struct{}{
+	ReportReasonSpam:                 {},
+	ReportReasonHarassment:           {},
+	ReportReasonInappropriateContent: {},
+	ReportReasonFakeProfile:          {},
+	ReportReasonUnderage:             {},
+	ReportReasonOther:                {},
+}
+
+// ReportStatus is the state of a report in the moderation queue: open -> assigned -> resolved.
+type ReportStatus string
+
+const (
+	ReportStatusOpen     ReportStatus = "open"
+	ReportStatusAssigned ReportStatus = "assigned"
+	ReportStatusResolved ReportStatus = "resolved"
+)
+
+var ReportStatuses = map[ReportStatus]struct{}{
+	ReportStatusOpen:     {},
+	ReportStatusAssigned: {},
+	ReportStatusResolved: {},
+}
+
+// ReportResolution is the outcome decided by the moderator.
+type ReportResolution string
+
+const (
+	ReportResolutionDismissed ReportResolution = "dismissed"
+	ReportResolutionWarned    ReportResolution = "warned"
+	ReportResolutionSuspended ReportResolution = "suspended"
+)
+
+var ReportResolutions = map[ReportResolution]struct{}{
+	ReportResolutionDismissed: {},
+	ReportResolutionWarned:    {},
+	ReportResolutionSuspended: {},
+}
+
+// Report is a complaint from the reporter that the reported user violates the terms of service.
+type Report struct {
+	ID         uuid.UUID    `validate:"required"`
+	ReporterID uuid.UUID    `validate:"required"`
+	ReportedID uuid.UUID    `validate:"required"`
+	Reason     ReportReason `validate:"required,report_reason"`
+	Comment    string       `validate:"max=1000"`
+	Status     ReportStatus `validate:"required,report_status"`
+	// AssigneeID is the moderator in charge of the report. Nil means unassigned.
+	AssigneeID     uuid.UUID
+	Resolution     ReportResolution `validate:"omitempty,report_resolution"`
+	ResolutionNote string           `validate:"max=1000"`
+	// ResolvedAt is zero until the report is resolved.
+	ResolvedAt time.Time
+	CreatedAt  time.Time `validate:"required"`
+	UpdatedAt  time.Time `validate:"required"`
+}
+
+type InputReportParams struct {
+	ID         uuid.UUID
+	ReporterID uuid.UUID
+	ReportedID uuid.UUID
+	Reason     string
+	Comment    string
+}
+
+func NewReport(params InputReportParams, now time.Time) *Report {
+	if params.ID == uuid.Nil() {
+		params.ID = uuid.New()
+	}
+	return &Report{
+		ID:         params.ID,
+		ReporterID: params.ReporterID,
+		ReportedID: params.ReportedID,
+		Reason:     ReportReason(params.Reason),
+		Comment:    params.Comment,
+		Status:     ReportStatusOpen,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+func (r *Report) Validate() error {
+	validate := validator.New()
+	if err := validate.RegisterValidation("report_reason", validateReportReason); err != nil {
+		return err
+	}
+	if err := validate.RegisterValidation("report_status", validateReportStatus); err != nil {
+		return err
+	}
+	if err := validate.RegisterValidation("report_resolution", validateReportResolution); err != nil {
+		return err
+	}
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	if r.ReporterID == r.ReportedID {
+		return ErrReportSelf
+	}
+	return nil
+}
+
+func validateReportReason(fl validator.FieldLevel) bool {
+	reason, ok := fl.Field().Interface().(ReportReason)
+	if !ok {
+		return false
+	}
+	_, exists := ReportReasons[reason]
+	return exists
+}
+
+func validateReportStatus(fl validator.FieldLevel) bool {
+	status, ok := fl.Field().Interface().(ReportStatus)
+	if !ok {
+		return false
+	}
+	_, exists := ReportStatuses[status]
+	return exists
+}
+
+func validateReportResolution(fl validator.FieldLevel) bool {
+	resolution, ok := fl.Field().Interface().(ReportResolution)
+	if !ok {
+		return false
+	}
+	_, exists := ReportResolutions[resolution]
+	return exists
+}
+
+func (r *Report) IsResolved() bool {
+	return r.Status == ReportStatusResolved
+}
+
+// Assign hands the report to the moderator. An unresolved report can be reassigned.
+func (r *Report) Assign(assigneeID uuid.UUID, now time.Time) error {
+	if r.IsResolved() {
+		return ErrReportIsResolved
+	}
+	if assigneeID == uuid.Nil() {
+		return ErrReportAssigneeIsInvalid
+	}
+	r.AssigneeID = assigneeID
+	r.Status = ReportStatusAssigned
+	r.UpdatedAt = now
+	return nil
+}
+
+// Resolve closes the assigned report with the outcome decided by the moderator.
+func (r *Report) Resolve(resolution ReportResolution, note string, now time.Time) error {
+	if r.IsResolved() {
+		return ErrReportIsResolved
+	}
+	if r.Status != ReportStatusAssigned {
+		return ErrReportIsNotAssigned
+	}
+	if _, ok := ReportResolutions[resolution]; !ok {
+		return ErrReportResolutionInvalid
+	}
+	r.Status = ReportStatusResolved
+	r.Resolution = resolution
+	r.ResolutionNote = note
+	r.ResolvedAt = now
+	r.UpdatedAt = now
+	return nil
+}
+
+// SuspendsReported reports whether the resolution suspends the reported user.
+func (r *Report) SuspendsReported() bool {
+	return r.IsResolved() && r.Resolution == ReportResolutionSuspended
+}
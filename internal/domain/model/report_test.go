@@ -0,0 +1,95 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func TestValidateReport(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		params  InputReportParams
+		wantErr bool
+	}{
+		{
+			name:    "OK: valid report",
+			params:  InputReportParams{ReporterID: userID, ReportedID: uuid.New(), Reason: "spam"},
+			wantErr: false,
+		},
+		{
+			name:    "NG: report self",
+			params:  InputReportParams{ReporterID: userID, ReportedID: userID, Reason: "spam"},
+			wantErr: true,
+		},
+		{
+			name:    "NG: invalid reason",
+			params:  InputReportParams{ReporterID: userID, ReportedID: uuid.New(), Reason: "boring"},
+			wantErr: true,
+		},
+		{
+			name:    "NG: empty reported id",
+			params:  InputReportParams{ReporterID: userID, ReportedID: uuid.Nil(), Reason: "spam"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewReport(tt.params, time.Now()).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReportWorkflow(t *testing.T) {
+	now := time.Now()
+	newReport := func() *Report {
+		return NewReport(InputReportParams{ReporterID: uuid.New(), ReportedID: uuid.New(), Reason: "harassment"}, now)
+	}
+	moderatorID := uuid.New()
+
+	t.Run("OK: assign and resolve with suspension", func(t *testing.T) {
+		report := newReport()
+		if err := report.Assign(moderatorID, now); err != nil {
+			t.Fatalf("Assign() error = %v", err)
+		}
+		if err := report.Resolve(ReportResolutionSuspended, "repeated harassment", now); err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+		if !report.SuspendsReported() || report.ResolvedAt.IsZero() {
+			t.Errorf("Resolve() got status = %v, resolution = %v", report.Status, report.Resolution)
+		}
+	})
+
+	t.Run("NG: resolve without assignment", func(t *testing.T) {
+		if err := newReport().Resolve(ReportResolutionDismissed, "", now); err != ErrReportIsNotAssigned {
+			t.Errorf("Resolve() error = %v, want %v", err, ErrReportIsNotAssigned)
+		}
+	})
+
+	t.Run("NG: resolve with invalid resolution", func(t *testing.T) {
+		report := newReport()
+		_ = report.Assign(moderatorID, now)
+		if err := report.Resolve("banned", "", now); err != ErrReportResolutionInvalid {
+			t.Errorf("Resolve() error = %v, want %v", err, ErrReportResolutionInvalid)
+		}
+		if report.IsResolved() {
+			t.Error("Resolve() resolved the report with an invalid resolution")
+		}
+	})
+
+	t.Run("NG: assign resolved report", func(t *testing.T) {
+		report := newReport()
+		_ = report.Assign(moderatorID, now)
+		_ = report.Resolve(ReportResolutionDismissed, "", now)
+		if err := report.Assign(uuid.New(), now); err != ErrReportIsResolved {
+			t.Errorf("Assign() error = %v, want %v", err, ErrReportIsResolved)
+		}
+	})
+}
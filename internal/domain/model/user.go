@@ -1,40 +1,355 @@
 package model
 
 import (
+	"strings"
 	"time"
 
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
 	"github.com/go-playground/validator/v10"
 )
 
+const (
+	// UserMinimumAge is the minimum age in years to use the service.
+	UserMinimumAge = 18
+	// UserMaxInterests is the maximum number of interests on a profile.
+	UserMaxInterests = 20
+)
+
+var (
+	ErrUserStatusIsNotActive       = domainerr.NewSentinel(domainerr.ReasonUserNotActive, "user status is not active")
+	ErrUserStatusIsSuspended       = domainerr.NewSentinel(domainerr.ReasonUserSuspended, "user status is suspended")
+	ErrUserStatusIsNotSuspended    = domainerr.NewSentinel(domainerr.ReasonUserNotSuspended, "user status is not suspended")
+	ErrUserStatusIsWithdrawn       = domainerr.NewSentinel(domainerr.ReasonUserWithdrawn, "user status is withdrawn")
+	ErrUserStatusIsNotWithdrawn    = domainerr.NewSentinel(domainerr.ReasonUserNotWithdrawn, "user status is not withdrawn")
+	ErrUserGracePeriodExpired      = domainerr.NewSentinel(domainerr.ReasonUserGracePeriodExpired, "user withdrawal grace period has expired")
+	ErrUserEmailIsVerified         = domainerr.NewSentinel(domainerr.ReasonUserEmailVerified, "user email is already verified")
+	ErrUserEmailIsEmpty            = domainerr.NewSentinel(domainerr.ReasonUserEmailEmpty, "user email is empty")
+	ErrUserEmailIsUnchanged        = domainerr.NewSentinel(domainerr.ReasonUserEmailUnchanged, "user email is unchanged")
+	ErrUserEmailChangeIsNotPending = domainerr.NewSentinel(domainerr.ReasonUserEmailChangeNotPending, "user email change is not pending")
+)
+
+type UserStatus string
+
+const (
+	UserStatusActive    UserStatus = "active"
+	UserStatusSuspended UserStatus = "suspended"
+	UserStatusWithdrawn UserStatus = "withdrawn"
+)
+
+var UserStatuses = map[UserStatus]struct{}{
+	UserStatusActive:    {},
+	UserStatusSuspended: {},
+	UserStatusWithdrawn: {},
+}
+
+type UserGender string
+
+const (
+	UserGenderMale   UserGender = "male"
+	UserGenderFemale UserGender = "female"
+	UserGenderOther  UserGender = "other"
+)
+
+var UserGenders = map[UserGender]struct{}{
+	UserGenderMale:   {},
+	UserGenderFemale: {},
+	UserGenderOther:  {},
+}
+
 type User struct {
-	ID        uuid.UUID `validate:"required"`
-	Email     string    `validate:"required,email"`
-	CreatedAt time.Time `validate:"required"`
-	UpdatedAt time.Time `validate:"required"`
+	ID    uuid.UUID `validate:"required"`
+	Email string    `validate:"required,email"`
+	// PendingEmail is the new email waiting for confirmation, and empty otherwise.
+	PendingEmail string     `validate:"omitempty,email"`
+	DisplayName  string     `validate:"max=50"`
+	Birthdate    time.Time  `validate:"user_min_age"`
+	Gender       UserGender `validate:"omitempty,user_gender"`
+	Bio          string     `validate:"max=500"`
+	Locale       string     `validate:"omitempty,bcp47_language_tag"`
+	Interests    []string   `validate:"max=20,dive,min=1,max=30,excludesall=0x2C"`
+	Status       UserStatus `validate:"required,user_status"`
+	CreatedAt    time.Time  `validate:"required"`
+	UpdatedAt    time.Time  `validate:"required"`
+	// EmailVerifiedAt is set once the user proves to own the email, and zero until then.
+	EmailVerifiedAt time.Time
+	// DeletedAt is set while the user is withdrawn, and zero otherwise.
+	DeletedAt time.Time
+	// DeletionEnqueuedAt is when the permanent deletion of the withdrawn user was last enqueued, and zero otherwise.
+	DeletionEnqueuedAt time.Time
+
+	events
 }
 
 type InputUserParams struct {
-	ID    uuid.UUID
-	Email string
+	ID          uuid.UUID
+	Email       string
+	DisplayName string
+	Birthdate   time.Time
+	Gender      string
+	Bio         string
+	Locale      string
+	Interests   []string
 }
 
-func NewUser(params InputUserParams) *User {
+// NewUser returns an active user whose email is not verified yet.
+func NewUser(params InputUserParams, now time.Time) *User {
 	if params.ID == uuid.Nil() {
 		params.ID = uuid.New()
 	}
-	return &User{
-		ID:        params.ID,
-		Email:     params.Email,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	user := &User{
+		ID:          params.ID,
+		Email:       params.Email,
+		DisplayName: params.DisplayName,
+		Birthdate:   params.Birthdate,
+		Gender:      UserGender(params.Gender),
+		Bio:         params.Bio,
+		Locale:      params.Locale,
+		Interests:   NormalizeInterests(params.Interests),
+		Status:      UserStatusActive,
+		CreatedAt:   now,
+		UpdatedAt:   now,
 	}
+	user.recordEvent(EventUserCreated)
+	return user
 }
 
 func (u *User) Validate() error {
 	validate := validator.New()
+	if err := validate.RegisterValidation("user_status", validateUserStatus); err != nil {
+		return err
+	}
+	if err := validate.RegisterValidation("user_gender", validateUserGender); err != nil {
+		return err
+	}
+	if err := validate.RegisterValidation("user_min_age", validateUserMinAge); err != nil {
+		return err
+	}
 	if err := validate.Struct(u); err != nil {
 		return err
 	}
 	return nil
 }
+
+func validateUserStatus(fl validator.FieldLevel) bool {
+	status, ok := fl.Field().Interface().(UserStatus)
+	if !ok {
+		return false
+	}
+	_, exists := UserStatuses[status]
+	return exists
+}
+
+func validateUserGender(fl validator.FieldLevel) bool {
+	gender, ok := fl.Field().Interface().(UserGender)
+	if !ok {
+		return false
+	}
+	_, exists := UserGenders[gender]
+	return exists
+}
+
+// validateUserMinAge accepts an unset birthdate, since the profile may be completed later.
+// The age is checked as of the last update, which is when the birthdate was given.
+func validateUserMinAge(fl validator.FieldLevel) bool {
+	birthdate, ok := fl.Field().Interface().(time.Time)
+	if !ok {
+		return false
+	}
+	if birthdate.IsZero() {
+		return true
+	}
+	updatedAt, ok := fl.Parent().FieldByName("UpdatedAt").Interface().(time.Time)
+	if !ok {
+		return false
+	}
+	return !birthdate.AddDate(UserMinimumAge, 0, 0).After(updatedAt)
+}
+
+// UpdateProfile replaces the editable attributes. The status only changes through the transitions below,
+// and the email only through the email change.
+func (u *User) UpdateProfile(params InputUserParams, now time.Time) {
+	u.DisplayName = params.DisplayName
+	u.Birthdate = params.Birthdate
+	u.Gender = UserGender(params.Gender)
+	u.Bio = params.Bio
+	u.Locale = params.Locale
+	u.Interests = NormalizeInterests(params.Interests)
+	u.UpdatedAt = now
+	u.recordEvent(EventUserProfileUpdated)
+}
+
+// NormalizeInterests trims and lowercases the interests and drops empty and duplicated ones, keeping the order.
+func NormalizeInterests(interests []string) []string {
+	var normalized []string
+	seen := make(map[string]struct{}, len(interests))
+	for _, interest := range interests {
+		interest = strings.ToLower(strings.TrimSpace(interest))
+		if interest == "" {
+			continue
+		}
+		if _, ok := seen[interest]; ok {
+			continue
+		}
+		seen[interest] = struct{}{}
+		normalized = append(normalized, interest)
+	}
+	return normalized
+}
+
+func (u *User) IsActive() bool {
+	return u.Status == UserStatusActive
+}
+
+func (u *User) IsEmailVerified() bool {
+	return !u.EmailVerifiedAt.IsZero()
+}
+
+func (u *User) VerifyEmail(now time.Time) error {
+	if u.IsEmailVerified() {
+		return ErrUserEmailIsVerified
+	}
+	u.EmailVerifiedAt = now
+	u.UpdatedAt = now
+	u.recordEvent(EventUserEmailVerified)
+	return nil
+}
+
+// RequestEmailChange keeps the new email pending until the user confirms it. A newer request replaces the pending one.
+func (u *User) RequestEmailChange(email string, now time.Time) error {
+	if email == "" {
+		return ErrUserEmailIsEmpty
+	}
+	if email == u.Email {
+		return ErrUserEmailIsUnchanged
+	}
+	u.PendingEmail = email
+	u.UpdatedAt = now
+	u.recordEvent(EventUserEmailChangeRequested)
+	return nil
+}
+
+// ConfirmEmailChange swaps to the pending email. The confirmation proves the user owns the new email.
+func (u *User) ConfirmEmailChange(email string, now time.Time) error {
+	if u.PendingEmail == "" || u.PendingEmail != email {
+		return ErrUserEmailChangeIsNotPending
+	}
+	oldEmail := u.Email
+	u.Email = email
+	u.PendingEmail = ""
+	u.EmailVerifiedAt = now
+	u.UpdatedAt = now
+	u.recordEmailChanged(oldEmail)
+	return nil
+}
+
+// RevertEmailChange undoes the change from oldEmail to newEmail, whether it is still pending or already confirmed.
+// Any other pending change is dropped as well, since the undo is a sign that the account was taken over.
+func (u *User) RevertEmailChange(oldEmail, newEmail string, now time.Time) error {
+	switch {
+	case u.Email == newEmail:
+		u.Email = oldEmail
+		u.PendingEmail = ""
+		u.UpdatedAt = now
+		u.recordEmailChanged(newEmail)
+	case u.PendingEmail == newEmail:
+		u.PendingEmail = ""
+		u.UpdatedAt = now
+		u.recordEvent(EventUserEmailChangeCancelled)
+	default:
+		return ErrUserEmailChangeIsNotPending
+	}
+	return nil
+}
+
+func (u *User) recordEmailChanged(oldEmail string) {
+	u.record(UserEmailChangedEvent{
+		UserEvent: UserEvent{Name: EventUserEmailChanged, UserID: u.ID, At: u.UpdatedAt},
+		OldEmail:  oldEmail,
+		NewEmail:  u.Email,
+	})
+}
+
+func (u *User) Suspend(now time.Time) error {
+	if u.Status != UserStatusActive {
+		return ErrUserStatusIsNotActive
+	}
+	u.Status = UserStatusSuspended
+	u.UpdatedAt = now
+	u.recordEvent(EventUserSuspended)
+	return nil
+}
+
+func (u *User) Reinstate(now time.Time) error {
+	if u.Status != UserStatusSuspended {
+		return ErrUserStatusIsNotSuspended
+	}
+	u.Status = UserStatusActive
+	u.UpdatedAt = now
+	u.recordEvent(EventUserReinstated)
+	return nil
+}
+
+// Withdraw soft-deletes the user. The user is permanently deleted once the grace period has passed.
+// A suspended user cannot withdraw, since reactivating would then lift the suspension.
+func (u *User) Withdraw(now time.Time) error {
+	switch u.Status {
+	case UserStatusWithdrawn:
+		return ErrUserStatusIsWithdrawn
+	case UserStatusSuspended:
+		return ErrUserStatusIsSuspended
+	}
+	u.Status = UserStatusWithdrawn
+	u.UpdatedAt = now
+	u.DeletedAt = now
+	u.recordEvent(EventUserWithdrawn)
+	return nil
+}
+
+// Reactivate restores a withdrawn user within the grace period.
+func (u *User) Reactivate(gracePeriod time.Duration, now time.Time) error {
+	if u.Status != UserStatusWithdrawn {
+		return ErrUserStatusIsNotWithdrawn
+	}
+	if !now.Before(u.PermanentDeletionAt(gracePeriod)) {
+		return ErrUserGracePeriodExpired
+	}
+	u.Status = UserStatusActive
+	u.UpdatedAt = now
+	u.DeletedAt = time.Time{}
+	u.DeletionEnqueuedAt = time.Time{}
+	u.recordEvent(EventUserReactivated)
+	return nil
+}
+
+// MarkDeletionEnqueued records that the permanent deletion of the withdrawn user was enqueued.
+func (u *User) MarkDeletionEnqueued(now time.Time) {
+	u.DeletionEnqueuedAt = now
+}
+
+func (u *User) IsDeleted() bool {
+	return !u.DeletedAt.IsZero()
+}
+
+// PermanentDeletionAt returns when the withdrawn user becomes eligible for permanent deletion.
+func (u *User) PermanentDeletionAt(gracePeriod time.Duration) time.Time {
+	if !u.IsDeleted() {
+		return time.Time{}
+	}
+	return u.DeletedAt.Add(gracePeriod)
+}
+
+func (u *User) IsPermanentlyDeletable(gracePeriod time.Duration, now time.Time) bool {
+	return u.Status == UserStatusWithdrawn && u.IsDeleted() && !now.Before(u.PermanentDeletionAt(gracePeriod))
+}
+
+// UserCursor points at a user to page the users created after it.
+// Users are ordered by CreatedAt, and by ID among the ones created at the same time.
+type UserCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func NewUserCursor(user *User) *UserCursor {
+	return &UserCursor{CreatedAt: user.CreatedAt, ID: user.ID}
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	ErrUserBlockSelf = domainerr.NewSentinel(domainerr.ReasonUserBlockSelf, "user cannot block themselves")
+)
+
+// UserBlock means the blocker does not want any contact with the blocked user.
+// A block works in both directions, although only the blocker can remove it.
+type UserBlock struct {
+	BlockerID uuid.UUID `validate:"required"`
+	BlockedID uuid.UUID `validate:"required"`
+	CreatedAt time.Time `validate:"required"`
+}
+
+type InputUserBlockParams struct {
+	BlockerID uuid.UUID
+	BlockedID uuid.UUID
+}
+
+func NewUserBlock(params InputUserBlockParams, now time.Time) *UserBlock {
+	return &UserBlock{
+		BlockerID: params.BlockerID,
+		BlockedID: params.BlockedID,
+		CreatedAt: now,
+	}
+}
+
+func (b *UserBlock) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(b); err != nil {
+		return err
+	}
+	if b.BlockerID == b.BlockedID {
+		return ErrUserBlockSelf
+	}
+	return nil
+}
+
+// Involves reports whether the block is between the two users, in either direction.
+func (b *UserBlock) Involves(userID1, userID2 uuid.UUID) bool {
+	return (b.BlockerID == userID1 && b.BlockedID == userID2) ||
+		(b.BlockerID == userID2 && b.BlockedID == userID1)
+}
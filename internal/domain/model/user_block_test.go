@@ -0,0 +1,54 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func TestValidateUserBlock(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		block   *UserBlock
+		wantErr bool
+	}{
+		{
+			name:    "OK: valid block",
+			block:   &UserBlock{BlockerID: userID, BlockedID: uuid.New(), CreatedAt: time.Now()},
+			wantErr: false,
+		},
+		{
+			name:    "NG: block self",
+			block:   &UserBlock{BlockerID: userID, BlockedID: userID, CreatedAt: time.Now()},
+			wantErr: true,
+		},
+		{
+			name:    "NG: empty blocked id",
+			block:   &UserBlock{BlockerID: userID, BlockedID: uuid.Nil(), CreatedAt: time.Now()},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.block.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserBlock_Involves(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	block := NewUserBlock(InputUserBlockParams{BlockerID: a, BlockedID: b}, time.Now())
+	if !block.Involves(a, b) || !block.Involves(b, a) {
+		t.Error("Involves() = false for the blocked pair")
+	}
+	if block.Involves(a, uuid.New()) {
+		t.Error("Involves() = true for another pair")
+	}
+}
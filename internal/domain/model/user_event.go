@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+const (
+	EventUserCreated              EventName = "user.created"
+	EventUserProfileUpdated       EventName = "user.profile_updated"
+	EventUserEmailVerified        EventName = "user.email_verified"
+	EventUserEmailChangeRequested EventName = "user.email_change_requested"
+	EventUserEmailChanged         EventName = "user.email_changed"
+	EventUserEmailChangeCancelled EventName = "user.email_change_cancelled"
+	EventUserSuspended            EventName = "user.suspended"
+	EventUserReinstated           EventName = "user.reinstated"
+	EventUserWithdrawn            EventName = "user.withdrawn"
+	EventUserReactivated          EventName = "user.reactivated"
+)
+
+// UserEventNames lists every event recorded by a user, for subscribers interested in any change of the user.
+var UserEventNames = []EventName{
+	EventUserCreated,
+	EventUserProfileUpdated,
+	EventUserEmailVerified,
+	EventUserEmailChangeRequested,
+	EventUserEmailChanged,
+	EventUserEmailChangeCancelled,
+	EventUserSuspended,
+	EventUserReinstated,
+	EventUserWithdrawn,
+	EventUserReactivated,
+}
+
+type UserEvent struct {
+	Name   EventName
+	UserID uuid.UUID
+	At     time.Time
+}
+
+func (e UserEvent) EventName() EventName {
+	return e.Name
+}
+
+func (e UserEvent) AggregateID() uuid.UUID {
+	return e.UserID
+}
+
+func (e UserEvent) OccurredAt() time.Time {
+	return e.At
+}
+
+// UserEmailChangedEvent is recorded when the email is swapped, by a confirmation or by an undo.
+type UserEmailChangedEvent struct {
+	UserEvent
+	OldEmail string
+	NewEmail string
+}
+
+func (u *User) recordEvent(name EventName) {
+	u.record(UserEvent{Name: name, UserID: u.ID, At: u.UpdatedAt})
+}
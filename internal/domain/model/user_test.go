@@ -1,6 +1,7 @@
 package model
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -26,29 +27,42 @@ func TestNewUser(t *testing.T) {
 				},
 			},
 			want: &User{
-				Email: "test@example.com",
+				Email:  "test@example.com",
+				Status: UserStatusActive,
+			},
+		},
+		{
+			name: "OK: interests are normalized",
+			args: args{
+				params: InputUserParams{
+					Email:     "test@example.com",
+					Interests: []string{" Hiking", "music", "hiking", ""},
+				},
+			},
+			want: &User{
+				Email:     "test@example.com",
+				Interests: []string{"hiking", "music"},
+				Status:    UserStatusActive,
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := NewUser(tt.args.params)
+			now := time.Now()
+			got := NewUser(tt.args.params, now)
 
 			diff := cmp.Diff(
 				got, tt.want,
 				cmpopts.IgnoreFields(User{}, "ID", "CreatedAt", "UpdatedAt"),
+				cmpopts.IgnoreUnexported(User{}),
 			)
 			if diff != "" {
 				t.Errorf("NewUser() mismatching (-got +want):\n%s", diff)
 			}
 
-			now := time.Now()
-			if got.CreatedAt.Sub(now) > time.Second {
-				t.Error("CreatedAt should be close to current time")
-			}
-			if got.UpdatedAt.Sub(now) > time.Second {
-				t.Error("UpdatedAt should be close to current time")
+			if !got.CreatedAt.Equal(now) || !got.UpdatedAt.Equal(now) {
+				t.Errorf("CreatedAt = %v, UpdatedAt = %v, want %v", got.CreatedAt, got.UpdatedAt, now)
 			}
 		})
 	}
@@ -65,16 +79,92 @@ func TestValidateUser(t *testing.T) {
 			user: &User{
 				ID:        uuid.New(),
 				Email:     "test@example.com",
+				Status:    UserStatusActive,
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
 			},
 			wantErr: false,
 		},
+		{
+			name: "OK: valid user with profile",
+			user: &User{
+				ID:          uuid.New(),
+				Email:       "test@example.com",
+				DisplayName: "Test User",
+				Birthdate:   time.Now().AddDate(-UserMinimumAge, 0, -1),
+				Gender:      UserGenderOther,
+				Bio:         "Hello",
+				Locale:      "ja-JP",
+				Status:      UserStatusActive,
+				CreatedAt:   time.Now(),
+				UpdatedAt:   time.Now(),
+			},
+			wantErr: false,
+		},
 		{
 			name: "NG: invalid email",
 			user: &User{
 				ID:        uuid.New(),
 				Email:     "invalid-email",
+				Status:    UserStatusActive,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "NG: younger than minimum age",
+			user: &User{
+				ID:        uuid.New(),
+				Email:     "test@example.com",
+				Birthdate: time.Now().AddDate(-UserMinimumAge, 0, 1),
+				Status:    UserStatusActive,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "NG: invalid gender",
+			user: &User{
+				ID:        uuid.New(),
+				Email:     "test@example.com",
+				Gender:    "invalid",
+				Status:    UserStatusActive,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "NG: invalid locale",
+			user: &User{
+				ID:        uuid.New(),
+				Email:     "test@example.com",
+				Locale:    "not a locale",
+				Status:    UserStatusActive,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "NG: interest contains a comma",
+			user: &User{
+				ID:        uuid.New(),
+				Email:     "test@example.com",
+				Interests: []string{"hiking,music"},
+				Status:    UserStatusActive,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "NG: empty status",
+			user: &User{
+				ID:        uuid.New(),
+				Email:     "test@example.com",
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
 			},
@@ -91,3 +181,170 @@ func TestValidateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_StatusTransitions(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     UserStatus
+		transition func(u *User, now time.Time) error
+		want       UserStatus
+		wantErr    bool
+	}{
+		{
+			name:       "OK: suspend active user",
+			status:     UserStatusActive,
+			transition: (*User).Suspend,
+			want:       UserStatusSuspended,
+		},
+		{
+			name:       "NG: suspend withdrawn user",
+			status:     UserStatusWithdrawn,
+			transition: (*User).Suspend,
+			want:       UserStatusWithdrawn,
+			wantErr:    true,
+		},
+		{
+			name:       "OK: reinstate suspended user",
+			status:     UserStatusSuspended,
+			transition: (*User).Reinstate,
+			want:       UserStatusActive,
+		},
+		{
+			name:       "NG: reinstate active user",
+			status:     UserStatusActive,
+			transition: (*User).Reinstate,
+			want:       UserStatusActive,
+			wantErr:    true,
+		},
+		{
+			name:       "OK: withdraw active user",
+			status:     UserStatusActive,
+			transition: (*User).Withdraw,
+			want:       UserStatusWithdrawn,
+		},
+		{
+			name:       "NG: withdraw suspended user",
+			status:     UserStatusSuspended,
+			transition: (*User).Withdraw,
+			want:       UserStatusSuspended,
+			wantErr:    true,
+		},
+		{
+			name:       "NG: withdraw withdrawn user",
+			status:     UserStatusWithdrawn,
+			transition: (*User).Withdraw,
+			want:       UserStatusWithdrawn,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{Status: tt.status}
+			err := tt.transition(user, time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("transition error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if user.Status != tt.want {
+				t.Errorf("status = %v, want %v", user.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestUser_Reactivate(t *testing.T) {
+	gracePeriod := 24 * time.Hour
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		status    UserStatus
+		deletedAt time.Time
+		want      UserStatus
+		wantErr   error
+	}{
+		{
+			name:      "OK: reactivate within grace period",
+			status:    UserStatusWithdrawn,
+			deletedAt: now.Add(-time.Hour),
+			want:      UserStatusActive,
+		},
+		{
+			name:      "NG: grace period expired",
+			status:    UserStatusWithdrawn,
+			deletedAt: now.Add(-gracePeriod - time.Hour),
+			want:      UserStatusWithdrawn,
+			wantErr:   ErrUserGracePeriodExpired,
+		},
+		{
+			name:    "NG: reactivate active user",
+			status:  UserStatusActive,
+			want:    UserStatusActive,
+			wantErr: ErrUserStatusIsNotWithdrawn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{Status: tt.status, DeletedAt: tt.deletedAt}
+			err := user.Reactivate(gracePeriod, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Reactivate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if user.Status != tt.want {
+				t.Errorf("status = %v, want %v", user.Status, tt.want)
+			}
+			if tt.wantErr == nil && user.IsDeleted() {
+				t.Errorf("deletedAt = %v, want zero", user.DeletedAt)
+			}
+		})
+	}
+}
+
+func TestUser_Withdraw_IsPermanentlyDeletable(t *testing.T) {
+	now := time.Now()
+	user := &User{Status: UserStatusActive}
+	if err := user.Withdraw(now); err != nil {
+		t.Fatalf("Withdraw() error = %v", err)
+	}
+	if !user.IsDeleted() {
+		t.Errorf("deletedAt is not set")
+	}
+	if user.IsPermanentlyDeletable(time.Hour, now.Add(time.Hour-time.Nanosecond)) {
+		t.Errorf("IsPermanentlyDeletable() = true within grace period")
+	}
+	if !user.IsPermanentlyDeletable(time.Hour, now.Add(time.Hour)) {
+		t.Errorf("IsPermanentlyDeletable() = false after grace period")
+	}
+}
+
+func TestUser_VerifyEmail(t *testing.T) {
+	now := time.Now()
+	user := NewUser(InputUserParams{Email: "test@example.com"}, now)
+	if user.IsEmailVerified() {
+		t.Fatalf("IsEmailVerified() = true for a new user")
+	}
+	if err := user.VerifyEmail(now); err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if !user.IsEmailVerified() {
+		t.Errorf("IsEmailVerified() = false after verification")
+	}
+	if err := user.VerifyEmail(now); !errors.Is(err, ErrUserEmailIsVerified) {
+		t.Errorf("VerifyEmail() error = %v, wantErr %v", err, ErrUserEmailIsVerified)
+	}
+}
+
+func TestUser_SuspendedCannotEscapeByWithdrawing(t *testing.T) {
+	now := time.Now()
+	user := &User{Status: UserStatusSuspended}
+	if err := user.Withdraw(now); !errors.Is(err, ErrUserStatusIsSuspended) {
+		t.Fatalf("Withdraw() error = %v, want %v", err, ErrUserStatusIsSuspended)
+	}
+	if err := user.Reactivate(time.Hour, now); !errors.Is(err, ErrUserStatusIsNotWithdrawn) {
+		t.Errorf("Reactivate() error = %v, want %v", err, ErrUserStatusIsNotWithdrawn)
+	}
+	if user.Status != UserStatusSuspended || user.IsDeleted() {
+		t.Errorf("status = %v, deletedAt = %v, want still suspended", user.Status, user.DeletedAt)
+	}
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// AuditLogRepository is append-only: audit logs are never updated nor deleted.
+type AuditLogRepository interface {
+	Save(ctx context.Context, log *model.AuditLog) (*model.AuditLog, error)
+	// Search returns the logs matching the filter, newest first.
+	Search(ctx context.Context, filter AuditLogFilter) ([]*model.AuditLog, error)
+}
+
+// AuditLogFilter narrows the audit logs. Zero values match any log.
+type AuditLogFilter struct {
+	ActorID    uuid.UUID
+	TargetType model.AuditTargetType
+	TargetID   string
+	// From is inclusive and To is exclusive.
+	From   time.Time
+	To     time.Time
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type ConversationRepository interface {
+	Save(ctx context.Context, conversation *model.Conversation) (*model.Conversation, error)
+	FindByMatchingID(ctx context.Context, matchingID uuid.UUID) (*model.Conversation, error)
+}
+
+type ChatMessageRepository interface {
+	Save(ctx context.Context, message *model.ChatMessage) (*model.ChatMessage, error)
+	FindById(ctx context.Context, id uuid.UUID) (*model.ChatMessage, error)
+	// FindAllByConversationID returns the messages older than the cursor, newest first. A nil cursor starts from the newest.
+	FindAllByConversationID(ctx context.Context, conversationID uuid.UUID, cursor *model.ChatMessageCursor, limit int) ([]*model.ChatMessage, error)
+	// MarkRead sets readAt on the unread messages of the sender created up to upTo, and returns how many were marked.
+	MarkRead(ctx context.Context, conversationID, senderID uuid.UUID, upTo, readAt time.Time) (int, error)
+}
+
+// ChatMessagePublisher pushes the messages and read receipts to the connected clients of the user in real time.
+type ChatMessagePublisher interface {
+	PublishMessage(ctx context.Context, userID uuid.UUID, message *model.ChatMessage) error
+	PublishReceipt(ctx context.Context, userID uuid.UUID, receipt *model.ChatMessageReceipt) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type EmailChangeRepository interface {
+	Save(ctx context.Context, change *model.EmailChange) (*model.EmailChange, error)
+	// FindByIdForUpdate locks the change until the transaction ends, so that it is confirmed or undone only once.
+	FindByIdForUpdate(ctx context.Context, id uuid.UUID) (*model.EmailChange, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type EmailVerificationRepository interface {
+	Save(ctx context.Context, verification *model.EmailVerification) (*model.EmailVerification, error)
+	// FindByIdForUpdate locks the verification until the transaction ends, so that it is used only once.
+	FindByIdForUpdate(ctx context.Context, id uuid.UUID) (*model.EmailVerification, error)
+	// CountByUserIdSinceForUpdate counts the verifications created for the user since the time, and locks them until the
+	// transaction ends, so that concurrent requests of the same user are counted one at a time.
+	CountByUserIdSinceForUpdate(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+}
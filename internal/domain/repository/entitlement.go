@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// EntitlementRepository looks up what the user is allowed to do under the plan.
+type EntitlementRepository interface {
+	// FindByUserID returns the entitlement of the free plan for a user without a plan.
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*model.Entitlement, error)
+	SavePlan(ctx context.Context, userID uuid.UUID, plan model.Plan) error
+}
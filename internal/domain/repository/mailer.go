@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+)
+
+// Mailer delivers emails. Callers should send through the mail queue rather than calling it in a request.
+type Mailer interface {
+	Send(ctx context.Context, mail *model.Mail) error
+}
@@ -2,15 +2,26 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
 )
 
+// ErrMatchingPairAlreadyExists is returned by Save when the pair of users already has a matching.
+var ErrMatchingPairAlreadyExists = errors.New("matching pair already exists")
+
 type MatchingRepository interface {
 	Save(ctx context.Context, matching *model.Matching) (*model.Matching, error)
 	FindById(ctx context.Context, id uuid.UUID) (*model.Matching, error)
 	FindByParticipants(ctx context.Context, meID, partnerID uuid.UUID) (*model.Matching, error)
+	// FindByPair finds the matching of the pair in either direction.
+	FindByPair(ctx context.Context, userID1, userID2 uuid.UUID) (*model.Matching, error)
+	// FindByPairForUpdate finds the matching of the pair in either direction and locks it.
+	FindByPairForUpdate(ctx context.Context, userID1, userID2 uuid.UUID) (*model.Matching, error)
 	FindAllByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*model.Matching, error)
+	FindAllMutualByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*model.Matching, error)
+	FindAllOverdue(ctx context.Context, before time.Time, limit int) ([]*model.Matching, error)
 	Remove(ctx context.Context, id uuid.UUID) (*uuid.UUID, error)
 }
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type MatchingHistoryRepository interface {
+	Save(ctx context.Context, history *model.MatchingHistory) (*model.MatchingHistory, error)
+	FindAllByMatchingID(ctx context.Context, matchingID uuid.UUID) ([]*model.MatchingHistory, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// MatchingQuotaUsageRepository is the audit record of the matching requests sent per day,
+// and the source of truth when the counter is lost.
+type MatchingQuotaUsageRepository interface {
+	Increment(ctx context.Context, userID uuid.UUID, day time.Time) error
+	// Count returns 0 when the user sent no request on the day.
+	Count(ctx context.Context, userID uuid.UUID, day time.Time) (int, error)
+}
+
+// MatchingQuotaCounterRepository counts the matching requests sent per day atomically.
+type MatchingQuotaCounterRepository interface {
+	// Add adds the delta to the counter of the day, which expires at expireAt, and returns the new count.
+	Add(ctx context.Context, userID uuid.UUID, day time.Time, delta int, expireAt time.Time) (int, error)
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// NotificationRepository is the inbox of the users.
+type NotificationRepository interface {
+	// Save creates the notification, and leaves the inbox as it is when the notification is already in it.
+	Save(ctx context.Context, notification *model.Notification) (*model.Notification, error)
+	FindById(ctx context.Context, id uuid.UUID) (*model.Notification, error)
+	// FindAllByUserID returns the notifications of the user, newest first.
+	FindAllByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*model.Notification, error)
+	CountUnreadByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	// MarkRead sets readAt on the unread notification of the user, or on all of them when id is nil, and returns how many were marked.
+	MarkRead(ctx context.Context, userID, id uuid.UUID, readAt time.Time) (int, error)
+}
+
+type NotificationPreferenceRepository interface {
+	Save(ctx context.Context, preference *model.NotificationPreference) (*model.NotificationPreference, error)
+	// FindByUserID returns nil when the user has not chosen any preference.
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*model.NotificationPreference, error)
+}
+
+// NotificationDeliveryRepository records the delivery of the notifications to each channel.
+type NotificationDeliveryRepository interface {
+	Save(ctx context.Context, delivery *model.NotificationDelivery) (*model.NotificationDelivery, error)
+	// FindByNotificationIDAndChannel returns nil when the notification has not been delivered to the channel yet.
+	FindByNotificationIDAndChannel(ctx context.Context, notificationID uuid.UUID, channel model.NotificationChannel) (*model.NotificationDelivery, error)
+}
+
+// NotificationDeliverer delivers notifications through a channel.
+// A notification is only delivered again to a channel that failed, but also when its delivery could not be recorded,
+// so a deliverer should still tolerate duplicates.
+type NotificationDeliverer interface {
+	Channel() model.NotificationChannel
+	Deliver(ctx context.Context, user *model.User, notification *model.Notification) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+)
+
+type OutboxRepository interface {
+	Save(ctx context.Context, message *model.OutboxMessage) (*model.OutboxMessage, error)
+	// FindUnsentForUpdate locks the oldest unsent messages below the max attempts, skipping the ones locked by another relay.
+	FindUnsentForUpdate(ctx context.Context, limit int) ([]*model.OutboxMessage, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// RecommendationCacheRepository keeps the precomputed recommendations of each user.
+type RecommendationCacheRepository interface {
+	// FindByUserID returns nil when the recommendations are not cached.
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Recommendation, error)
+	Store(ctx context.Context, userID uuid.UUID, recommendations []*model.Recommendation, ttl time.Duration) error
+	Remove(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type ReportRepository interface {
+	Save(ctx context.Context, report *model.Report) (*model.Report, error)
+	FindById(ctx context.Context, id uuid.UUID) (*model.Report, error)
+	// FindByIdForUpdate locks the report until the transaction ends, so that moderators do not resolve it twice.
+	FindByIdForUpdate(ctx context.Context, id uuid.UUID) (*model.Report, error)
+	FindAll(ctx context.Context, filter ReportFilter) ([]*model.Report, error)
+}
+
+// ReportFilter narrows the moderation queue. Zero values match any report.
+type ReportFilter struct {
+	Status     model.ReportStatus
+	AssigneeID uuid.UUID
+	Limit      int
+	Offset     int
+}
@@ -2,21 +2,44 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
 )
 
+// ErrUserEmailAlreadyExists is returned by Save when another user has the email.
+var ErrUserEmailAlreadyExists = errors.New("user email already exists")
+
 type UserRepository interface {
 	Save(ctx context.Context, user *model.User) (*model.User, error)
+	// ExistsByEmail also finds the email of withdrawn users, who keep it until the permanent deletion.
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
 	FindById(ctx context.Context, id uuid.UUID) (*model.User, error)
+	FindByIdWithDeleted(ctx context.Context, id uuid.UUID) (*model.User, error)
+	// FindByIdWithDeletedForUpdate locks the user, withdrawn or not, until the transaction ends.
+	FindByIdWithDeletedForUpdate(ctx context.Context, id uuid.UUID) (*model.User, error)
+	FindByIds(ctx context.Context, ids []uuid.UUID) ([]*model.User, error)
 	FindAll(ctx context.Context, limit, offset int) ([]*model.User, error)
+	// FindAllAfter returns the users created after the cursor, oldest first. A nil cursor starts from the oldest.
+	// Unlike the offsets of FindAll, the cursor neither skips nor repeats users when users are created or withdrawn meanwhile.
+	FindAllAfter(ctx context.Context, cursor *model.UserCursor, limit int) ([]*model.User, error)
+	// FindAllDeletedBefore returns the users withdrawn before before, but those whose permanent deletion
+	// was enqueued after enqueuedBefore, which the subscriber is still expected to delete.
+	FindAllDeletedBefore(ctx context.Context, before, enqueuedBefore time.Time, limit int) ([]*model.User, error)
+	// FindAllRecommendationCandidates returns the active users, recently updated first, that the user could match with:
+	// neither matched with the user in any status nor blocked in either direction.
+	FindAllRecommendationCandidates(ctx context.Context, userID uuid.UUID, limit int) ([]*model.User, error)
+	// FindRecommendationCandidatesByIds returns the users among the IDs that are still candidates for the user.
+	FindRecommendationCandidatesByIds(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) ([]*model.User, error)
 	Remove(ctx context.Context, id uuid.UUID) (*uuid.UUID, error)
 }
 
 type UserCacheRepository interface {
 	FindById(ctx context.Context, id uuid.UUID) (*model.User, error)
+	FindByIds(ctx context.Context, ids []uuid.UUID) ([]*model.User, error)
 	Store(ctx context.Context, user *model.User, ttl time.Duration) error
+	StoreMany(ctx context.Context, users []*model.User, ttl time.Duration) error
 	Remove(ctx context.Context, id uuid.UUID) error
 }
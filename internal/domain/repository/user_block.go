@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// ErrUserBlockAlreadyExists is returned by Save when the blocker already blocks the user.
+var ErrUserBlockAlreadyExists = errors.New("user block already exists")
+
+type UserBlockRepository interface {
+	Save(ctx context.Context, block *model.UserBlock) (*model.UserBlock, error)
+	Exists(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error)
+	// ExistsForUpdate locks the block, or the gap where it would be, until the transaction ends.
+	ExistsForUpdate(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error)
+	// FindAllBetween returns the blocks between the two users in either direction.
+	FindAllBetween(ctx context.Context, userID1, userID2 uuid.UUID) ([]*model.UserBlock, error)
+	FindAllByBlocker(ctx context.Context, blockerID uuid.UUID, limit, offset int) ([]*model.UserBlock, error)
+	// Remove returns false when there was no block to remove.
+	Remove(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error)
+}
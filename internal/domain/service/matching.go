@@ -9,11 +9,15 @@ import (
 
 var (
 	ErrMatchingMeAndPartnerAreSameUser = errors.New("me and partner are the same user")
+	ErrMatchingUserIsBlocked           = errors.New("me or partner blocks the other")
+	ErrMatchingUserIsSuspended         = errors.New("me or partner is suspended")
+	ErrMatchingUserIsUnverified        = errors.New("me or partner has not verified the email")
 )
 
 type MatchingDomainService struct{}
 
-func (s *MatchingDomainService) Validate(ctx context.Context, me, partner *model.User) error {
+// Validate checks that me and partner can match. blocks are the blocks between the two users in either direction.
+func (s *MatchingDomainService) Validate(ctx context.Context, me, partner *model.User, blocks []*model.UserBlock) error {
 	// Write the business logic for the match.
 	// For example, a match for an unsubscribed user or a user who violates the Terms of Service is invalid.
 	// It can only be validated by a call to the domain model.
@@ -26,5 +30,16 @@ func (s *MatchingDomainService) Validate(ctx context.Context, me, partner *model
 	if me.ID == partner.ID {
 		return ErrMatchingMeAndPartnerAreSameUser
 	}
+	if me.Status == model.UserStatusSuspended || partner.Status == model.UserStatusSuspended {
+		return ErrMatchingUserIsSuspended
+	}
+	if !me.IsEmailVerified() || !partner.IsEmailVerified() {
+		return ErrMatchingUserIsUnverified
+	}
+	for _, block := range blocks {
+		if block.Involves(me.ID, partner.ID) {
+			return ErrMatchingUserIsBlocked
+		}
+	}
 	return nil
 }
@@ -13,6 +13,7 @@ func TestValidate(t *testing.T) {
 	type args struct {
 		me      *model.User
 		partner *model.User
+		blocks  []*model.UserBlock
 	}
 	tests := []struct {
 		name    string
@@ -23,16 +24,20 @@ func TestValidate(t *testing.T) {
 			name: "OK: matching is created successfully",
 			args: args{
 				me: &model.User{
-					ID:        uuid.New(),
-					Email:     "me@example.com",
-					CreatedAt: time.Now(),
-					UpdatedAt: time.Now(),
+					ID:              uuid.New(),
+					Email:           "me@example.com",
+					Status:          model.UserStatusActive,
+					EmailVerifiedAt: time.Now(),
+					CreatedAt:       time.Now(),
+					UpdatedAt:       time.Now(),
 				},
 				partner: &model.User{
-					ID:        uuid.New(),
-					Email:     "partner@example.com",
-					CreatedAt: time.Now(),
-					UpdatedAt: time.Now(),
+					ID:              uuid.New(),
+					Email:           "partner@example.com",
+					Status:          model.UserStatusActive,
+					EmailVerifiedAt: time.Now(),
+					CreatedAt:       time.Now(),
+					UpdatedAt:       time.Now(),
 				},
 			},
 			wantErr: false,
@@ -41,29 +46,106 @@ func TestValidate(t *testing.T) {
 			name: "NG: me and partner are the same user",
 			args: args{
 				me: &model.User{
-					ID:        uuid.MustParse("019354c2-47f4-7036-84ff-17ed69ff96e0"),
-					Email:     "me@example.com",
+					ID:              uuid.MustParse("019354c2-47f4-7036-84ff-17ed69ff96e0"),
+					Email:           "me@example.com",
+					Status:          model.UserStatusActive,
+					EmailVerifiedAt: time.Now(),
+					CreatedAt:       time.Now(),
+					UpdatedAt:       time.Now(),
+				},
+				partner: &model.User{
+					ID:              uuid.MustParse("019354c2-47f4-7036-84ff-17ed69ff96e0"),
+					Email:           "partner@example.com",
+					Status:          model.UserStatusActive,
+					EmailVerifiedAt: time.Now(),
+					CreatedAt:       time.Now(),
+					UpdatedAt:       time.Now(),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "NG: partner is suspended",
+			args: args{
+				me: &model.User{
+					ID:              uuid.New(),
+					Email:           "me@example.com",
+					Status:          model.UserStatusActive,
+					EmailVerifiedAt: time.Now(),
+					CreatedAt:       time.Now(),
+					UpdatedAt:       time.Now(),
+				},
+				partner: &model.User{
+					ID:        uuid.New(),
+					Email:     "partner@example.com",
+					Status:    model.UserStatusSuspended,
 					CreatedAt: time.Now(),
 					UpdatedAt: time.Now(),
 				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "NG: partner has not verified the email",
+			args: args{
+				me: &model.User{
+					ID:              uuid.New(),
+					Email:           "me@example.com",
+					Status:          model.UserStatusActive,
+					EmailVerifiedAt: time.Now(),
+					CreatedAt:       time.Now(),
+					UpdatedAt:       time.Now(),
+				},
 				partner: &model.User{
-					ID:        uuid.MustParse("019354c2-47f4-7036-84ff-17ed69ff96e0"),
+					ID:        uuid.New(),
 					Email:     "partner@example.com",
+					Status:    model.UserStatusActive,
 					CreatedAt: time.Now(),
 					UpdatedAt: time.Now(),
 				},
 			},
 			wantErr: true,
 		},
+		{
+			name: "NG: partner blocks me",
+			args: args{
+				me: &model.User{
+					ID:              uuid.MustParse("019354c2-47f4-7036-84ff-17ed69ff96e0"),
+					Email:           "me@example.com",
+					Status:          model.UserStatusActive,
+					EmailVerifiedAt: time.Now(),
+					CreatedAt:       time.Now(),
+					UpdatedAt:       time.Now(),
+				},
+				partner: &model.User{
+					ID:              uuid.MustParse("019354c2-47f4-7036-84ff-17ed69ff96e1"),
+					Email:           "partner@example.com",
+					Status:          model.UserStatusActive,
+					EmailVerifiedAt: time.Now(),
+					CreatedAt:       time.Now(),
+					UpdatedAt:       time.Now(),
+				},
+				blocks: []*model.UserBlock{
+					{
+						BlockerID: uuid.MustParse("019354c2-47f4-7036-84ff-17ed69ff96e1"),
+						BlockedID: uuid.MustParse("019354c2-47f4-7036-84ff-17ed69ff96e0"),
+						CreatedAt: time.Now(),
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "NG: me is nil",
 			args: args{
 				me: nil,
 				partner: &model.User{
-					ID:        uuid.MustParse("019354c2-47f4-7036-84ff-17ed69ff96e0"),
-					Email:     "partner@example.com",
-					CreatedAt: time.Now(),
-					UpdatedAt: time.Now(),
+					ID:              uuid.MustParse("019354c2-47f4-7036-84ff-17ed69ff96e0"),
+					Email:           "partner@example.com",
+					Status:          model.UserStatusActive,
+					EmailVerifiedAt: time.Now(),
+					CreatedAt:       time.Now(),
+					UpdatedAt:       time.Now(),
 				},
 			},
 			wantErr: true,
@@ -74,7 +156,7 @@ func TestValidate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := MatchingDomainService{}
 			ctx := context.Background()
-			err := s.Validate(ctx, tt.args.me, tt.args.partner)
+			err := s.Validate(ctx, tt.args.me, tt.args.partner, tt.args.blocks)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+)
+
+type RecommendationDomainService struct {
+	scorers []WeightedScorer
+}
+
+// NewRecommendationDomainService uses DefaultCandidateScorers when no scorer is given.
+func NewRecommendationDomainService(scorers ...WeightedScorer) *RecommendationDomainService {
+	if len(scorers) == 0 {
+		scorers = DefaultCandidateScorers()
+	}
+	return &RecommendationDomainService{scorers: scorers}
+}
+
+// Recommend ranks the candidates for me and returns the best ones up to the limit.
+// candidates must already exclude the users I matched with or who are blocked in either direction,
+// while me and users who cannot match, such as suspended ones, are skipped here.
+func (s *RecommendationDomainService) Recommend(ctx context.Context, me *model.User, candidates []*model.User, limit int, now time.Time) []*model.Recommendation {
+	recommendations := make([]*model.Recommendation, 0, len(candidates))
+	updatedAt := make(map[*model.Recommendation]time.Time, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.ID == me.ID || !candidate.IsActive() {
+			continue
+		}
+		recommendation := s.score(me, candidate)
+		recommendation.GeneratedAt = now
+		recommendations = append(recommendations, recommendation)
+		updatedAt[recommendation] = candidate.UpdatedAt
+	}
+
+	// Ties go to the recently updated candidate, then to the ID to keep the order stable
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !updatedAt[a].Equal(updatedAt[b]) {
+			return updatedAt[a].After(updatedAt[b])
+		}
+		return a.CandidateID.String() < b.CandidateID.String()
+	})
+	if limit > 0 && len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+	return recommendations
+}
+
+func (s *RecommendationDomainService) score(me, candidate *model.User) *model.Recommendation {
+	recommendation := &model.Recommendation{
+		UserID:      me.ID,
+		CandidateID: candidate.ID,
+		Scores:      make(map[string]float64, len(s.scorers)),
+	}
+	var total, weights float64
+	for _, ws := range s.scorers {
+		if ws.Weight <= 0 {
+			continue
+		}
+		score := clampScore(ws.Scorer.Score(me, candidate))
+		recommendation.Scores[ws.Scorer.Name()] = score
+		total += score * ws.Weight
+		weights += ws.Weight
+	}
+	if weights > 0 {
+		recommendation.Score = total / weights
+	}
+	return recommendation
+}
+
+// clampScore keeps a custom scorer from outweighing the others with a score out of range.
+func clampScore(score float64) float64 {
+	switch {
+	case score < 0:
+		return 0
+	case score > 1:
+		return 1
+	default:
+		return score
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+)
+
+// CandidateScorer scores how good the candidate is as a partner for me, from 0 to 1.
+type CandidateScorer interface {
+	Name() string
+	Score(me, candidate *model.User) float64
+}
+
+// WeightedScorer is a scorer and its weight in the total score.
+type WeightedScorer struct {
+	Scorer CandidateScorer
+	Weight float64
+}
+
+// DefaultRecencyHalfLife is how long it takes for the recency score of an inactive user to halve.
+const DefaultRecencyHalfLife = 7 * 24 * time.Hour
+
+// RecencyScorer prefers candidates who updated their profile recently.
+// The score halves every HalfLife since the last update.
+type RecencyScorer struct {
+	HalfLife time.Duration
+}
+
+func (s RecencyScorer) Name() string {
+	return "recency"
+}
+
+func (s RecencyScorer) Score(_, candidate *model.User) float64 {
+	halfLife := s.HalfLife
+	if halfLife <= 0 {
+		halfLife = DefaultRecencyHalfLife
+	}
+	age := time.Since(candidate.UpdatedAt)
+	if age <= 0 {
+		return 1
+	}
+	return math.Exp2(-float64(age) / float64(halfLife))
+}
+
+// ProfileCompletenessScorer prefers candidates who filled in more of their profile.
+type ProfileCompletenessScorer struct{}
+
+func (s ProfileCompletenessScorer) Name() string {
+	return "completeness"
+}
+
+func (s ProfileCompletenessScorer) Score(_, candidate *model.User) float64 {
+	filled := []bool{
+		candidate.DisplayName != "",
+		!candidate.Birthdate.IsZero(),
+		candidate.Gender != "",
+		candidate.Bio != "",
+		candidate.Locale != "",
+		len(candidate.Interests) > 0,
+	}
+	count := 0
+	for _, ok := range filled {
+		if ok {
+			count++
+		}
+	}
+	return float64(count) / float64(len(filled))
+}
+
+// SharedInterestsScorer prefers candidates with interests in common, by the Jaccard index of the interests.
+type SharedInterestsScorer struct{}
+
+func (s SharedInterestsScorer) Name() string {
+	return "shared_interests"
+}
+
+func (s SharedInterestsScorer) Score(me, candidate *model.User) float64 {
+	if len(me.Interests) == 0 || len(candidate.Interests) == 0 {
+		return 0
+	}
+	mine := make(map[string]struct{}, len(me.Interests))
+	for _, interest := range me.Interests {
+		mine[interest] = struct{}{}
+	}
+	union := len(mine)
+	shared := 0
+	seen := make(map[string]struct{}, len(candidate.Interests))
+	for _, interest := range candidate.Interests {
+		if _, ok := seen[interest]; ok {
+			continue
+		}
+		seen[interest] = struct{}{}
+		if _, ok := mine[interest]; ok {
+			shared++
+		} else {
+			union++
+		}
+	}
+	return float64(shared) / float64(union)
+}
+
+// DefaultCandidateScorers weighs shared interests the most, since they matter more than activity or profile quality.
+func DefaultCandidateScorers() []WeightedScorer {
+	return []WeightedScorer{
+		{Scorer: RecencyScorer{HalfLife: DefaultRecencyHalfLife}, Weight: 1},
+		{Scorer: ProfileCompletenessScorer{}, Weight: 1},
+		{Scorer: SharedInterestsScorer{}, Weight: 2},
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func newTestCandidate(interests []string, updatedAt time.Time) *model.User {
+	return &model.User{
+		ID:        uuid.New(),
+		Email:     "candidate@example.com",
+		Interests: interests,
+		Status:    model.UserStatusActive,
+		CreatedAt: updatedAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+func TestRecencyScorer(t *testing.T) {
+	scorer := RecencyScorer{HalfLife: 24 * time.Hour}
+
+	tests := []struct {
+		name      string
+		updatedAt time.Time
+		want      float64
+	}{
+		{name: "OK: just updated", updatedAt: time.Now().Add(time.Minute), want: 1},
+		{name: "OK: one half life ago", updatedAt: time.Now().Add(-24 * time.Hour), want: 0.5},
+		{name: "OK: two half lives ago", updatedAt: time.Now().Add(-48 * time.Hour), want: 0.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorer.Score(nil, newTestCandidate(nil, tt.updatedAt))
+			if math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("Score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfileCompletenessScorer(t *testing.T) {
+	empty := newTestCandidate(nil, time.Now())
+	full := newTestCandidate([]string{"hiking"}, time.Now())
+	full.DisplayName = "Alice"
+	full.Birthdate = time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC)
+	full.Gender = model.UserGenderFemale
+	full.Bio = "Hello"
+	full.Locale = "ja-JP"
+
+	if got := (ProfileCompletenessScorer{}).Score(nil, empty); got != 0 {
+		t.Errorf("Score() of empty profile = %v, want 0", got)
+	}
+	if got := (ProfileCompletenessScorer{}).Score(nil, full); got != 1 {
+		t.Errorf("Score() of full profile = %v, want 1", got)
+	}
+}
+
+func TestSharedInterestsScorer(t *testing.T) {
+	me := newTestCandidate([]string{"hiking", "music", "cooking"}, time.Now())
+
+	tests := []struct {
+		name      string
+		interests []string
+		want      float64
+	}{
+		{name: "OK: same interests", interests: []string{"cooking", "music", "hiking"}, want: 1},
+		{name: "OK: half shared", interests: []string{"hiking", "music", "movies"}, want: 0.5},
+		{name: "OK: nothing shared", interests: []string{"movies"}, want: 0},
+		{name: "OK: no interests", interests: nil, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := (SharedInterestsScorer{}).Score(me, newTestCandidate(tt.interests, time.Now()))
+			if math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("Score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fixedScorer scores the candidates by ID, to test the ranking regardless of the real scorers.
+type fixedScorer map[uuid.UUID]float64
+
+func (s fixedScorer) Name() string {
+	return "fixed"
+}
+
+func (s fixedScorer) Score(_, candidate *model.User) float64 {
+	return s[candidate.ID]
+}
+
+func TestRecommendationDomainService_Recommend(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	me := newTestCandidate([]string{"hiking"}, now)
+	best := newTestCandidate(nil, now)
+	second := newTestCandidate(nil, now)
+	tieOlder := newTestCandidate(nil, now.Add(-time.Hour))
+	suspended := newTestCandidate(nil, now)
+	suspended.Status = model.UserStatusSuspended
+	outOfRange := newTestCandidate(nil, now)
+
+	svc := NewRecommendationDomainService(WeightedScorer{
+		Scorer: fixedScorer{
+			me.ID:         1,
+			best.ID:       0.9,
+			second.ID:     0.5,
+			tieOlder.ID:   0.5,
+			suspended.ID:  1,
+			outOfRange.ID: -3,
+		},
+		Weight: 1,
+	})
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []uuid.UUID
+	}{
+		{
+			name: "OK: skips me and suspended users and ranks by score then recency",
+			want: []uuid.UUID{best.ID, second.ID, tieOlder.ID, outOfRange.ID},
+		},
+		{
+			name:  "OK: limit",
+			limit: 2,
+			want:  []uuid.UUID{best.ID, second.ID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := svc.Recommend(ctx, me, []*model.User{outOfRange, tieOlder, suspended, me, second, best}, tt.limit, time.Now())
+			if len(got) != len(tt.want) {
+				t.Fatalf("Recommend() got %d recommendations, want %d", len(got), len(tt.want))
+			}
+			for i, recommendation := range got {
+				if recommendation.CandidateID != tt.want[i] {
+					t.Errorf("Recommend()[%d] = %v, want %v", i, recommendation.CandidateID, tt.want[i])
+				}
+				if recommendation.Score < 0 || recommendation.Score > 1 {
+					t.Errorf("Recommend()[%d].Score = %v, want within [0, 1]", i, recommendation.Score)
+				}
+			}
+		})
+	}
+}
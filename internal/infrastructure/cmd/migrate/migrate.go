@@ -0,0 +1,75 @@
+package migrate
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/migrate"
+)
+
+func MigrateCmd() *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the MySQL schema with the migrations built into the binary",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+	migrateCmd.AddCommand(&cobra.Command{
+		Use:   "up",
+		Short: "Apply the pending migrations",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := migrate.Up(); err != nil {
+				log.Fatal(err)
+			}
+		},
+	})
+	migrateCmd.AddCommand(&cobra.Command{
+		Use:   "down [steps]",
+		Short: "Revert the last migrations, one by default",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			steps := 1
+			if len(args) > 0 {
+				n, err := strconv.Atoi(args[0])
+				if err != nil || n < 1 {
+					log.Fatal(fmt.Errorf("invalid steps %q", args[0]))
+				}
+				steps = n
+			}
+			if err := migrate.Down(steps); err != nil {
+				log.Fatal(err)
+			}
+		},
+	})
+	migrateCmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Show the version of the schema and the pending migrations",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := migrate.Status(cmd.OutOrStdout()); err != nil {
+				log.Fatal(err)
+			}
+		},
+	})
+	migrateCmd.AddCommand(&cobra.Command{
+		Use:   "force version",
+		Short: "Set the version without migrating, once a failed migration is repaired by hand",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			version, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				log.Fatal(fmt.Errorf("invalid version %q", args[0]))
+			}
+			if err := migrate.Force(uint(version)); err != nil {
+				log.Fatal(err)
+			}
+		},
+	})
+
+	return migrateCmd
+}
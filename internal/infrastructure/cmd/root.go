@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/cmd/http"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/cmd/migrate"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/cmd/subscriber"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/cmd/task"
 )
@@ -19,6 +20,7 @@ func RootCmd() *cobra.Command {
 	rootCmd.AddCommand(http.HTTPCmd())
 	rootCmd.AddCommand(subscriber.SubscriberCmd())
 	rootCmd.AddCommand(task.TaskCmd())
+	rootCmd.AddCommand(migrate.MigrateCmd())
 
 	return rootCmd
 }
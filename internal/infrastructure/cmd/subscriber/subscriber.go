@@ -7,6 +7,9 @@ import (
 
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/subscriber"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/subscriber/dequeue_and_delete_user"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/subscriber/dispatch_notification"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/subscriber/relay_outbox"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/subscriber/send_mail"
 )
 
 func SubscriberCmd() *cobra.Command {
@@ -27,5 +30,35 @@ func SubscriberCmd() *cobra.Command {
 		},
 	})
 
+	subscriberCmd.AddCommand(&cobra.Command{
+		Use:   "mail",
+		Short: "Send the mails in the mail queue",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := subscriber.Run(send_mail.Run, args); err != nil {
+				log.Fatal(err)
+			}
+		},
+	})
+
+	subscriberCmd.AddCommand(&cobra.Command{
+		Use:   "notification",
+		Short: "Deliver the notifications in the notification queue through the channels the users allow",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := subscriber.Run(dispatch_notification.Run, args); err != nil {
+				log.Fatal(err)
+			}
+		},
+	})
+
+	subscriberCmd.AddCommand(&cobra.Command{
+		Use:   "outbox",
+		Short: "Relay the messages in the outbox table to their queues",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := subscriber.Run(relay_outbox.Run, args); err != nil {
+				log.Fatal(err)
+			}
+		},
+	})
+
 	return subscriberCmd
 }
@@ -7,6 +7,8 @@ import (
 
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/task"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/task/enqueue_user_deletion"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/task/expire_matchings"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/task/refresh_recommendations"
 )
 
 func TaskCmd() *cobra.Command {
@@ -16,11 +18,35 @@ func TaskCmd() *cobra.Command {
 			cmd.HelpFunc()(cmd, args)
 		},
 	}
+	var opts task.Options
+	taskCmd.PersistentFlags().StringSliceVar(&opts.TenantIDs, "tenant", nil, "tenants to run the task in (default every tenant of TENANT_IDS)")
+	taskCmd.PersistentFlags().StringVar(&opts.FakeNow, "fake-now", "", "time in RFC 3339 to run the task at instead of the current time, e.g. 2025-01-31T00:00:00Z")
+
 	taskCmd.AddCommand(&cobra.Command{
 		Use:   "sample",
 		Short: "sample",
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := task.Run(enqueue_user_deletion.Run, args); err != nil {
+			if err := task.Run(enqueue_user_deletion.Run, args, opts); err != nil {
+				log.Fatal(err)
+			}
+		},
+	})
+	taskCmd.AddCommand(&cobra.Command{
+		Use:   "expire_matchings [batch_size]",
+		Short: "Expire overdue pending matchings",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := task.Run(expire_matchings.Run, args, opts); err != nil {
+				log.Fatal(err)
+			}
+		},
+	})
+	taskCmd.AddCommand(&cobra.Command{
+		Use:   "refresh_recommendations [batch_size]",
+		Short: "Regenerate the cached recommendations of every active user",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := task.Run(refresh_recommendations.Run, args, opts); err != nil {
 				log.Fatal(err)
 			}
 		},
@@ -0,0 +1,42 @@
+// Package grpc maps the errors to the gRPC status codes, for a gRPC transport of the usecases.
+package grpc
+
+import (
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+)
+
+// Code is a gRPC status code, with the values of google.golang.org/grpc/codes.
+type Code uint32
+
+const (
+	CodeInvalidArgument    Code = 3
+	CodeNotFound           Code = 5
+	CodeAlreadyExists      Code = 6
+	CodePermissionDenied   Code = 7
+	CodeResourceExhausted  Code = 8
+	CodeFailedPrecondition Code = 9
+	CodeInternal           Code = 13
+	CodeUnauthenticated    Code = 16
+)
+
+// StatusCode returns the gRPC status code of the errors of the code.
+func StatusCode(code domainerr.ErrorCode) Code {
+	switch code {
+	case domainerr.InvalidArgument:
+		return CodeInvalidArgument
+	case domainerr.NotFound:
+		return CodeNotFound
+	case domainerr.AlreadyExists:
+		return CodeAlreadyExists
+	case domainerr.Unauthorized:
+		return CodeUnauthenticated
+	case domainerr.PermissionDenied:
+		return CodePermissionDenied
+	case domainerr.PreconditionFailed:
+		return CodeFailedPrecondition
+	case domainerr.ResourceExhausted:
+		return CodeResourceExhausted
+	default:
+		return CodeInternal
+	}
+}
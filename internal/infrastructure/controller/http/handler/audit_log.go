@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/marshaller"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/interactor"
+)
+
+// @title			Audit Log Handler
+// @description	Handles HTTP requests for the audit log of the changes
+type AuditLogHandler struct {
+	AuditLogInteractor interactor.AuditLogInteractor
+}
+
+// @Summary		Search the audit log
+// @Description	Returns the audit logs from the newest, optionally filtered by actor, target and time range
+// @Tags			admin
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			actorId		query		string	false	"Actor user ID"	format(uuid)
+// @Param			targetType	query		string	false	"Target type"	Enums(user, matching, user_block, report)
+// @Param			targetId	query		string	false	"Target ID"
+// @Param			from		query		string	false	"Inclusive start (RFC 3339)"	format(date-time)
+// @Param			to			query		string	false	"Exclusive end (RFC 3339)"		format(date-time)
+// @Param			limit		query		int		false	"Items per page"				default(10)
+// @Param			offset		query		int		false	"Skip items"					default(0)
+// @Success		200			{object}	response.SearchAuditLogsResponse
+// @Failure		400			{object}	error.DomainError
+// @Failure		401			{object}	error.DomainError
+// @Failure		403			{object}	error.DomainError
+// @Failure		500			{object}	error.DomainError
+// @Router			/admin/audit_logs [get]
+func (h *AuditLogHandler) Search(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeSearchAuditLogsRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.AuditLogInteractor.Search(
+		r.Context(),
+		marshaller.ToSearchAuditLogsInput(params),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToSearchAuditLogsResponse(output),
+	)
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/marshaller"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/interactor"
+)
+
+// @title			Conversation Handler
+// @description	Handles HTTP requests for the messages between mutually matched users
+type ConversationHandler struct {
+	ConversationInteractor interactor.ConversationInteractor
+}
+
+// @Summary		Send a message
+// @Description	Sends a message to the partner of an accepted matching and pushes it to the partner in real time
+// @Tags			conversations
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id			path		string								true	"Sender ID"		format(uuid)
+// @Param			partnerId	path		string								true	"Partner ID"	format(uuid)
+// @Param			body		body		request.SendChatMessageRequestBody	true	"Message"
+// @Success		201			{object}	response.SendChatMessageResponse
+// @Failure		400			{object}	error.DomainError
+// @Failure		401			{object}	error.DomainError
+// @Failure		403			{object}	error.DomainError
+// @Failure		404			{object}	error.DomainError
+// @Failure		412			{object}	error.DomainError
+// @Failure		500			{object}	error.DomainError
+// @Router			/users/{id}/matchings/{partnerId}/messages [post]
+func (h *ConversationHandler) Send(w http.ResponseWriter, r *http.Request) {
+	params, reqBody, err := request.DecodeSendChatMessageRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.ConversationInteractor.Send(
+		r.Context(),
+		marshaller.ToSendChatMessageInput(params, reqBody),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusCreated,
+		marshaller.ToSendChatMessageResponse(output),
+	)
+}
+
+// @Summary		List messages
+// @Description	Returns the messages with the partner from the newest. Pass nextCursor as cursor to get the older ones
+// @Tags			conversations
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id			path		string	true	"User ID"		format(uuid)
+// @Param			partnerId	path		string	true	"Partner ID"	format(uuid)
+// @Param			cursor		query		string	false	"Cursor of the page"
+// @Param			limit		query		int		false	"Items per page"	default(10)	maximum(100)
+// @Success		200			{object}	response.ListChatMessagesResponse
+// @Failure		400			{object}	error.DomainError
+// @Failure		401			{object}	error.DomainError
+// @Failure		403			{object}	error.DomainError
+// @Failure		404			{object}	error.DomainError
+// @Failure		500			{object}	error.DomainError
+// @Router			/users/{id}/matchings/{partnerId}/messages [get]
+func (h *ConversationHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeListChatMessagesRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.ConversationInteractor.List(
+		r.Context(),
+		marshaller.ToListChatMessagesInput(params),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToListChatMessagesResponse(output),
+	)
+}
+
+// @Summary		Mark messages as read
+// @Description	Marks the messages received from the partner as read up to the given message, or all of them, and pushes the read receipt to the partner
+// @Tags			conversations
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id			path		string									true	"Reader ID"		format(uuid)
+// @Param			partnerId	path		string									true	"Partner ID"	format(uuid)
+// @Param			body		body		request.MarkChatMessagesReadRequestBody	false	"Newest message read"
+// @Success		200			{object}	response.MarkChatMessagesReadResponse
+// @Failure		400			{object}	error.DomainError
+// @Failure		401			{object}	error.DomainError
+// @Failure		403			{object}	error.DomainError
+// @Failure		404			{object}	error.DomainError
+// @Failure		500			{object}	error.DomainError
+// @Router			/users/{id}/matchings/{partnerId}/messages/read [post]
+func (h *ConversationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	params, reqBody, err := request.DecodeMarkChatMessagesReadRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.ConversationInteractor.MarkRead(
+		r.Context(),
+		marshaller.ToMarkChatMessagesReadInput(params, reqBody),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToMarkChatMessagesReadResponse(output),
+	)
+}
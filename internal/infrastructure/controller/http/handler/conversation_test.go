@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/middleware"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/token"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// serveUserRoute serves the request through the authentication of the routes of a user, as Run does.
+// The handlers are only reached by the user, so a request rejected here never calls their interactor.
+func serveUserRoute(t *testing.T, method, pattern, path string, h http.HandlerFunc, principalID uuid.UUID) *httptest.ResponseRecorder {
+	t.Helper()
+	signer := token.NewSigner("secret")
+	r := chi.NewRouter()
+	r.Use(middleware.Authenticate(middleware.AuthConfig{Signer: signer}))
+	r.With(middleware.RequireUser("id")).Method(method, pattern, h)
+
+	req := httptest.NewRequest(method, path, strings.NewReader(`{}`))
+	if principalID != uuid.Nil() {
+		signed, err := signer.SignJWT(map[string]string{"sub": principalID.String(), "role": "user"})
+		if err != nil {
+			t.Fatalf("SignJWT() error = %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+signed)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestConversationHandler_RequireUser(t *testing.T) {
+	h := &ConversationHandler{}
+	userID := uuid.New()
+	partnerID := uuid.New()
+	path := "/users/" + userID.String() + "/matchings/" + partnerID.String() + "/messages"
+
+	routes := []struct {
+		name    string
+		method  string
+		pattern string
+		path    string
+		handler http.HandlerFunc
+	}{
+		{name: "Send", method: http.MethodPost, pattern: "/users/{id}/matchings/{partnerId}/messages", path: path, handler: h.Send},
+		{name: "List", method: http.MethodGet, pattern: "/users/{id}/matchings/{partnerId}/messages", path: path, handler: h.List},
+		{name: "MarkRead", method: http.MethodPost, pattern: "/users/{id}/matchings/{partnerId}/messages/read", path: path + "/read", handler: h.MarkRead},
+	}
+	principals := []struct {
+		name        string
+		principalID uuid.UUID
+		wantStatus  int
+	}{
+		{name: "NG: without token", principalID: uuid.Nil(), wantStatus: http.StatusUnauthorized},
+		{name: "NG: as the partner", principalID: partnerID, wantStatus: http.StatusForbidden},
+		{name: "NG: as another user", principalID: uuid.New(), wantStatus: http.StatusForbidden},
+	}
+
+	for _, route := range routes {
+		for _, p := range principals {
+			t.Run(route.name+"/"+p.name, func(t *testing.T) {
+				rec := serveUserRoute(t, route.method, route.pattern, route.path, route.handler, p.principalID)
+				if rec.Code != p.wantStatus {
+					t.Errorf("status = %d, want %d", rec.Code, p.wantStatus)
+				}
+			})
+		}
+	}
+}
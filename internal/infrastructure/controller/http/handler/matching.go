@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/marshaller"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+)
+
+// @title			Matching Handler
+// @description	Handles HTTP requests for matching operations
+type MatchingHandler struct {
+	MatchingInteractor port.MatchingUsecase
+	Shaper             *marshaller.Shaper
+}
+
+// @Summary	List matchings of a user
+// @Tags		matchings
+// @Accept		json
+// @Produce	json
+// @Param		id					path		string	true	"User ID"	format(uuid)
+// @Param		mutual				query		bool	false	"Return only mutual matchings"
+// @Param		limit				query		int		false	"Items per page"	default(10)
+// @Param		offset				query		int		false	"Skip items"		default(0)
+// @Param		fields[matchings]	query		string	false	"Comma separated matching fields to return"
+// @Param		include				query		string	false	"Comma separated related resources to embed, e.g. partner"
+// @Success	200					{object}	response.ListMatchingsResponse
+// @Failure	400					{object}	error.DomainError
+// @Failure	500					{object}	error.DomainError
+// @Router		/users/{id}/matchings [get]
+func (h *MatchingHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeListMatchingsRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.MatchingInteractor.ListByMeID(
+		r.Context(),
+		marshaller.ToListMatchingsInput(params),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	res, err := h.Shaper.ShapeField(
+		r.Context(),
+		marshaller.ResourceTypeMatchings,
+		marshaller.ToListMatchingsResponse(output),
+		"matchings",
+		request.DecodeSparseParams(r),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, res)
+}
+
+// @Summary		Get the timeline of a pair's matching
+// @Description	Returns every status change of the matching between the users, oldest first
+// @Tags			matchings
+// @Accept			json
+// @Produce		json
+// @Param			id			path		string	true	"User ID"		format(uuid)
+// @Param			partnerId	path		string	true	"Partner ID"	format(uuid)
+// @Success		200			{object}	response.MatchingTimelineResponse
+// @Failure		400			{object}	error.DomainError
+// @Failure		404			{object}	error.DomainError
+// @Failure		500			{object}	error.DomainError
+// @Router			/users/{id}/matchings/{partnerId}/timeline [get]
+func (h *MatchingHandler) Timeline(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeGetMatchingTimelineRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.MatchingInteractor.Timeline(
+		r.Context(),
+		marshaller.ToGetMatchingTimelineInput(params),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToMatchingTimelineResponse(output),
+	)
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/marshaller"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/interactor"
+)
+
+// @title			Notification Handler
+// @description	Handles HTTP requests for the inbox and the notification channels of the users
+type NotificationHandler struct {
+	NotificationInteractor interactor.NotificationInteractor
+}
+
+// @Summary		List notifications
+// @Description	Returns the inbox of the user from the newest, with the number of unread notifications
+// @Tags			notifications
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id			path		string	true	"User ID"	format(uuid)
+// @Param			unreadOnly	query		bool	false	"Only the unread notifications"
+// @Param			limit		query		int		false	"Items per page"	default(10)	maximum(100)
+// @Param			offset		query		int		false	"Offset"			default(0)
+// @Success		200			{object}	response.ListNotificationsResponse
+// @Failure		400			{object}	error.DomainError
+// @Failure		401			{object}	error.DomainError
+// @Failure		403			{object}	error.DomainError
+// @Failure		500			{object}	error.DomainError
+// @Router			/users/{id}/notifications [get]
+func (h *NotificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeListNotificationsRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.NotificationInteractor.List(
+		r.Context(),
+		marshaller.ToListNotificationsInput(params),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToListNotificationsResponse(output),
+	)
+}
+
+// @Summary		Count unread notifications
+// @Description	Returns the number of unread notifications in the inbox of the user, for a badge
+// @Tags			notifications
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id	path		string	true	"User ID"	format(uuid)
+// @Success		200	{object}	response.CountUnreadNotificationsResponse
+// @Failure		400	{object}	error.DomainError
+// @Failure		401	{object}	error.DomainError
+// @Failure		403	{object}	error.DomainError
+// @Failure		500	{object}	error.DomainError
+// @Router			/users/{id}/notifications/unread_count [get]
+func (h *NotificationHandler) CountUnread(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeNotificationRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.NotificationInteractor.CountUnread(
+		r.Context(),
+		marshaller.ToCountUnreadNotificationsInput(params),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToCountUnreadNotificationsResponse(output),
+	)
+}
+
+// @Summary		Mark notifications as read
+// @Description	Marks the given notification of the user as read, or all of them
+// @Tags			notifications
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string										true	"User ID"	format(uuid)
+// @Param			body	body		request.MarkNotificationsReadRequestBody	false	"Notification read"
+// @Success		200		{object}	response.MarkNotificationsReadResponse
+// @Failure		400		{object}	error.DomainError
+// @Failure		401		{object}	error.DomainError
+// @Failure		403		{object}	error.DomainError
+// @Failure		404		{object}	error.DomainError
+// @Failure		500		{object}	error.DomainError
+// @Router			/users/{id}/notifications/read [post]
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	params, reqBody, err := request.DecodeMarkNotificationsReadRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.NotificationInteractor.MarkRead(
+		r.Context(),
+		marshaller.ToMarkNotificationsReadInput(params, reqBody),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToMarkNotificationsReadResponse(output),
+	)
+}
+
+// @Summary		Get notification preference
+// @Description	Returns whether the user receives notifications through each channel
+// @Tags			notifications
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id	path		string	true	"User ID"	format(uuid)
+// @Success		200	{object}	response.NotificationPreferenceResponse
+// @Failure		400	{object}	error.DomainError
+// @Failure		401	{object}	error.DomainError
+// @Failure		403	{object}	error.DomainError
+// @Failure		500	{object}	error.DomainError
+// @Router			/users/{id}/notification_preference [get]
+func (h *NotificationHandler) GetPreference(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeNotificationRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.NotificationInteractor.GetPreference(
+		r.Context(),
+		marshaller.ToGetNotificationPreferenceInput(params),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToNotificationPreferenceResponse(output.Preference),
+	)
+}
+
+// @Summary		Update notification preference
+// @Description	Turns the notification channels of the user on or off, leaving the others as they are
+// @Tags			notifications
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string											true	"User ID"	format(uuid)
+// @Param			body	body		request.UpdateNotificationPreferenceRequestBody	true	"Channels"
+// @Success		200		{object}	response.NotificationPreferenceResponse
+// @Failure		400		{object}	error.DomainError
+// @Failure		401		{object}	error.DomainError
+// @Failure		403		{object}	error.DomainError
+// @Failure		404		{object}	error.DomainError
+// @Failure		500		{object}	error.DomainError
+// @Router			/users/{id}/notification_preference [put]
+func (h *NotificationHandler) UpdatePreference(w http.ResponseWriter, r *http.Request) {
+	params, reqBody, err := request.DecodeUpdateNotificationPreferenceRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.NotificationInteractor.UpdatePreference(
+		r.Context(),
+		marshaller.ToUpdateNotificationPreferenceInput(params, reqBody),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToNotificationPreferenceResponse(output.Preference),
+	)
+}
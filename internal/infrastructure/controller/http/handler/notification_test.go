@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func TestNotificationHandler_RequireUser(t *testing.T) {
+	h := &NotificationHandler{}
+	userID := uuid.New()
+	path := "/users/" + userID.String()
+
+	routes := []struct {
+		name    string
+		method  string
+		pattern string
+		path    string
+		handler http.HandlerFunc
+	}{
+		{name: "List", method: http.MethodGet, pattern: "/users/{id}/notifications", path: path + "/notifications", handler: h.List},
+		{name: "CountUnread", method: http.MethodGet, pattern: "/users/{id}/notifications/unread_count", path: path + "/notifications/unread_count", handler: h.CountUnread},
+		{name: "MarkRead", method: http.MethodPost, pattern: "/users/{id}/notifications/read", path: path + "/notifications/read", handler: h.MarkRead},
+		{name: "GetPreference", method: http.MethodGet, pattern: "/users/{id}/notification_preference", path: path + "/notification_preference", handler: h.GetPreference},
+		{name: "UpdatePreference", method: http.MethodPut, pattern: "/users/{id}/notification_preference", path: path + "/notification_preference", handler: h.UpdatePreference},
+	}
+	principals := []struct {
+		name        string
+		principalID uuid.UUID
+		wantStatus  int
+	}{
+		{name: "NG: without token", principalID: uuid.Nil(), wantStatus: http.StatusUnauthorized},
+		{name: "NG: as another user", principalID: uuid.New(), wantStatus: http.StatusForbidden},
+	}
+
+	for _, route := range routes {
+		for _, p := range principals {
+			t.Run(route.name+"/"+p.name, func(t *testing.T) {
+				rec := serveUserRoute(t, route.method, route.pattern, route.path, route.handler, p.principalID)
+				if rec.Code != p.wantStatus {
+					t.Errorf("status = %d, want %d", rec.Code, p.wantStatus)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/marshaller"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/interactor"
+)
+
+// @title			Recommendation Handler
+// @description	Handles HTTP requests for matching candidate recommendations
+type RecommendationHandler struct {
+	RecommendationInteractor interactor.RecommendationInteractor
+}
+
+// @Summary		List recommended partners
+// @Description	Returns the candidates the user can match with, best first. Users already matched, blocked or suspended are excluded
+// @Tags			recommendations
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string	true	"User ID"					format(uuid)
+// @Param			limit	query		int		false	"Max candidates (up to 50)"	default(10)
+// @Success		200		{object}	response.ListRecommendationsResponse
+// @Failure		400		{object}	error.DomainError
+// @Failure		404		{object}	error.DomainError
+// @Failure		412		{object}	error.DomainError
+// @Failure		500		{object}	error.DomainError
+// @Router			/users/{id}/recommendations [get]
+func (h *RecommendationHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeListRecommendationsRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.RecommendationInteractor.List(
+		r.Context(),
+		marshaller.ToListRecommendationsInput(params),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToListRecommendationsResponse(output),
+	)
+}
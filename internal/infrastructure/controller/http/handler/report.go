@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"net/http"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/marshaller"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/auth"
+)
+
+// @title			Report Handler
+// @description	Handles HTTP requests for user reports and the moderation queue
+type ReportHandler struct {
+	ReportInteractor port.ReportUsecase
+}
+
+// @Summary		Report a user
+// @Description	Reports the user for a terms of service violation and notifies the moderation team
+// @Tags			reports
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string							true	"Reporter user ID"	format(uuid)
+// @Param			body	body		request.CreateReportRequestBody	true	"Report"
+// @Success		201		{object}	response.CreateReportResponse
+// @Failure		400		{object}	error.DomainError
+// @Failure		404		{object}	error.DomainError
+// @Failure		500		{object}	error.DomainError
+// @Router			/users/{id}/reports [post]
+func (h *ReportHandler) Create(w http.ResponseWriter, r *http.Request) {
+	params, reqBody, err := request.DecodeCreateReportRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.ReportInteractor.Create(
+		r.Context(),
+		marshaller.ToCreateReportInput(params, reqBody),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusCreated,
+		marshaller.ToCreateReportResponse(output),
+	)
+}
+
+// @Summary		List the moderation queue
+// @Description	Returns the reports from the oldest, optionally filtered by status and assignee
+// @Tags			admin
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			status		query		string	false	"Report status"		Enums(open, assigned, resolved)
+// @Param			assigneeId	query		string	false	"Assignee ID"		format(uuid)
+// @Param			limit		query		int		false	"Items per page"	default(10)
+// @Param			offset		query		int		false	"Skip items"		default(0)
+// @Success		200			{object}	response.ListReportsResponse
+// @Failure		400			{object}	error.DomainError
+// @Failure		401			{object}	error.DomainError
+// @Failure		403			{object}	error.DomainError
+// @Failure		500			{object}	error.DomainError
+// @Router			/admin/reports [get]
+func (h *ReportHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeListReportsRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.ReportInteractor.List(
+		r.Context(),
+		marshaller.ToListReportsInput(params),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToListReportsResponse(output),
+	)
+}
+
+// @Summary	Assign a report to the calling moderator
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Security	BearerAuth
+// @Param		reportId	path		string	true	"Report ID"	format(uuid)
+// @Success	200			{object}	response.AssignReportResponse
+// @Failure	400			{object}	error.DomainError
+// @Failure	401			{object}	error.DomainError
+// @Failure	403			{object}	error.DomainError
+// @Failure	404			{object}	error.DomainError
+// @Failure	412			{object}	error.DomainError
+// @Failure	500			{object}	error.DomainError
+// @Router		/admin/reports/{reportId}/assign [post]
+func (h *ReportHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeAssignReportRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	// The /admin routes are authenticated, and the report is assigned to the admin making the request
+	principal, err := auth.FromContext(r.Context())
+	if err != nil {
+		response.WriteError(w, r, domainerr.New(domainerr.ReasonAuthRequired, err, nil))
+		return
+	}
+	output, err := h.ReportInteractor.Assign(
+		r.Context(),
+		marshaller.ToAssignReportInput(params, principal),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToAssignReportResponse(output),
+	)
+}
+
+// @Summary		Resolve an assigned report
+// @Description	Closes the report. The suspended resolution also suspends the reported user
+// @Tags			admin
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			reportId	path		string								true	"Report ID"	format(uuid)
+// @Param			body		body		request.ResolveReportRequestBody	true	"Resolution"
+// @Success		200			{object}	response.ResolveReportResponse
+// @Failure		400			{object}	error.DomainError
+// @Failure		401			{object}	error.DomainError
+// @Failure		403			{object}	error.DomainError
+// @Failure		404			{object}	error.DomainError
+// @Failure		412			{object}	error.DomainError
+// @Failure		500			{object}	error.DomainError
+// @Router			/admin/reports/{reportId}/resolve [post]
+func (h *ReportHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	params, reqBody, err := request.DecodeResolveReportRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.ReportInteractor.Resolve(
+		r.Context(),
+		marshaller.ToResolveReportInput(params, reqBody),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToResolveReportResponse(output),
+	)
+}
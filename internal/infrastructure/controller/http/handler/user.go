@@ -8,13 +8,14 @@ import (
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/marshaller"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
-	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/interactor"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
 )
 
 // @title			User Handler
 // @description	Handles HTTP requests for user operations
 type UserHandler struct {
-	UserInteractor interactor.UserInteractor
+	UserInteractor port.UserUsecase
+	Shaper         *marshaller.Shaper
 }
 
 // @Summary	Create a new user
@@ -29,7 +30,7 @@ type UserHandler struct {
 func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 	reqBody, err := request.DecodeCreateUserRequest(r)
 	if err != nil {
-		response.WriteError(w, err)
+		response.WriteError(w, r, err)
 		return
 	}
 	output, err := h.UserInteractor.Create(
@@ -37,7 +38,7 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 		marshaller.ToCreateUserInput(reqBody),
 	)
 	if err != nil {
-		response.WriteError(w, err)
+		response.WriteError(w, r, err)
 		return
 	}
 	response.WriteJSON(
@@ -51,16 +52,18 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Tags		users
 // @Accept		json
 // @Produce	json
-// @Param		id	path		string	true	"User ID"	format(uuid)
-// @Success	200	{object}	response.GetUserResponse
-// @Failure	400	{object}	error.DomainError
-// @Failure	404	{object}	error.DomainError
-// @Failure	500	{object}	error.DomainError
+// @Param		id				path		string	true	"User ID"	format(uuid)
+// @Param		fields[users]	query		string	false	"Comma separated user fields to return"
+// @Param		include			query		string	false	"Comma separated related resources to embed, e.g. matchings.partner"
+// @Success	200				{object}	response.GetUserResponse
+// @Failure	400				{object}	error.DomainError
+// @Failure	404				{object}	error.DomainError
+// @Failure	500				{object}	error.DomainError
 // @Router		/users/{id} [get]
 func (h *UserHandler) Get(w http.ResponseWriter, r *http.Request) {
 	params, err := request.DecodeGetUserRequest(r)
 	if err != nil {
-		response.WriteError(w, err)
+		response.WriteError(w, r, err)
 		return
 	}
 	output, err := h.UserInteractor.Get(
@@ -68,30 +71,78 @@ func (h *UserHandler) Get(w http.ResponseWriter, r *http.Request) {
 		marshaller.ToGetUserInput(params),
 	)
 	if err != nil {
-		response.WriteError(w, err)
+		response.WriteError(w, r, err)
 		return
 	}
-	response.WriteJSON(
-		w,
-		http.StatusOK,
+	res, err := h.Shaper.Shape(
+		r.Context(),
+		marshaller.ResourceTypeUsers,
 		marshaller.ToGetUserResponse(output),
+		request.DecodeSparseParams(r),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, res)
+}
+
+// @Summary		Get users by ID list
+// @Description	Returns the users in the order of the requested IDs and reports the IDs that were not found
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Param			body			body		request.BatchGetUsersRequestBody	true	"User IDs (up to 100)"
+// @Param			fields[users]	query		string								false	"Comma separated user fields to return"
+// @Param			include			query		string								false	"Comma separated related resources to embed, e.g. matchings.partner"
+// @Success		200				{object}	response.BatchGetUsersResponse
+// @Failure		400				{object}	error.DomainError
+// @Failure		500				{object}	error.DomainError
+// @Router			/users:batchGet [post]
+func (h *UserHandler) BatchGet(w http.ResponseWriter, r *http.Request) {
+	reqBody, err := request.DecodeBatchGetUsersRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.UserInteractor.BatchGet(
+		r.Context(),
+		marshaller.ToBatchGetUsersInput(reqBody),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	res, err := h.Shaper.ShapeField(
+		r.Context(),
+		marshaller.ResourceTypeUsers,
+		marshaller.ToBatchGetUsersResponse(output),
+		"users",
+		request.DecodeSparseParams(r),
 	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, res)
 }
 
 // @Summary	List all users
 // @Tags		users
 // @Accept		json
 // @Produce	json
-// @Param		limit	query		int	false	"Items per page"	default(10)
-// @Param		offset	query		int	false	"Skip items"		default(0)
-// @Success	200		{object}	response.ListUsersResponse
-// @Failure	400		{object}	error.DomainError
-// @Failure	500		{object}	error.DomainError
+// @Param		limit			query		int		false	"Items per page"	default(10)
+// @Param		offset			query		int		false	"Skip items"		default(0)
+// @Param		fields[users]	query		string	false	"Comma separated user fields to return"
+// @Param		include			query		string	false	"Comma separated related resources to embed, e.g. matchings.partner"
+// @Success	200				{object}	response.ListUsersResponse
+// @Failure	400				{object}	error.DomainError
+// @Failure	500				{object}	error.DomainError
 // @Router		/users [get]
 func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
 	limit, offset, err := request.DecodeListUserRequest(r)
 	if err != nil {
-		response.WriteError(w, err)
+		response.WriteError(w, r, err)
 		return
 	}
 	output, err := h.UserInteractor.List(
@@ -99,14 +150,21 @@ func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
 		marshaller.ToListUsersInput(limit, offset),
 	)
 	if err != nil {
-		response.WriteError(w, err)
+		response.WriteError(w, r, err)
 		return
 	}
-	response.WriteJSON(
-		w,
-		http.StatusOK,
+	res, err := h.Shaper.ShapeField(
+		r.Context(),
+		marshaller.ResourceTypeUsers,
 		marshaller.ToListUsersResponse(output, limit, offset),
+		"users",
+		request.DecodeSparseParams(r),
 	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, res)
 }
 
 // @Summary	Update user by ID
@@ -126,7 +184,7 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 	reqBody, err := request.DecodeUpdateUserRequest(r)
 	if err != nil {
-		response.WriteError(w, err)
+		response.WriteError(w, r, err)
 		return
 	}
 	output, err := h.UserInteractor.Update(
@@ -134,7 +192,7 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 		marshaller.ToUpdateUserInput(reqBody, params.ID),
 	)
 	if err != nil {
-		response.WriteError(w, err)
+		response.WriteError(w, r, err)
 		return
 	}
 	response.WriteJSON(
@@ -152,12 +210,13 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 // @Success	200	{object}	response.DeleteUserResponse
 // @Failure	400	{object}	error.DomainError
 // @Failure	404	{object}	error.DomainError
+// @Failure	412	{object}	error.DomainError
 // @Failure	500	{object}	error.DomainError
 // @Router		/users/{id} [delete]
 func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	params, err := request.DecodeDeleteUserRequest(r)
 	if err != nil {
-		response.WriteError(w, err)
+		response.WriteError(w, r, err)
 		return
 	}
 	output, err := h.UserInteractor.Delete(
@@ -165,7 +224,7 @@ func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		marshaller.ToDeleteUserInput(params),
 	)
 	if err != nil {
-		response.WriteError(w, err)
+		response.WriteError(w, r, err)
 		return
 	}
 	response.WriteJSON(
@@ -174,3 +233,200 @@ func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		marshaller.ToDeleteUserResponse(output),
 	)
 }
+
+// @Summary	Reactivate a withdrawn user within the grace period
+// @Tags		users
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"User ID"	format(uuid)
+// @Success	200	{object}	response.ReactivateUserResponse
+// @Failure	400	{object}	error.DomainError
+// @Failure	404	{object}	error.DomainError
+// @Failure	412	{object}	error.DomainError
+// @Failure	500	{object}	error.DomainError
+// @Router		/users/{id}/reactivate [post]
+func (h *UserHandler) Reactivate(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeReactivateUserRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.UserInteractor.Reactivate(
+		r.Context(),
+		marshaller.ToReactivateUserInput(params),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToReactivateUserResponse(output),
+	)
+}
+
+// @Summary	Mail a new token to verify the email of the user
+// @Tags		users
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"User ID"	format(uuid)
+// @Success	202	{object}	response.RequestEmailVerificationResponse
+// @Failure	400	{object}	error.DomainError
+// @Failure	404	{object}	error.DomainError
+// @Failure	412	{object}	error.DomainError
+// @Failure	429	{object}	error.DomainError
+// @Failure	500	{object}	error.DomainError
+// @Router		/users/{id}/verification [post]
+func (h *UserHandler) RequestEmailVerification(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeRequestEmailVerificationRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.UserInteractor.RequestEmailVerification(
+		r.Context(),
+		marshaller.ToRequestEmailVerificationInput(params),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusAccepted,
+		marshaller.ToRequestEmailVerificationResponse(output),
+	)
+}
+
+// @Summary	Verify the email of a user with the mailed token
+// @Tags		users
+// @Accept		json
+// @Produce	json
+// @Param		body	body		request.VerifyEmailRequestBody	true	"Verification token"
+// @Success	200		{object}	response.VerifyEmailResponse
+// @Failure	400		{object}	error.DomainError
+// @Failure	404		{object}	error.DomainError
+// @Failure	412		{object}	error.DomainError
+// @Failure	500		{object}	error.DomainError
+// @Router		/users/verify [post]
+func (h *UserHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	reqBody, err := request.DecodeVerifyEmailRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.UserInteractor.VerifyEmail(
+		r.Context(),
+		marshaller.ToVerifyEmailInput(reqBody),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToVerifyEmailResponse(output),
+	)
+}
+
+// @Summary		Request to change the email of the user
+// @Description	The new email stays pending until it is confirmed with the token mailed to it. The old email is notified with a token to undo the change.
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string									true	"User ID"	format(uuid)
+// @Param			body	body		request.RequestEmailChangeRequestBody	true	"New email"
+// @Success		202		{object}	response.RequestEmailChangeResponse
+// @Failure		400		{object}	error.DomainError
+// @Failure		404		{object}	error.DomainError
+// @Failure		409		{object}	error.DomainError
+// @Failure		500		{object}	error.DomainError
+// @Router			/users/{id}/email [post]
+func (h *UserHandler) RequestEmailChange(w http.ResponseWriter, r *http.Request) {
+	params, reqBody, err := request.DecodeRequestEmailChangeRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.UserInteractor.RequestEmailChange(
+		r.Context(),
+		marshaller.ToRequestEmailChangeInput(params, reqBody),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusAccepted,
+		marshaller.ToRequestEmailChangeResponse(output),
+	)
+}
+
+// @Summary	Confirm an email change with the token mailed to the new email
+// @Tags		users
+// @Accept		json
+// @Produce	json
+// @Param		body	body		request.EmailChangeTokenRequestBody	true	"Confirmation token"
+// @Success	200		{object}	response.ConfirmEmailChangeResponse
+// @Failure	400		{object}	error.DomainError
+// @Failure	404		{object}	error.DomainError
+// @Failure	409		{object}	error.DomainError
+// @Failure	412		{object}	error.DomainError
+// @Failure	500		{object}	error.DomainError
+// @Router		/users/email/confirm [post]
+func (h *UserHandler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	reqBody, err := request.DecodeEmailChangeTokenRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.UserInteractor.ConfirmEmailChange(
+		r.Context(),
+		marshaller.ToConfirmEmailChangeInput(reqBody),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToConfirmEmailChangeResponse(output),
+	)
+}
+
+// @Summary	Undo an email change with the token mailed to the old email
+// @Tags		users
+// @Accept		json
+// @Produce	json
+// @Param		body	body		request.EmailChangeTokenRequestBody	true	"Undo token"
+// @Success	200		{object}	response.UndoEmailChangeResponse
+// @Failure	400		{object}	error.DomainError
+// @Failure	404		{object}	error.DomainError
+// @Failure	409		{object}	error.DomainError
+// @Failure	412		{object}	error.DomainError
+// @Failure	500		{object}	error.DomainError
+// @Router		/users/email/undo [post]
+func (h *UserHandler) UndoEmailChange(w http.ResponseWriter, r *http.Request) {
+	reqBody, err := request.DecodeEmailChangeTokenRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.UserInteractor.UndoEmailChange(
+		r.Context(),
+		marshaller.ToUndoEmailChangeInput(reqBody),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToUndoEmailChangeResponse(output),
+	)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/marshaller"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+)
+
+// @title			User Block Handler
+// @description	Handles HTTP requests for user block operations
+type UserBlockHandler struct {
+	UserBlockInteractor port.UserBlockUsecase
+}
+
+// @Summary		Block a user
+// @Description	Blocks the user in both directions and rejects the pending matching between the users
+// @Tags			blocks
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string							true	"Blocker user ID"	format(uuid)
+// @Param			body	body		request.BlockUserRequestBody	true	"User to block"
+// @Success		201		{object}	response.BlockUserResponse
+// @Failure		400		{object}	error.DomainError
+// @Failure		404		{object}	error.DomainError
+// @Failure		409		{object}	error.DomainError
+// @Failure		500		{object}	error.DomainError
+// @Router			/users/{id}/blocks [post]
+func (h *UserBlockHandler) Block(w http.ResponseWriter, r *http.Request) {
+	params, reqBody, err := request.DecodeBlockUserRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.UserBlockInteractor.Block(
+		r.Context(),
+		marshaller.ToBlockUserInput(params, reqBody),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusCreated,
+		marshaller.ToBlockUserResponse(output),
+	)
+}
+
+// @Summary	Unblock a user
+// @Tags		blocks
+// @Accept		json
+// @Produce	json
+// @Param		id			path		string	true	"Blocker user ID"	format(uuid)
+// @Param		blockedId	path		string	true	"Blocked user ID"	format(uuid)
+// @Success	200			{object}	response.UnblockUserResponse
+// @Failure	400			{object}	error.DomainError
+// @Failure	404			{object}	error.DomainError
+// @Failure	500			{object}	error.DomainError
+// @Router		/users/{id}/blocks/{blockedId} [delete]
+func (h *UserBlockHandler) Unblock(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeUnblockUserRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.UserBlockInteractor.Unblock(
+		r.Context(),
+		marshaller.ToUnblockUserInput(params),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToUnblockUserResponse(output),
+	)
+}
+
+// @Summary	List users blocked by a user
+// @Tags		blocks
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string	true	"Blocker user ID"	format(uuid)
+// @Param		limit	query		int		false	"Items per page"	default(10)
+// @Param		offset	query		int		false	"Skip items"		default(0)
+// @Success	200		{object}	response.ListUserBlocksResponse
+// @Failure	400		{object}	error.DomainError
+// @Failure	500		{object}	error.DomainError
+// @Router		/users/{id}/blocks [get]
+func (h *UserBlockHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := request.DecodeListUserBlocksRequest(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	output, err := h.UserBlockInteractor.List(
+		r.Context(),
+		marshaller.ToListUserBlocksInput(params),
+	)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(
+		w,
+		http.StatusOK,
+		marshaller.ToListUserBlocksResponse(output),
+	)
+}
@@ -0,0 +1,64 @@
+package marshaller
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+)
+
+// Input Marshalling
+func ToSearchAuditLogsInput(params *request.SearchAuditLogsParams) *port.SearchAuditLogsInput {
+	input := &port.SearchAuditLogsInput{
+		TargetType: params.TargetType,
+		TargetID:   params.TargetID,
+		Limit:      params.Limit,
+		Offset:     params.Offset,
+	}
+	if params.ActorID != "" {
+		input.ActorID = uuid.MustParse(params.ActorID)
+	}
+	if params.From != "" {
+		input.From, _ = time.Parse(time.RFC3339, params.From)
+	}
+	if params.To != "" {
+		input.To, _ = time.Parse(time.RFC3339, params.To)
+	}
+	return input
+}
+
+// Output Marshalling
+func ToAuditLogResponse(log *model.AuditLog) response.AuditLogResponse {
+	diff := make(map[string]response.AuditChangeResponse, len(log.Diff))
+	for field, change := range log.Diff {
+		diff[field] = response.AuditChangeResponse{Before: change.Before, After: change.After}
+	}
+	res := response.AuditLogResponse{
+		ID:         log.ID.String(),
+		Action:     log.Action,
+		TargetType: string(log.TargetType),
+		TargetID:   log.TargetID,
+		Diff:       diff,
+		RequestID:  log.RequestID,
+		Source:     string(log.Source),
+		CreatedAt:  log.CreatedAt,
+	}
+	if log.ActorID != uuid.Nil {
+		res.ActorID = log.ActorID.String()
+	}
+	return res
+}
+
+func ToSearchAuditLogsResponse(output *port.SearchAuditLogsOutput) response.SearchAuditLogsResponse {
+	logs := make([]response.AuditLogResponse, len(output.AuditLogs))
+	for i, log := range output.AuditLogs {
+		logs[i] = ToAuditLogResponse(log)
+	}
+	return response.SearchAuditLogsResponse{
+		AuditLogs: logs,
+	}
+}
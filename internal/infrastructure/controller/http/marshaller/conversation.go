@@ -0,0 +1,98 @@
+package marshaller
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+)
+
+// Input Marshalling
+func ToSendChatMessageInput(params *request.ConversationParams, req *request.SendChatMessageRequestBody) *port.SendChatMessageInput {
+	return &port.SendChatMessageInput{
+		SenderID:  uuid.MustParse(params.UserID),
+		PartnerID: uuid.MustParse(params.PartnerID),
+		Body:      req.Body,
+	}
+}
+
+func ToListChatMessagesInput(params *request.ListChatMessagesParams) *port.ListChatMessagesInput {
+	return &port.ListChatMessagesInput{
+		UserID:    uuid.MustParse(params.UserID),
+		PartnerID: uuid.MustParse(params.PartnerID),
+		Cursor:    params.Cursor,
+		Limit:     params.Limit,
+	}
+}
+
+func ToMarkChatMessagesReadInput(params *request.ConversationParams, req *request.MarkChatMessagesReadRequestBody) *port.MarkChatMessagesReadInput {
+	input := &port.MarkChatMessagesReadInput{
+		UserID:    uuid.MustParse(params.UserID),
+		PartnerID: uuid.MustParse(params.PartnerID),
+	}
+	if req.UpToMessageID != "" {
+		input.UpToMessageID = uuid.MustParse(req.UpToMessageID)
+	}
+	return input
+}
+
+// Output Marshalling
+func ToConversationResponse(conversation *model.Conversation) response.ConversationResponse {
+	res := response.ConversationResponse{
+		ID:         conversation.ID.String(),
+		MatchingID: conversation.MatchingID.String(),
+		User1ID:    conversation.User1ID.String(),
+		User2ID:    conversation.User2ID.String(),
+		Status:     string(conversation.Status),
+		CreatedAt:  conversation.CreatedAt,
+	}
+	if !conversation.ArchivedAt.IsZero() {
+		archivedAt := conversation.ArchivedAt
+		res.ArchivedAt = &archivedAt
+	}
+	return res
+}
+
+func ToChatMessageResponse(message *model.ChatMessage) response.ChatMessageResponse {
+	res := response.ChatMessageResponse{
+		ID:             message.ID.String(),
+		ConversationID: message.ConversationID.String(),
+		SenderID:       message.SenderID.String(),
+		Body:           message.Body,
+		CreatedAt:      message.CreatedAt,
+	}
+	if message.IsRead() {
+		readAt := message.ReadAt
+		res.ReadAt = &readAt
+	}
+	return res
+}
+
+func ToSendChatMessageResponse(output *port.SendChatMessageOutput) response.SendChatMessageResponse {
+	return response.SendChatMessageResponse(ToChatMessageResponse(output.Message))
+}
+
+func ToListChatMessagesResponse(output *port.ListChatMessagesOutput) response.ListChatMessagesResponse {
+	messages := make([]response.ChatMessageResponse, len(output.Messages))
+	for i, message := range output.Messages {
+		messages[i] = ToChatMessageResponse(message)
+	}
+	res := response.ListChatMessagesResponse{
+		Messages:   messages,
+		NextCursor: output.NextCursor,
+	}
+	if output.Conversation != nil {
+		conversation := ToConversationResponse(output.Conversation)
+		res.Conversation = &conversation
+	}
+	return res
+}
+
+func ToMarkChatMessagesReadResponse(output *port.MarkChatMessagesReadOutput) response.MarkChatMessagesReadResponse {
+	return response.MarkChatMessagesReadResponse{
+		ReadCount: output.ReadCount,
+		ReadAt:    output.ReadAt,
+	}
+}
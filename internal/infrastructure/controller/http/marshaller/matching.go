@@ -0,0 +1,137 @@
+package marshaller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/interactor"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+)
+
+// Input Marshalling
+func ToListMatchingsInput(req *request.ListMatchingsParams) *port.ListMatchingByMeIDInput {
+	return &port.ListMatchingByMeIDInput{
+		MeID:       uuid.MustParse(req.UserID),
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+		MutualOnly: req.Mutual,
+	}
+}
+
+func ToGetMatchingTimelineInput(req *request.GetMatchingTimelineParams) *port.GetMatchingTimelineInput {
+	return &port.GetMatchingTimelineInput{
+		MeID:      uuid.MustParse(req.UserID),
+		PartnerID: uuid.MustParse(req.PartnerID),
+	}
+}
+
+// Output Marshalling
+func ToMatchingResponse(matching *model.Matching) response.MatchingResponse {
+	res := response.MatchingResponse{
+		ID:        matching.ID.String(),
+		MeID:      matching.MeID.String(),
+		PartnerID: matching.PartnerID.String(),
+		Status:    string(matching.Status),
+		CreatedAt: matching.CreatedAt,
+		UpdatedAt: matching.UpdatedAt,
+	}
+	if !matching.ExpiresAt.IsZero() {
+		res.ExpiresAt = &matching.ExpiresAt
+	}
+	return res
+}
+
+func ToMatchingResponses(matchings []*model.Matching) []response.MatchingResponse {
+	responses := make([]response.MatchingResponse, len(matchings))
+	for i, matching := range matchings {
+		responses[i] = ToMatchingResponse(matching)
+	}
+	return responses
+}
+
+func ToListMatchingsResponse(output *port.ListMatchingByMeIDOutput) response.ListMatchingsResponse {
+	return response.ListMatchingsResponse{
+		Matchings: ToMatchingResponses(output.Matchings),
+	}
+}
+
+func ToMatchingTimelineResponse(output *port.GetMatchingTimelineOutput) response.MatchingTimelineResponse {
+	history := make([]response.MatchingHistoryResponse, len(output.Histories))
+	for i, h := range output.Histories {
+		history[i] = response.MatchingHistoryResponse{
+			ID:        h.ID.String(),
+			Action:    string(h.Action),
+			From:      string(h.From),
+			To:        string(h.To),
+			Reason:    h.Reason,
+			CreatedAt: h.CreatedAt,
+		}
+		if !h.IsBySystem() {
+			history[i].ActorID = h.ActorID.String()
+		}
+	}
+	return response.MatchingTimelineResponse{
+		Matching: ToMatchingResponse(output.Matching),
+		History:  history,
+	}
+}
+
+// Relationships
+func RegisterMatchingRelationships(s *Shaper, userInteractor port.UserUsecase) *Shaper {
+	// The users of a page are loaded with a batch get instead of one get per matching
+	loadUsers := func(key string) func(ctx context.Context, parents []map[string]interface{}) ([]interface{}, error) {
+		return func(ctx context.Context, parents []map[string]interface{}) ([]interface{}, error) {
+			ids := make([]uuid.UUID, len(parents))
+			unique := make([]uuid.UUID, 0, len(parents))
+			seen := make(map[uuid.UUID]struct{}, len(parents))
+			for i, parent := range parents {
+				id, err := resourceID(parent, key)
+				if err != nil {
+					return nil, err
+				}
+				ids[i] = id
+				if _, ok := seen[id]; !ok {
+					seen[id] = struct{}{}
+					unique = append(unique, id)
+				}
+			}
+
+			users := make(map[uuid.UUID]*model.User, len(unique))
+			for chunk := range slices.Chunk(unique, interactor.MaxBatchGetUsers) {
+				output, err := userInteractor.BatchGet(ctx, &port.BatchGetUsersInput{IDs: chunk})
+				if err != nil {
+					return nil, err
+				}
+				for _, user := range output.Users {
+					users[user.ID] = user
+				}
+			}
+
+			// A missing user is left out, as Get would return no user for it
+			loaded := make([]interface{}, len(parents))
+			for i, id := range ids {
+				if user, ok := users[id]; ok {
+					loaded[i] = ToUserResponse(user)
+				}
+			}
+			return loaded, nil
+		}
+	}
+	s.Register(ResourceTypeMatchings, "me", Relationship{Type: ResourceTypeUsers, LoadMany: loadUsers("meId")})
+	s.Register(ResourceTypeMatchings, "partner", Relationship{Type: ResourceTypeUsers, LoadMany: loadUsers("partnerId")})
+	return s
+}
+
+func resourceID(resource map[string]interface{}, key string) (uuid.UUID, error) {
+	value, ok := resource[key].(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("resource has no %s", key)
+	}
+	return uuid.Parse(value)
+}
@@ -0,0 +1,106 @@
+package marshaller
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+)
+
+// Input Marshalling
+func ToListNotificationsInput(params *request.ListNotificationsParams) *port.ListNotificationsInput {
+	return &port.ListNotificationsInput{
+		UserID:     uuid.MustParse(params.UserID),
+		UnreadOnly: params.UnreadOnly,
+		Limit:      params.Limit,
+		Offset:     params.Offset,
+	}
+}
+
+func ToCountUnreadNotificationsInput(params *request.NotificationParams) *port.CountUnreadNotificationsInput {
+	return &port.CountUnreadNotificationsInput{
+		UserID: uuid.MustParse(params.UserID),
+	}
+}
+
+func ToMarkNotificationsReadInput(params *request.NotificationParams, req *request.MarkNotificationsReadRequestBody) *port.MarkNotificationsReadInput {
+	input := &port.MarkNotificationsReadInput{
+		UserID: uuid.MustParse(params.UserID),
+	}
+	if req.NotificationID != "" {
+		input.NotificationID = uuid.MustParse(req.NotificationID)
+	}
+	return input
+}
+
+func ToGetNotificationPreferenceInput(params *request.NotificationParams) *port.GetNotificationPreferenceInput {
+	return &port.GetNotificationPreferenceInput{
+		UserID: uuid.MustParse(params.UserID),
+	}
+}
+
+func ToUpdateNotificationPreferenceInput(params *request.NotificationParams, req *request.UpdateNotificationPreferenceRequestBody) *port.UpdateNotificationPreferenceInput {
+	channels := make(map[model.NotificationChannel]bool, len(req.Channels))
+	for channel, enabled := range req.Channels {
+		channels[model.NotificationChannel(channel)] = enabled
+	}
+	return &port.UpdateNotificationPreferenceInput{
+		UserID:   uuid.MustParse(params.UserID),
+		Channels: channels,
+	}
+}
+
+// Output Marshalling
+func ToNotificationResponse(notification *model.Notification) response.NotificationResponse {
+	res := response.NotificationResponse{
+		ID:        notification.ID.String(),
+		Type:      string(notification.Type),
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Data:      notification.Data,
+		CreatedAt: notification.CreatedAt,
+	}
+	if notification.IsRead() {
+		readAt := notification.ReadAt
+		res.ReadAt = &readAt
+	}
+	return res
+}
+
+func ToListNotificationsResponse(output *port.ListNotificationsOutput) response.ListNotificationsResponse {
+	notifications := make([]response.NotificationResponse, len(output.Notifications))
+	for i, notification := range output.Notifications {
+		notifications[i] = ToNotificationResponse(notification)
+	}
+	return response.ListNotificationsResponse{
+		Notifications: notifications,
+		UnreadCount:   output.UnreadCount,
+	}
+}
+
+func ToCountUnreadNotificationsResponse(output *port.CountUnreadNotificationsOutput) response.CountUnreadNotificationsResponse {
+	return response.CountUnreadNotificationsResponse{
+		UnreadCount: output.UnreadCount,
+	}
+}
+
+func ToMarkNotificationsReadResponse(output *port.MarkNotificationsReadOutput) response.MarkNotificationsReadResponse {
+	return response.MarkNotificationsReadResponse{
+		ReadCount: output.ReadCount,
+		ReadAt:    output.ReadAt,
+	}
+}
+
+// ToNotificationPreferenceResponse lists every channel, so that clients do not need to know that a missing one is on.
+func ToNotificationPreferenceResponse(preference *model.NotificationPreference) response.NotificationPreferenceResponse {
+	channels := make(map[string]bool, len(model.NotificationChannels))
+	for channel := range model.NotificationChannels {
+		channels[string(channel)] = preference.Allows(channel)
+	}
+	return response.NotificationPreferenceResponse{
+		Channels:  channels,
+		UpdatedAt: preference.UpdatedAt,
+	}
+}
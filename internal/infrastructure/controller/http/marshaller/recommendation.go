@@ -0,0 +1,33 @@
+package marshaller
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+)
+
+// Input Marshalling
+func ToListRecommendationsInput(params *request.ListRecommendationsParams) *port.ListRecommendationsInput {
+	return &port.ListRecommendationsInput{
+		UserID: uuid.MustParse(params.UserID),
+		Limit:  params.Limit,
+	}
+}
+
+// Output Marshalling
+func ToListRecommendationsResponse(output *port.ListRecommendationsOutput) response.ListRecommendationsResponse {
+	recommendations := make([]response.RecommendationResponse, len(output.Recommendations))
+	for i, recommended := range output.Recommendations {
+		recommendations[i] = response.RecommendationResponse{
+			User:        ToUserResponse(recommended.User),
+			Score:       recommended.Recommendation.Score,
+			Scores:      recommended.Recommendation.Scores,
+			GeneratedAt: recommended.Recommendation.GeneratedAt,
+		}
+	}
+	return response.ListRecommendationsResponse{
+		Recommendations: recommendations,
+	}
+}
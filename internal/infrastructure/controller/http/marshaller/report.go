@@ -0,0 +1,101 @@
+package marshaller
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/auth"
+)
+
+// Input Marshalling
+func ToCreateReportInput(params *request.CreateReportParams, req *request.CreateReportRequestBody) *port.CreateReportInput {
+	return &port.CreateReportInput{
+		ReporterID: uuid.MustParse(params.UserID),
+		ReportedID: uuid.MustParse(req.ReportedID),
+		Reason:     req.Reason,
+		Comment:    req.Comment,
+	}
+}
+
+func ToListReportsInput(params *request.ListReportsParams) *port.ListReportsInput {
+	input := &port.ListReportsInput{
+		Status: params.Status,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+	if params.AssigneeID != "" {
+		input.AssigneeID = uuid.MustParse(params.AssigneeID)
+	}
+	return input
+}
+
+func ToAssignReportInput(params *request.ReportParams, principal auth.Principal) *port.AssignReportInput {
+	return &port.AssignReportInput{
+		ID:         uuid.MustParse(params.ReportID),
+		AssigneeID: principal.UserID,
+	}
+}
+
+func ToResolveReportInput(params *request.ReportParams, req *request.ResolveReportRequestBody) *port.ResolveReportInput {
+	return &port.ResolveReportInput{
+		ID:         uuid.MustParse(params.ReportID),
+		Resolution: req.Resolution,
+		Note:       req.Note,
+	}
+}
+
+// Output Marshalling
+func ToReportResponse(report *model.Report) response.ReportResponse {
+	res := response.ReportResponse{
+		ID:             report.ID.String(),
+		ReporterID:     report.ReporterID.String(),
+		ReportedID:     report.ReportedID.String(),
+		Reason:         string(report.Reason),
+		Comment:        report.Comment,
+		Status:         string(report.Status),
+		Resolution:     string(report.Resolution),
+		ResolutionNote: report.ResolutionNote,
+		CreatedAt:      report.CreatedAt,
+		UpdatedAt:      report.UpdatedAt,
+	}
+	if report.AssigneeID != uuid.Nil {
+		res.AssigneeID = report.AssigneeID.String()
+	}
+	if !report.ResolvedAt.IsZero() {
+		resolvedAt := report.ResolvedAt
+		res.ResolvedAt = &resolvedAt
+	}
+	return res
+}
+
+func ToCreateReportResponse(output *port.CreateReportOutput) response.CreateReportResponse {
+	return response.CreateReportResponse(ToReportResponse(output.Report))
+}
+
+func ToListReportsResponse(output *port.ListReportsOutput) response.ListReportsResponse {
+	reports := make([]response.ReportResponse, len(output.Reports))
+	for i, report := range output.Reports {
+		reports[i] = ToReportResponse(report)
+	}
+	return response.ListReportsResponse{
+		Reports: reports,
+	}
+}
+
+func ToAssignReportResponse(output *port.AssignReportOutput) response.AssignReportResponse {
+	return response.AssignReportResponse(ToReportResponse(output.Report))
+}
+
+func ToResolveReportResponse(output *port.ResolveReportOutput) response.ResolveReportResponse {
+	res := response.ResolveReportResponse{
+		Report: ToReportResponse(output.Report),
+	}
+	if output.SuspendedUser != nil {
+		user := ToUserResponse(output.SuspendedUser)
+		res.SuspendedUser = &user
+	}
+	return res
+}
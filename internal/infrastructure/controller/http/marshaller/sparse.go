@@ -0,0 +1,221 @@
+package marshaller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+)
+
+const (
+	ResourceTypeUsers     = "users"
+	ResourceTypeMatchings = "matchings"
+)
+
+// Relationship loads the resources related to a marshalled parent resource.
+// Load returns a response object, a slice of them, or nil.
+// LoadMany loads them for every parent of a page at once, returning one such result per parent,
+// so that a page does not cost a query per parent. It is used instead of Load when it is set.
+type Relationship struct {
+	Type     string
+	Load     func(ctx context.Context, parent map[string]interface{}) (interface{}, error)
+	LoadMany func(ctx context.Context, parents []map[string]interface{}) ([]interface{}, error)
+}
+
+func (r Relationship) load(ctx context.Context, parents []map[string]interface{}) ([]interface{}, error) {
+	if r.LoadMany != nil {
+		loaded, err := r.LoadMany(ctx, parents)
+		if err != nil {
+			return nil, err
+		}
+		if len(loaded) != len(parents) {
+			return nil, fmt.Errorf("relationship loaded %d results for %d parents", len(loaded), len(parents))
+		}
+		return loaded, nil
+	}
+	loaded := make([]interface{}, len(parents))
+	for i, parent := range parents {
+		result, err := r.Load(ctx, parent)
+		if err != nil {
+			return nil, err
+		}
+		loaded[i] = result
+	}
+	return loaded, nil
+}
+
+// Shaper applies sparse fieldsets and includes to marshalled responses.
+// Relationships are registered per resource type so that every handler can reuse them.
+type Shaper struct {
+	relationships map[string]map[string]Relationship
+}
+
+type includeTree map[string]includeTree
+
+func NewShaper() *Shaper {
+	return &Shaper{
+		relationships: map[string]map[string]Relationship{},
+	}
+}
+
+func (s *Shaper) Register(resourceType, name string, rel Relationship) *Shaper {
+	if _, ok := s.relationships[resourceType]; !ok {
+		s.relationships[resourceType] = map[string]Relationship{}
+	}
+	s.relationships[resourceType][name] = rel
+	return s
+}
+
+// Shape applies the params to a single resource or a slice of resources.
+// The response is returned unchanged when no params are given.
+func (s *Shaper) Shape(ctx context.Context, resourceType string, v interface{}, params *request.SparseParams) (interface{}, error) {
+	if s == nil || params.IsEmpty() {
+		return v, nil
+	}
+	include, err := s.buildIncludeTree(resourceType, params.Include)
+	if err != nil {
+		return nil, err
+	}
+	data, err := toGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.shape(ctx, resourceType, resources(data), include, params.Fields); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ShapeField applies the params to the resources under field of an envelope response such as a list.
+func (s *Shaper) ShapeField(ctx context.Context, resourceType string, v interface{}, field string, params *request.SparseParams) (interface{}, error) {
+	if s == nil || params.IsEmpty() {
+		return v, nil
+	}
+	data, err := toGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+	envelope, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("response is not an object: %T", v)
+	}
+	shaped, err := s.Shape(ctx, resourceType, envelope[field], params)
+	if err != nil {
+		return nil, err
+	}
+	envelope[field] = shaped
+	return envelope, nil
+}
+
+func (s *Shaper) buildIncludeTree(resourceType string, paths []string) (includeTree, error) {
+	tree := includeTree{}
+	for _, path := range paths {
+		node := tree
+		currentType := resourceType
+		for _, name := range strings.Split(path, ".") {
+			rel, ok := s.relationships[currentType][name]
+			if !ok {
+				return nil, domainerr.New(
+					domainerr.ReasonIncludePathUnsupported,
+					nil,
+					map[string]interface{}{"include": path},
+				)
+			}
+			if _, ok := node[name]; !ok {
+				node[name] = includeTree{}
+			}
+			node = node[name]
+			currentType = rel.Type
+		}
+	}
+	return tree, nil
+}
+
+// shape shapes the resources in place. Each relationship is loaded once for all of them,
+// and the related resources of every level are shaped together in turn.
+func (s *Shaper) shape(ctx context.Context, resourceType string, data []map[string]interface{}, include includeTree, fields map[string][]string) error {
+	if len(data) == 0 {
+		return nil
+	}
+	// Relationships are loaded before filtering, because they may depend on filtered attributes
+	related := make([]map[string]interface{}, len(data))
+	for name, child := range include {
+		rel := s.relationships[resourceType][name]
+		loaded, err := rel.load(ctx, data)
+		if err != nil {
+			return err
+		}
+		var children []map[string]interface{}
+		for i, l := range loaded {
+			generic, err := toGeneric(l)
+			if err != nil {
+				return err
+			}
+			if related[i] == nil {
+				related[i] = make(map[string]interface{}, len(include))
+			}
+			related[i][name] = generic
+			children = append(children, resources(generic)...)
+		}
+		if err := s.shape(ctx, rel.Type, children, child, fields); err != nil {
+			return err
+		}
+	}
+	for i, resource := range data {
+		if allowed, ok := fields[resourceType]; ok {
+			filterFields(resource, allowed)
+		}
+		for name, value := range related[i] {
+			resource[name] = value
+		}
+	}
+	return nil
+}
+
+// resources returns the resource objects of a generic response, a single one or a slice of them.
+func resources(v interface{}) []map[string]interface{} {
+	switch data := v.(type) {
+	case []interface{}:
+		var items []map[string]interface{}
+		for _, item := range data {
+			items = append(items, resources(item)...)
+		}
+		return items
+	case map[string]interface{}:
+		return []map[string]interface{}{data}
+	default:
+		return nil
+	}
+}
+
+// filterFields keeps only the allowed attributes. The id is always kept to identify the resource.
+func filterFields(data map[string]interface{}, allowed []string) {
+	keep := make(map[string]struct{}, len(allowed)+1)
+	keep["id"] = struct{}{}
+	for _, field := range allowed {
+		keep[field] = struct{}{}
+	}
+	for key := range data {
+		if _, ok := keep[key]; !ok {
+			delete(data, key)
+		}
+	}
+}
+
+func toGeneric(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,164 @@
+package marshaller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+)
+
+func setupTestShaper() *Shaper {
+	users := map[string]response.UserResponse{
+		"u1": {ID: "u1", Email: "u1@example.com"},
+		"u2": {ID: "u2", Email: "u2@example.com"},
+	}
+	s := NewShaper()
+	s.Register(ResourceTypeUsers, "matchings", Relationship{
+		Type: ResourceTypeMatchings,
+		Load: func(ctx context.Context, parent map[string]interface{}) (interface{}, error) {
+			return []response.MatchingResponse{
+				{ID: "m1", MeID: parent["id"].(string), PartnerID: "u2", Status: "pending"},
+			}, nil
+		},
+	})
+	s.Register(ResourceTypeMatchings, "partner", Relationship{
+		Type: ResourceTypeUsers,
+		Load: func(ctx context.Context, parent map[string]interface{}) (interface{}, error) {
+			return users[parent["partnerId"].(string)], nil
+		},
+	})
+	return s
+}
+
+func TestShaper_Shape(t *testing.T) {
+	user := response.UserResponse{
+		ID:        "u1",
+		Email:     "u1@example.com",
+		Status:    "active",
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name    string
+		params  *request.SparseParams
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "OK: no params returns the response unchanged",
+			params: &request.SparseParams{},
+			want:   `{"id":"u1","email":"u1@example.com","displayName":"","bio":"","status":"active","createdAt":"2024-01-01T00:00:00Z","updatedAt":"2024-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "OK: sparse fieldset keeps id and requested fields",
+			params: &request.SparseParams{
+				Fields: map[string][]string{ResourceTypeUsers: {"email"}},
+			},
+			want: `{"id":"u1","email":"u1@example.com"}`,
+		},
+		{
+			name: "OK: nested include with fieldsets per resource type",
+			params: &request.SparseParams{
+				Fields: map[string][]string{
+					ResourceTypeUsers:     {"email"},
+					ResourceTypeMatchings: {"status"},
+				},
+				Include: []string{"matchings.partner"},
+			},
+			want: `{"id":"u1","email":"u1@example.com","matchings":[{"id":"m1","status":"pending","partner":{"id":"u2","email":"u2@example.com"}}]}`,
+		},
+		{
+			name: "NG: unsupported include path",
+			params: &request.SparseParams{
+				Include: []string{"matchings.unknown"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := setupTestShaper().Shape(context.Background(), ResourceTypeUsers, user, tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Shape() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			var gotJSON, wantJSON interface{}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			if err := json.Unmarshal(b, &gotJSON); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			if diff := cmp.Diff(gotJSON, wantJSON); diff != "" {
+				t.Errorf("Shape() mismatching (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestShaper_ShapeField_LoadMany(t *testing.T) {
+	var calls int
+	s := NewShaper()
+	s.Register(ResourceTypeMatchings, "partner", Relationship{
+		Type: ResourceTypeUsers,
+		LoadMany: func(ctx context.Context, parents []map[string]interface{}) ([]interface{}, error) {
+			calls++
+			loaded := make([]interface{}, len(parents))
+			for i, parent := range parents {
+				if id := parent["partnerId"].(string); id != "missing" {
+					loaded[i] = response.UserResponse{ID: id, Email: id + "@example.com"}
+				}
+			}
+			return loaded, nil
+		},
+	})
+	list := response.ListMatchingsResponse{Matchings: []response.MatchingResponse{
+		{ID: "m1", MeID: "u1", PartnerID: "u2"},
+		{ID: "m2", MeID: "u1", PartnerID: "u3"},
+		{ID: "m3", MeID: "u1", PartnerID: "missing"},
+	}}
+	params := &request.SparseParams{
+		Fields:  map[string][]string{ResourceTypeMatchings: {"partnerId"}, ResourceTypeUsers: {"email"}},
+		Include: []string{"partner"},
+	}
+
+	got, err := s.ShapeField(context.Background(), ResourceTypeMatchings, list, "matchings", params)
+	if err != nil {
+		t.Fatalf("ShapeField() error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("LoadMany called %d times, want once for the page", calls)
+	}
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	want := `{"matchings":[` +
+		`{"id":"m1","partnerId":"u2","partner":{"id":"u2","email":"u2@example.com"}},` +
+		`{"id":"m2","partnerId":"u3","partner":{"id":"u3","email":"u3@example.com"}},` +
+		`{"id":"m3","partnerId":"missing","partner":null}]}`
+	var gotJSON, wantJSON interface{}
+	if err := json.Unmarshal(b, &gotJSON); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantJSON); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if diff := cmp.Diff(gotJSON, wantJSON); diff != "" {
+		t.Errorf("ShapeField() mismatching (-got +want):\n%s", diff)
+	}
+}
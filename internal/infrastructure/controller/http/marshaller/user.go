@@ -1,6 +1,9 @@
 package marshaller
 
 import (
+	"context"
+	"time"
+
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
@@ -8,10 +11,19 @@ import (
 	"github.com/google/uuid"
 )
 
+// maxIncludedMatchings caps the matchings embedded into a user by `include=matchings`.
+const maxIncludedMatchings = 100
+
 // Input Marshalling
 func ToCreateUserInput(req *request.CreateUserRequestBody) *port.CreateUserInput {
 	return &port.CreateUserInput{
-		Email: req.Email,
+		Email:       req.Email,
+		DisplayName: req.DisplayName,
+		Birthdate:   toBirthdate(req.Birthdate),
+		Gender:      req.Gender,
+		Bio:         req.Bio,
+		Locale:      req.Locale,
+		Interests:   req.Interests,
 	}
 }
 
@@ -21,6 +33,16 @@ func ToGetUserInput(req *request.GetUserParams) *port.GetUserInput {
 	}
 }
 
+func ToBatchGetUsersInput(req *request.BatchGetUsersRequestBody) *port.BatchGetUsersInput {
+	ids := make([]uuid.UUID, len(req.IDs))
+	for i, id := range req.IDs {
+		ids[i] = uuid.MustParse(id)
+	}
+	return &port.BatchGetUsersInput{
+		IDs: ids,
+	}
+}
+
 func ToListUsersInput(limit, offset int) *port.ListUserInput {
 	return &port.ListUserInput{
 		Limit:  limit,
@@ -30,8 +52,14 @@ func ToListUsersInput(limit, offset int) *port.ListUserInput {
 
 func ToUpdateUserInput(req *request.UpdateUserRequestBody, id string) *port.UpdateUserInput {
 	return &port.UpdateUserInput{
-		ID:    uuid.MustParse(id),
-		Email: req.Email,
+		ID:          uuid.MustParse(id),
+		Email:       req.Email,
+		DisplayName: req.DisplayName,
+		Birthdate:   toBirthdate(req.Birthdate),
+		Gender:      req.Gender,
+		Bio:         req.Bio,
+		Locale:      req.Locale,
+		Interests:   req.Interests,
 	}
 }
 
@@ -41,13 +69,76 @@ func ToDeleteUserInput(req *request.DeleteUserParams) *port.DeleteUserInput {
 	}
 }
 
+func ToReactivateUserInput(req *request.ReactivateUserParams) *port.ReactivateUserInput {
+	return &port.ReactivateUserInput{
+		ID: uuid.MustParse(req.ID),
+	}
+}
+
+func ToRequestEmailVerificationInput(req *request.RequestEmailVerificationParams) *port.RequestEmailVerificationInput {
+	return &port.RequestEmailVerificationInput{
+		ID: uuid.MustParse(req.ID),
+	}
+}
+
+func ToVerifyEmailInput(req *request.VerifyEmailRequestBody) *port.VerifyEmailInput {
+	return &port.VerifyEmailInput{
+		Token: req.Token,
+	}
+}
+
+func ToRequestEmailChangeInput(params *request.RequestEmailChangeParams, req *request.RequestEmailChangeRequestBody) *port.RequestEmailChangeInput {
+	return &port.RequestEmailChangeInput{
+		ID:    uuid.MustParse(params.ID),
+		Email: req.Email,
+	}
+}
+
+func ToConfirmEmailChangeInput(req *request.EmailChangeTokenRequestBody) *port.ConfirmEmailChangeInput {
+	return &port.ConfirmEmailChangeInput{
+		Token: req.Token,
+	}
+}
+
+func ToUndoEmailChangeInput(req *request.EmailChangeTokenRequestBody) *port.UndoEmailChangeInput {
+	return &port.UndoEmailChangeInput{
+		Token: req.Token,
+	}
+}
+
+// toBirthdate parses a birthdate already validated by the request decoder.
+func toBirthdate(birthdate string) time.Time {
+	t, err := time.Parse(request.BirthdateLayout, birthdate)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
 // Output Marshalling
 func ToUserResponse(user *model.User) response.UserResponse {
+	var birthdate string
+	if !user.Birthdate.IsZero() {
+		birthdate = user.Birthdate.Format(request.BirthdateLayout)
+	}
+	var emailVerifiedAt *time.Time
+	if user.IsEmailVerified() {
+		emailVerifiedAt = &user.EmailVerifiedAt
+	}
 	return response.UserResponse{
-		ID:        user.ID.String(),
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:              user.ID.String(),
+		Email:           user.Email,
+		PendingEmail:    user.PendingEmail,
+		DisplayName:     user.DisplayName,
+		Birthdate:       birthdate,
+		Gender:          string(user.Gender),
+		Bio:             user.Bio,
+		Locale:          user.Locale,
+		Interests:       user.Interests,
+		Status:          string(user.Status),
+		EmailVerifiedAt: emailVerifiedAt,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
 	}
 }
 
@@ -59,6 +150,22 @@ func ToGetUserResponse(output *port.GetUserOutput) response.GetUserResponse {
 	return response.GetUserResponse(ToUserResponse(output.User))
 }
 
+func ToBatchGetUsersResponse(output *port.BatchGetUsersOutput) response.BatchGetUsersResponse {
+	users := make([]response.UserResponse, len(output.Users))
+	for i, user := range output.Users {
+		users[i] = ToUserResponse(user)
+	}
+	missingIDs := make([]string, len(output.MissingIDs))
+	for i, id := range output.MissingIDs {
+		missingIDs[i] = id.String()
+	}
+
+	return response.BatchGetUsersResponse{
+		Users:      users,
+		MissingIDs: missingIDs,
+	}
+}
+
 func ToListUsersResponse(output *port.ListUserOutput, limit, offset int) response.ListUsersResponse {
 	users := make([]response.UserResponse, len(output.Users))
 	for i, user := range output.Users {
@@ -80,6 +187,61 @@ func ToUpdateUserResponse(output *port.UpdateUserOutput) response.UpdateUserResp
 
 func ToDeleteUserResponse(output *port.DeleteUserOutput) response.DeleteUserResponse {
 	return response.DeleteUserResponse{
-		ID: output.ID.String(),
+		ID:                  output.ID.String(),
+		PermanentDeletionAt: output.PermanentDeletionAt,
 	}
 }
+
+func ToReactivateUserResponse(output *port.ReactivateUserOutput) response.ReactivateUserResponse {
+	return response.ReactivateUserResponse(ToUserResponse(output.User))
+}
+
+func ToRequestEmailVerificationResponse(output *port.RequestEmailVerificationOutput) response.RequestEmailVerificationResponse {
+	return response.RequestEmailVerificationResponse{
+		ID:        output.ID.String(),
+		ExpiresAt: output.ExpiresAt,
+	}
+}
+
+func ToVerifyEmailResponse(output *port.VerifyEmailOutput) response.VerifyEmailResponse {
+	return response.VerifyEmailResponse(ToUserResponse(output.User))
+}
+
+func ToRequestEmailChangeResponse(output *port.RequestEmailChangeOutput) response.RequestEmailChangeResponse {
+	return response.RequestEmailChangeResponse{
+		ID:            output.ID.String(),
+		PendingEmail:  output.PendingEmail,
+		ExpiresAt:     output.ExpiresAt,
+		UndoExpiresAt: output.UndoExpiresAt,
+	}
+}
+
+func ToConfirmEmailChangeResponse(output *port.ConfirmEmailChangeOutput) response.ConfirmEmailChangeResponse {
+	return response.ConfirmEmailChangeResponse(ToUserResponse(output.User))
+}
+
+func ToUndoEmailChangeResponse(output *port.UndoEmailChangeOutput) response.UndoEmailChangeResponse {
+	return response.UndoEmailChangeResponse(ToUserResponse(output.User))
+}
+
+// Relationships
+func RegisterUserRelationships(s *Shaper, matchingInteractor port.MatchingUsecase) *Shaper {
+	s.Register(ResourceTypeUsers, "matchings", Relationship{
+		Type: ResourceTypeMatchings,
+		Load: func(ctx context.Context, parent map[string]interface{}) (interface{}, error) {
+			id, err := resourceID(parent, "id")
+			if err != nil {
+				return nil, err
+			}
+			output, err := matchingInteractor.ListByMeID(ctx, &port.ListMatchingByMeIDInput{
+				MeID:  id,
+				Limit: maxIncludedMatchings,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return ToMatchingResponses(output.Matchings), nil
+		},
+	})
+	return s
+}
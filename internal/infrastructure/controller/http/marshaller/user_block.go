@@ -0,0 +1,70 @@
+package marshaller
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/request"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+)
+
+// Input Marshalling
+func ToBlockUserInput(params *request.BlockUserParams, req *request.BlockUserRequestBody) *port.BlockUserInput {
+	return &port.BlockUserInput{
+		BlockerID: uuid.MustParse(params.UserID),
+		BlockedID: uuid.MustParse(req.BlockedID),
+	}
+}
+
+func ToUnblockUserInput(params *request.UnblockUserParams) *port.UnblockUserInput {
+	return &port.UnblockUserInput{
+		BlockerID: uuid.MustParse(params.UserID),
+		BlockedID: uuid.MustParse(params.BlockedID),
+	}
+}
+
+func ToListUserBlocksInput(params *request.ListUserBlocksParams) *port.ListUserBlocksInput {
+	return &port.ListUserBlocksInput{
+		BlockerID: uuid.MustParse(params.UserID),
+		Limit:     params.Limit,
+		Offset:    params.Offset,
+	}
+}
+
+// Output Marshalling
+func ToUserBlockResponse(block *model.UserBlock) response.UserBlockResponse {
+	return response.UserBlockResponse{
+		BlockerID: block.BlockerID.String(),
+		BlockedID: block.BlockedID.String(),
+		CreatedAt: block.CreatedAt,
+	}
+}
+
+func ToBlockUserResponse(output *port.BlockUserOutput) response.BlockUserResponse {
+	res := response.BlockUserResponse{
+		Block: ToUserBlockResponse(output.Block),
+	}
+	if output.RejectedMatching != nil {
+		matching := ToMatchingResponse(output.RejectedMatching)
+		res.RejectedMatching = &matching
+	}
+	return res
+}
+
+func ToUnblockUserResponse(output *port.UnblockUserOutput) response.UnblockUserResponse {
+	return response.UnblockUserResponse{
+		BlockerID: output.BlockerID.String(),
+		BlockedID: output.BlockedID.String(),
+	}
+}
+
+func ToListUserBlocksResponse(output *port.ListUserBlocksOutput) response.ListUserBlocksResponse {
+	blocks := make([]response.UserBlockResponse, len(output.Blocks))
+	for i, block := range output.Blocks {
+		blocks[i] = ToUserBlockResponse(block)
+	}
+	return response.ListUserBlocksResponse{
+		Blocks: blocks,
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/audit"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/auth"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// Audit puts who is making the request into the context for the audit log.
+// It must run after chimiddleware.RequestID and Authenticate. The actor is the user proven by the token,
+// so that it cannot be forged, and an unauthenticated request is recorded as anonymous.
+func Audit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actorID := uuid.Nil()
+		if principal, err := auth.FromContext(r.Context()); err == nil {
+			actorID = principal.UserID
+		}
+		ctx := audit.WithMetadata(r.Context(), audit.Metadata{
+			ActorID:   actorID,
+			RequestID: chimiddleware.GetReqID(r.Context()),
+			Source:    model.AuditSourceHTTP,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
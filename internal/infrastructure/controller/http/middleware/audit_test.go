@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/audit"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/auth"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func TestAudit(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		want      uuid.UUID
+	}{
+		{name: "OK: actor from principal", principal: &auth.Principal{UserID: userID, Role: auth.RoleUser}, want: userID},
+		{name: "OK: anonymous without principal", want: uuid.Nil()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got uuid.UUID
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = audit.MetadataFromContext(r.Context()).ActorID
+			})
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+			// A header claiming another actor is not trusted
+			req.Header.Set("X-Actor-Id", uuid.New().String())
+			if tt.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), *tt.principal))
+			}
+
+			Audit(next).ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("actor = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/environment"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/auth"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/token"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type AuthConfig struct {
+	// Signer verifies the JWT of the requests. When it is nil, no request is authenticated.
+	Signer *token.Signer
+}
+
+func NewAuthConfig(e *environment.Environment) AuthConfig {
+	cfg := AuthConfig{}
+	if e.AuthJWTSecret != "" {
+		cfg.Signer = token.NewSigner(e.AuthJWTSecret)
+	}
+	return cfg
+}
+
+type authClaims struct {
+	Subject  string `json:"sub"`
+	Role     string `json:"role"`
+	TenantID string `json:"tenant_id,omitempty"`
+}
+
+// Authenticate puts the principal of the bearer token into the context. A request without a token goes on unauthenticated,
+// and the routes that need a user reject it with RequireRole, while a request with an invalid token is rejected here.
+func Authenticate(cfg AuthConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || cfg.Signer == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			principal, err := cfg.verify(bearer)
+			if err != nil {
+				response.WriteError(w, r, domainerr.New(domainerr.ReasonAuthTokenInvalid, err, nil))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+func (c AuthConfig) verify(bearer string) (auth.Principal, error) {
+	var claims authClaims
+	if err := c.Signer.VerifyJWT(bearer, &claims, time.Now()); err != nil {
+		return auth.Principal{}, err
+	}
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return auth.Principal{}, err
+	}
+	principal := auth.Principal{UserID: userID, Role: auth.Role(claims.Role)}
+	if claims.TenantID != "" {
+		if principal.TenantID, err = tenant.Parse(claims.TenantID); err != nil {
+			return auth.Principal{}, err
+		}
+	}
+	return principal, nil
+}
+
+// RequireRole rejects the requests that are not authenticated, or whose principal does not have the role.
+// It must run after Authenticate.
+func RequireRole(role auth.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := auth.FromContext(r.Context())
+			if err != nil {
+				response.WriteError(w, r, domainerr.New(domainerr.ReasonAuthRequired, err, nil))
+				return
+			}
+			if principal.Role != role {
+				response.WriteError(w, r, domainerr.New(domainerr.ReasonAuthRoleDenied, nil, map[string]interface{}{"role": role}))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireUser rejects the requests that are not authenticated, or whose principal is not the user of the path parameter,
+// so that the resources of a user are only reached by that user. It must run after Authenticate.
+func RequireUser(param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := auth.FromContext(r.Context())
+			if err != nil {
+				response.WriteError(w, r, domainerr.New(domainerr.ReasonAuthRequired, err, nil))
+				return
+			}
+			id := chi.URLParam(r, param)
+			if userID, err := uuid.Parse(id); err != nil || userID != principal.UserID {
+				response.WriteError(w, r, domainerr.New(domainerr.ReasonAuthUserDenied, nil, map[string]interface{}{"id": id}))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
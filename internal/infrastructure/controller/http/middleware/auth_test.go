@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/auth"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/token"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func TestAuthenticate(t *testing.T) {
+	signer := token.NewSigner("secret")
+	userID := uuid.New()
+	sign := func(signer *token.Signer, claims authClaims) string {
+		signed, err := signer.SignJWT(claims)
+		if err != nil {
+			t.Fatalf("SignJWT() error = %v", err)
+		}
+		return "Bearer " + signed
+	}
+
+	tests := []struct {
+		name          string
+		cfg           AuthConfig
+		authorization string
+		wantStatus    int
+		want          *auth.Principal
+	}{
+		{
+			name:          "OK: principal from token",
+			cfg:           AuthConfig{Signer: signer},
+			authorization: sign(signer, authClaims{Subject: userID.String(), Role: "admin", TenantID: "acme"}),
+			wantStatus:    http.StatusOK,
+			want:          &auth.Principal{UserID: userID, Role: auth.RoleAdmin, TenantID: "acme"},
+		},
+		{
+			name:       "OK: unauthenticated without token",
+			cfg:        AuthConfig{Signer: signer},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "OK: unauthenticated when tokens are not verified",
+			cfg:           AuthConfig{},
+			authorization: sign(signer, authClaims{Subject: userID.String(), Role: "admin"}),
+			wantStatus:    http.StatusOK,
+		},
+		{
+			name:          "NG: token signed with another secret",
+			cfg:           AuthConfig{Signer: signer},
+			authorization: sign(token.NewSigner("another"), authClaims{Subject: userID.String(), Role: "admin"}),
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "NG: token without subject",
+			cfg:           AuthConfig{Signer: signer},
+			authorization: sign(signer, authClaims{Role: "admin"}),
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "NG: malformed tenant",
+			cfg:           AuthConfig{Signer: signer},
+			authorization: sign(signer, authClaims{Subject: userID.String(), Role: "user", TenantID: "../acme"}),
+			wantStatus:    http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *auth.Principal
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if principal, err := auth.FromContext(r.Context()); err == nil {
+					got = &principal
+				}
+				w.WriteHeader(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/reports", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			Authenticate(tt.cfg)(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if (got == nil) != (tt.want == nil) || got != nil && *got != *tt.want {
+				t.Errorf("principal = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		principal  *auth.Principal
+		wantStatus int
+	}{
+		{name: "OK: admin", principal: &auth.Principal{UserID: uuid.New(), Role: auth.RoleAdmin}, wantStatus: http.StatusOK},
+		{name: "NG: another role", principal: &auth.Principal{UserID: uuid.New(), Role: auth.RoleUser}, wantStatus: http.StatusForbidden},
+		{name: "NG: unauthenticated", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/reports", nil)
+			if tt.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), *tt.principal))
+			}
+			rec := httptest.NewRecorder()
+
+			RequireRole(auth.RoleAdmin)(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRequireUser(t *testing.T) {
+	userID := uuid.New()
+	tests := []struct {
+		name       string
+		principal  *auth.Principal
+		id         string
+		wantStatus int
+	}{
+		{name: "OK: the user", principal: &auth.Principal{UserID: userID, Role: auth.RoleUser}, id: userID.String(), wantStatus: http.StatusOK},
+		{name: "NG: another user", principal: &auth.Principal{UserID: uuid.New(), Role: auth.RoleUser}, id: userID.String(), wantStatus: http.StatusForbidden},
+		{name: "NG: an admin is not the user", principal: &auth.Principal{UserID: uuid.New(), Role: auth.RoleAdmin}, id: userID.String(), wantStatus: http.StatusForbidden},
+		{name: "NG: malformed ID", principal: &auth.Principal{UserID: userID, Role: auth.RoleUser}, id: "me", wantStatus: http.StatusForbidden},
+		{name: "NG: unauthenticated", id: userID.String(), wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := chi.NewRouter()
+			r.With(RequireUser("id")).Get("/api/v1/users/{id}/notifications", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+tt.id+"/notifications", nil)
+			if tt.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), *tt.principal))
+			}
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
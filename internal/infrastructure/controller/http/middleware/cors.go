@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/environment"
+)
+
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// NewCORSConfig builds the CORS configuration from the per-environment defaults,
+// overridden by any values set in the environment variables. It fails when any origin is allowed with credentials,
+// which would let every site make requests with the cookies of the user.
+func NewCORSConfig(e *environment.Environment) (CORSConfig, error) {
+	cfg := defaultCORSConfig(e.Environment)
+	if len(e.CORSAllowedOrigins) > 0 {
+		cfg.AllowedOrigins = e.CORSAllowedOrigins
+	}
+	if len(e.CORSAllowedMethods) > 0 {
+		cfg.AllowedMethods = e.CORSAllowedMethods
+	}
+	if len(e.CORSAllowedHeaders) > 0 {
+		cfg.AllowedHeaders = e.CORSAllowedHeaders
+	}
+	if len(e.CORSExposedHeaders) > 0 {
+		cfg.ExposedHeaders = e.CORSExposedHeaders
+	}
+	if e.CORSAllowCredentials != nil {
+		cfg.AllowCredentials = *e.CORSAllowCredentials
+	}
+	if e.CORSMaxAge > 0 {
+		cfg.MaxAge = time.Duration(e.CORSMaxAge) * time.Second
+	}
+	if cfg.allowsAnyOrigin() && cfg.AllowCredentials {
+		return CORSConfig{}, errors.New("CORS_ALLOWED_ORIGINS=* cannot be used with credentials, list the origins or set CORS_ALLOW_CREDENTIALS=false")
+	}
+	return cfg, nil
+}
+
+func defaultCORSConfig(env string) CORSConfig {
+	cfg := CORSConfig{
+		AllowedMethods: []string{
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodPut,
+			http.MethodPatch,
+			http.MethodDelete,
+		},
+		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-Request-Id", "X-Tenant-Id"},
+		ExposedHeaders: []string{"X-Request-Id"},
+	}
+
+	switch env {
+	case "local", "test":
+		// Common ports of SPA dev servers
+		cfg.AllowedOrigins = []string{"http://localhost:3000", "http://localhost:5173"}
+		cfg.AllowCredentials = true
+		cfg.MaxAge = 5 * time.Minute
+	default:
+		// Deny every cross-origin request until origins are configured explicitly
+		cfg.AllowedOrigins = []string{}
+		cfg.MaxAge = 10 * time.Minute
+	}
+	return cfg
+}
+
+// CORS handles preflight requests and sets the CORS response headers for allowed origins.
+func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
+	allowedMethods := strings.Join(cfg.AllowedMethods, ", ")
+	allowedHeaders := strings.Join(cfg.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			w.Header().Add("Vary", "Origin")
+			if preflight {
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+			}
+
+			if !cfg.isOriginAllowed(origin) {
+				if preflight {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Credentials are never allowed with the wildcard, so that no origin is reflected with them
+			if cfg.allowsAnyOrigin() {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				if cfg.AllowCredentials {
+					w.Header().Set("Access-Control-Allow-Credentials", "true")
+				}
+			}
+
+			if !preflight {
+				if exposedHeaders != "" {
+					w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !cfg.isMethodAllowed(r.Header.Get("Access-Control-Request-Method")) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+			if allowedHeaders != "" {
+				w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+			}
+			if cfg.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", maxAge)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+func (c CORSConfig) allowsAnyOrigin() bool {
+	for _, o := range c.AllowedOrigins {
+		if o == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func (c CORSConfig) isOriginAllowed(origin string) bool {
+	for _, o := range c.AllowedOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c CORSConfig) isMethodAllowed(method string) bool {
+	for _, m := range c.AllowedMethods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
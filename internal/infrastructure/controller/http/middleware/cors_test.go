@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/environment"
+)
+
+func TestCORS(t *testing.T) {
+	cfg := CORSConfig{
+		AllowedOrigins:   []string{"http://localhost:3000"},
+		AllowedMethods:   []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders:   []string{"Content-Type"},
+		AllowCredentials: true,
+		MaxAge:           5 * time.Minute,
+	}
+
+	tests := []struct {
+		name            string
+		method          string
+		origin          string
+		requestMethod   string
+		wantStatus      int
+		wantAllowOrigin string
+		wantMaxAge      string
+	}{
+		{
+			name:            "OK: simple request from allowed origin",
+			method:          http.MethodGet,
+			origin:          "http://localhost:3000",
+			wantStatus:      http.StatusOK,
+			wantAllowOrigin: "http://localhost:3000",
+		},
+		{
+			name:            "OK: preflight from allowed origin",
+			method:          http.MethodOptions,
+			origin:          "http://localhost:3000",
+			requestMethod:   http.MethodPost,
+			wantStatus:      http.StatusNoContent,
+			wantAllowOrigin: "http://localhost:3000",
+			wantMaxAge:      "300",
+		},
+		{
+			name:       "OK: request without origin",
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "NG: simple request from disallowed origin",
+			method:     http.MethodGet,
+			origin:     "http://evil.example.com",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "NG: preflight from disallowed origin",
+			method:        http.MethodOptions,
+			origin:        "http://evil.example.com",
+			requestMethod: http.MethodPost,
+			wantStatus:    http.StatusForbidden,
+		},
+		{
+			name:            "NG: preflight with disallowed method",
+			method:          http.MethodOptions,
+			origin:          "http://localhost:3000",
+			requestMethod:   http.MethodDelete,
+			wantStatus:      http.StatusForbidden,
+			wantAllowOrigin: "http://localhost:3000",
+		},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/users", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.requestMethod != "" {
+				req.Header.Set("Access-Control-Request-Method", tt.requestMethod)
+			}
+			rec := httptest.NewRecorder()
+
+			CORS(cfg)(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantAllowOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantAllowOrigin)
+			}
+			if got := rec.Header().Get("Access-Control-Max-Age"); got != tt.wantMaxAge {
+				t.Errorf("Access-Control-Max-Age = %q, want %q", got, tt.wantMaxAge)
+			}
+		})
+	}
+}
+
+func TestNewCORSConfig(t *testing.T) {
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name                 string
+		env                  environment.Environment
+		wantAllowCredentials bool
+		wantErr              bool
+	}{
+		{
+			name:                 "OK: default credentials in local",
+			env:                  environment.Environment{Environment: "local"},
+			wantAllowCredentials: true,
+		},
+		{
+			name: "OK: credentials switched off",
+			env: environment.Environment{Environment: "local", HTTPEnvironment: environment.HTTPEnvironment{
+				CORSAllowCredentials: &disabled,
+			}},
+			wantAllowCredentials: false,
+		},
+		{
+			name: "OK: any origin without credentials",
+			env: environment.Environment{Environment: "local", HTTPEnvironment: environment.HTTPEnvironment{
+				CORSAllowedOrigins:   []string{"*"},
+				CORSAllowCredentials: &disabled,
+			}},
+			wantAllowCredentials: false,
+		},
+		{
+			name: "NG: any origin with credentials",
+			env: environment.Environment{Environment: "production", HTTPEnvironment: environment.HTTPEnvironment{
+				CORSAllowedOrigins:   []string{"*"},
+				CORSAllowCredentials: &enabled,
+			}},
+			wantErr: true,
+		},
+		{
+			name: "NG: any origin with the default credentials of local",
+			env: environment.Environment{Environment: "local", HTTPEnvironment: environment.HTTPEnvironment{
+				CORSAllowedOrigins: []string{"*"},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewCORSConfig(&tt.env)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewCORSConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if cfg.AllowCredentials != tt.wantAllowCredentials {
+				t.Errorf("AllowCredentials = %t, want %t", cfg.AllowCredentials, tt.wantAllowCredentials)
+			}
+		})
+	}
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	// Built by hand, bypassing the check of NewCORSConfig
+	cfg := CORSConfig{AllowedOrigins: []string{"*"}, AllowedMethods: []string{http.MethodGet}, AllowCredentials: true}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+	req.Header.Set("Origin", "http://evil.example.com")
+	rec := httptest.NewRecorder()
+
+	CORS(cfg)(next).ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, "*")
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Access-Control-Allow-Credentials = %q, want none", got)
+	}
+}
@@ -1,33 +1,33 @@
 package middleware
 
 import (
-	"context"
 	"log/slog"
 	"net/http"
 	"time"
 
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
-)
 
-type LoggerKey string
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/logger"
+)
 
+// Logger puts a logger describing the request into the context. It must run after chimiddleware.RequestID.
 func Logger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 		traceID := uuid.New().String()
 
-		ctx := r.Context()
-		logger := slog.With(
+		requestLogger := slog.With(
 			"trace_id", traceID,
+			"request_id", chimiddleware.GetReqID(r.Context()),
 			"method", r.Method,
 			"path", r.URL.Path,
 		)
-		ctx = context.WithValue(ctx, LoggerKey("logger"), logger)
-		r = r.WithContext(ctx)
+		r = r.WithContext(logger.WithContext(r.Context(), requestLogger))
 
 		next.ServeHTTP(w, r)
 
-		logger.Info("request completed",
+		requestLogger.Info("request completed",
 			"duration", time.Since(start),
 		)
 	})
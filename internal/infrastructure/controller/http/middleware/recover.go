@@ -1,25 +1,41 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
+	"runtime/debug"
 
-	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/environment"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/errorreport"
 )
 
-func Recover(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if err := recover(); err != nil {
-				appErr := error.NewDomainError(
-					error.Critical,
-					"Internal server error",
-					nil,
-					map[string]interface{}{"panic": err},
-				)
-				response.WriteError(w, appErr)
-			}
-		}()
-		next.ServeHTTP(w, r)
-	})
+// NewErrorConfig exposes the internal errors to the clients only in the local environments.
+func NewErrorConfig(e *environment.Environment, reporter errorreport.Reporter) response.ErrorConfig {
+	cfg := response.ErrorConfig{Reporter: reporter}
+	switch e.Environment {
+	case "local", "test":
+		cfg.ExposeInternal = true
+	}
+	return cfg
+}
+
+// Recover renders a panic as an internal error, and sets how the internal errors of the request are rendered.
+// It must run after Logger, so that the panic is logged with the request.
+func Recover(cfg response.ErrorConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r = r.WithContext(response.WithErrorConfig(r.Context(), cfg))
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					// The handler gave up on the response on purpose
+					if recovered == http.ErrAbortHandler {
+						panic(recovered)
+					}
+					response.WriteInternalError(w, r, fmt.Errorf("panic: %v", recovered), debug.Stack())
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
 }
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/errorreport"
+)
+
+type fakeReporter struct {
+	reports []errorreport.Report
+}
+
+func (r *fakeReporter) Report(ctx context.Context, report errorreport.Report) error {
+	r.reports = append(r.reports, report)
+	return nil
+}
+
+func TestRecover(t *testing.T) {
+	panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("dial tcp 10.0.0.1:3306: connection refused")
+	})
+	failing := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response.WriteError(w, r, errors.New("Error 1146: Table 'app.users' doesn't exist"))
+	})
+
+	tests := []struct {
+		name           string
+		handler        http.Handler
+		exposeInternal bool
+		acceptLanguage string
+		wantMessage    string
+		wantReported   string
+	}{
+		{
+			name:         "OK: panic hidden",
+			handler:      panicking,
+			wantMessage:  "Internal server error",
+			wantReported: "connection refused",
+		},
+		{
+			name:           "OK: error hidden in the language of the client",
+			handler:        failing,
+			acceptLanguage: "ja",
+			wantMessage:    "サーバー内部でエラーが発生しました",
+			wantReported:   "Error 1146",
+		},
+		{
+			name:           "OK: panic exposed",
+			handler:        panicking,
+			exposeInternal: true,
+			wantMessage:    "panic: dial tcp 10.0.0.1:3306: connection refused",
+			wantReported:   "connection refused",
+		},
+		{
+			name:           "OK: error exposed",
+			handler:        failing,
+			exposeInternal: true,
+			wantMessage:    "Error 1146: Table 'app.users' doesn't exist",
+			wantReported:   "Error 1146",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := &fakeReporter{}
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			rec := httptest.NewRecorder()
+
+			Recover(response.ErrorConfig{ExposeInternal: tt.exposeInternal, Reporter: reporter})(tt.handler).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusInternalServerError {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+			}
+			var got response.ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("invalid response body %q: %v", rec.Body.String(), err)
+			}
+			if got.Message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", got.Message, tt.wantMessage)
+			}
+			if len(reporter.reports) != 1 {
+				t.Fatalf("reported %d errors, want 1", len(reporter.reports))
+			}
+			report := reporter.reports[0]
+			if got.ErrorID == "" || report.ID != got.ErrorID {
+				t.Errorf("errorId = %q, reported %q", got.ErrorID, report.ID)
+			}
+			if !strings.Contains(report.Err.Error(), tt.wantReported) || len(report.Stack) == 0 {
+				t.Errorf("reported %v without the full error and its stack", report.Err)
+			}
+		})
+	}
+}
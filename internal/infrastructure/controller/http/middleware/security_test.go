@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/environment"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      environment.Environment
+		path     string
+		wantHSTS string
+		wantCSP  string
+	}{
+		{
+			name:     "OK: API in production",
+			env:      environment.Environment{Environment: "production"},
+			path:     "/api/v1/users",
+			wantHSTS: "max-age=31536000; includeSubDomains",
+			wantCSP:  apiContentSecurityPolicy,
+		},
+		{
+			name:    "OK: no HSTS in local",
+			env:     environment.Environment{Environment: "local"},
+			path:    "/api/v1/users",
+			wantCSP: apiContentSecurityPolicy,
+		},
+		{
+			name:     "OK: HSTS max age overridden",
+			env:      environment.Environment{Environment: "local", HTTPEnvironment: environment.HTTPEnvironment{HSTSMaxAge: 60}},
+			path:     "/api/v1/users",
+			wantHSTS: "max-age=60",
+			wantCSP:  apiContentSecurityPolicy,
+		},
+		{
+			name:     "OK: swagger UI policy",
+			env:      environment.Environment{Environment: "production"},
+			path:     "/swagger/index.html",
+			wantHSTS: "max-age=31536000; includeSubDomains",
+			wantCSP:  swaggerContentSecurityPolicy,
+		},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			SecurityHeaders(NewSecurityHeadersConfig(&tt.env))(next).ServeHTTP(rec, req)
+
+			want := map[string]string{
+				"X-Content-Type-Options":    "nosniff",
+				"X-Frame-Options":           "DENY",
+				"Referrer-Policy":           "no-referrer",
+				"Strict-Transport-Security": tt.wantHSTS,
+				"Content-Security-Policy":   tt.wantCSP,
+			}
+			for name, value := range want {
+				if got := rec.Header().Get(name); got != value {
+					t.Errorf("%s = %q, want %q", name, got, value)
+				}
+			}
+		})
+	}
+}
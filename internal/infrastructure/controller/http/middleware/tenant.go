@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/response"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/environment"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/auth"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+)
+
+// TenantIDHeader names the tenant of the request when the API does not verify JWTs, which is only allowed in local and test.
+const TenantIDHeader = "X-Tenant-Id"
+
+type TenantConfig struct {
+	// TenantIDs are the tenants served. A request naming another tenant is rejected.
+	TenantIDs []tenant.ID
+	// DefaultID serves the requests naming no tenant. They are rejected when it is empty.
+	// When FromToken is set, it only serves the authenticated requests whose token has no tenant_id claim.
+	DefaultID tenant.ID
+	// FromToken takes the tenant from the tenant_id claim of the authenticated token only. It is set when the API verifies tokens.
+	FromToken bool
+}
+
+// NewTenantConfig builds the tenant configuration from the environment variables, and fails on a malformed tenant ID.
+// Anyone can send the X-Tenant-Id header, so it fails outside local and test unless the tenant comes from verified tokens.
+func NewTenantConfig(e *environment.Environment) (TenantConfig, error) {
+	cfg := TenantConfig{}
+	for _, s := range e.TenantIDs {
+		id, err := tenant.Parse(strings.TrimSpace(s))
+		if err != nil {
+			return TenantConfig{}, fmt.Errorf("TENANT_IDS %q: %w", s, err)
+		}
+		cfg.TenantIDs = append(cfg.TenantIDs, id)
+	}
+	if e.TenantDefault != "" {
+		id, err := tenant.Parse(e.TenantDefault)
+		if err != nil {
+			return TenantConfig{}, fmt.Errorf("TENANT_DEFAULT %q: %w", e.TenantDefault, err)
+		}
+		if !cfg.serves(id) {
+			return TenantConfig{}, fmt.Errorf("TENANT_DEFAULT %q is not in TENANT_IDS", e.TenantDefault)
+		}
+		cfg.DefaultID = id
+	}
+	cfg.FromToken = e.AuthJWTSecret != ""
+	if !cfg.FromToken {
+		switch e.Environment {
+		case "local", "test":
+		default:
+			return TenantConfig{}, fmt.Errorf("AUTH_JWT_SECRET is required in %s, the %s header alone does not isolate the tenants", e.Environment, TenantIDHeader)
+		}
+	}
+	return cfg, nil
+}
+
+// Tenant puts the tenant of the request into the context. It must run after Authenticate. The repositories refuse to run without it,
+// so a route mounted behind this middleware can never read or write the data of another tenant.
+func Tenant(cfg TenantConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, err := cfg.resolve(r)
+			if err != nil {
+				response.WriteError(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(tenant.WithID(r.Context(), id)))
+		})
+	}
+}
+
+func (c TenantConfig) resolve(r *http.Request) (tenant.ID, error) {
+	name := r.Header.Get(TenantIDHeader)
+	if c.FromToken {
+		principal, err := auth.FromContext(r.Context())
+		if err != nil {
+			return "", domainerr.New(domainerr.ReasonAuthRequired, err, nil)
+		}
+		claimed := principal.TenantID.String()
+		// The header cannot override the tenant of the token
+		if name != "" && name != claimed {
+			return "", domainerr.New(domainerr.ReasonTenantMismatch, nil, map[string]interface{}{"tenantId": name})
+		}
+		name = claimed
+	}
+
+	if name == "" {
+		if c.DefaultID == "" {
+			return "", domainerr.New(domainerr.ReasonTenantRequired, nil, nil)
+		}
+		return c.DefaultID, nil
+	}
+	id, err := tenant.Parse(name)
+	if err != nil || !c.serves(id) {
+		return "", domainerr.New(domainerr.ReasonTenantUnknown, err, map[string]interface{}{"tenantId": name})
+	}
+	return id, nil
+}
+
+func (c TenantConfig) serves(id tenant.ID) bool {
+	for _, t := range c.TenantIDs {
+		if t == id {
+			return true
+		}
+	}
+	return false
+}
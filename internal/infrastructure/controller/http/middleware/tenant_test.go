@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/environment"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/token"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func TestTenant(t *testing.T) {
+	signer := token.NewSigner("secret")
+	sign := func(signer *token.Signer, tenantID string) string {
+		signed, err := signer.SignJWT(authClaims{Subject: uuid.New().String(), Role: "user", TenantID: tenantID})
+		if err != nil {
+			t.Fatalf("SignJWT() error = %v", err)
+		}
+		return "Bearer " + signed
+	}
+	headerCfg := TenantConfig{TenantIDs: []tenant.ID{"default", "acme"}}
+	defaultCfg := TenantConfig{TenantIDs: []tenant.ID{"default", "acme"}, DefaultID: "default"}
+	jwtCfg := TenantConfig{TenantIDs: []tenant.ID{"default", "acme"}, DefaultID: "default", FromToken: true}
+
+	tests := []struct {
+		name          string
+		cfg           TenantConfig
+		header        string
+		authorization string
+		wantStatus    int
+		wantTenant    tenant.ID
+	}{
+		{
+			name:       "OK: tenant from header",
+			cfg:        headerCfg,
+			header:     "acme",
+			wantStatus: http.StatusOK,
+			wantTenant: "acme",
+		},
+		{
+			name:       "OK: default tenant without header",
+			cfg:        defaultCfg,
+			wantStatus: http.StatusOK,
+			wantTenant: "default",
+		},
+		{
+			name:          "OK: tenant from token",
+			cfg:           jwtCfg,
+			authorization: sign(signer, "acme"),
+			wantStatus:    http.StatusOK,
+			wantTenant:    "acme",
+		},
+		{
+			name:          "OK: default tenant for token without tenant",
+			cfg:           jwtCfg,
+			authorization: sign(signer, ""),
+			wantStatus:    http.StatusOK,
+			wantTenant:    "default",
+		},
+		{
+			name:          "OK: header agreeing with token",
+			cfg:           jwtCfg,
+			header:        "acme",
+			authorization: sign(signer, "acme"),
+			wantStatus:    http.StatusOK,
+			wantTenant:    "acme",
+		},
+		{
+			name:       "NG: no tenant without default",
+			cfg:        headerCfg,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "NG: unknown tenant",
+			cfg:        headerCfg,
+			header:     "other",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "NG: malformed tenant",
+			cfg:        headerCfg,
+			header:     "../acme",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "NG: header overriding token",
+			cfg:           jwtCfg,
+			header:        "default",
+			authorization: sign(signer, "acme"),
+			wantStatus:    http.StatusForbidden,
+		},
+		{
+			name:       "NG: header without token when tokens are verified",
+			cfg:        jwtCfg,
+			header:     "acme",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "NG: no token when tokens are verified",
+			cfg:        jwtCfg,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "NG: token signed with another secret",
+			cfg:           jwtCfg,
+			authorization: sign(token.NewSigner("another"), "acme"),
+			wantStatus:    http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got tenant.ID
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = tenant.FromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+			if tt.header != "" {
+				req.Header.Set(TenantIDHeader, tt.header)
+			}
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			// The tokens are verified by Authenticate, which runs first
+			authCfg := AuthConfig{}
+			if tt.cfg.FromToken {
+				authCfg.Signer = signer
+			}
+			Authenticate(authCfg)(Tenant(tt.cfg)(next)).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got != tt.wantTenant {
+				t.Errorf("tenant = %q, want %q", got, tt.wantTenant)
+			}
+		})
+	}
+}
+
+func TestNewTenantConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		env           environment.Environment
+		wantFromToken bool
+		wantErr       bool
+	}{
+		{
+			name:          "OK: tenant from token",
+			env:           environment.Environment{Environment: "production", AuthEnvironment: environment.AuthEnvironment{AuthJWTSecret: "secret"}},
+			wantFromToken: true,
+		},
+		{
+			name: "OK: tenant from header in local",
+			env:  environment.Environment{Environment: "local"},
+		},
+		{
+			name:    "NG: tenant from header in production",
+			env:     environment.Environment{Environment: "production"},
+			wantErr: true,
+		},
+		{
+			name: "NG: default tenant not served",
+			env: environment.Environment{Environment: "local", TenantEnvironment: environment.TenantEnvironment{
+				TenantIDs:     []string{"acme"},
+				TenantDefault: "default",
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := tt.env
+			if env.TenantIDs == nil {
+				env.TenantIDs = []string{"default"}
+			}
+			got, err := NewTenantConfig(&env)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTenantConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.FromToken != tt.wantFromToken {
+				t.Errorf("NewTenantConfig() fromToken = %v, want %v", got.FromToken, tt.wantFromToken)
+			}
+		})
+	}
+}
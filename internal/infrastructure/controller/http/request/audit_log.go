@@ -0,0 +1,56 @@
+package request
+
+import (
+	"net/http"
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+)
+
+type SearchAuditLogsParams struct {
+	ActorID    string `query:"actorId"`
+	TargetType string `query:"targetType"`
+	TargetID   string `query:"targetId"`
+	From       string `query:"from"`
+	To         string `query:"to"`
+	Limit      int    `query:"limit"`
+	Offset     int    `query:"offset"`
+}
+
+// Request Decoding
+func DecodeSearchAuditLogsRequest(r *http.Request) (*SearchAuditLogsParams, error) {
+	limit, offset, err := DecodeListUserRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	query := r.URL.Query()
+	params := &SearchAuditLogsParams{
+		ActorID:    query.Get("actorId"),
+		TargetType: query.Get("targetType"),
+		TargetID:   query.Get("targetId"),
+		From:       query.Get("from"),
+		To:         query.Get("to"),
+		Limit:      limit,
+		Offset:     offset,
+	}
+	if params.ActorID != "" {
+		if err := validateUserID(params.ActorID); err != nil {
+			return nil, err
+		}
+	}
+	if params.TargetType != "" {
+		if _, ok := model.AuditTargetTypes[model.AuditTargetType(params.TargetType)]; !ok {
+			return nil, domainerr.New(domainerr.ReasonParameterInvalid, nil, map[string]interface{}{"parameter": "target type", "targetType": params.TargetType})
+		}
+	}
+	for name, value := range map[string]string{"from": params.From, "to": params.To} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return nil, domainerr.New(domainerr.ReasonParameterInvalid, err, map[string]interface{}{"parameter": name, name: value})
+		}
+	}
+	return params, nil
+}
@@ -0,0 +1,93 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+)
+
+type ConversationParams struct {
+	UserID    string `param:"id"`
+	PartnerID string `param:"partnerId"`
+}
+
+type SendChatMessageRequestBody struct {
+	Body string `json:"body" maxLength:"2000"`
+}
+
+type ListChatMessagesParams struct {
+	ConversationParams
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit"`
+}
+
+type MarkChatMessagesReadRequestBody struct {
+	// UpToMessageID is the newest message read. Empty marks every message as read.
+	UpToMessageID string `json:"upToMessageId" format:"uuid"`
+}
+
+// Request Decoding
+func DecodeSendChatMessageRequest(r *http.Request) (*ConversationParams, *SendChatMessageRequestBody, error) {
+	params, err := decodeConversationParams(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var req SendChatMessageRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, nil, domainerr.New(domainerr.ReasonRequestBodyInvalid, err, nil)
+	}
+	return params, &req, nil
+}
+
+func DecodeListChatMessagesRequest(r *http.Request) (*ListChatMessagesParams, error) {
+	params, err := decodeConversationParams(r)
+	if err != nil {
+		return nil, err
+	}
+	limit, _, err := DecodeListUserRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	return &ListChatMessagesParams{
+		ConversationParams: *params,
+		Cursor:             r.URL.Query().Get("cursor"),
+		Limit:              limit,
+	}, nil
+}
+
+// DecodeMarkChatMessagesReadRequest accepts an empty body to mark every message as read.
+func DecodeMarkChatMessagesReadRequest(r *http.Request) (*ConversationParams, *MarkChatMessagesReadRequestBody, error) {
+	params, err := decodeConversationParams(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var req MarkChatMessagesReadRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, domainerr.New(domainerr.ReasonRequestBodyInvalid, err, nil)
+	}
+	if req.UpToMessageID != "" {
+		if _, err := uuid.Parse(req.UpToMessageID); err != nil {
+			return nil, nil, domainerr.New(domainerr.ReasonParameterInvalid, err, map[string]interface{}{"parameter": "message ID", "id": req.UpToMessageID})
+		}
+	}
+	return params, &req, nil
+}
+
+func decodeConversationParams(r *http.Request) (*ConversationParams, error) {
+	params := &ConversationParams{
+		UserID:    chi.URLParam(r, "id"),
+		PartnerID: chi.URLParam(r, "partnerId"),
+	}
+	for _, id := range []string{params.UserID, params.PartnerID} {
+		if err := validateUserID(id); err != nil {
+			return nil, err
+		}
+	}
+	return params, nil
+}
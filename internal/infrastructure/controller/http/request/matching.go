@@ -0,0 +1,60 @@
+package request
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+)
+
+type ListMatchingsParams struct {
+	UserID string `param:"id"`
+	Limit  int    `query:"limit"`
+	Offset int    `query:"offset"`
+	Mutual bool   `query:"mutual"`
+}
+
+type GetMatchingTimelineParams struct {
+	UserID    string `param:"id"`
+	PartnerID string `param:"partnerId"`
+}
+
+// Request Decoding
+func DecodeListMatchingsRequest(r *http.Request) (*ListMatchingsParams, error) {
+	userID := chi.URLParam(r, "id")
+	if err := validateUserID(userID); err != nil {
+		return nil, err
+	}
+	limit, offset, err := DecodeListUserRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	mutual := false
+	if v := r.URL.Query().Get("mutual"); v != "" {
+		mutual, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, domainerr.New(domainerr.ReasonParameterInvalid, err, map[string]interface{}{"parameter": "mutual", "mutual": v})
+		}
+	}
+	return &ListMatchingsParams{
+		UserID: userID,
+		Limit:  limit,
+		Offset: offset,
+		Mutual: mutual,
+	}, nil
+}
+
+func DecodeGetMatchingTimelineRequest(r *http.Request) (*GetMatchingTimelineParams, error) {
+	params := &GetMatchingTimelineParams{
+		UserID:    chi.URLParam(r, "id"),
+		PartnerID: chi.URLParam(r, "partnerId"),
+	}
+	for _, id := range []string{params.UserID, params.PartnerID} {
+		if err := validateUserID(id); err != nil {
+			return nil, err
+		}
+	}
+	return params, nil
+}
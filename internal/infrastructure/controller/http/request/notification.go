@@ -0,0 +1,104 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+)
+
+type NotificationParams struct {
+	UserID string `param:"id"`
+}
+
+type ListNotificationsParams struct {
+	NotificationParams
+	UnreadOnly bool `query:"unreadOnly"`
+	Limit      int  `query:"limit"`
+	Offset     int  `query:"offset"`
+}
+
+type MarkNotificationsReadRequestBody struct {
+	// NotificationID is the notification read. Empty marks every notification as read.
+	NotificationID string `json:"notificationId" format:"uuid"`
+}
+
+type UpdateNotificationPreferenceRequestBody struct {
+	// Channels turns the channels on or off by name: in_app, email or push. The channels not in it are left as they are.
+	Channels map[string]bool `json:"channels"`
+}
+
+// Request Decoding
+func DecodeNotificationRequest(r *http.Request) (*NotificationParams, error) {
+	userID := chi.URLParam(r, "id")
+	if err := validateUserID(userID); err != nil {
+		return nil, err
+	}
+	return &NotificationParams{UserID: userID}, nil
+}
+
+func DecodeListNotificationsRequest(r *http.Request) (*ListNotificationsParams, error) {
+	params, err := DecodeNotificationRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	limit, offset, err := DecodeListUserRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	unreadOnly := false
+	if v := r.URL.Query().Get("unreadOnly"); v != "" {
+		unreadOnly, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, domainerr.New(domainerr.ReasonParameterInvalid, err, map[string]interface{}{"parameter": "unreadOnly", "unreadOnly": v})
+		}
+	}
+	return &ListNotificationsParams{
+		NotificationParams: *params,
+		UnreadOnly:         unreadOnly,
+		Limit:              limit,
+		Offset:             offset,
+	}, nil
+}
+
+// DecodeMarkNotificationsReadRequest accepts an empty body to mark every notification as read.
+func DecodeMarkNotificationsReadRequest(r *http.Request) (*NotificationParams, *MarkNotificationsReadRequestBody, error) {
+	params, err := DecodeNotificationRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var req MarkNotificationsReadRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, domainerr.New(domainerr.ReasonRequestBodyInvalid, err, nil)
+	}
+	if req.NotificationID != "" {
+		if _, err := uuid.Parse(req.NotificationID); err != nil {
+			return nil, nil, domainerr.New(domainerr.ReasonParameterInvalid, err, map[string]interface{}{"parameter": "notification ID", "id": req.NotificationID})
+		}
+	}
+	return params, &req, nil
+}
+
+func DecodeUpdateNotificationPreferenceRequest(r *http.Request) (*NotificationParams, *UpdateNotificationPreferenceRequestBody, error) {
+	params, err := DecodeNotificationRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var req UpdateNotificationPreferenceRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, nil, domainerr.New(domainerr.ReasonRequestBodyInvalid, err, nil)
+	}
+	for channel := range req.Channels {
+		if _, ok := model.NotificationChannels[model.NotificationChannel(channel)]; !ok {
+			return nil, nil, domainerr.New(domainerr.ReasonNotificationChannelUnknown, nil, map[string]interface{}{"channel": channel})
+		}
+	}
+	return params, &req, nil
+}
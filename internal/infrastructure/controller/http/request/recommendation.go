@@ -0,0 +1,28 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ListRecommendationsParams struct {
+	UserID string `param:"id"`
+	Limit  int    `query:"limit"`
+}
+
+// Request Decoding
+func DecodeListRecommendationsRequest(r *http.Request) (*ListRecommendationsParams, error) {
+	params := &ListRecommendationsParams{
+		UserID: chi.URLParam(r, "id"),
+	}
+	if err := validateUserID(params.UserID); err != nil {
+		return nil, err
+	}
+	limit, _, err := DecodeListUserRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	params.Limit = limit
+	return params, nil
+}
@@ -0,0 +1,106 @@
+package request
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+)
+
+type CreateReportParams struct {
+	UserID string `param:"id"`
+}
+
+type CreateReportRequestBody struct {
+	ReportedID string `json:"reportedId" format:"uuid"`
+	Reason     string `json:"reason" enums:"spam,harassment,inappropriate_content,fake_profile,underage,other"`
+	Comment    string `json:"comment"`
+}
+
+type ListReportsParams struct {
+	Status     string `query:"status"`
+	AssigneeID string `query:"assigneeId"`
+	Limit      int    `query:"limit"`
+	Offset     int    `query:"offset"`
+}
+
+type ReportParams struct {
+	ReportID string `param:"reportId"`
+}
+
+type ResolveReportRequestBody struct {
+	Resolution string `json:"resolution" enums:"dismissed,warned,suspended"`
+	Note       string `json:"note"`
+}
+
+// Request Decoding
+func DecodeCreateReportRequest(r *http.Request) (*CreateReportParams, *CreateReportRequestBody, error) {
+	params := &CreateReportParams{
+		UserID: chi.URLParam(r, "id"),
+	}
+	if err := validateUserID(params.UserID); err != nil {
+		return nil, nil, err
+	}
+	var req CreateReportRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, nil, domainerr.New(domainerr.ReasonRequestBodyInvalid, err, nil)
+	}
+	if err := validateUserID(req.ReportedID); err != nil {
+		return nil, nil, err
+	}
+	return params, &req, nil
+}
+
+func DecodeListReportsRequest(r *http.Request) (*ListReportsParams, error) {
+	limit, offset, err := DecodeListUserRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	params := &ListReportsParams{
+		Status:     r.URL.Query().Get("status"),
+		AssigneeID: r.URL.Query().Get("assigneeId"),
+		Limit:      limit,
+		Offset:     offset,
+	}
+	if params.Status != "" {
+		if _, ok := model.ReportStatuses[model.ReportStatus(params.Status)]; !ok {
+			return nil, domainerr.New(domainerr.ReasonParameterInvalid, nil, map[string]interface{}{"parameter": "status", "status": params.Status})
+		}
+	}
+	if params.AssigneeID != "" {
+		if err := validateUserID(params.AssigneeID); err != nil {
+			return nil, err
+		}
+	}
+	return params, nil
+}
+
+func DecodeAssignReportRequest(r *http.Request) (*ReportParams, error) {
+	return decodeReportParams(r)
+}
+
+func DecodeResolveReportRequest(r *http.Request) (*ReportParams, *ResolveReportRequestBody, error) {
+	params, err := decodeReportParams(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var req ResolveReportRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, nil, domainerr.New(domainerr.ReasonRequestBodyInvalid, err, nil)
+	}
+	return params, &req, nil
+}
+
+func decodeReportParams(r *http.Request) (*ReportParams, error) {
+	params := &ReportParams{
+		ReportID: chi.URLParam(r, "reportId"),
+	}
+	if _, err := uuid.Parse(params.ReportID); err != nil {
+		return nil, domainerr.New(domainerr.ReasonParameterInvalid, err, map[string]interface{}{"parameter": "report ID", "id": params.ReportID})
+	}
+	return params, nil
+}
@@ -0,0 +1,45 @@
+package request
+
+import (
+	"net/http"
+	"strings"
+)
+
+// SparseParams holds the JSON:API-style query parameters that shape a response,
+// e.g. `fields[users]=email` and `include=matchings.partner`.
+type SparseParams struct {
+	Fields  map[string][]string
+	Include []string
+}
+
+func (p *SparseParams) IsEmpty() bool {
+	return p == nil || (len(p.Fields) == 0 && len(p.Include) == 0)
+}
+
+func DecodeSparseParams(r *http.Request) *SparseParams {
+	params := &SparseParams{
+		Fields: map[string][]string{},
+	}
+	for key, values := range r.URL.Query() {
+		switch {
+		case key == "include":
+			params.Include = append(params.Include, splitList(values)...)
+		case strings.HasPrefix(key, "fields[") && strings.HasSuffix(key, "]"):
+			resourceType := strings.TrimSuffix(strings.TrimPrefix(key, "fields["), "]")
+			params.Fields[resourceType] = append(params.Fields[resourceType], splitList(values)...)
+		}
+	}
+	return params
+}
+
+func splitList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
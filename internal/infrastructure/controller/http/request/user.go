@@ -4,19 +4,34 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
+// BirthdateLayout is the format of the birthdate in requests and responses.
+const BirthdateLayout = time.DateOnly
+
 type CreateUserRequestBody struct {
-	Email string `json:"email"`
+	Email       string   `json:"email"`
+	DisplayName string   `json:"displayName"`
+	Birthdate   string   `json:"birthdate"   example:"2000-01-31"`
+	Gender      string   `json:"gender"      enums:"male,female,other"`
+	Bio         string   `json:"bio"`
+	Locale      string   `json:"locale"      example:"ja-JP"`
+	Interests   []string `json:"interests"   example:"hiking,music"`
 }
 
 type GetUserParams struct {
 	ID string `param:"id"`
 }
 
+type BatchGetUsersRequestBody struct {
+	IDs []string `json:"ids"`
+}
+
 type ListUsersQueryParams struct {
 	Page     int    `query:"page"`
 	PageSize int    `query:"pageSize"`
@@ -29,13 +44,44 @@ type UpdateUserParams struct {
 }
 
 type UpdateUserRequestBody struct {
-	Email string `json:"email"`
+	Email       string   `json:"email"`
+	DisplayName string   `json:"displayName"`
+	Birthdate   string   `json:"birthdate"   example:"2000-01-31"`
+	Gender      string   `json:"gender"      enums:"male,female,other"`
+	Bio         string   `json:"bio"`
+	Locale      string   `json:"locale"      example:"ja-JP"`
+	Interests   []string `json:"interests"   example:"hiking,music"`
 }
 
 type DeleteUserParams struct {
 	ID string `param:"id"`
 }
 
+type ReactivateUserParams struct {
+	ID string `param:"id"`
+}
+
+type RequestEmailVerificationParams struct {
+	ID string `param:"id"`
+}
+
+type VerifyEmailRequestBody struct {
+	Token string `json:"token"`
+}
+
+type RequestEmailChangeParams struct {
+	ID string `param:"id"`
+}
+
+type RequestEmailChangeRequestBody struct {
+	Email string `json:"email"`
+}
+
+// EmailChangeTokenRequestBody carries the token mailed to confirm or to undo an email change.
+type EmailChangeTokenRequestBody struct {
+	Token string `json:"token"`
+}
+
 // Request Decoding
 func DecodeListUserRequest(r *http.Request) (int, int, error) {
 	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
@@ -52,7 +98,23 @@ func DecodeListUserRequest(r *http.Request) (int, int, error) {
 func DecodeCreateUserRequest(r *http.Request) (*CreateUserRequestBody, error) {
 	var req CreateUserRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return nil, domainerr.NewDomainError(domainerr.InvalidArgument, "Invalid request body", err, nil)
+		return nil, domainerr.New(domainerr.ReasonRequestBodyInvalid, err, nil)
+	}
+	if err := validateBirthdate(req.Birthdate); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func DecodeBatchGetUsersRequest(r *http.Request) (*BatchGetUsersRequestBody, error) {
+	var req BatchGetUsersRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, domainerr.New(domainerr.ReasonRequestBodyInvalid, err, nil)
+	}
+	for _, id := range req.IDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, domainerr.New(domainerr.ReasonParameterInvalid, err, map[string]interface{}{"parameter": "user ID", "id": id})
+		}
 	}
 	return &req, nil
 }
@@ -66,7 +128,10 @@ func DecodeGetUserRequest(r *http.Request) (*GetUserParams, error) {
 func DecodeUpdateUserRequest(r *http.Request) (*UpdateUserRequestBody, error) {
 	var req UpdateUserRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		return nil, domainerr.NewDomainError(domainerr.InvalidArgument, "Invalid request body", err, nil)
+		return nil, domainerr.New(domainerr.ReasonRequestBodyInvalid, err, nil)
+	}
+	if err := validateBirthdate(req.Birthdate); err != nil {
+		return nil, err
 	}
 	return &req, nil
 }
@@ -76,3 +141,66 @@ func DecodeDeleteUserRequest(r *http.Request) (*DeleteUserParams, error) {
 		ID: chi.URLParam(r, "id"),
 	}, nil
 }
+
+func DecodeReactivateUserRequest(r *http.Request) (*ReactivateUserParams, error) {
+	return &ReactivateUserParams{
+		ID: chi.URLParam(r, "id"),
+	}, nil
+}
+
+func DecodeRequestEmailVerificationRequest(r *http.Request) (*RequestEmailVerificationParams, error) {
+	id := chi.URLParam(r, "id")
+	if err := validateUserID(id); err != nil {
+		return nil, err
+	}
+	return &RequestEmailVerificationParams{
+		ID: id,
+	}, nil
+}
+
+func DecodeVerifyEmailRequest(r *http.Request) (*VerifyEmailRequestBody, error) {
+	var req VerifyEmailRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, domainerr.New(domainerr.ReasonRequestBodyInvalid, err, nil)
+	}
+	if req.Token == "" {
+		return nil, domainerr.New(domainerr.ReasonParameterRequired, nil, map[string]interface{}{"parameter": "token"})
+	}
+	return &req, nil
+}
+
+func DecodeRequestEmailChangeRequest(r *http.Request) (*RequestEmailChangeParams, *RequestEmailChangeRequestBody, error) {
+	id := chi.URLParam(r, "id")
+	if err := validateUserID(id); err != nil {
+		return nil, nil, err
+	}
+	var req RequestEmailChangeRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, nil, domainerr.New(domainerr.ReasonRequestBodyInvalid, err, nil)
+	}
+	if req.Email == "" {
+		return nil, nil, domainerr.New(domainerr.ReasonParameterRequired, nil, map[string]interface{}{"parameter": "email"})
+	}
+	return &RequestEmailChangeParams{ID: id}, &req, nil
+}
+
+func DecodeEmailChangeTokenRequest(r *http.Request) (*EmailChangeTokenRequestBody, error) {
+	var req EmailChangeTokenRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, domainerr.New(domainerr.ReasonRequestBodyInvalid, err, nil)
+	}
+	if req.Token == "" {
+		return nil, domainerr.New(domainerr.ReasonParameterRequired, nil, map[string]interface{}{"parameter": "token"})
+	}
+	return &req, nil
+}
+
+func validateBirthdate(birthdate string) error {
+	if birthdate == "" {
+		return nil
+	}
+	if _, err := time.Parse(BirthdateLayout, birthdate); err != nil {
+		return domainerr.New(domainerr.ReasonParameterInvalid, err, map[string]interface{}{"parameter": "birthdate", "birthdate": birthdate})
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package request
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+)
+
+type BlockUserParams struct {
+	UserID string `param:"id"`
+}
+
+type BlockUserRequestBody struct {
+	BlockedID string `json:"blockedId" format:"uuid"`
+}
+
+type UnblockUserParams struct {
+	UserID    string `param:"id"`
+	BlockedID string `param:"blockedId"`
+}
+
+type ListUserBlocksParams struct {
+	UserID string `param:"id"`
+	Limit  int    `query:"limit"`
+	Offset int    `query:"offset"`
+}
+
+// Request Decoding
+func DecodeBlockUserRequest(r *http.Request) (*BlockUserParams, *BlockUserRequestBody, error) {
+	params := &BlockUserParams{
+		UserID: chi.URLParam(r, "id"),
+	}
+	if err := validateUserID(params.UserID); err != nil {
+		return nil, nil, err
+	}
+	var req BlockUserRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, nil, domainerr.New(domainerr.ReasonRequestBodyInvalid, err, nil)
+	}
+	if err := validateUserID(req.BlockedID); err != nil {
+		return nil, nil, err
+	}
+	return params, &req, nil
+}
+
+func DecodeUnblockUserRequest(r *http.Request) (*UnblockUserParams, error) {
+	params := &UnblockUserParams{
+		UserID:    chi.URLParam(r, "id"),
+		BlockedID: chi.URLParam(r, "blockedId"),
+	}
+	for _, id := range []string{params.UserID, params.BlockedID} {
+		if err := validateUserID(id); err != nil {
+			return nil, err
+		}
+	}
+	return params, nil
+}
+
+func DecodeListUserBlocksRequest(r *http.Request) (*ListUserBlocksParams, error) {
+	params := &ListUserBlocksParams{
+		UserID: chi.URLParam(r, "id"),
+	}
+	if err := validateUserID(params.UserID); err != nil {
+		return nil, err
+	}
+	limit, offset, err := DecodeListUserRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	params.Limit = limit
+	params.Offset = offset
+	return params, nil
+}
+
+func validateUserID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return domainerr.New(domainerr.ReasonParameterInvalid, err, map[string]interface{}{"parameter": "user ID", "id": id})
+	}
+	return nil
+}
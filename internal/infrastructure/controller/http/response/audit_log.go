@@ -0,0 +1,27 @@
+package response
+
+import (
+	"time"
+)
+
+type AuditChangeResponse struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type AuditLogResponse struct {
+	ID string `json:"id"`
+	// ActorID is empty when the change was made by the system, or an anonymous caller.
+	ActorID    string                         `json:"actorId,omitempty"`
+	Action     string                         `json:"action"`
+	TargetType string                         `json:"targetType"`
+	TargetID   string                         `json:"targetId"`
+	Diff       map[string]AuditChangeResponse `json:"diff"`
+	RequestID  string                         `json:"requestId,omitempty"`
+	Source     string                         `json:"source"`
+	CreatedAt  time.Time                      `json:"createdAt"`
+}
+
+type SearchAuditLogsResponse struct {
+	AuditLogs []AuditLogResponse `json:"auditLogs"`
+}
@@ -0,0 +1,39 @@
+package response
+
+import (
+	"time"
+)
+
+type ConversationResponse struct {
+	ID         string     `json:"id"`
+	MatchingID string     `json:"matchingId"`
+	User1ID    string     `json:"user1Id"`
+	User2ID    string     `json:"user2Id"`
+	Status     string     `json:"status"`
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type ChatMessageResponse struct {
+	ID             string     `json:"id"`
+	ConversationID string     `json:"conversationId"`
+	SenderID       string     `json:"senderId"`
+	Body           string     `json:"body"`
+	ReadAt         *time.Time `json:"readAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+type SendChatMessageResponse ChatMessageResponse
+
+type ListChatMessagesResponse struct {
+	// Conversation is absent until the first message is sent.
+	Conversation *ConversationResponse `json:"conversation,omitempty"`
+	Messages     []ChatMessageResponse `json:"messages"`
+	// NextCursor pages the older messages, and is absent on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type MarkChatMessagesReadResponse struct {
+	ReadCount int       `json:"readCount"`
+	ReadAt    time.Time `json:"readAt"`
+}
@@ -0,0 +1,138 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/i18n"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/errorreport"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/logger"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type ErrorResponse struct {
+	Message string                 `json:"message"`
+	Code    string                 `json:"code"`
+	Reason  string                 `json:"reason,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	// ErrorID identifies an internal error in the logs and the error reports.
+	ErrorID string `json:"errorId,omitempty"`
+}
+
+// ErrorConfig decides how the internal errors, those the client did not cause, are rendered.
+type ErrorConfig struct {
+	// ExposeInternal returns the internal errors verbatim instead of a generic message, for local development.
+	ExposeInternal bool
+	// Reporter also sends the internal errors to a crash aggregation service. It is optional.
+	Reporter errorreport.Reporter
+}
+
+type errorConfigKey struct{}
+
+// WithErrorConfig sets how the internal errors of the request are rendered. Without it they are hidden and not reported.
+func WithErrorConfig(ctx context.Context, cfg ErrorConfig) context.Context {
+	return context.WithValue(ctx, errorConfigKey{}, cfg)
+}
+
+func errorConfigFromContext(ctx context.Context) ErrorConfig {
+	cfg, _ := ctx.Value(errorConfigKey{}).(ErrorConfig)
+	return cfg
+}
+
+// WriteError writes the error with the status of its code, and the message in the language of the Accept-Language header.
+// An error of the domain model that a usecase returned as is gets the status of its reason and the message of its template.
+// Any other error is internal.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	var appErr *domainerr.DomainError
+	if !errors.As(err, &appErr) {
+		reason, ok := domainerr.ReasonOf(err)
+		if !ok {
+			WriteInternalError(w, r, err, debug.Stack())
+			return
+		}
+		appErr = domainerr.New(reason, err, nil)
+	}
+	if appErr.Code == domainerr.Critical {
+		WriteInternalError(w, r, appErr, debug.Stack())
+		return
+	}
+	if appErr.Code == domainerr.ResourceExhausted {
+		setRetryAfter(w, appErr)
+	}
+	lang := i18n.MatchLanguage(r.Header.Get("Accept-Language"))
+	w.Header().Set("Content-Language", lang.String())
+	WriteJSON(w, HTTPStatus(appErr.Code), ErrorResponse{
+		Message: i18n.ErrorMessage(appErr, lang),
+		Code:    string(appErr.Code),
+		Reason:  string(appErr.Reason),
+		Details: appErr.Details,
+	})
+}
+
+// WriteInternalError logs and reports the error with its stack under a new error ID.
+// Unless the internals are exposed, the client only gets a generic message and the ID to quote.
+func WriteInternalError(w http.ResponseWriter, r *http.Request, err error, stack []byte) {
+	ctx := r.Context()
+	cfg := errorConfigFromContext(ctx)
+	errorID := uuid.New().String()
+
+	logger.FromContext(ctx).ErrorContext(ctx, "internal error",
+		"error_id", errorID,
+		"error", err.Error(),
+		"stack", string(stack),
+	)
+	if cfg.Reporter != nil {
+		reportErr := cfg.Reporter.Report(ctx, errorreport.Report{
+			ID:    errorID,
+			Err:   err,
+			Stack: stack,
+			Tags: map[string]string{
+				"request_id": chimiddleware.GetReqID(ctx),
+				"method":     r.Method,
+				"path":       r.URL.Path,
+			},
+		})
+		if reportErr != nil {
+			logger.FromContext(ctx).ErrorContext(ctx, "failed to report error", "error_id", errorID, "error", reportErr.Error())
+		}
+	}
+
+	lang := i18n.MatchLanguage(r.Header.Get("Accept-Language"))
+	internal := domainerr.New(domainerr.ReasonInternal, err, nil)
+	res := ErrorResponse{
+		Message: i18n.ErrorMessage(internal, lang),
+		Code:    string(internal.Code),
+		Reason:  string(internal.Reason),
+		ErrorID: errorID,
+	}
+	if cfg.ExposeInternal {
+		res.Message = err.Error()
+		var appErr *domainerr.DomainError
+		if errors.As(err, &appErr) {
+			res.Details = appErr.Details
+		}
+	}
+	w.Header().Set("Content-Language", lang.String())
+	WriteJSON(w, http.StatusInternalServerError, res)
+}
+
+// setRetryAfter tells the client when to retry if the error details contain the reset time.
+func setRetryAfter(w http.ResponseWriter, appErr *domainerr.DomainError) {
+	resetAt, ok := appErr.Details["resetAt"].(time.Time)
+	if !ok {
+		return
+	}
+	seconds := int(math.Ceil(time.Until(resetAt).Seconds()))
+	if seconds < 0 {
+		seconds = 0
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+}
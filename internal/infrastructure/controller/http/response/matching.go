@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+)
+
+type MatchingResponse struct {
+	ID        string     `json:"id"`
+	MeID      string     `json:"meId"`
+	PartnerID string     `json:"partnerId"`
+	Status    string     `json:"status"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+type ListMatchingsResponse struct {
+	Matchings []MatchingResponse `json:"matchings"`
+}
+
+type MatchingHistoryResponse struct {
+	ID string `json:"id"`
+	// ActorID is omitted when the system performed the action, e.g. expiration.
+	ActorID   string    `json:"actorId,omitempty"`
+	Action    string    `json:"action"`
+	From      string    `json:"from,omitempty"`
+	To        string    `json:"to"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type MatchingTimelineResponse struct {
+	Matching MatchingResponse          `json:"matching"`
+	History  []MatchingHistoryResponse `json:"history"`
+}
@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+)
+
+type NotificationResponse struct {
+	ID        string            `json:"id"`
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Data      map[string]string `json:"data"`
+	ReadAt    *time.Time        `json:"readAt,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+type ListNotificationsResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	UnreadCount   int                    `json:"unreadCount"`
+}
+
+type CountUnreadNotificationsResponse struct {
+	UnreadCount int `json:"unreadCount"`
+}
+
+type MarkNotificationsReadResponse struct {
+	ReadCount int       `json:"readCount"`
+	ReadAt    time.Time `json:"readAt"`
+}
+
+type NotificationPreferenceResponse struct {
+	// Channels tells whether each channel is on, by name.
+	Channels  map[string]bool `json:"channels"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+}
@@ -0,0 +1,17 @@
+package response
+
+import (
+	"time"
+)
+
+type RecommendationResponse struct {
+	User UserResponse `json:"user"`
+	// Score is the weighted score from 0 to 1, and Scores are the unweighted scores by scorer.
+	Score       float64            `json:"score"`
+	Scores      map[string]float64 `json:"scores"`
+	GeneratedAt time.Time          `json:"generatedAt"`
+}
+
+type ListRecommendationsResponse struct {
+	Recommendations []RecommendationResponse `json:"recommendations"`
+}
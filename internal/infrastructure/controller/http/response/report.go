@@ -0,0 +1,34 @@
+package response
+
+import (
+	"time"
+)
+
+type ReportResponse struct {
+	ID             string     `json:"id"`
+	ReporterID     string     `json:"reporterId"`
+	ReportedID     string     `json:"reportedId"`
+	Reason         string     `json:"reason"`
+	Comment        string     `json:"comment"`
+	Status         string     `json:"status"`
+	AssigneeID     string     `json:"assigneeId,omitempty"`
+	Resolution     string     `json:"resolution,omitempty"`
+	ResolutionNote string     `json:"resolutionNote,omitempty"`
+	ResolvedAt     *time.Time `json:"resolvedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+type CreateReportResponse ReportResponse
+
+type ListReportsResponse struct {
+	Reports []ReportResponse `json:"reports"`
+}
+
+type AssignReportResponse ReportResponse
+
+type ResolveReportResponse struct {
+	Report ReportResponse `json:"report"`
+	// SuspendedUser is the reported user suspended by the resolution, if any.
+	SuspendedUser *UserResponse `json:"suspendedUser,omitempty"`
+}
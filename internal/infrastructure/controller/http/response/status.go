@@ -0,0 +1,29 @@
+package response
+
+import (
+	"net/http"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+)
+
+// HTTPStatus returns the HTTP status of the errors of the code.
+func HTTPStatus(code domainerr.ErrorCode) int {
+	switch code {
+	case domainerr.InvalidArgument:
+		return http.StatusBadRequest
+	case domainerr.NotFound:
+		return http.StatusNotFound
+	case domainerr.AlreadyExists:
+		return http.StatusConflict
+	case domainerr.Unauthorized:
+		return http.StatusUnauthorized
+	case domainerr.PermissionDenied:
+		return http.StatusForbidden
+	case domainerr.PreconditionFailed:
+		return http.StatusPreconditionFailed
+	case domainerr.ResourceExhausted:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
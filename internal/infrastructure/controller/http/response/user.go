@@ -5,16 +5,32 @@ import (
 )
 
 type UserResponse struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID          string   `json:"id"`
+	Email       string   `json:"email"`
+	DisplayName string   `json:"displayName"`
+	Birthdate   string   `json:"birthdate,omitempty" example:"2000-01-31"`
+	Gender      string   `json:"gender,omitempty"`
+	Bio         string   `json:"bio"`
+	Locale      string   `json:"locale,omitempty"`
+	Interests   []string `json:"interests,omitempty"`
+	Status      string   `json:"status"`
+	// EmailVerifiedAt is omitted until the user verifies the email.
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt,omitempty"`
+	// PendingEmail is the new email waiting for confirmation, omitted when no change is pending.
+	PendingEmail string    `json:"pendingEmail,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
 }
 
 type CreateUserResponse UserResponse
 
 type GetUserResponse UserResponse
 
+type BatchGetUsersResponse struct {
+	Users      []UserResponse `json:"users"`
+	MissingIDs []string       `json:"missingIds"`
+}
+
 type ListUsersResponse struct {
 	Users     []UserResponse `json:"users"`
 	Total     int            `json:"total"`
@@ -26,5 +42,26 @@ type ListUsersResponse struct {
 type UpdateUserResponse UserResponse
 
 type DeleteUserResponse struct {
-	ID string `json:"id"`
+	ID                  string    `json:"id"`
+	PermanentDeletionAt time.Time `json:"permanentDeletionAt"`
+}
+
+type ReactivateUserResponse UserResponse
+
+type RequestEmailVerificationResponse struct {
+	ID        string    `json:"id"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type VerifyEmailResponse UserResponse
+
+type RequestEmailChangeResponse struct {
+	ID            string    `json:"id"`
+	PendingEmail  string    `json:"pendingEmail"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	UndoExpiresAt time.Time `json:"undoExpiresAt"`
 }
+
+type ConfirmEmailChangeResponse UserResponse
+
+type UndoEmailChangeResponse UserResponse
@@ -0,0 +1,26 @@
+package response
+
+import (
+	"time"
+)
+
+type UserBlockResponse struct {
+	BlockerID string    `json:"blockerId"`
+	BlockedID string    `json:"blockedId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type BlockUserResponse struct {
+	Block UserBlockResponse `json:"block"`
+	// RejectedMatching is the pending matching rejected by the block, if any.
+	RejectedMatching *MatchingResponse `json:"rejectedMatching,omitempty"`
+}
+
+type UnblockUserResponse struct {
+	BlockerID string `json:"blockerId"`
+	BlockedID string `json:"blockedId"`
+}
+
+type ListUserBlocksResponse struct {
+	Blocks []UserBlockResponse `json:"blocks"`
+}
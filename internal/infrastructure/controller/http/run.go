@@ -16,7 +16,10 @@ import (
 
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/dependency"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/handler"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/marshaller"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/controller/http/middleware"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/auth"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
 )
 
 func Run() error {
@@ -24,7 +27,18 @@ func Run() error {
 	defer cancel()
 
 	// Inject dependencies
-	dependency, err := dependency.Inject(ctx)
+	dependency, err := dependency.Inject(ctx, clock.New())
+	if err != nil {
+		return err
+	}
+
+	authConfig := middleware.NewAuthConfig(dependency.Environment)
+	tenantConfig, err := middleware.NewTenantConfig(dependency.Environment)
+	if err != nil {
+		return err
+	}
+
+	corsConfig, err := middleware.NewCORSConfig(dependency.Environment)
 	if err != nil {
 		return err
 	}
@@ -32,15 +46,45 @@ func Run() error {
 	r := chi.NewRouter()
 
 	// Set up middleware
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recover)
 	r.Use(chimiddleware.RequestID)
+	r.Use(middleware.Logger)
+	r.Use(middleware.Recover(middleware.NewErrorConfig(dependency.Environment, dependency.ErrorReporter)))
+	r.Use(middleware.SecurityHeaders(middleware.NewSecurityHeadersConfig(dependency.Environment)))
+	r.Use(middleware.CORS(corsConfig))
 	r.Use(chimiddleware.RealIP)
 	r.Use(chimiddleware.Timeout(60 * time.Second))
 
+	// Register relationships for sparse fieldsets and includes
+	shaper := marshaller.NewShaper()
+	marshaller.RegisterUserRelationships(shaper, dependency.MatchingInteractor)
+	marshaller.RegisterMatchingRelationships(shaper, dependency.UserInteractor)
+
 	// Initialize handlers
 	userHandler := &handler.UserHandler{
 		UserInteractor: dependency.UserInteractor,
+		Shaper:         shaper,
+	}
+	matchingHandler := &handler.MatchingHandler{
+		MatchingInteractor: dependency.MatchingInteractor,
+		Shaper:             shaper,
+	}
+	userBlockHandler := &handler.UserBlockHandler{
+		UserBlockInteractor: dependency.UserBlockInteractor,
+	}
+	recommendationHandler := &handler.RecommendationHandler{
+		RecommendationInteractor: dependency.RecommendationInteractor,
+	}
+	reportHandler := &handler.ReportHandler{
+		ReportInteractor: dependency.ReportInteractor,
+	}
+	conversationHandler := &handler.ConversationHandler{
+		ConversationInteractor: dependency.ConversationInteractor,
+	}
+	notificationHandler := &handler.NotificationHandler{
+		NotificationInteractor: dependency.NotificationInteractor,
+	}
+	auditLogHandler := &handler.AuditLogHandler{
+		AuditLogInteractor: dependency.AuditLogInteractor,
 	}
 	healthHandler := &handler.HealthHandler{
 		HealthInteractor: dependency.HealthInteractor,
@@ -54,12 +98,51 @@ func Run() error {
 
 	// Set up API routes
 	r.Route("/api/v1", func(r chi.Router) {
-		r.Route("/users", func(r chi.Router) {
-			r.Get("/", userHandler.List)
-			r.Post("/", userHandler.Create)
-			r.Get("/{id}", userHandler.Get)
-			r.Put("/{id}", userHandler.Update)
-			r.Delete("/{id}", userHandler.Delete)
+		// Every route but the health checks runs in the tenant of the request
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.Authenticate(authConfig))
+			r.Use(middleware.Tenant(tenantConfig))
+			r.Use(middleware.Audit)
+			r.Post("/users:batchGet", userHandler.BatchGet)
+			r.Route("/users", func(r chi.Router) {
+				r.Get("/", userHandler.List)
+				r.Post("/", userHandler.Create)
+				r.Post("/verify", userHandler.VerifyEmail)
+				r.Post("/email/confirm", userHandler.ConfirmEmailChange)
+				r.Post("/email/undo", userHandler.UndoEmailChange)
+				r.Get("/{id}", userHandler.Get)
+				r.Put("/{id}", userHandler.Update)
+				r.Delete("/{id}", userHandler.Delete)
+				r.Post("/{id}/reactivate", userHandler.Reactivate)
+				r.Post("/{id}/verification", userHandler.RequestEmailVerification)
+				r.Post("/{id}/email", userHandler.RequestEmailChange)
+				r.Get("/{id}/matchings", matchingHandler.List)
+				r.Get("/{id}/matchings/{partnerId}/timeline", matchingHandler.Timeline)
+				// The messages and the notifications of a user are only reached by the user
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.RequireUser("id"))
+					r.Get("/{id}/matchings/{partnerId}/messages", conversationHandler.List)
+					r.Post("/{id}/matchings/{partnerId}/messages", conversationHandler.Send)
+					r.Post("/{id}/matchings/{partnerId}/messages/read", conversationHandler.MarkRead)
+					r.Get("/{id}/notifications", notificationHandler.List)
+					r.Get("/{id}/notifications/unread_count", notificationHandler.CountUnread)
+					r.Post("/{id}/notifications/read", notificationHandler.MarkRead)
+					r.Get("/{id}/notification_preference", notificationHandler.GetPreference)
+					r.Put("/{id}/notification_preference", notificationHandler.UpdatePreference)
+				})
+				r.Get("/{id}/recommendations", recommendationHandler.List)
+				r.Get("/{id}/blocks", userBlockHandler.List)
+				r.Post("/{id}/blocks", userBlockHandler.Block)
+				r.Delete("/{id}/blocks/{blockedId}", userBlockHandler.Unblock)
+				r.Post("/{id}/reports", reportHandler.Create)
+			})
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(middleware.RequireRole(auth.RoleAdmin))
+				r.Get("/reports", reportHandler.List)
+				r.Post("/reports/{reportId}/assign", reportHandler.Assign)
+				r.Post("/reports/{reportId}/resolve", reportHandler.Resolve)
+				r.Get("/audit_logs", auditLogHandler.Search)
+			})
 		})
 		r.Route("/health", func(r chi.Router) {
 			r.Get("/check", healthHandler.Check)
@@ -0,0 +1,101 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/caarlos0/env/v10"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/environment"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/migration"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/schema"
+)
+
+// migrateEnvironment is the part of the environment the migrations need, so that they run before the other services are configured.
+type migrateEnvironment struct {
+	Environment string `env:"ENV,required"`
+	environment.DBEnvironment
+}
+
+func Up() error {
+	return run(func(ctx context.Context, migrator *migration.Migrator) error {
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			log.Printf("applied %d_%s", m.Version, m.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			log.Printf("no migration to apply")
+		}
+		return err
+	})
+}
+
+func Down(steps int) error {
+	return run(func(ctx context.Context, migrator *migration.Migrator) error {
+		reverted, err := migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			log.Printf("reverted %d_%s", m.Version, m.Name)
+		}
+		return err
+	})
+}
+
+func Force(version uint) error {
+	return run(func(ctx context.Context, migrator *migration.Migrator) error {
+		if err := migrator.Force(ctx, version); err != nil {
+			return err
+		}
+		log.Printf("forced version %d", version)
+		return nil
+	})
+}
+
+func Status(w io.Writer) error {
+	return run(func(ctx context.Context, migrator *migration.Migrator) error {
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "version: %d\n", status.Version)
+		fmt.Fprintf(w, "dirty: %t\n", status.Dirty)
+		fmt.Fprintf(w, "latest: %d\n", status.Latest)
+		for _, m := range status.Pending {
+			fmt.Fprintf(w, "pending: %d_%s\n", m.Version, m.Name)
+		}
+		return nil
+	})
+}
+
+func run(f func(ctx context.Context, migrator *migration.Migrator) error) error {
+	// The lock waits up to 10 minutes for the migrations of another replica
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	e := &migrateEnvironment{}
+	if err := env.Parse(e); err != nil {
+		return fmt.Errorf("failed to parse environment variables: %w", err)
+	}
+	db, err := mysql.InitDB(ctx, mysql.DBConfig{
+		Environment:     e.Environment,
+		Host:            e.DBHost,
+		Port:            e.DBPort,
+		User:            e.DBUser,
+		Password:        e.DBPassword,
+		DBName:          e.DBDatabase,
+		MultiStatements: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := migration.NewMigrator(db, schema.FS)
+	if err != nil {
+		return err
+	}
+	return f(ctx, migrator)
+}
@@ -0,0 +1,46 @@
+package relay_outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/dependency"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+)
+
+const (
+	// pollInterval is how long the relay waits when the outbox has nothing to send.
+	pollInterval = time.Second
+	// retryInterval is how long the relay waits after failing to read the outbox.
+	retryInterval = 5 * time.Second
+)
+
+func Run(ctx context.Context, dependency *dependency.Dependency, args []string) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+		output, err := dependency.OutboxInteractor.Relay(ctx, &port.RelayOutboxInput{
+			BatchSize: 100,
+		})
+		switch {
+		case err != nil:
+			log.Printf("Error relaying outbox: %v", err)
+			ticker.Reset(retryInterval)
+		case output.SentCount+output.FailedCount > 0:
+			// More messages may be waiting, so the next batch is relayed right away
+			continue
+		default:
+			ticker.Reset(pollInterval)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"syscall"
 
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/dependency"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/audit"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
 )
 
 type MessageHandler func(ctx context.Context, dependency *dependency.Dependency, args []string) error
@@ -15,10 +18,11 @@ func Run(f MessageHandler, args []string) error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	dependency, err := dependency.Inject(ctx)
+	dependency, err := dependency.Inject(ctx, clock.New())
 	if err != nil {
 		return err
 	}
 
+	ctx = audit.WithMetadata(ctx, audit.Metadata{Source: model.AuditSourceSubscriber})
 	return f(ctx, dependency, args)
 }
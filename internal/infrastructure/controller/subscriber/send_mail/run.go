@@ -0,0 +1,26 @@
+package send_mail
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/dependency"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+)
+
+func Run(ctx context.Context, dependency *dependency.Dependency, args []string) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+			if _, err := dependency.MailInteractor.DequeueAndSend(ctx, &port.DequeueAndSendMailInput{
+				BatchSize: 10,
+			}); err != nil {
+				log.Printf("Error processing message: %v", err)
+				time.Sleep(5 * time.Second)
+			}
+		}
+	}
+}
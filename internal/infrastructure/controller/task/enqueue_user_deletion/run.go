@@ -10,8 +10,18 @@ import (
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
 )
 
+// Run enqueues the given user for deletion, or every user whose withdrawal grace period has expired if no ID is given.
 func Run(ctx context.Context, dependency *dependency.Dependency, args []string) error {
 	userInteractor := dependency.UserInteractor
+	if len(args) == 0 {
+		output, err := userInteractor.EnqueueExpiredUserDeletions(ctx, &port.EnqueueExpiredUserDeletionsInput{})
+		if err != nil {
+			return err
+		}
+		log.Printf("Enqueued %d expired user deletions\n", len(output.IDs))
+		return nil
+	}
+
 	user, err := userInteractor.EnqueueUserDeletion(ctx, &port.EnqueueUserDeletionInput{
 		ID: uuid.MustParse(args[0]),
 	})
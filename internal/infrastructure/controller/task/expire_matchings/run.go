@@ -0,0 +1,30 @@
+package expire_matchings
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/dependency"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+)
+
+// Run expires the overdue pending matchings. An optional first argument overrides the batch size.
+func Run(ctx context.Context, dependency *dependency.Dependency, args []string) error {
+	input := &port.ExpireOverdueMatchingsInput{}
+	if len(args) > 0 {
+		batchSize, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid batch size %q: %w", args[0], err)
+		}
+		input.BatchSize = batchSize
+	}
+
+	output, err := dependency.MatchingInteractor.ExpireOverdue(ctx, input)
+	if err != nil {
+		return err
+	}
+	log.Printf("Expired %d matchings\n", output.ExpiredCount)
+	return nil
+}
@@ -2,18 +2,73 @@ package task
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/dependency"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/audit"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
 )
 
-func Run(f func(ctx context.Context, dependency *dependency.Dependency, args []string) error, args []string) error {
+// Options are the flags shared by every task.
+type Options struct {
+	// TenantIDs are the tenants to run the task in. The task runs in every tenant of TENANT_IDS when it is empty.
+	TenantIDs []string
+	// FakeNow is the time in RFC 3339 the task runs at instead of the current time, to rehearse the time-based tasks.
+	// The clock is frozen at it, so the whole run sees the same time.
+	FakeNow string
+}
+
+func Run(f func(ctx context.Context, dependency *dependency.Dependency, args []string) error, args []string, opts Options) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 3000*time.Second)
 	defer cancel()
 
-	dependency, err := dependency.Inject(ctx)
+	clk, err := newClock(opts.FakeNow)
 	if err != nil {
 		return err
 	}
-	return f(ctx, dependency, args)
+	dependency, err := dependency.Inject(ctx, clk)
+	if err != nil {
+		return err
+	}
+	ctx = audit.WithMetadata(ctx, audit.Metadata{Source: model.AuditSourceTask})
+
+	// The repositories are scoped to a tenant, so the task runs once in each tenant
+	tenantIDs := opts.TenantIDs
+	if len(tenantIDs) == 0 {
+		tenantIDs = dependency.Environment.TenantIDs
+	}
+	var errs []error
+	for _, s := range tenantIDs {
+		tenantID, err := tenant.Parse(strings.TrimSpace(s))
+		if err != nil {
+			return fmt.Errorf("tenant %q: %w", s, err)
+		}
+		if !slices.Contains(dependency.Environment.TenantIDs, tenantID.String()) {
+			return fmt.Errorf("tenant %q is not in TENANT_IDS", s)
+		}
+		if err := f(tenant.WithID(ctx, tenantID), dependency, args); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenantID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newClock returns the clock frozen at fakeNow, or the real clock when it is empty.
+func newClock(fakeNow string) (clock.Clock, error) {
+	if fakeNow == "" {
+		return clock.New(), nil
+	}
+	now, err := time.Parse(time.RFC3339, fakeNow)
+	if err != nil {
+		return nil, fmt.Errorf("fake now %q: %w", fakeNow, err)
+	}
+	log.Printf("running at the fake time %s", now.Format(time.RFC3339))
+	return clock.NewFake(now), nil
 }
@@ -1,19 +1,110 @@
 package environment
 
+import "time"
+
 type Environment struct {
 	Port        string `env:"PORT,required"`
 	Environment string `env:"ENV,required"`
+	HTTPEnvironment
+	AuthEnvironment
+	TenantEnvironment
+	UserEnvironment
+	RecommendationEnvironment
+	MatchingEnvironment
+	MailerEnvironment
+	NotificationEnvironment
+	ErrorReporterEnvironment
 	DBEnvironment
 	RedisEnvironment
 	SQSEnvironment
 }
 
+// HTTPEnvironment holds optional overrides for the HTTP middleware.
+// Empty or unset values fall back to the per-environment defaults of the middleware.
+type HTTPEnvironment struct {
+	CORSAllowedOrigins   []string `env:"CORS_ALLOWED_ORIGINS" envSeparator:","`
+	CORSAllowedMethods   []string `env:"CORS_ALLOWED_METHODS" envSeparator:","`
+	CORSAllowedHeaders   []string `env:"CORS_ALLOWED_HEADERS" envSeparator:","`
+	CORSExposedHeaders   []string `env:"CORS_EXPOSED_HEADERS" envSeparator:","`
+	CORSAllowCredentials *bool    `env:"CORS_ALLOW_CREDENTIALS"`
+	CORSMaxAge           int      `env:"CORS_MAX_AGE"`
+	HSTSMaxAge           int      `env:"HSTS_MAX_AGE"`
+}
+
+// AuthEnvironment holds the secret of the JWTs that authenticate the requests.
+// When it is empty, no request is authenticated, so the admin routes reject every request.
+type AuthEnvironment struct {
+	AuthJWTSecret string `env:"AUTH_JWT_SECRET"`
+}
+
+// TenantEnvironment lists the tenants served and how a request names its tenant.
+// The tenant comes from the tenant_id claim of the JWT when AUTH_JWT_SECRET is set, and a request without a token is rejected.
+// The X-Tenant-Id header names the tenant instead only in local and test, where AUTH_JWT_SECRET can be empty.
+// A request naming no tenant is served in TENANT_DEFAULT, or rejected when it is empty.
+type TenantEnvironment struct {
+	TenantIDs     []string `env:"TENANT_IDS" envSeparator:"," envDefault:"default"`
+	TenantDefault string   `env:"TENANT_DEFAULT"`
+}
+
+type UserEnvironment struct {
+	// UserWithdrawalGracePeriod is how long a withdrawn user can reactivate before permanent deletion.
+	UserWithdrawalGracePeriod time.Duration `env:"USER_WITHDRAWAL_GRACE_PERIOD" envDefault:"720h"`
+	// UserEmailTokenSecret signs the tokens mailed to verify and change the email. Rotating it invalidates the tokens not used yet.
+	UserEmailTokenSecret     string        `env:"USER_EMAIL_TOKEN_SECRET,required"`
+	UserEmailVerificationTTL time.Duration `env:"USER_EMAIL_VERIFICATION_TTL" envDefault:"24h"`
+	// UserEmailVerificationLimit is how many verification mails a user can request within UserEmailVerificationLimitWindow.
+	UserEmailVerificationLimit       int           `env:"USER_EMAIL_VERIFICATION_LIMIT" envDefault:"5"`
+	UserEmailVerificationLimitWindow time.Duration `env:"USER_EMAIL_VERIFICATION_LIMIT_WINDOW" envDefault:"1h"`
+	UserEmailChangeTTL               time.Duration `env:"USER_EMAIL_CHANGE_TTL" envDefault:"24h"`
+	// UserEmailChangeUndoPeriod is how long the old email can undo an email change.
+	UserEmailChangeUndoPeriod time.Duration `env:"USER_EMAIL_CHANGE_UNDO_PERIOD" envDefault:"168h"`
+}
+
+type RecommendationEnvironment struct {
+	// RecommendationCacheTTL is how long the precomputed recommendations are served before being regenerated.
+	RecommendationCacheTTL time.Duration `env:"RECOMMENDATION_CACHE_TTL" envDefault:"1h"`
+}
+
+type MatchingEnvironment struct {
+	// MatchingDailyLimitFree and MatchingDailyLimitPremium are how many matching requests a user of each plan can send per UTC day.
+	MatchingDailyLimitFree    int `env:"MATCHING_DAILY_LIMIT_FREE" envDefault:"20"`
+	MatchingDailyLimitPremium int `env:"MATCHING_DAILY_LIMIT_PREMIUM" envDefault:"100"`
+}
+
+// MailerEnvironment selects how the mails are delivered.
+// The "file" driver writes them to MAILER_FILE_PATH, or to the console when it is empty.
+// The driver defaults to "file" in local and test, and has to be set in the other environments,
+// since the mails hold the tokens to verify and change the email.
+type MailerEnvironment struct {
+	MailerDriver   string `env:"MAILER_DRIVER"`
+	MailerFrom     string `env:"MAILER_FROM" envDefault:"no-reply@example.com"`
+	MailerFilePath string `env:"MAILER_FILE_PATH"`
+	SMTPHost       string `env:"SMTP_HOST" envDefault:"localhost"`
+	SMTPPort       string `env:"SMTP_PORT" envDefault:"1025"`
+	SMTPUsername   string `env:"SMTP_USERNAME"`
+	SMTPPassword   string `env:"SMTP_PASSWORD"`
+}
+
+// NotificationEnvironment configures the delivery channels of the notifications.
+// The fake push channel writes to NOTIFICATION_PUSH_FILE_PATH, or to the console when it is empty.
+type NotificationEnvironment struct {
+	NotificationPushFilePath string `env:"NOTIFICATION_PUSH_FILE_PATH"`
+}
+
+// ErrorReporterEnvironment selects where the internal errors are reported, in addition to the logs.
+// The "stdout" driver writes them to the console, and an empty driver reports nothing.
+type ErrorReporterEnvironment struct {
+	ErrorReporterDriver string `env:"ERROR_REPORTER_DRIVER"`
+}
+
 type DBEnvironment struct {
 	DBHost     string `env:"DB_HOST,required"`
 	DBPort     string `env:"DB_PORT,required"`
 	DBUser     string `env:"DB_USER,required"`
 	DBPassword string `env:"DB_PASSWORD,required"`
 	DBDatabase string `env:"DB_DATABASE,required"`
+	// DBSchemaCheck refuses to start when migrations are not applied yet, instead of failing on the first query to a missing column.
+	DBSchemaCheck bool `env:"DB_SCHEMA_CHECK"`
 }
 
 type RedisEnvironment struct {
@@ -26,4 +117,10 @@ type SQSEnvironment struct {
 	AWSRegion          string `env:"AWS_REGION,required"`
 	AWSEndpoint        string `env:"AWS_ENDPOINT,required"`
 	SQSQueueNameSample string `env:"SQS_QUEUE_NAME_SAMPLE,required"`
+	// SQSQueueNameModeration is the queue of the moderation team's channel, notified of reports.
+	SQSQueueNameModeration string `env:"SQS_QUEUE_NAME_MODERATION,required"`
+	// SQSQueueNameMail is the queue of the mails waiting to be sent by the mail subscriber.
+	SQSQueueNameMail string `env:"SQS_QUEUE_NAME_MAIL,required"`
+	// SQSQueueNameNotification is the queue of the notifications waiting to be fanned out by the notification subscriber.
+	SQSQueueNameNotification string `env:"SQS_QUEUE_NAME_NOTIFICATION,required"`
 }
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+)
+
+// MailerFileRepository writes the mails to a file instead of sending them, for local development.
+type MailerFileRepository struct {
+	mu    sync.Mutex
+	w     io.Writer
+	clock clock.Clock
+}
+
+// NewMailerFileRepository appends the mails to the file at path, or writes them to the console when path is empty.
+func NewMailerFileRepository(path string, clock clock.Clock) (*MailerFileRepository, error) {
+	if path == "" {
+		return &MailerFileRepository{w: os.Stdout, clock: clock}, nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &MailerFileRepository{w: f, clock: clock}, nil
+}
+
+func (r *MailerFileRepository) Send(ctx context.Context, mail *model.Mail) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err := fmt.Fprintf(r.w, "----- %s -----\nTo: %s\nSubject: %s\n\n%s\n",
+		r.clock.Now().Format(time.RFC3339), mail.To, mail.Subject, mail.Body)
+	return err
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+)
+
+// NotificationPushFileRepository is a fake push channel that writes the notifications to a file, one JSON per line, for local testing.
+type NotificationPushFileRepository struct {
+	mu    sync.Mutex
+	w     io.Writer
+	clock clock.Clock
+}
+
+// NewNotificationPushFileRepository appends the notifications to the file at path, or writes them to the console when path is empty.
+func NewNotificationPushFileRepository(path string, clock clock.Clock) (*NotificationPushFileRepository, error) {
+	if path == "" {
+		return &NotificationPushFileRepository{w: os.Stdout, clock: clock}, nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationPushFileRepository{w: f, clock: clock}, nil
+}
+
+// notificationPush is a line of the file, shaped like the payload of a push service.
+type notificationPush struct {
+	ID     string            `json:"id"`
+	UserID string            `json:"userId"`
+	Type   string            `json:"type"`
+	Title  string            `json:"title"`
+	Body   string            `json:"body"`
+	Data   map[string]string `json:"data"`
+	SentAt time.Time         `json:"sentAt"`
+}
+
+func (r *NotificationPushFileRepository) Channel() model.NotificationChannel {
+	return model.NotificationChannelPush
+}
+
+func (r *NotificationPushFileRepository) Deliver(ctx context.Context, user *model.User, notification *model.Notification) error {
+	line, err := json.Marshal(notificationPush{
+		ID:     notification.ID.String(),
+		UserID: user.ID.String(),
+		Type:   string(notification.Type),
+		Title:  notification.Title,
+		Body:   notification.Body,
+		Data:   notification.Data,
+		SentAt: r.clock.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err = r.w.Write(append(line, '\n'))
+	return err
+}
@@ -17,6 +17,8 @@ type DBConfig struct {
 	User        string
 	Password    string
 	DBName      string
+	// MultiStatements lets a query hold several statements, which the migrations need.
+	MultiStatements bool
 }
 
 // InitDB はMySQLデータベース接続を初期化します
@@ -32,6 +34,9 @@ func InitDB(ctx context.Context, config DBConfig) (*sql.DB, error) {
 			config.Port,
 			config.DBName,
 		)
+		if config.MultiStatements {
+			dsn += "&multiStatements=true"
+		}
 	default:
 		return nil, fmt.Errorf("invalid environment: %s", config.Environment)
 	}
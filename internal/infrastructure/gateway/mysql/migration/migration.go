@@ -0,0 +1,335 @@
+// Package migration applies the schema migrations. It reads the files and keeps the version table of golang-migrate,
+// so a database migrated by one can be migrated by the other.
+package migration
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+var (
+	// ErrDirty means a migration failed halfway. The schema has to be repaired by hand, then forced to the version it is at.
+	ErrDirty = errors.New("schema is dirty")
+	// ErrBehind means migrations are not applied yet.
+	ErrBehind = errors.New("schema is behind")
+	// ErrLocked means another process kept the migration lock for the whole lock timeout.
+	ErrLocked = errors.New("migration lock is held by another process")
+)
+
+const (
+	versionTable = "schema_migrations"
+	// errNoSuchTable is the MySQL error number of a missing table.
+	errNoSuchTable = 1146
+	// lockTimeout is how long a migration waits for the one running in another replica.
+	lockTimeout = 10 * time.Minute
+)
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is a version of the schema and the statements to migrate to and from it.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+	hasUp   bool
+	hasDown bool
+}
+
+// Status is the version the database is at. Version 0 means no migration is applied.
+type Status struct {
+	Version uint
+	Dirty   bool
+	// Latest is the version of the last migration.
+	Latest uint
+	// Pending are the migrations not applied yet.
+	Pending []Migration
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator reads the migrations of fsys. The statements of a migration run at once,
+// so db has to be opened with multiStatements.
+func NewMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := parse(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// parse reads the migrations of fsys, sorted by version.
+func parse(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	byVersion := map[uint]*Migration{}
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("migration %s: invalid version", entry.Name())
+		}
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+		m, ok := byVersion[uint(version)]
+		if !ok {
+			m = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+			m.hasUp = true
+		} else {
+			m.Down = string(body)
+			m.hasDown = true
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if !m.hasUp {
+			return nil, fmt.Errorf("migration %d_%s has no up migration", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	return migrations, nil
+}
+
+// pending returns the migrations after version.
+func pending(migrations []Migration, version uint) []Migration {
+	i, _ := slices.BinarySearchFunc(migrations, version, func(m Migration, v uint) int { return cmp.Compare(m.Version, v) })
+	if i < len(migrations) && migrations[i].Version == version {
+		i++
+	}
+	return migrations[i:]
+}
+
+// applied returns the migrations up to version, the last first, and fails when version is not one of them.
+func applied(migrations []Migration, version uint) ([]Migration, error) {
+	if version == 0 {
+		return nil, nil
+	}
+	i, found := slices.BinarySearchFunc(migrations, version, func(m Migration, v uint) int { return cmp.Compare(m.Version, v) })
+	if !found {
+		return nil, fmt.Errorf("schema is at version %d, which is not among the migrations", version)
+	}
+	reversed := slices.Clone(migrations[:i+1])
+	slices.Reverse(reversed)
+	return reversed, nil
+}
+
+// Latest returns the version of the last migration.
+func (m *Migrator) Latest() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies the pending migrations and returns them.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := m.readUnlessDirty(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range pending(m.migrations, version) {
+			if err := run(ctx, conn, migration.Up, migration.Version); err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the last steps migrations and returns them, the last first.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := m.readUnlessDirty(ctx, conn)
+		if err != nil {
+			return err
+		}
+		reverted, err := applied(m.migrations, version)
+		if err != nil {
+			return err
+		}
+		for i, migration := range reverted[:min(steps, len(reverted))] {
+			if !migration.hasDown {
+				return fmt.Errorf("migration %d_%s has no down migration", migration.Version, migration.Name)
+			}
+			var previous uint
+			if i+1 < len(reverted) {
+				previous = reverted[i+1].Version
+			}
+			if err := run(ctx, conn, migration.Down, previous); err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Force sets the version without running any migration, to clear the dirty flag once the schema is repaired.
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	if version != 0 && !slices.ContainsFunc(m.migrations, func(m Migration) bool { return m.Version == version }) {
+		return fmt.Errorf("version %d is not among the migrations", version)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		return setVersion(ctx, conn, version, false)
+	})
+}
+
+// Status reads the version of the database without taking the lock.
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	version, dirty, err := readVersion(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	return &Status{Version: version, Dirty: dirty, Latest: m.Latest(), Pending: pending(m.migrations, version)}, nil
+}
+
+// Check fails when the schema is dirty or behind the migrations. A schema ahead of them passes,
+// so the replicas of the previous release keep serving while a new one rolls out.
+func (m *Migrator) Check(ctx context.Context) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if status.Dirty {
+		return fmt.Errorf("%w at version %d", ErrDirty, status.Version)
+	}
+	if status.Version < status.Latest {
+		return fmt.Errorf("%w: at version %d of %d", ErrBehind, status.Version, status.Latest)
+	}
+	return nil
+}
+
+func (m *Migrator) readUnlessDirty(ctx context.Context, conn *sql.Conn) (uint, error) {
+	version, dirty, err := readVersion(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w at version %d, repair it and force the version", ErrDirty, version)
+	}
+	return version, nil
+}
+
+// withLock runs f holding an advisory lock on the database, so that the replicas starting together migrate one at a time.
+// The lock belongs to the connection, so f has to run every statement on conn.
+func (m *Migrator) withLock(ctx context.Context, f func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var database string
+	if err := conn.QueryRowContext(ctx, "SELECT DATABASE()").Scan(&database); err != nil {
+		return err
+	}
+	name := "migrate:" + database
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", name, lockTimeout.Seconds()).Scan(&locked); err != nil {
+		return fmt.Errorf("failed to take the migration lock: %w", err)
+	}
+	if locked.Int64 != 1 {
+		return ErrLocked
+	}
+	defer func() {
+		// The lock is released with the connection anyway, so a failure to release it is only reported
+		if _, releaseErr := conn.ExecContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?)", name); releaseErr != nil && err == nil {
+			err = fmt.Errorf("failed to release the migration lock: %w", releaseErr)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+versionTable+" (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)"); err != nil {
+		return fmt.Errorf("failed to create %s: %w", versionTable, err)
+	}
+	return f(conn)
+}
+
+// run executes statements and moves to version, marking the schema dirty until they succeed.
+// MySQL commits the DDL statements one by one, so a failure leaves the schema halfway.
+func run(ctx context.Context, conn *sql.Conn, statements string, version uint) error {
+	if err := setVersion(ctx, conn, version, true); err != nil {
+		return err
+	}
+	if strings.TrimSpace(statements) != "" {
+		if _, err := conn.ExecContext(ctx, statements); err != nil {
+			return err
+		}
+	}
+	return setVersion(ctx, conn, version, false)
+}
+
+// readVersion returns 0 when no migration is applied, including when the version table does not exist yet.
+func readVersion(ctx context.Context, conn *sql.Conn) (uint, bool, error) {
+	var version uint
+	var dirty bool
+	err := conn.QueryRowContext(ctx, "SELECT version, dirty FROM "+versionTable+" LIMIT 1").Scan(&version, &dirty)
+	var mysqlErr *mysql.MySQLError
+	switch {
+	case errors.Is(err, sql.ErrNoRows), errors.As(err, &mysqlErr) && mysqlErr.Number == errNoSuchTable:
+		return 0, false, nil
+	case err != nil:
+		return 0, false, fmt.Errorf("failed to read the schema version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+func setVersion(ctx context.Context, conn *sql.Conn, version uint, dirty bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM "+versionTable); err != nil {
+		return fmt.Errorf("failed to set the schema version: %w", err)
+	}
+	// Like golang-migrate, no row is left once every migration is reverted
+	if version != 0 || dirty {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO "+versionTable+" (version, dirty) VALUES (?, ?)", version, dirty); err != nil {
+			return fmt.Errorf("failed to set the schema version: %w", err)
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,123 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/schema"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []uint
+		wantErr bool
+	}{
+		{
+			name: "OK: sorted by version",
+			fsys: fstest.MapFS{
+				"000010_add_index.up.sql":       {Data: []byte("CREATE INDEX")},
+				"000010_add_index.down.sql":     {Data: []byte("DROP INDEX")},
+				"000002_create_user.up.sql":     {Data: []byte("CREATE TABLE")},
+				"000002_create_user.down.sql":   {Data: []byte("DROP TABLE")},
+				"000003_without_down.up.sql":    {Data: []byte("ALTER TABLE")},
+				"schema.go":                     {Data: []byte("package schema")},
+				"000004_not_a_migration.sql":    {Data: []byte("SELECT 1")},
+				"000005_empty_up_file.up.sql":   {Data: []byte("")},
+				"000005_empty_up_file.down.sql": {Data: []byte("")},
+			},
+			want: []uint{2, 3, 5, 10},
+		},
+		{
+			name: "NG: down without up",
+			fsys: fstest.MapFS{
+				"000001_create_user.down.sql": {Data: []byte("DROP TABLE")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "NG: same version named twice",
+			fsys: fstest.MapFS{
+				"000001_create_user.up.sql":  {Data: []byte("CREATE TABLE")},
+				"000001_create_users.up.sql": {Data: []byte("CREATE TABLE")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "NG: version 0",
+			fsys: fstest.MapFS{
+				"000000_create_user.up.sql": {Data: []byte("CREATE TABLE")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := parse(tt.fsys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, versions(migrations)); diff != "" {
+				t.Errorf("parse() versions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParse_Schema(t *testing.T) {
+	migrations, err := parse(schema.FS)
+	if err != nil {
+		t.Fatalf("parse() error = %v", err)
+	}
+	for i, m := range migrations {
+		if m.Version != uint(i+1) {
+			t.Errorf("migration %d_%s, want version %d", m.Version, m.Name, i+1)
+		}
+		if !m.hasDown {
+			t.Errorf("migration %d_%s has no down migration", m.Version, m.Name)
+		}
+	}
+}
+
+func TestPendingAndApplied(t *testing.T) {
+	migrations := []Migration{{Version: 1}, {Version: 2}, {Version: 5}}
+
+	tests := []struct {
+		name           string
+		version        uint
+		wantPending    []uint
+		wantApplied    []uint
+		wantAppliedErr bool
+	}{
+		{name: "Nothing applied", version: 0, wantPending: []uint{1, 2, 5}},
+		{name: "Partly applied", version: 2, wantPending: []uint{5}, wantApplied: []uint{2, 1}},
+		{name: "Up to date", version: 5, wantApplied: []uint{5, 2, 1}},
+		{name: "Unknown version", version: 3, wantPending: []uint{5}, wantAppliedErr: true},
+		{name: "Ahead", version: 6, wantAppliedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.wantPending, versions(pending(migrations, tt.version))); diff != "" {
+				t.Errorf("pending() mismatch (-want +got):\n%s", diff)
+			}
+			got, err := applied(migrations, tt.version)
+			if (err != nil) != tt.wantAppliedErr {
+				t.Fatalf("applied() error = %v, wantErr %v", err, tt.wantAppliedErr)
+			}
+			if diff := cmp.Diff(tt.wantApplied, versions(got)); diff != "" {
+				t.Errorf("applied() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func versions(migrations []Migration) []uint {
+	var versions []uint
+	for _, m := range migrations {
+		versions = append(versions, m.Version)
+	}
+	return versions
+}
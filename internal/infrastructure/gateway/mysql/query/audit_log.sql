@@ -0,0 +1,28 @@
+-- Every query is scoped to the tenant, so that the admins of a tenant only see the logs of their tenant
+
+-- name: CreateAuditLog :exec
+INSERT INTO `audit_log` (
+    id,
+    tenant_id,
+    actor_id,
+    action,
+    target_type,
+    target_id,
+    diff,
+    request_id,
+    source,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: SearchAuditLogs :many
+SELECT * FROM `audit_log`
+WHERE tenant_id = sqlc.arg('tenant_id')
+    AND (sqlc.narg('actor_id') IS NULL OR actor_id = sqlc.narg('actor_id'))
+    AND (sqlc.narg('target_type') IS NULL OR target_type = sqlc.narg('target_type'))
+    AND (sqlc.narg('target_id') IS NULL OR target_id = sqlc.narg('target_id'))
+    AND (sqlc.narg('from') IS NULL OR created_at >= sqlc.narg('from'))
+    AND (sqlc.narg('to') IS NULL OR created_at < sqlc.narg('to'))
+ORDER BY created_at DESC, id DESC
+LIMIT ? OFFSET ?;
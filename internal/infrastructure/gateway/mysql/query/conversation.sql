@@ -0,0 +1,71 @@
+-- Every query is scoped to the tenant, so that a conversation or a message of another tenant is never found
+
+-- name: CreateConversation :exec
+INSERT INTO `conversation` (
+    id,
+    tenant_id,
+    matching_id,
+    user1_id,
+    user2_id,
+    status,
+    archived_at,
+    created_at,
+    updated_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: UpdateConversation :exec
+UPDATE `conversation`
+SET
+    status = ?,
+    archived_at = ?,
+    updated_at = ?
+WHERE tenant_id = ? AND id = ?;
+
+-- name: ExistsConversation :one
+SELECT EXISTS(
+    SELECT 1 FROM `conversation` WHERE tenant_id = ? AND id = ?
+);
+
+-- name: GetConversationByMatchingID :one
+SELECT * FROM `conversation`
+WHERE tenant_id = ? AND matching_id = ? LIMIT 1;
+
+-- name: CreateChatMessage :exec
+INSERT INTO `chat_message` (
+    id,
+    tenant_id,
+    conversation_id,
+    sender_id,
+    body,
+    read_at,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetChatMessage :one
+SELECT * FROM `chat_message`
+WHERE tenant_id = ? AND id = ? LIMIT 1;
+
+-- name: ListChatMessages :many
+SELECT * FROM `chat_message`
+WHERE tenant_id = sqlc.arg('tenant_id')
+    AND conversation_id = sqlc.arg('conversation_id')
+    AND (
+        sqlc.narg('cursor_created_at') IS NULL
+        OR created_at < sqlc.narg('cursor_created_at')
+        OR (created_at = sqlc.narg('cursor_created_at') AND id < sqlc.narg('cursor_id'))
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT ?;
+
+-- name: MarkChatMessagesRead :execrows
+UPDATE `chat_message`
+SET read_at = ?
+WHERE tenant_id = ?
+    AND conversation_id = ?
+    AND sender_id = ?
+    AND read_at IS NULL
+    AND created_at <= ?;
@@ -0,0 +1,34 @@
+-- Every query is scoped to the tenant, so that a change of another tenant is never found
+
+-- name: CreateEmailChange :exec
+INSERT INTO `email_change` (
+    id,
+    tenant_id,
+    user_id,
+    old_email,
+    new_email,
+    expires_at,
+    undo_expires_at,
+    confirmed_at,
+    reverted_at,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: UpdateEmailChange :exec
+UPDATE `email_change`
+SET
+    confirmed_at = ?,
+    reverted_at = ?
+WHERE tenant_id = ? AND id = ?;
+
+-- name: ExistsEmailChange :one
+SELECT EXISTS(
+    SELECT 1 FROM `email_change` WHERE tenant_id = ? AND id = ?
+);
+
+-- name: GetEmailChangeForUpdate :one
+SELECT * FROM `email_change`
+WHERE tenant_id = ? AND id = ? LIMIT 1
+FOR UPDATE;
@@ -0,0 +1,36 @@
+-- Every query is scoped to the tenant, so that a verification of another tenant is never found
+
+-- name: CreateEmailVerification :exec
+INSERT INTO `email_verification` (
+    id,
+    tenant_id,
+    user_id,
+    email,
+    expires_at,
+    used_at,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: UpdateEmailVerification :exec
+UPDATE `email_verification`
+SET
+    used_at = ?
+WHERE tenant_id = ? AND id = ?;
+
+-- name: ExistsEmailVerification :one
+SELECT EXISTS(
+    SELECT 1 FROM `email_verification` WHERE tenant_id = ? AND id = ?
+);
+
+-- name: GetEmailVerificationForUpdate :one
+SELECT * FROM `email_verification`
+WHERE tenant_id = ? AND id = ? LIMIT 1
+FOR UPDATE;
+
+-- name: CountEmailVerificationsByUserSince :one
+-- The locking read also locks the gap of the user in the index, so that concurrent requests of the user are counted one at a time
+SELECT COUNT(*) FROM `email_verification`
+WHERE tenant_id = ? AND user_id = ? AND created_at >= ?
+FOR UPDATE;
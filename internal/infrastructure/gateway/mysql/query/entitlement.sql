@@ -0,0 +1,20 @@
+-- Every query is scoped to the tenant, so that a plan of another tenant is never found
+
+-- name: GetUserPlan :one
+SELECT plan FROM `user_plan`
+WHERE tenant_id = ? AND user_id = ? LIMIT 1;
+
+-- name: UpsertUserPlan :exec
+-- The row of another tenant is left as it is
+INSERT INTO `user_plan` (
+    tenant_id,
+    user_id,
+    plan,
+    created_at,
+    updated_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+ON DUPLICATE KEY UPDATE
+    plan = IF(tenant_id = VALUES(tenant_id), VALUES(plan), plan),
+    updated_at = IF(tenant_id = VALUES(tenant_id), VALUES(updated_at), updated_at);
@@ -1,41 +1,84 @@
+-- Every query is scoped to the tenant, so that a matching of another tenant is never found
+
 -- name: GetMatching :one
 SELECT * FROM `matching`
-WHERE id = ? LIMIT 1;
+WHERE tenant_id = ? AND id = ? LIMIT 1;
 
 -- name: GetMatchingByParticipants :one
 SELECT * FROM `matching`
-WHERE me_id = ? AND partner_id = ?
+WHERE tenant_id = ? AND me_id = ? AND partner_id = ?
+LIMIT 1;
+
+-- name: GetMatchingByPairKey :one
+SELECT * FROM `matching`
+WHERE tenant_id = ? AND pair_key = ?
 LIMIT 1;
 
+-- name: GetMatchingByPairKeyForUpdate :one
+SELECT * FROM `matching`
+WHERE tenant_id = ? AND pair_key = ?
+LIMIT 1
+FOR UPDATE;
+
+-- Matchings of pairs with a block in either direction are hidden from the lists
+
 -- name: ListMatchingsByUser :many
 SELECT * FROM `matching`
-WHERE me_id = ? OR partner_id = ?
+WHERE matching.tenant_id = ? AND (me_id = ? OR partner_id = ?)
+    AND NOT EXISTS (
+        SELECT 1 FROM `user_block` b
+        WHERE (b.blocker_id = matching.me_id AND b.blocked_id = matching.partner_id)
+            OR (b.blocker_id = matching.partner_id AND b.blocked_id = matching.me_id)
+    )
+LIMIT ? OFFSET ?;
+
+-- name: ListMutualMatchingsByUser :many
+SELECT * FROM `matching`
+WHERE matching.tenant_id = ? AND (me_id = ? OR partner_id = ?) AND `status` = 'accepted'
+    AND NOT EXISTS (
+        SELECT 1 FROM `user_block` b
+        WHERE (b.blocker_id = matching.me_id AND b.blocked_id = matching.partner_id)
+            OR (b.blocker_id = matching.partner_id AND b.blocked_id = matching.me_id)
+    )
 LIMIT ? OFFSET ?;
 
+-- name: ListOverdueMatchings :many
+SELECT * FROM `matching`
+WHERE tenant_id = ? AND `status` = 'pending' AND expires_at <= ?
+ORDER BY expires_at
+LIMIT ?
+FOR UPDATE SKIP LOCKED;
+
 -- name: ExistsMatching :one
 SELECT EXISTS(
-    SELECT 1 FROM `matching` WHERE id = ?
+    SELECT 1 FROM `matching` WHERE tenant_id = ? AND id = ?
 );
 
 -- name: CreateMatching :execresult
 INSERT INTO `matching` (
     id,
+    tenant_id,
     me_id,
     partner_id,
+    pair_key,
     `status`,
+    expires_at,
     created_at,
     updated_at
 ) VALUES (
-    ?, ?, ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
 );
 
 -- name: UpdateMatching :execresult
 UPDATE `matching`
 SET
+    me_id = ?,
+    partner_id = ?,
     `status` = ?,
+    expires_at = ?,
     updated_at = ?
-WHERE id = ?;
+WHERE tenant_id = ? AND id = ?;
 
 -- name: DeleteMatching :exec
 DELETE FROM `matching`
-WHERE id = ?;
+WHERE tenant_id = ? AND id = ?;
@@ -0,0 +1,21 @@
+-- Every query is scoped to the tenant, so that a history of another tenant is never found
+
+-- name: CreateMatchingHistory :exec
+INSERT INTO `matching_history` (
+    id,
+    tenant_id,
+    matching_id,
+    actor_id,
+    action,
+    from_status,
+    to_status,
+    reason,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: ListMatchingHistoriesByMatching :many
+SELECT * FROM `matching_history`
+WHERE tenant_id = ? AND matching_id = ?
+ORDER BY created_at, id;
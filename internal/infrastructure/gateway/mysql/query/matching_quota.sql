@@ -0,0 +1,21 @@
+-- Every query is scoped to the tenant, so that a usage of another tenant is never found
+
+-- name: IncrementMatchingQuotaUsage :exec
+-- The row of another tenant is left as it is
+INSERT INTO `matching_quota_usage` (
+    tenant_id,
+    user_id,
+    day,
+    used,
+    created_at,
+    updated_at
+) VALUES (
+    ?, ?, ?, 1, ?, ?
+)
+ON DUPLICATE KEY UPDATE
+    used = IF(tenant_id = VALUES(tenant_id), used + 1, used),
+    updated_at = IF(tenant_id = VALUES(tenant_id), VALUES(updated_at), updated_at);
+
+-- name: GetMatchingQuotaUsage :one
+SELECT used FROM `matching_quota_usage`
+WHERE tenant_id = ? AND user_id = ? AND day = ? LIMIT 1;
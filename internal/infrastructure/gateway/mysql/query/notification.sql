@@ -0,0 +1,88 @@
+-- Every query is scoped to the tenant, so that a notification of another tenant is never found
+
+-- name: CreateNotification :exec
+INSERT INTO `notification` (
+    id,
+    tenant_id,
+    user_id,
+    type,
+    title,
+    body,
+    data,
+    read_at,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: ExistsNotification :one
+SELECT EXISTS(
+    SELECT 1 FROM `notification` WHERE tenant_id = ? AND id = ?
+);
+
+-- name: GetNotification :one
+SELECT * FROM `notification`
+WHERE tenant_id = ? AND id = ? LIMIT 1;
+
+-- name: ListNotificationsByUserID :many
+SELECT * FROM `notification`
+WHERE tenant_id = ? AND user_id = ?
+ORDER BY created_at DESC, id DESC
+LIMIT ? OFFSET ?;
+
+-- name: ListUnreadNotificationsByUserID :many
+SELECT * FROM `notification`
+WHERE tenant_id = ? AND user_id = ? AND read_at IS NULL
+ORDER BY created_at DESC, id DESC
+LIMIT ? OFFSET ?;
+
+-- name: CountUnreadNotificationsByUserID :one
+SELECT COUNT(*) FROM `notification`
+WHERE tenant_id = ? AND user_id = ? AND read_at IS NULL;
+
+-- name: MarkNotificationsRead :execrows
+UPDATE `notification`
+SET read_at = sqlc.arg('read_at')
+WHERE tenant_id = sqlc.arg('tenant_id')
+    AND user_id = sqlc.arg('user_id')
+    AND read_at IS NULL
+    AND (sqlc.narg('id') IS NULL OR id = sqlc.narg('id'));
+
+-- name: UpsertNotificationPreference :exec
+-- The row of another tenant is left as it is
+INSERT INTO `notification_preference` (
+    tenant_id,
+    user_id,
+    channels,
+    updated_at
+) VALUES (
+    ?, ?, ?, ?
+) ON DUPLICATE KEY UPDATE
+    channels = IF(tenant_id = VALUES(tenant_id), VALUES(channels), channels),
+    updated_at = IF(tenant_id = VALUES(tenant_id), VALUES(updated_at), updated_at);
+
+-- name: GetNotificationPreference :one
+SELECT * FROM `notification_preference`
+WHERE tenant_id = ? AND user_id = ? LIMIT 1;
+
+-- name: UpsertNotificationDelivery :exec
+-- The row of another tenant is left as it is
+INSERT INTO `notification_delivery` (
+    tenant_id,
+    notification_id,
+    channel,
+    attempts,
+    last_error,
+    delivered_at,
+    updated_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+) ON DUPLICATE KEY UPDATE
+    attempts = IF(tenant_id = VALUES(tenant_id), VALUES(attempts), attempts),
+    last_error = IF(tenant_id = VALUES(tenant_id), VALUES(last_error), last_error),
+    delivered_at = IF(tenant_id = VALUES(tenant_id), VALUES(delivered_at), delivered_at),
+    updated_at = IF(tenant_id = VALUES(tenant_id), VALUES(updated_at), updated_at);
+
+-- name: GetNotificationDelivery :one
+SELECT * FROM `notification_delivery`
+WHERE tenant_id = ? AND notification_id = ? AND channel = ? LIMIT 1;
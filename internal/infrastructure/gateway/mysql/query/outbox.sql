@@ -0,0 +1,33 @@
+-- name: CreateOutboxMessage :exec
+INSERT INTO `outbox` (
+    id,
+    destination,
+    body,
+    attributes,
+    attempts,
+    last_error,
+    created_at,
+    sent_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: UpdateOutboxMessage :exec
+UPDATE `outbox`
+SET
+    attempts = ?,
+    last_error = ?,
+    sent_at = ?
+WHERE id = ?;
+
+-- name: ExistsOutboxMessage :one
+SELECT EXISTS(
+    SELECT 1 FROM `outbox` WHERE id = ?
+);
+
+-- name: ListUnsentOutboxMessagesForUpdate :many
+SELECT * FROM `outbox`
+WHERE sent_at IS NULL AND attempts < ?
+ORDER BY created_at, id
+LIMIT ?
+FOR UPDATE SKIP LOCKED;
@@ -0,0 +1,54 @@
+-- Every query is scoped to the tenant, so that a report of another tenant is never found,
+-- and the moderators of a tenant only see the reports of their tenant
+
+-- name: CreateReport :exec
+INSERT INTO `report` (
+    id,
+    tenant_id,
+    reporter_id,
+    reported_id,
+    reason,
+    comment,
+    status,
+    assignee_id,
+    resolution,
+    resolution_note,
+    resolved_at,
+    created_at,
+    updated_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: UpdateReport :exec
+UPDATE `report`
+SET
+    status = ?,
+    assignee_id = ?,
+    resolution = ?,
+    resolution_note = ?,
+    resolved_at = ?,
+    updated_at = ?
+WHERE tenant_id = ? AND id = ?;
+
+-- name: ExistsReport :one
+SELECT EXISTS(
+    SELECT 1 FROM `report` WHERE tenant_id = ? AND id = ?
+);
+
+-- name: GetReport :one
+SELECT * FROM `report`
+WHERE tenant_id = ? AND id = ? LIMIT 1;
+
+-- name: GetReportForUpdate :one
+SELECT * FROM `report`
+WHERE tenant_id = ? AND id = ? LIMIT 1
+FOR UPDATE;
+
+-- name: ListReports :many
+SELECT * FROM `report`
+WHERE tenant_id = sqlc.arg('tenant_id')
+    AND (sqlc.narg('status') IS NULL OR status = sqlc.narg('status'))
+    AND (sqlc.narg('assignee_id') IS NULL OR assignee_id = sqlc.narg('assignee_id'))
+ORDER BY created_at ASC
+LIMIT ? OFFSET ?;
@@ -1,37 +1,141 @@
+-- Every query is scoped to the tenant, so that a user of another tenant is never found
+
 -- name: GetUser :one
 SELECT * FROM `user`
-WHERE id = ? LIMIT 1;
+WHERE tenant_id = ? AND id = ? AND deleted_at IS NULL LIMIT 1;
+
+-- name: GetUserWithDeleted :one
+SELECT * FROM `user`
+WHERE tenant_id = ? AND id = ? LIMIT 1;
+
+-- name: GetUserWithDeletedForUpdate :one
+SELECT * FROM `user`
+WHERE tenant_id = ? AND id = ? LIMIT 1
+FOR UPDATE;
+
+-- name: ListUsersByIDs :many
+SELECT * FROM `user`
+WHERE tenant_id = sqlc.arg('tenant_id') AND id IN (sqlc.slice('ids')) AND deleted_at IS NULL;
 
 -- name: ListUsers :many
 SELECT * FROM `user`
-ORDER BY created_at DESC
+WHERE tenant_id = ? AND deleted_at IS NULL
+ORDER BY created_at DESC, id DESC
 LIMIT ? OFFSET ?;
 
+-- name: ListUsersAfter :many
+SELECT * FROM `user`
+WHERE tenant_id = sqlc.arg('tenant_id')
+    AND deleted_at IS NULL
+    AND (
+        sqlc.narg('cursor_created_at') IS NULL
+        OR created_at > sqlc.narg('cursor_created_at')
+        OR (created_at = sqlc.narg('cursor_created_at') AND id > sqlc.narg('cursor_id'))
+    )
+ORDER BY created_at, id
+LIMIT ?;
+
+-- name: ListUsersDeletedBefore :many
+SELECT * FROM `user`
+WHERE tenant_id = sqlc.arg('tenant_id')
+    AND deleted_at IS NOT NULL AND deleted_at <= sqlc.arg('deleted_before')
+    AND (deletion_enqueued_at IS NULL OR deletion_enqueued_at <= sqlc.arg('enqueued_before'))
+ORDER BY deleted_at
+LIMIT ?;
+
 -- name: CreateUser :execresult
 INSERT INTO `user` (
     id,
+    tenant_id,
     email,
+    pending_email,
+    display_name,
+    birthdate,
+    gender,
+    bio,
+    locale,
+    interests,
+    `status`,
+    email_verified_at,
     created_at,
-    updated_at
+    updated_at,
+    deleted_at,
+    deletion_enqueued_at
 ) VALUES (
-    ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 );
 
 -- name: UpdateUser :execresult
 UPDATE `user`
 SET
     email = ?,
-    updated_at = ?
-WHERE id = ?;
+    pending_email = ?,
+    display_name = ?,
+    birthdate = ?,
+    gender = ?,
+    bio = ?,
+    locale = ?,
+    interests = ?,
+    `status` = ?,
+    email_verified_at = ?,
+    updated_at = ?,
+    deleted_at = ?,
+    deletion_enqueued_at = ?
+WHERE tenant_id = ? AND id = ?;
 
 -- name: DeleteUser :exec
 DELETE FROM `user`
-WHERE id = ?;
+WHERE tenant_id = ? AND id = ?;
 
 -- name: ExistsUser :one
 SELECT EXISTS(
-    SELECT 1 FROM `user` WHERE id = ?
+    SELECT 1 FROM `user` WHERE tenant_id = ? AND id = ?
+);
+
+-- name: ExistsUserByEmail :one
+SELECT EXISTS(
+    SELECT 1 FROM `user` WHERE tenant_id = ? AND email = ?
 );
 
 -- name: CountUsers :one
-SELECT COUNT(*) FROM `user`;
+SELECT COUNT(*) FROM `user`
+WHERE tenant_id = ? AND deleted_at IS NULL;
+
+-- name: ListRecommendationCandidates :many
+SELECT u.* FROM `user` u
+WHERE u.tenant_id = sqlc.arg('tenant_id')
+    AND u.id <> sqlc.arg('user_id')
+    AND u.deleted_at IS NULL
+    AND u.`status` = 'active'
+    AND u.email_verified_at IS NOT NULL
+    AND NOT EXISTS (
+        SELECT 1 FROM `matching` m
+        WHERE (m.me_id = sqlc.arg('user_id') AND m.partner_id = u.id)
+            OR (m.me_id = u.id AND m.partner_id = sqlc.arg('user_id'))
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM `user_block` b
+        WHERE (b.blocker_id = sqlc.arg('user_id') AND b.blocked_id = u.id)
+            OR (b.blocker_id = u.id AND b.blocked_id = sqlc.arg('user_id'))
+    )
+ORDER BY u.updated_at DESC
+LIMIT ?;
+
+-- name: ListRecommendationCandidatesByIDs :many
+SELECT u.* FROM `user` u
+WHERE u.tenant_id = sqlc.arg('tenant_id')
+    AND u.id IN (sqlc.slice('ids'))
+    AND u.id <> sqlc.arg('user_id')
+    AND u.deleted_at IS NULL
+    AND u.`status` = 'active'
+    AND u.email_verified_at IS NOT NULL
+    AND NOT EXISTS (
+        SELECT 1 FROM `matching` m
+        WHERE (m.me_id = sqlc.arg('user_id') AND m.partner_id = u.id)
+            OR (m.me_id = u.id AND m.partner_id = sqlc.arg('user_id'))
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM `user_block` b
+        WHERE (b.blocker_id = sqlc.arg('user_id') AND b.blocked_id = u.id)
+            OR (b.blocker_id = u.id AND b.blocked_id = sqlc.arg('user_id'))
+    );
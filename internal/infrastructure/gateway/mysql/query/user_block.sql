@@ -0,0 +1,39 @@
+-- Every query is scoped to the tenant, so that a block of another tenant is never found
+
+-- name: CreateUserBlock :exec
+INSERT INTO `user_block` (
+    tenant_id,
+    blocker_id,
+    blocked_id,
+    created_at
+) VALUES (
+    ?, ?, ?, ?
+);
+
+-- name: DeleteUserBlock :execresult
+DELETE FROM `user_block`
+WHERE tenant_id = ? AND blocker_id = ? AND blocked_id = ?;
+
+-- name: ExistsUserBlock :one
+SELECT EXISTS(
+    SELECT 1 FROM `user_block` WHERE tenant_id = ? AND blocker_id = ? AND blocked_id = ?
+);
+
+-- name: CountUserBlocksForUpdate :one
+SELECT COUNT(*) FROM `user_block`
+WHERE tenant_id = ? AND blocker_id = ? AND blocked_id = ?
+FOR UPDATE;
+
+-- name: ListUserBlocksBetween :many
+SELECT * FROM `user_block`
+WHERE tenant_id = sqlc.arg('tenant_id')
+    AND (
+        (blocker_id = sqlc.arg('first_user_id') AND blocked_id = sqlc.arg('second_user_id'))
+        OR (blocker_id = sqlc.arg('second_user_id') AND blocked_id = sqlc.arg('first_user_id'))
+    );
+
+-- name: ListUserBlocksByBlocker :many
+SELECT * FROM `user_block`
+WHERE tenant_id = ? AND blocker_id = ?
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?;
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/sqlc"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// AuditLogMySQLRepository scopes every query to the tenant of the context, and fails without one.
+type AuditLogMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewAuditLogMySQLRepository(db *sql.DB) *AuditLogMySQLRepository {
+	return &AuditLogMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *AuditLogMySQLRepository) Save(ctx context.Context, log *model.AuditLog) (*model.AuditLog, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	diff, err := json.Marshal(log.Diff)
+	if err != nil {
+		return nil, err
+	}
+	err = q.CreateAuditLog(ctx, sqlc.CreateAuditLogParams{
+		ID:         uuid.Bytes(log.ID),
+		TenantID:   tenantID.String(),
+		ActorID:    toNullUUID(log.ActorID),
+		Action:     log.Action,
+		TargetType: string(log.TargetType),
+		TargetID:   log.TargetID,
+		Diff:       diff,
+		RequestID:  truncate(log.RequestID, 255),
+		Source:     string(log.Source),
+		CreatedAt:  log.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+func (r *AuditLogMySQLRepository) Search(ctx context.Context, filter repository.AuditLogFilter) ([]*model.AuditLog, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	rows, err := q.SearchAuditLogs(ctx, sqlc.SearchAuditLogsParams{
+		TenantID:   tenantID.String(),
+		ActorID:    toNullUUID(filter.ActorID),
+		TargetType: toNullString(string(filter.TargetType)),
+		TargetID:   toNullString(filter.TargetID),
+		From:       toNullTime(filter.From),
+		To:         toNullTime(filter.To),
+		Limit:      int32(filter.Limit),
+		Offset:     int32(filter.Offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	logs := make([]*model.AuditLog, 0, len(rows))
+	for _, row := range rows {
+		log, err := toAuditLogModel(row)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+
+func toAuditLogModel(row sqlc.AuditLog) (*model.AuditLog, error) {
+	var diff model.AuditDiff
+	if err := json.Unmarshal(row.Diff, &diff); err != nil {
+		return nil, err
+	}
+	return &model.AuditLog{
+		ID:         uuid.MustFromBytes(row.ID),
+		ActorID:    fromNullUUID(row.ActorID),
+		Action:     row.Action,
+		TargetType: model.AuditTargetType(row.TargetType),
+		TargetID:   row.TargetID,
+		Diff:       diff,
+		RequestID:  row.RequestID,
+		Source:     model.AuditSource(row.Source),
+		CreatedAt:  row.CreatedAt,
+	}, nil
+}
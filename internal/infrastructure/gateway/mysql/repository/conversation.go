@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/sqlc"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// ConversationMySQLRepository scopes every query to the tenant of the context, and fails without one.
+type ConversationMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewConversationMySQLRepository(db *sql.DB) *ConversationMySQLRepository {
+	return &ConversationMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *ConversationMySQLRepository) Save(ctx context.Context, conversation *model.Conversation) (*model.Conversation, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	exists, err := q.ExistsConversation(ctx, sqlc.ExistsConversationParams{TenantID: tenantID.String(), ID: uuid.Bytes(conversation.ID)})
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		err = q.UpdateConversation(ctx, sqlc.UpdateConversationParams{
+			Status:     string(conversation.Status),
+			ArchivedAt: toNullTime(conversation.ArchivedAt),
+			UpdatedAt:  conversation.UpdatedAt,
+			TenantID:   tenantID.String(),
+			ID:         uuid.Bytes(conversation.ID),
+		})
+	} else {
+		err = q.CreateConversation(ctx, sqlc.CreateConversationParams{
+			ID:         uuid.Bytes(conversation.ID),
+			TenantID:   tenantID.String(),
+			MatchingID: uuid.Bytes(conversation.MatchingID),
+			User1ID:    uuid.Bytes(conversation.User1ID),
+			User2ID:    uuid.Bytes(conversation.User2ID),
+			Status:     string(conversation.Status),
+			ArchivedAt: toNullTime(conversation.ArchivedAt),
+			CreatedAt:  conversation.CreatedAt,
+			UpdatedAt:  conversation.UpdatedAt,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+func (r *ConversationMySQLRepository) FindByMatchingID(ctx context.Context, matchingID uuid.UUID) (*model.Conversation, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	conversation, err := q.GetConversationByMatchingID(ctx, sqlc.GetConversationByMatchingIDParams{TenantID: tenantID.String(), MatchingID: uuid.Bytes(matchingID)})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &model.Conversation{
+		ID:         uuid.MustFromBytes(conversation.ID),
+		MatchingID: uuid.MustFromBytes(conversation.MatchingID),
+		User1ID:    uuid.MustFromBytes(conversation.User1ID),
+		User2ID:    uuid.MustFromBytes(conversation.User2ID),
+		Status:     model.ConversationStatus(conversation.Status),
+		ArchivedAt: conversation.ArchivedAt.Time,
+		CreatedAt:  conversation.CreatedAt,
+		UpdatedAt:  conversation.UpdatedAt,
+	}, nil
+}
+
+// ChatMessageMySQLRepository scopes every query to the tenant of the context, and fails without one.
+type ChatMessageMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewChatMessageMySQLRepository(db *sql.DB) *ChatMessageMySQLRepository {
+	return &ChatMessageMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// Save creates the message. Messages are never edited, and read receipts are set by MarkRead.
+func (r *ChatMessageMySQLRepository) Save(ctx context.Context, message *model.ChatMessage) (*model.ChatMessage, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	err = q.CreateChatMessage(ctx, sqlc.CreateChatMessageParams{
+		ID:             uuid.Bytes(message.ID),
+		TenantID:       tenantID.String(),
+		ConversationID: uuid.Bytes(message.ConversationID),
+		SenderID:       uuid.Bytes(message.SenderID),
+		Body:           message.Body,
+		ReadAt:         toNullTime(message.ReadAt),
+		CreatedAt:      message.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func (r *ChatMessageMySQLRepository) FindById(ctx context.Context, id uuid.UUID) (*model.ChatMessage, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	message, err := q.GetChatMessage(ctx, sqlc.GetChatMessageParams{TenantID: tenantID.String(), ID: uuid.Bytes(id)})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toChatMessageModel(message), nil
+}
+
+func (r *ChatMessageMySQLRepository) FindAllByConversationID(ctx context.Context, conversationID uuid.UUID, cursor *model.ChatMessageCursor, limit int) ([]*model.ChatMessage, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	params := sqlc.ListChatMessagesParams{
+		TenantID:       tenantID.String(),
+		ConversationID: uuid.Bytes(conversationID),
+		Limit:          int32(limit),
+	}
+	if cursor != nil {
+		params.CursorCreatedAt = toNullTime(cursor.CreatedAt)
+		params.CursorID = toNullUUID(cursor.ID)
+	}
+	messages, err := q.ListChatMessages(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.ChatMessage, len(messages))
+	for i, message := range messages {
+		result[i] = toChatMessageModel(message)
+	}
+	return result, nil
+}
+
+func (r *ChatMessageMySQLRepository) MarkRead(ctx context.Context, conversationID, senderID uuid.UUID, upTo, readAt time.Time) (int, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	count, err := q.MarkChatMessagesRead(ctx, sqlc.MarkChatMessagesReadParams{
+		ReadAt:         toNullTime(readAt),
+		TenantID:       tenantID.String(),
+		ConversationID: uuid.Bytes(conversationID),
+		SenderID:       uuid.Bytes(senderID),
+		CreatedAt:      upTo,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func toChatMessageModel(message sqlc.ChatMessage) *model.ChatMessage {
+	return &model.ChatMessage{
+		ID:             uuid.MustFromBytes(message.ID),
+		ConversationID: uuid.MustFromBytes(message.ConversationID),
+		SenderID:       uuid.MustFromBytes(message.SenderID),
+		Body:           message.Body,
+		ReadAt:         message.ReadAt.Time,
+		CreatedAt:      message.CreatedAt,
+	}
+}
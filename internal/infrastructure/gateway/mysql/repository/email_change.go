@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/sqlc"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// EmailChangeMySQLRepository scopes every query to the tenant of the context, and fails without one.
+type EmailChangeMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewEmailChangeMySQLRepository(db *sql.DB) *EmailChangeMySQLRepository {
+	return &EmailChangeMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *EmailChangeMySQLRepository) Save(ctx context.Context, change *model.EmailChange) (*model.EmailChange, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	exists, err := q.ExistsEmailChange(ctx, sqlc.ExistsEmailChangeParams{TenantID: tenantID.String(), ID: uuid.Bytes(change.ID)})
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		err = q.UpdateEmailChange(ctx, sqlc.UpdateEmailChangeParams{
+			ConfirmedAt: toNullTime(change.ConfirmedAt),
+			RevertedAt:  toNullTime(change.RevertedAt),
+			TenantID:    tenantID.String(),
+			ID:          uuid.Bytes(change.ID),
+		})
+	} else {
+		err = q.CreateEmailChange(ctx, sqlc.CreateEmailChangeParams{
+			ID:            uuid.Bytes(change.ID),
+			TenantID:      tenantID.String(),
+			UserID:        uuid.Bytes(change.UserID),
+			OldEmail:      change.OldEmail,
+			NewEmail:      change.NewEmail,
+			ExpiresAt:     change.ExpiresAt,
+			UndoExpiresAt: change.UndoExpiresAt,
+			ConfirmedAt:   toNullTime(change.ConfirmedAt),
+			RevertedAt:    toNullTime(change.RevertedAt),
+			CreatedAt:     change.CreatedAt,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (r *EmailChangeMySQLRepository) FindByIdForUpdate(ctx context.Context, id uuid.UUID) (*model.EmailChange, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	change, err := q.GetEmailChangeForUpdate(ctx, sqlc.GetEmailChangeForUpdateParams{TenantID: tenantID.String(), ID: uuid.Bytes(id)})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toEmailChangeModel(change), nil
+}
+
+func toEmailChangeModel(change sqlc.EmailChange) *model.EmailChange {
+	return &model.EmailChange{
+		ID:            uuid.MustFromBytes(change.ID),
+		UserID:        uuid.MustFromBytes(change.UserID),
+		OldEmail:      change.OldEmail,
+		NewEmail:      change.NewEmail,
+		ExpiresAt:     change.ExpiresAt,
+		UndoExpiresAt: change.UndoExpiresAt,
+		ConfirmedAt:   change.ConfirmedAt.Time,
+		RevertedAt:    change.RevertedAt.Time,
+		CreatedAt:     change.CreatedAt,
+	}
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/sqlc"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// EmailVerificationMySQLRepository scopes every query to the tenant of the context, and fails without one.
+type EmailVerificationMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewEmailVerificationMySQLRepository(db *sql.DB) *EmailVerificationMySQLRepository {
+	return &EmailVerificationMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *EmailVerificationMySQLRepository) Save(ctx context.Context, verification *model.EmailVerification) (*model.EmailVerification, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	exists, err := q.ExistsEmailVerification(ctx, sqlc.ExistsEmailVerificationParams{TenantID: tenantID.String(), ID: uuid.Bytes(verification.ID)})
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		err = q.UpdateEmailVerification(ctx, sqlc.UpdateEmailVerificationParams{
+			UsedAt:   toNullTime(verification.UsedAt),
+			TenantID: tenantID.String(),
+			ID:       uuid.Bytes(verification.ID),
+		})
+	} else {
+		err = q.CreateEmailVerification(ctx, sqlc.CreateEmailVerificationParams{
+			ID:        uuid.Bytes(verification.ID),
+			TenantID:  tenantID.String(),
+			UserID:    uuid.Bytes(verification.UserID),
+			Email:     verification.Email,
+			ExpiresAt: verification.ExpiresAt,
+			UsedAt:    toNullTime(verification.UsedAt),
+			CreatedAt: verification.CreatedAt,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return verification, nil
+}
+
+func (r *EmailVerificationMySQLRepository) FindByIdForUpdate(ctx context.Context, id uuid.UUID) (*model.EmailVerification, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	verification, err := q.GetEmailVerificationForUpdate(ctx, sqlc.GetEmailVerificationForUpdateParams{TenantID: tenantID.String(), ID: uuid.Bytes(id)})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toEmailVerificationModel(verification), nil
+}
+
+func (r *EmailVerificationMySQLRepository) CountByUserIdSinceForUpdate(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	count, err := q.CountEmailVerificationsByUserSince(ctx, sqlc.CountEmailVerificationsByUserSinceParams{
+		TenantID:  tenantID.String(),
+		UserID:    uuid.Bytes(userID),
+		CreatedAt: since,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func toEmailVerificationModel(verification sqlc.EmailVerification) *model.EmailVerification {
+	return &model.EmailVerification{
+		ID:        uuid.MustFromBytes(verification.ID),
+		UserID:    uuid.MustFromBytes(verification.UserID),
+		Email:     verification.Email,
+		ExpiresAt: verification.ExpiresAt,
+		UsedAt:    verification.UsedAt.Time,
+		CreatedAt: verification.CreatedAt,
+	}
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/sqlc"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// EntitlementMySQLRepository scopes every query to the tenant of the context, and fails without one.
+type EntitlementMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+	limits  model.PlanLimits
+	clock   clock.Clock
+}
+
+func NewEntitlementMySQLRepository(db *sql.DB, limits model.PlanLimits, clock clock.Clock) *EntitlementMySQLRepository {
+	return &EntitlementMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+		limits:  limits,
+		clock:   clock,
+	}
+}
+
+func (r *EntitlementMySQLRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*model.Entitlement, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	plan, err := q.GetUserPlan(ctx, sqlc.GetUserPlanParams{TenantID: tenantID.String(), UserID: uuid.Bytes(userID)})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.NewEntitlement(userID, model.PlanFree, r.limits), nil
+		}
+		return nil, err
+	}
+	return model.NewEntitlement(userID, model.Plan(plan), r.limits), nil
+}
+
+func (r *EntitlementMySQLRepository) SavePlan(ctx context.Context, userID uuid.UUID, plan model.Plan) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	now := r.clock.Now()
+	return q.UpsertUserPlan(ctx, sqlc.UpsertUserPlanParams{
+		TenantID:  tenantID.String(),
+		UserID:    uuid.Bytes(userID),
+		Plan:      string(plan),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
@@ -6,11 +6,14 @@ import (
 	"time"
 
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/sqlc"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
 )
 
+// MatchingMySQLRepository scopes every query to the tenant of the context, and fails without one.
 type MatchingMySQLRepository struct {
 	db      *sql.DB
 	queries *sqlc.Queries
@@ -24,40 +27,59 @@ func NewMatchingMySQLRepository(db *sql.DB) *MatchingMySQLRepository {
 }
 
 func (r *MatchingMySQLRepository) Save(ctx context.Context, matching *model.Matching) (*model.Matching, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	q := transaction.GetQueries(ctx, r.queries)
-	exists, err := q.ExistsMatching(ctx, matching.ID.String())
+	exists, err := q.ExistsMatching(ctx, sqlc.ExistsMatchingParams{TenantID: tenantID.String(), ID: uuid.Bytes(matching.ID)})
 	if err != nil {
 		return nil, err
 	}
 
 	if exists {
 		_, err = q.UpdateMatching(ctx, sqlc.UpdateMatchingParams{
+			MeID:      uuid.Bytes(matching.MeID),
+			PartnerID: uuid.Bytes(matching.PartnerID),
 			Status:    string(matching.Status),
-			UpdatedAt: time.Now(),
-			ID:        matching.ID.String(),
+			ExpiresAt: toNullTime(matching.ExpiresAt),
+			UpdatedAt: matching.UpdatedAt,
+			TenantID:  tenantID.String(),
+			ID:        uuid.Bytes(matching.ID),
 		})
 	} else {
 		_, err = q.CreateMatching(ctx, sqlc.CreateMatchingParams{
-			ID:        matching.ID.String(),
-			MeID:      matching.MeID.String(),
-			PartnerID: matching.PartnerID.String(),
+			ID:        uuid.Bytes(matching.ID),
+			TenantID:  tenantID.String(),
+			MeID:      uuid.Bytes(matching.MeID),
+			PartnerID: uuid.Bytes(matching.PartnerID),
+			PairKey:   matching.PairKey(),
 			Status:    string(matching.Status),
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+			ExpiresAt: toNullTime(matching.ExpiresAt),
+			CreatedAt: matching.CreatedAt,
+			UpdatedAt: matching.UpdatedAt,
 		})
 	}
 
 	if err != nil {
+		if isDuplicateEntry(err) {
+			return nil, repository.ErrMatchingPairAlreadyExists
+		}
 		return nil, err
 	}
 	return matching, nil
 }
 
 func (r *MatchingMySQLRepository) FindAllByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*model.Matching, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	q := transaction.GetQueries(ctx, r.queries)
 	matchings, err := q.ListMatchingsByUser(ctx, sqlc.ListMatchingsByUserParams{
-		MeID:      userID.String(),
-		PartnerID: userID.String(),
+		TenantID:  tenantID.String(),
+		MeID:      uuid.Bytes(userID),
+		PartnerID: uuid.Bytes(userID),
 		Limit:     int32(limit),
 		Offset:    int32(offset),
 	})
@@ -67,21 +89,66 @@ func (r *MatchingMySQLRepository) FindAllByUser(ctx context.Context, userID uuid
 
 	result := make([]*model.Matching, len(matchings))
 	for i, m := range matchings {
-		result[i] = &model.Matching{
-			ID:        uuid.MustParse(m.ID),
-			MeID:      uuid.MustParse(m.MeID),
-			PartnerID: uuid.MustParse(m.PartnerID),
-			Status:    model.MatchingStatus(m.Status),
-			CreatedAt: m.CreatedAt,
-			UpdatedAt: m.UpdatedAt,
-		}
+		result[i] = toMatchingModel(m)
+	}
+	return result, nil
+}
+
+func (r *MatchingMySQLRepository) FindAllMutualByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*model.Matching, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	matchings, err := q.ListMutualMatchingsByUser(ctx, sqlc.ListMutualMatchingsByUserParams{
+		TenantID:  tenantID.String(),
+		MeID:      uuid.Bytes(userID),
+		PartnerID: uuid.Bytes(userID),
+		Limit:     int32(limit),
+		Offset:    int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Matching, len(matchings))
+	for i, m := range matchings {
+		result[i] = toMatchingModel(m)
+	}
+	return result, nil
+}
+
+// FindAllOverdue locks up to limit pending matchings that expired before the given time.
+// Rows locked by another transaction are skipped, so that batches can run concurrently.
+func (r *MatchingMySQLRepository) FindAllOverdue(ctx context.Context, before time.Time, limit int) ([]*model.Matching, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	matchings, err := q.ListOverdueMatchings(ctx, sqlc.ListOverdueMatchingsParams{
+		TenantID:  tenantID.String(),
+		ExpiresAt: toNullTime(before),
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Matching, len(matchings))
+	for i, m := range matchings {
+		result[i] = toMatchingModel(m)
 	}
 	return result, nil
 }
 
 func (r *MatchingMySQLRepository) FindById(ctx context.Context, id uuid.UUID) (*model.Matching, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	q := transaction.GetQueries(ctx, r.queries)
-	matching, err := q.GetMatching(ctx, id.String())
+	matching, err := q.GetMatching(ctx, sqlc.GetMatchingParams{TenantID: tenantID.String(), ID: uuid.Bytes(id)})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -89,21 +156,19 @@ func (r *MatchingMySQLRepository) FindById(ctx context.Context, id uuid.UUID) (*
 		return nil, err
 	}
 
-	return &model.Matching{
-		ID:        uuid.MustParse(matching.ID),
-		MeID:      uuid.MustParse(matching.MeID),
-		PartnerID: uuid.MustParse(matching.PartnerID),
-		Status:    model.MatchingStatus(matching.Status),
-		CreatedAt: matching.CreatedAt,
-		UpdatedAt: matching.UpdatedAt,
-	}, nil
+	return toMatchingModel(matching), nil
 }
 
 func (r *MatchingMySQLRepository) FindByParticipants(ctx context.Context, meID, partnerID uuid.UUID) (*model.Matching, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	q := transaction.GetQueries(ctx, r.queries)
 	matching, err := q.GetMatchingByParticipants(ctx, sqlc.GetMatchingByParticipantsParams{
-		MeID:      meID.String(),
-		PartnerID: partnerID.String(),
+		TenantID:  tenantID.String(),
+		MeID:      uuid.Bytes(meID),
+		PartnerID: uuid.Bytes(partnerID),
 	})
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -112,22 +177,70 @@ func (r *MatchingMySQLRepository) FindByParticipants(ctx context.Context, meID,
 		return nil, err
 	}
 
-	return &model.Matching{
-		ID:        uuid.MustParse(matching.ID),
-		MeID:      uuid.MustParse(matching.MeID),
-		PartnerID: uuid.MustParse(matching.PartnerID),
-		Status:    model.MatchingStatus(matching.Status),
-		CreatedAt: matching.CreatedAt,
-		UpdatedAt: matching.UpdatedAt,
-	}, nil
+	return toMatchingModel(matching), nil
 }
 
-func (r *MatchingMySQLRepository) Remove(ctx context.Context, id uuid.UUID) (*uuid.UUID, error) {
+func (r *MatchingMySQLRepository) FindByPair(ctx context.Context, userID1, userID2 uuid.UUID) (*model.Matching, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	q := transaction.GetQueries(ctx, r.queries)
-	err := q.DeleteMatching(ctx, id.String())
+	matching, err := q.GetMatchingByPairKey(ctx, sqlc.GetMatchingByPairKeyParams{
+		TenantID: tenantID.String(),
+		PairKey:  model.MatchingPairKey(userID1, userID2),
+	})
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toMatchingModel(matching), nil
+}
+
+func (r *MatchingMySQLRepository) FindByPairForUpdate(ctx context.Context, userID1, userID2 uuid.UUID) (*model.Matching, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	matching, err := q.GetMatchingByPairKeyForUpdate(ctx, sqlc.GetMatchingByPairKeyForUpdateParams{
+		TenantID: tenantID.String(),
+		PairKey:  model.MatchingPairKey(userID1, userID2),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toMatchingModel(matching), nil
+}
+
+func (r *MatchingMySQLRepository) Remove(ctx context.Context, id uuid.UUID) (*uuid.UUID, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	if err := q.DeleteMatching(ctx, sqlc.DeleteMatchingParams{TenantID: tenantID.String(), ID: uuid.Bytes(id)}); err != nil {
 		return nil, err
 	}
 
 	return &id, nil
 }
+
+func toMatchingModel(matching sqlc.Matching) *model.Matching {
+	return &model.Matching{
+		ID:        uuid.MustFromBytes(matching.ID),
+		MeID:      uuid.MustFromBytes(matching.MeID),
+		PartnerID: uuid.MustFromBytes(matching.PartnerID),
+		Status:    model.MatchingStatus(matching.Status),
+		ExpiresAt: matching.ExpiresAt.Time,
+		CreatedAt: matching.CreatedAt,
+		UpdatedAt: matching.UpdatedAt,
+	}
+}
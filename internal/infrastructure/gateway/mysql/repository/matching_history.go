@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/sqlc"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// MatchingHistoryMySQLRepository scopes every query to the tenant of the context, and fails without one.
+type MatchingHistoryMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewMatchingHistoryMySQLRepository(db *sql.DB) *MatchingHistoryMySQLRepository {
+	return &MatchingHistoryMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// Save appends the history. Histories are never updated.
+func (r *MatchingHistoryMySQLRepository) Save(ctx context.Context, history *model.MatchingHistory) (*model.MatchingHistory, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	var actorID []byte
+	if !history.IsBySystem() {
+		actorID = uuid.Bytes(history.ActorID)
+	}
+	err = q.CreateMatchingHistory(ctx, sqlc.CreateMatchingHistoryParams{
+		ID:         uuid.Bytes(history.ID),
+		TenantID:   tenantID.String(),
+		MatchingID: uuid.Bytes(history.MatchingID),
+		ActorID:    actorID,
+		Action:     string(history.Action),
+		FromStatus: string(history.From),
+		ToStatus:   string(history.To),
+		Reason:     history.Reason,
+		CreatedAt:  history.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func (r *MatchingHistoryMySQLRepository) FindAllByMatchingID(ctx context.Context, matchingID uuid.UUID) ([]*model.MatchingHistory, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	histories, err := q.ListMatchingHistoriesByMatching(ctx, sqlc.ListMatchingHistoriesByMatchingParams{TenantID: tenantID.String(), MatchingID: uuid.Bytes(matchingID)})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.MatchingHistory, len(histories))
+	for i, h := range histories {
+		result[i] = &model.MatchingHistory{
+			ID:         uuid.MustFromBytes(h.ID),
+			MatchingID: uuid.MustFromBytes(h.MatchingID),
+			ActorID:    fromNullUUID(h.ActorID),
+			Action:     model.MatchingAction(h.Action),
+			From:       model.MatchingStatus(h.FromStatus),
+			To:         model.MatchingStatus(h.ToStatus),
+			Reason:     h.Reason,
+			CreatedAt:  h.CreatedAt,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/sqlc"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// MatchingQuotaUsageMySQLRepository scopes every query to the tenant of the context, and fails without one.
+type MatchingQuotaUsageMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+	clock   clock.Clock
+}
+
+func NewMatchingQuotaUsageMySQLRepository(db *sql.DB, clock clock.Clock) *MatchingQuotaUsageMySQLRepository {
+	return &MatchingQuotaUsageMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+		clock:   clock,
+	}
+}
+
+func (r *MatchingQuotaUsageMySQLRepository) Increment(ctx context.Context, userID uuid.UUID, day time.Time) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	now := r.clock.Now()
+	return q.IncrementMatchingQuotaUsage(ctx, sqlc.IncrementMatchingQuotaUsageParams{
+		TenantID:  tenantID.String(),
+		UserID:    uuid.Bytes(userID),
+		Day:       day,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+func (r *MatchingQuotaUsageMySQLRepository) Count(ctx context.Context, userID uuid.UUID, day time.Time) (int, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	used, err := q.GetMatchingQuotaUsage(ctx, sqlc.GetMatchingQuotaUsageParams{
+		TenantID: tenantID.String(),
+		UserID:   uuid.Bytes(userID),
+		Day:      day,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return int(used), nil
+}
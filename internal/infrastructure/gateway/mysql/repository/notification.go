@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/sqlc"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// NotificationMySQLRepository scopes every query to the tenant of the context, and fails without one.
+type NotificationMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewNotificationMySQLRepository(db *sql.DB) *NotificationMySQLRepository {
+	return &NotificationMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// Save creates the notification. Notifications are never edited, and read receipts are set by MarkRead.
+func (r *NotificationMySQLRepository) Save(ctx context.Context, notification *model.Notification) (*model.Notification, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	exists, err := q.ExistsNotification(ctx, sqlc.ExistsNotificationParams{TenantID: tenantID.String(), ID: uuid.Bytes(notification.ID)})
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return notification, nil
+	}
+
+	data, err := json.Marshal(notification.Data)
+	if err != nil {
+		return nil, err
+	}
+	err = q.CreateNotification(ctx, sqlc.CreateNotificationParams{
+		ID:        uuid.Bytes(notification.ID),
+		TenantID:  tenantID.String(),
+		UserID:    uuid.Bytes(notification.UserID),
+		Type:      string(notification.Type),
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Data:      data,
+		ReadAt:    toNullTime(notification.ReadAt),
+		CreatedAt: notification.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+func (r *NotificationMySQLRepository) FindById(ctx context.Context, id uuid.UUID) (*model.Notification, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	notification, err := q.GetNotification(ctx, sqlc.GetNotificationParams{TenantID: tenantID.String(), ID: uuid.Bytes(id)})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toNotificationModel(notification)
+}
+
+func (r *NotificationMySQLRepository) FindAllByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*model.Notification, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	var notifications []sqlc.Notification
+	if unreadOnly {
+		notifications, err = q.ListUnreadNotificationsByUserID(ctx, sqlc.ListUnreadNotificationsByUserIDParams{
+			TenantID: tenantID.String(),
+			UserID:   uuid.Bytes(userID),
+			Limit:    int32(limit),
+			Offset:   int32(offset),
+		})
+	} else {
+		notifications, err = q.ListNotificationsByUserID(ctx, sqlc.ListNotificationsByUserIDParams{
+			TenantID: tenantID.String(),
+			UserID:   uuid.Bytes(userID),
+			Limit:    int32(limit),
+			Offset:   int32(offset),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Notification, len(notifications))
+	for i, notification := range notifications {
+		if result[i], err = toNotificationModel(notification); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (r *NotificationMySQLRepository) CountUnreadByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	count, err := q.CountUnreadNotificationsByUserID(ctx, sqlc.CountUnreadNotificationsByUserIDParams{TenantID: tenantID.String(), UserID: uuid.Bytes(userID)})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *NotificationMySQLRepository) MarkRead(ctx context.Context, userID, id uuid.UUID, readAt time.Time) (int, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	count, err := q.MarkNotificationsRead(ctx, sqlc.MarkNotificationsReadParams{
+		ReadAt:   toNullTime(readAt),
+		TenantID: tenantID.String(),
+		UserID:   uuid.Bytes(userID),
+		ID:       toNullUUID(id),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func toNotificationModel(notification sqlc.Notification) (*model.Notification, error) {
+	var data map[string]string
+	if err := json.Unmarshal(notification.Data, &data); err != nil {
+		return nil, err
+	}
+	return &model.Notification{
+		ID:        uuid.MustFromBytes(notification.ID),
+		UserID:    uuid.MustFromBytes(notification.UserID),
+		Type:      model.NotificationType(notification.Type),
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Data:      data,
+		ReadAt:    notification.ReadAt.Time,
+		CreatedAt: notification.CreatedAt,
+	}, nil
+}
+
+// NotificationPreferenceMySQLRepository scopes every query to the tenant of the context, and fails without one.
+type NotificationPreferenceMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewNotificationPreferenceMySQLRepository(db *sql.DB) *NotificationPreferenceMySQLRepository {
+	return &NotificationPreferenceMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *NotificationPreferenceMySQLRepository) Save(ctx context.Context, preference *model.NotificationPreference) (*model.NotificationPreference, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	channels, err := json.Marshal(preference.Channels)
+	if err != nil {
+		return nil, err
+	}
+	err = q.UpsertNotificationPreference(ctx, sqlc.UpsertNotificationPreferenceParams{
+		TenantID:  tenantID.String(),
+		UserID:    uuid.Bytes(preference.UserID),
+		Channels:  channels,
+		UpdatedAt: preference.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return preference, nil
+}
+
+func (r *NotificationPreferenceMySQLRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*model.NotificationPreference, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	preference, err := q.GetNotificationPreference(ctx, sqlc.GetNotificationPreferenceParams{TenantID: tenantID.String(), UserID: uuid.Bytes(userID)})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	channels := map[model.NotificationChannel]bool{}
+	if err := json.Unmarshal(preference.Channels, &channels); err != nil {
+		return nil, err
+	}
+	return &model.NotificationPreference{
+		UserID:    uuid.MustFromBytes(preference.UserID),
+		Channels:  channels,
+		UpdatedAt: preference.UpdatedAt,
+	}, nil
+}
+
+// NotificationDeliveryMySQLRepository scopes every query to the tenant of the context, and fails without one.
+type NotificationDeliveryMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewNotificationDeliveryMySQLRepository(db *sql.DB) *NotificationDeliveryMySQLRepository {
+	return &NotificationDeliveryMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *NotificationDeliveryMySQLRepository) Save(ctx context.Context, delivery *model.NotificationDelivery) (*model.NotificationDelivery, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	err = q.UpsertNotificationDelivery(ctx, sqlc.UpsertNotificationDeliveryParams{
+		TenantID:       tenantID.String(),
+		NotificationID: uuid.Bytes(delivery.NotificationID),
+		Channel:        string(delivery.Channel),
+		Attempts:       int32(delivery.Attempts),
+		LastError:      truncate(delivery.LastError, 1000),
+		DeliveredAt:    toNullTime(delivery.DeliveredAt),
+		UpdatedAt:      delivery.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (r *NotificationDeliveryMySQLRepository) FindByNotificationIDAndChannel(ctx context.Context, notificationID uuid.UUID, channel model.NotificationChannel) (*model.NotificationDelivery, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	delivery, err := q.GetNotificationDelivery(ctx, sqlc.GetNotificationDeliveryParams{
+		TenantID:       tenantID.String(),
+		NotificationID: uuid.Bytes(notificationID),
+		Channel:        string(channel),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &model.NotificationDelivery{
+		NotificationID: uuid.MustFromBytes(delivery.NotificationID),
+		Channel:        model.NotificationChannel(delivery.Channel),
+		Attempts:       int(delivery.Attempts),
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt.Time,
+		UpdatedAt:      delivery.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/sqlc"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type OutboxMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewOutboxMySQLRepository(db *sql.DB) *OutboxMySQLRepository {
+	return &OutboxMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *OutboxMySQLRepository) Save(ctx context.Context, message *model.OutboxMessage) (*model.OutboxMessage, error) {
+	q := transaction.GetQueries(ctx, r.queries)
+	exists, err := q.ExistsOutboxMessage(ctx, uuid.Bytes(message.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		err = q.UpdateOutboxMessage(ctx, sqlc.UpdateOutboxMessageParams{
+			Attempts:  int32(message.Attempts),
+			LastError: truncate(message.LastError, 1000),
+			SentAt:    toNullTime(message.SentAt),
+			ID:        uuid.Bytes(message.ID),
+		})
+	} else {
+		// The relay runs outside of any tenant, so the message keeps the tenant it was written in
+		var tenantID tenant.ID
+		if tenantID, err = tenant.FromContext(ctx); err != nil {
+			return nil, err
+		}
+		attributes := model.MessageAttributes{tenant.MessageAttribute: tenantID.String()}
+		for k, v := range message.Attributes {
+			attributes[k] = v
+		}
+		message.Attributes = attributes
+
+		var data []byte
+		if data, err = json.Marshal(message.Attributes); err != nil {
+			return nil, err
+		}
+		err = q.CreateOutboxMessage(ctx, sqlc.CreateOutboxMessageParams{
+			ID:          uuid.Bytes(message.ID),
+			Destination: string(message.Destination),
+			Body:        message.Body,
+			Attributes:  data,
+			Attempts:    int32(message.Attempts),
+			LastError:   truncate(message.LastError, 1000),
+			CreatedAt:   message.CreatedAt,
+			SentAt:      toNullTime(message.SentAt),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func (r *OutboxMySQLRepository) FindUnsentForUpdate(ctx context.Context, limit int) ([]*model.OutboxMessage, error) {
+	q := transaction.GetQueries(ctx, r.queries)
+	rows, err := q.ListUnsentOutboxMessagesForUpdate(ctx, sqlc.ListUnsentOutboxMessagesForUpdateParams{
+		Attempts: model.OutboxMaxAttempts,
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]*model.OutboxMessage, 0, len(rows))
+	for _, row := range rows {
+		message, err := toOutboxMessageModel(row)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func toOutboxMessageModel(row sqlc.Outbox) (*model.OutboxMessage, error) {
+	var attributes model.MessageAttributes
+	if err := json.Unmarshal(row.Attributes, &attributes); err != nil {
+		return nil, err
+	}
+	return &model.OutboxMessage{
+		ID:          uuid.MustFromBytes(row.ID),
+		Destination: model.OutboxDestination(row.Destination),
+		Body:        row.Body,
+		Attributes:  attributes,
+		Attempts:    int(row.Attempts),
+		LastError:   row.LastError,
+		CreatedAt:   row.CreatedAt,
+		SentAt:      row.SentAt.Time,
+	}, nil
+}
+
+// truncate cuts s to at most n runes to fit the column.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
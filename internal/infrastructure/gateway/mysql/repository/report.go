@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/sqlc"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// ReportMySQLRepository scopes every query to the tenant of the context, and fails without one.
+type ReportMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewReportMySQLRepository(db *sql.DB) *ReportMySQLRepository {
+	return &ReportMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *ReportMySQLRepository) Save(ctx context.Context, report *model.Report) (*model.Report, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	exists, err := q.ExistsReport(ctx, sqlc.ExistsReportParams{TenantID: tenantID.String(), ID: uuid.Bytes(report.ID)})
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		err = q.UpdateReport(ctx, sqlc.UpdateReportParams{
+			Status:         string(report.Status),
+			AssigneeID:     toNullUUID(report.AssigneeID),
+			Resolution:     toNullString(string(report.Resolution)),
+			ResolutionNote: report.ResolutionNote,
+			ResolvedAt:     toNullTime(report.ResolvedAt),
+			UpdatedAt:      report.UpdatedAt,
+			TenantID:       tenantID.String(),
+			ID:             uuid.Bytes(report.ID),
+		})
+	} else {
+		err = q.CreateReport(ctx, sqlc.CreateReportParams{
+			ID:             uuid.Bytes(report.ID),
+			TenantID:       tenantID.String(),
+			ReporterID:     uuid.Bytes(report.ReporterID),
+			ReportedID:     uuid.Bytes(report.ReportedID),
+			Reason:         string(report.Reason),
+			Comment:        report.Comment,
+			Status:         string(report.Status),
+			AssigneeID:     toNullUUID(report.AssigneeID),
+			Resolution:     toNullString(string(report.Resolution)),
+			ResolutionNote: report.ResolutionNote,
+			ResolvedAt:     toNullTime(report.ResolvedAt),
+			CreatedAt:      report.CreatedAt,
+			UpdatedAt:      report.UpdatedAt,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (r *ReportMySQLRepository) FindById(ctx context.Context, id uuid.UUID) (*model.Report, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	report, err := q.GetReport(ctx, sqlc.GetReportParams{TenantID: tenantID.String(), ID: uuid.Bytes(id)})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toReportModel(report), nil
+}
+
+func (r *ReportMySQLRepository) FindByIdForUpdate(ctx context.Context, id uuid.UUID) (*model.Report, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	report, err := q.GetReportForUpdate(ctx, sqlc.GetReportForUpdateParams{TenantID: tenantID.String(), ID: uuid.Bytes(id)})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toReportModel(report), nil
+}
+
+func (r *ReportMySQLRepository) FindAll(ctx context.Context, filter repository.ReportFilter) ([]*model.Report, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	reports, err := q.ListReports(ctx, sqlc.ListReportsParams{
+		TenantID:   tenantID.String(),
+		Status:     toNullString(string(filter.Status)),
+		AssigneeID: toNullUUID(filter.AssigneeID),
+		Limit:      int32(filter.Limit),
+		Offset:     int32(filter.Offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Report, len(reports))
+	for i, report := range reports {
+		result[i] = toReportModel(report)
+	}
+	return result, nil
+}
+
+func toReportModel(report sqlc.Report) *model.Report {
+	return &model.Report{
+		ID:             uuid.MustFromBytes(report.ID),
+		ReporterID:     uuid.MustFromBytes(report.ReporterID),
+		ReportedID:     uuid.MustFromBytes(report.ReportedID),
+		Reason:         model.ReportReason(report.Reason),
+		Comment:        report.Comment,
+		Status:         model.ReportStatus(report.Status),
+		AssigneeID:     fromNullUUID(report.AssigneeID),
+		Resolution:     model.ReportResolution(report.Resolution.String),
+		ResolutionNote: report.ResolutionNote,
+		ResolvedAt:     report.ResolvedAt.Time,
+		CreatedAt:      report.CreatedAt,
+		UpdatedAt:      report.UpdatedAt,
+	}
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// toNullUUID returns the bytes of a nullable UUID column, which is NULL for the nil UUID.
+func toNullUUID(id uuid.UUID) []byte {
+	if id == uuid.Nil() {
+		return nil
+	}
+	return uuid.Bytes(id)
+}
+
+// fromNullUUID returns the UUID of a nullable UUID column, which is the nil UUID for NULL.
+func fromNullUUID(b []byte) uuid.UUID {
+	if b == nil {
+		return uuid.Nil()
+	}
+	return uuid.MustFromBytes(b)
+}
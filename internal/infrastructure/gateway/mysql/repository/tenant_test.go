@@ -0,0 +1,386 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/testhelper"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// tenantFixture is a pair of users with an accepted matching in the default tenant,
+// which the tests try to reach from another tenant.
+type tenantFixture struct {
+	ctx      context.Context
+	otherCtx context.Context
+	db       *sql.DB
+	user1    *model.User
+	user2    *model.User
+	matching *model.Matching
+}
+
+func setupTenantFixture(t *testing.T) *tenantFixture {
+	t.Helper()
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	t.Cleanup(func() { testhelper.Cleanup(ctx, gw) })
+
+	f := &tenantFixture{
+		ctx:      ctx,
+		otherCtx: tenant.WithID(context.Background(), "acme"),
+		db:       gw.MySQLClient,
+	}
+	userRepo := NewUserMySQLRepository(f.db)
+	now := time.Now()
+	for _, user := range []**model.User{&f.user1, &f.user2} {
+		id := uuid.New()
+		if *user, err = userRepo.Save(ctx, model.NewUser(model.InputUserParams{ID: id, Email: id.String() + "@example.com"}, now)); err != nil {
+			t.Fatalf("Failed to create test user: %v", err)
+		}
+	}
+	matching := model.NewMatching(model.InputMatchingParams{
+		MeID:      f.user1.ID,
+		PartnerID: f.user2.ID,
+		Status:    string(model.MatchingStatusAccepted),
+	}, now)
+	if f.matching, err = NewMatchingMySQLRepository(f.db).Save(ctx, matching); err != nil {
+		t.Fatalf("Failed to create test matching: %v", err)
+	}
+	return f
+}
+
+func TestMatchingHistoryMySQLRepository_Tenant(t *testing.T) {
+	f := setupTenantFixture(t)
+	repo := NewMatchingHistoryMySQLRepository(f.db)
+	if _, err := repo.Save(f.ctx, model.NewMatchingCreatedHistory(f.matching)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := repo.FindAllByMatchingID(f.otherCtx, f.matching.ID)
+	if err != nil {
+		t.Fatalf("FindAllByMatchingID() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("FindAllByMatchingID() got %d histories, want none", len(got))
+	}
+	if _, err := repo.FindAllByMatchingID(context.Background(), f.matching.ID); err == nil {
+		t.Error("FindAllByMatchingID() without a tenant error = nil, want an error")
+	}
+}
+
+func TestUserBlockMySQLRepository_Tenant(t *testing.T) {
+	f := setupTenantFixture(t)
+	repo := NewUserBlockMySQLRepository(f.db)
+	if _, err := repo.Save(f.ctx, model.NewUserBlock(model.InputUserBlockParams{BlockerID: f.user1.ID, BlockedID: f.user2.ID}, time.Now())); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if exists, err := repo.Exists(f.otherCtx, f.user1.ID, f.user2.ID); err != nil || exists {
+		t.Errorf("Exists() got = %v, error = %v, want false", exists, err)
+	}
+	if got, err := repo.FindAllBetween(f.otherCtx, f.user1.ID, f.user2.ID); err != nil || len(got) != 0 {
+		t.Errorf("FindAllBetween() got %d blocks, error = %v, want none", len(got), err)
+	}
+	if got, err := repo.FindAllByBlocker(f.otherCtx, f.user1.ID, 10, 0); err != nil || len(got) != 0 {
+		t.Errorf("FindAllByBlocker() got %d blocks, error = %v, want none", len(got), err)
+	}
+	if removed, err := repo.Remove(f.otherCtx, f.user1.ID, f.user2.ID); err != nil || removed {
+		t.Errorf("Remove() got = %v, error = %v, want false", removed, err)
+	}
+	if exists, err := repo.Exists(f.ctx, f.user1.ID, f.user2.ID); err != nil || !exists {
+		t.Errorf("Exists() in own tenant got = %v, error = %v, want true", exists, err)
+	}
+	if _, err := repo.Exists(context.Background(), f.user1.ID, f.user2.ID); err == nil {
+		t.Error("Exists() without a tenant error = nil, want an error")
+	}
+}
+
+func TestReportMySQLRepository_Tenant(t *testing.T) {
+	f := setupTenantFixture(t)
+	repo := NewReportMySQLRepository(f.db)
+	report := model.NewReport(model.InputReportParams{
+		ReporterID: f.user1.ID,
+		ReportedID: f.user2.ID,
+		Reason:     string(model.ReportReasonSpam),
+	}, time.Now())
+	if _, err := repo.Save(f.ctx, report); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if got, err := repo.FindById(f.otherCtx, report.ID); err != nil || got != nil {
+		t.Errorf("FindById() got = %v, error = %v, want nil", got, err)
+	}
+	if got, err := repo.FindByIdForUpdate(f.otherCtx, report.ID); err != nil || got != nil {
+		t.Errorf("FindByIdForUpdate() got = %v, error = %v, want nil", got, err)
+	}
+	if got, err := repo.FindAll(f.otherCtx, repository.ReportFilter{Limit: 10}); err != nil || len(got) != 0 {
+		t.Errorf("FindAll() got %d reports, error = %v, want none", len(got), err)
+	}
+	// The report is not found in the other tenant, so saving it there collides with the existing ID
+	if _, err := repo.Save(f.otherCtx, report); err == nil {
+		t.Error("Save() in another tenant error = nil, want an error")
+	}
+	if _, err := repo.FindAll(context.Background(), repository.ReportFilter{Limit: 10}); err == nil {
+		t.Error("FindAll() without a tenant error = nil, want an error")
+	}
+}
+
+func TestEntitlementMySQLRepository_Tenant(t *testing.T) {
+	f := setupTenantFixture(t)
+	limits := model.PlanLimits{model.PlanFree: 1, model.PlanPremium: 10}
+	repo := NewEntitlementMySQLRepository(f.db, limits, clock.New())
+	if err := repo.SavePlan(f.ctx, f.user1.ID, model.PlanPremium); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+
+	if got, err := repo.FindByUserID(f.otherCtx, f.user1.ID); err != nil || got.Plan != model.PlanFree {
+		t.Errorf("FindByUserID() got = %v, error = %v, want the free plan", got, err)
+	}
+	// The upsert leaves the row of another tenant as it is
+	if err := repo.SavePlan(f.otherCtx, f.user1.ID, model.PlanFree); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+	if got, err := repo.FindByUserID(f.ctx, f.user1.ID); err != nil || got.Plan != model.PlanPremium {
+		t.Errorf("FindByUserID() in own tenant got = %v, error = %v, want the premium plan", got, err)
+	}
+	if err := repo.SavePlan(context.Background(), f.user1.ID, model.PlanFree); err == nil {
+		t.Error("SavePlan() without a tenant error = nil, want an error")
+	}
+}
+
+func TestMatchingQuotaUsageMySQLRepository_Tenant(t *testing.T) {
+	f := setupTenantFixture(t)
+	repo := NewMatchingQuotaUsageMySQLRepository(f.db, clock.New())
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	if err := repo.Increment(f.ctx, f.user1.ID, day); err != nil {
+		t.Fatalf("Increment() error = %v", err)
+	}
+
+	if got, err := repo.Count(f.otherCtx, f.user1.ID, day); err != nil || got != 0 {
+		t.Errorf("Count() got = %d, error = %v, want 0", got, err)
+	}
+	if err := repo.Increment(f.otherCtx, f.user1.ID, day); err != nil {
+		t.Fatalf("Increment() error = %v", err)
+	}
+	if got, err := repo.Count(f.ctx, f.user1.ID, day); err != nil || got != 1 {
+		t.Errorf("Count() in own tenant got = %d, error = %v, want 1", got, err)
+	}
+	if _, err := repo.Count(context.Background(), f.user1.ID, day); err == nil {
+		t.Error("Count() without a tenant error = nil, want an error")
+	}
+}
+
+func TestEmailVerificationMySQLRepository_Tenant(t *testing.T) {
+	f := setupTenantFixture(t)
+	repo := NewEmailVerificationMySQLRepository(f.db)
+	now := time.Now()
+	verification := model.NewEmailVerification(f.user1, time.Hour, now)
+	if _, err := repo.Save(f.ctx, verification); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if got, err := repo.FindByIdForUpdate(f.otherCtx, verification.ID); err != nil || got != nil {
+		t.Errorf("FindByIdForUpdate() got = %v, error = %v, want nil", got, err)
+	}
+	if got, err := repo.CountByUserIdSinceForUpdate(f.otherCtx, f.user1.ID, now.Add(-time.Hour)); err != nil || got != 0 {
+		t.Errorf("CountByUserIdSinceForUpdate() got = %d, error = %v, want 0", got, err)
+	}
+	if _, err := repo.FindByIdForUpdate(context.Background(), verification.ID); err == nil {
+		t.Error("FindByIdForUpdate() without a tenant error = nil, want an error")
+	}
+}
+
+func TestEmailChangeMySQLRepository_Tenant(t *testing.T) {
+	f := setupTenantFixture(t)
+	repo := NewEmailChangeMySQLRepository(f.db)
+	change := model.NewEmailChange(f.user1, "new-"+f.user1.Email, time.Hour, 24*time.Hour, time.Now())
+	if _, err := repo.Save(f.ctx, change); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if got, err := repo.FindByIdForUpdate(f.otherCtx, change.ID); err != nil || got != nil {
+		t.Errorf("FindByIdForUpdate() got = %v, error = %v, want nil", got, err)
+	}
+	if _, err := repo.FindByIdForUpdate(context.Background(), change.ID); err == nil {
+		t.Error("FindByIdForUpdate() without a tenant error = nil, want an error")
+	}
+}
+
+func TestAuditLogMySQLRepository_Tenant(t *testing.T) {
+	f := setupTenantFixture(t)
+	repo := NewAuditLogMySQLRepository(f.db)
+	log := model.NewAuditLog(model.InputAuditLogParams{
+		ActorID:    f.user1.ID,
+		Action:     "user.update",
+		TargetType: model.AuditTargetUser,
+		TargetID:   f.user1.ID.String(),
+		Source:     model.AuditSourceHTTP,
+	}, time.Now())
+	if _, err := repo.Save(f.ctx, log); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if got, err := repo.Search(f.otherCtx, repository.AuditLogFilter{Limit: 10}); err != nil || len(got) != 0 {
+		t.Errorf("Search() got %d logs, error = %v, want none", len(got), err)
+	}
+	if got, err := repo.Search(f.ctx, repository.AuditLogFilter{Limit: 10}); err != nil || len(got) != 1 {
+		t.Errorf("Search() in own tenant got %d logs, error = %v, want 1", len(got), err)
+	}
+	if _, err := repo.Search(context.Background(), repository.AuditLogFilter{Limit: 10}); err == nil {
+		t.Error("Search() without a tenant error = nil, want an error")
+	}
+}
+
+func TestConversationMySQLRepository_Tenant(t *testing.T) {
+	f := setupTenantFixture(t)
+	repo := NewConversationMySQLRepository(f.db)
+	conversation, err := model.NewConversation(f.matching, time.Now())
+	if err != nil {
+		t.Fatalf("NewConversation() error = %v", err)
+	}
+	if _, err := repo.Save(f.ctx, conversation); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if got, err := repo.FindByMatchingID(f.otherCtx, f.matching.ID); err != nil || got != nil {
+		t.Errorf("FindByMatchingID() got = %v, error = %v, want nil", got, err)
+	}
+	if _, err := repo.Save(f.otherCtx, conversation); err == nil {
+		t.Error("Save() in another tenant error = nil, want an error")
+	}
+	if _, err := repo.FindByMatchingID(context.Background(), f.matching.ID); err == nil {
+		t.Error("FindByMatchingID() without a tenant error = nil, want an error")
+	}
+}
+
+func TestChatMessageMySQLRepository_Tenant(t *testing.T) {
+	f := setupTenantFixture(t)
+	conversation, err := model.NewConversation(f.matching, time.Now())
+	if err != nil {
+		t.Fatalf("NewConversation() error = %v", err)
+	}
+	if _, err := NewConversationMySQLRepository(f.db).Save(f.ctx, conversation); err != nil {
+		t.Fatalf("Failed to create test conversation: %v", err)
+	}
+	repo := NewChatMessageMySQLRepository(f.db)
+	message := model.NewChatMessage(model.InputChatMessageParams{
+		ConversationID: conversation.ID,
+		SenderID:       f.user1.ID,
+		Body:           "Hello",
+	}, time.Now())
+	if _, err := repo.Save(f.ctx, message); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if got, err := repo.FindById(f.otherCtx, message.ID); err != nil || got != nil {
+		t.Errorf("FindById() got = %v, error = %v, want nil", got, err)
+	}
+	if got, err := repo.FindAllByConversationID(f.otherCtx, conversation.ID, nil, 10); err != nil || len(got) != 0 {
+		t.Errorf("FindAllByConversationID() got %d messages, error = %v, want none", len(got), err)
+	}
+	if got, err := repo.MarkRead(f.otherCtx, conversation.ID, f.user1.ID, time.Now(), time.Now()); err != nil || got != 0 {
+		t.Errorf("MarkRead() got = %d, error = %v, want 0", got, err)
+	}
+	if _, err := repo.FindById(context.Background(), message.ID); err == nil {
+		t.Error("FindById() without a tenant error = nil, want an error")
+	}
+}
+
+func TestNotificationMySQLRepository_Tenant(t *testing.T) {
+	f := setupTenantFixture(t)
+	repo := NewNotificationMySQLRepository(f.db)
+	notification, err := model.NewNotification(uuid.New(), f.user1.ID, model.NotificationTypeMatchingCreated, nil, time.Now())
+	if err != nil {
+		t.Fatalf("NewNotification() error = %v", err)
+	}
+	if _, err := repo.Save(f.ctx, notification); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if got, err := repo.FindById(f.otherCtx, notification.ID); err != nil || got != nil {
+		t.Errorf("FindById() got = %v, error = %v, want nil", got, err)
+	}
+	if got, err := repo.FindAllByUserID(f.otherCtx, f.user1.ID, false, 10, 0); err != nil || len(got) != 0 {
+		t.Errorf("FindAllByUserID() got %d notifications, error = %v, want none", len(got), err)
+	}
+	if got, err := repo.CountUnreadByUserID(f.otherCtx, f.user1.ID); err != nil || got != 0 {
+		t.Errorf("CountUnreadByUserID() got = %d, error = %v, want 0", got, err)
+	}
+	if got, err := repo.MarkRead(f.otherCtx, f.user1.ID, uuid.Nil(), time.Now()); err != nil || got != 0 {
+		t.Errorf("MarkRead() got = %d, error = %v, want 0", got, err)
+	}
+	if got, err := repo.CountUnreadByUserID(f.ctx, f.user1.ID); err != nil || got != 1 {
+		t.Errorf("CountUnreadByUserID() in own tenant got = %d, error = %v, want 1", got, err)
+	}
+	if _, err := repo.FindById(context.Background(), notification.ID); err == nil {
+		t.Error("FindById() without a tenant error = nil, want an error")
+	}
+}
+
+func TestNotificationPreferenceMySQLRepository_Tenant(t *testing.T) {
+	f := setupTenantFixture(t)
+	repo := NewNotificationPreferenceMySQLRepository(f.db)
+	preference := model.NewNotificationPreference(f.user1.ID, time.Now())
+	if err := preference.Set(map[model.NotificationChannel]bool{model.NotificationChannelEmail: false}, time.Now()); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if _, err := repo.Save(f.ctx, preference); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if got, err := repo.FindByUserID(f.otherCtx, f.user1.ID); err != nil || got != nil {
+		t.Errorf("FindByUserID() got = %v, error = %v, want nil", got, err)
+	}
+	// The upsert leaves the row of another tenant as it is
+	if _, err := repo.Save(f.otherCtx, model.NewNotificationPreference(f.user1.ID, time.Now())); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := repo.FindByUserID(f.ctx, f.user1.ID)
+	if err != nil || got == nil {
+		t.Fatalf("FindByUserID() in own tenant got = %v, error = %v", got, err)
+	}
+	if got.Channels[model.NotificationChannelEmail] {
+		t.Errorf("FindByUserID() in own tenant got channels = %v, want the email channel off", got.Channels)
+	}
+	if _, err := repo.FindByUserID(context.Background(), f.user1.ID); err == nil {
+		t.Error("FindByUserID() without a tenant error = nil, want an error")
+	}
+}
+
+func TestNotificationDeliveryMySQLRepository_Tenant(t *testing.T) {
+	f := setupTenantFixture(t)
+	repo := NewNotificationDeliveryMySQLRepository(f.db)
+	now := time.Now()
+	delivery := model.NewNotificationDelivery(uuid.New(), model.NotificationChannelEmail, now)
+	delivery.MarkDelivered(now)
+	if _, err := repo.Save(f.ctx, delivery); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if got, err := repo.FindByNotificationIDAndChannel(f.otherCtx, delivery.NotificationID, delivery.Channel); err != nil || got != nil {
+		t.Errorf("FindByNotificationIDAndChannel() got = %v, error = %v, want nil", got, err)
+	}
+	// The upsert leaves the row of another tenant as it is
+	if _, err := repo.Save(f.otherCtx, model.NewNotificationDelivery(delivery.NotificationID, delivery.Channel, now)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := repo.FindByNotificationIDAndChannel(f.ctx, delivery.NotificationID, delivery.Channel)
+	if err != nil || got == nil {
+		t.Fatalf("FindByNotificationIDAndChannel() in own tenant got = %v, error = %v", got, err)
+	}
+	if !got.IsDelivered() {
+		t.Errorf("FindByNotificationIDAndChannel() in own tenant got = %+v, want delivered", got)
+	}
+	if _, err := repo.FindByNotificationIDAndChannel(context.Background(), delivery.NotificationID, delivery.Channel); err == nil {
+		t.Error("FindByNotificationIDAndChannel() without a tenant error = nil, want an error")
+	}
+}
@@ -3,14 +3,18 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/sqlc"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
 )
 
+// UserMySQLRepository scopes every query to the tenant of the context, and fails without one.
 type UserMySQLRepository struct {
 	db      *sql.DB
 	queries *sqlc.Queries
@@ -24,38 +28,83 @@ func NewUserMySQLRepository(db *sql.DB) *UserMySQLRepository {
 }
 
 func (r *UserMySQLRepository) Save(ctx context.Context, user *model.User) (*model.User, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	q := transaction.GetQueries(ctx, r.queries)
-	exists, err := q.ExistsUser(ctx, user.ID.String())
+	exists, err := q.ExistsUser(ctx, sqlc.ExistsUserParams{TenantID: tenantID.String(), ID: uuid.Bytes(user.ID)})
 	if err != nil {
 		return nil, err
 	}
 
 	if exists {
 		_, err = q.UpdateUser(ctx, sqlc.UpdateUserParams{
-			Email:     user.Email,
-			UpdatedAt: time.Now(),
-			ID:        user.ID.String(),
+			Email:              user.Email,
+			PendingEmail:       user.PendingEmail,
+			DisplayName:        user.DisplayName,
+			Birthdate:          toNullTime(user.Birthdate),
+			Gender:             string(user.Gender),
+			Bio:                user.Bio,
+			Locale:             user.Locale,
+			Interests:          joinInterests(user.Interests),
+			Status:             string(user.Status),
+			EmailVerifiedAt:    toNullTime(user.EmailVerifiedAt),
+			UpdatedAt:          user.UpdatedAt,
+			DeletedAt:          toNullTime(user.DeletedAt),
+			DeletionEnqueuedAt: toNullTime(user.DeletionEnqueuedAt),
+			TenantID:           tenantID.String(),
+			ID:                 uuid.Bytes(user.ID),
 		})
 	} else {
 		_, err = q.CreateUser(ctx, sqlc.CreateUserParams{
-			ID:        user.ID.String(),
-			Email:     user.Email,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+			ID:                 uuid.Bytes(user.ID),
+			TenantID:           tenantID.String(),
+			Email:              user.Email,
+			PendingEmail:       user.PendingEmail,
+			DisplayName:        user.DisplayName,
+			Birthdate:          toNullTime(user.Birthdate),
+			Gender:             string(user.Gender),
+			Bio:                user.Bio,
+			Locale:             user.Locale,
+			Interests:          joinInterests(user.Interests),
+			Status:             string(user.Status),
+			EmailVerifiedAt:    toNullTime(user.EmailVerifiedAt),
+			CreatedAt:          user.CreatedAt,
+			UpdatedAt:          user.UpdatedAt,
+			DeletedAt:          toNullTime(user.DeletedAt),
+			DeletionEnqueuedAt: toNullTime(user.DeletionEnqueuedAt),
 		})
 	}
 
 	if err != nil {
+		if isDuplicateEntry(err) {
+			return nil, repository.ErrUserEmailAlreadyExists
+		}
 		return nil, err
 	}
 	return user, nil
 }
 
+func (r *UserMySQLRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	return q.ExistsUserByEmail(ctx, sqlc.ExistsUserByEmailParams{TenantID: tenantID.String(), Email: email})
+}
+
 func (r *UserMySQLRepository) FindAll(ctx context.Context, limit, offset int) ([]*model.User, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	q := transaction.GetQueries(ctx, r.queries)
 	users, err := q.ListUsers(ctx, sqlc.ListUsersParams{
-		Limit:  int32(limit),
-		Offset: int32(offset),
+		TenantID: tenantID.String(),
+		Limit:    int32(limit),
+		Offset:   int32(offset),
 	})
 	if err != nil {
 		return nil, err
@@ -63,19 +112,44 @@ func (r *UserMySQLRepository) FindAll(ctx context.Context, limit, offset int) ([
 
 	result := make([]*model.User, len(users))
 	for i, user := range users {
-		result[i] = &model.User{
-			ID:        uuid.MustParse(user.ID),
-			Email:     user.Email,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-		}
+		result[i] = toUserModel(user)
+	}
+	return result, nil
+}
+
+func (r *UserMySQLRepository) FindAllAfter(ctx context.Context, cursor *model.UserCursor, limit int) ([]*model.User, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	params := sqlc.ListUsersAfterParams{
+		TenantID: tenantID.String(),
+		Limit:    int32(limit),
+	}
+	if cursor != nil {
+		params.CursorCreatedAt = toNullTime(cursor.CreatedAt)
+		params.CursorID = toNullUUID(cursor.ID)
+	}
+	users, err := q.ListUsersAfter(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.User, len(users))
+	for i, user := range users {
+		result[i] = toUserModel(user)
 	}
 	return result, nil
 }
 
 func (r *UserMySQLRepository) FindById(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	q := transaction.GetQueries(ctx, r.queries)
-	user, err := q.GetUser(ctx, id.String())
+	user, err := q.GetUser(ctx, sqlc.GetUserParams{TenantID: tenantID.String(), ID: uuid.Bytes(id)})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -83,20 +157,188 @@ func (r *UserMySQLRepository) FindById(ctx context.Context, id uuid.UUID) (*mode
 		return nil, err
 	}
 
-	return &model.User{
-		ID:        uuid.MustParse(user.ID),
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	return toUserModel(user), nil
 }
 
-func (r *UserMySQLRepository) Remove(ctx context.Context, id uuid.UUID) (*uuid.UUID, error) {
+// FindByIdWithDeleted also returns the user while withdrawn, unlike FindById.
+func (r *UserMySQLRepository) FindByIdWithDeleted(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	user, err := q.GetUserWithDeleted(ctx, sqlc.GetUserWithDeletedParams{TenantID: tenantID.String(), ID: uuid.Bytes(id)})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toUserModel(user), nil
+}
+
+func (r *UserMySQLRepository) FindByIdWithDeletedForUpdate(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	user, err := q.GetUserWithDeletedForUpdate(ctx, sqlc.GetUserWithDeletedForUpdateParams{TenantID: tenantID.String(), ID: uuid.Bytes(id)})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toUserModel(user), nil
+}
+
+func (r *UserMySQLRepository) FindAllDeletedBefore(ctx context.Context, before, enqueuedBefore time.Time, limit int) ([]*model.User, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	q := transaction.GetQueries(ctx, r.queries)
-	err := q.DeleteUser(ctx, id.String())
+	users, err := q.ListUsersDeletedBefore(ctx, sqlc.ListUsersDeletedBeforeParams{
+		TenantID:       tenantID.String(),
+		DeletedBefore:  toNullTime(before),
+		EnqueuedBefore: toNullTime(enqueuedBefore),
+		Limit:          int32(limit),
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	result := make([]*model.User, len(users))
+	for i, user := range users {
+		result[i] = toUserModel(user)
+	}
+	return result, nil
+}
+
+func (r *UserMySQLRepository) FindByIds(ctx context.Context, ids []uuid.UUID) ([]*model.User, error) {
+	if len(ids) == 0 {
+		return []*model.User{}, nil
+	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	params := make([][]byte, len(ids))
+	for i, id := range ids {
+		params[i] = uuid.Bytes(id)
+	}
+	users, err := q.ListUsersByIDs(ctx, sqlc.ListUsersByIDsParams{TenantID: tenantID.String(), Ids: params})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.User, len(users))
+	for i, user := range users {
+		result[i] = toUserModel(user)
+	}
+	return result, nil
+}
+
+func (r *UserMySQLRepository) FindAllRecommendationCandidates(ctx context.Context, userID uuid.UUID, limit int) ([]*model.User, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	users, err := q.ListRecommendationCandidates(ctx, sqlc.ListRecommendationCandidatesParams{
+		TenantID: tenantID.String(),
+		UserID:   uuid.Bytes(userID),
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.User, len(users))
+	for i, user := range users {
+		result[i] = toUserModel(user)
+	}
+	return result, nil
+}
+
+func (r *UserMySQLRepository) FindRecommendationCandidatesByIds(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) ([]*model.User, error) {
+	if len(ids) == 0 {
+		return []*model.User{}, nil
+	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	params := make([][]byte, len(ids))
+	for i, id := range ids {
+		params[i] = uuid.Bytes(id)
+	}
+	users, err := q.ListRecommendationCandidatesByIDs(ctx, sqlc.ListRecommendationCandidatesByIDsParams{
+		TenantID: tenantID.String(),
+		Ids:      params,
+		UserID:   uuid.Bytes(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.User, len(users))
+	for i, user := range users {
+		result[i] = toUserModel(user)
+	}
+	return result, nil
+}
+
+func (r *UserMySQLRepository) Remove(ctx context.Context, id uuid.UUID) (*uuid.UUID, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	if err := q.DeleteUser(ctx, sqlc.DeleteUserParams{TenantID: tenantID.String(), ID: uuid.Bytes(id)}); err != nil {
+		return nil, err
+	}
+
 	return &id, nil
 }
+
+func toUserModel(user sqlc.User) *model.User {
+	return &model.User{
+		ID:                 uuid.MustFromBytes(user.ID),
+		Email:              user.Email,
+		PendingEmail:       user.PendingEmail,
+		DisplayName:        user.DisplayName,
+		Birthdate:          user.Birthdate.Time,
+		Gender:             model.UserGender(user.Gender),
+		Bio:                user.Bio,
+		Locale:             user.Locale,
+		Interests:          splitInterests(user.Interests),
+		Status:             model.UserStatus(user.Status),
+		EmailVerifiedAt:    user.EmailVerifiedAt.Time,
+		CreatedAt:          user.CreatedAt,
+		UpdatedAt:          user.UpdatedAt,
+		DeletedAt:          user.DeletedAt.Time,
+		DeletionEnqueuedAt: user.DeletionEnqueuedAt.Time,
+	}
+}
+
+// joinInterests stores the interests as comma separated tags. A normalized interest never contains a comma.
+func joinInterests(interests []string) string {
+	return strings.Join(interests, ",")
+}
+
+func splitInterests(interests string) []string {
+	if interests == "" {
+		return nil
+	}
+	return strings.Split(interests, ",")
+}
+
+func toNullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
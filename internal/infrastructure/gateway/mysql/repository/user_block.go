@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/sqlc"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// UserBlockMySQLRepository scopes every query to the tenant of the context, and fails without one.
+type UserBlockMySQLRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewUserBlockMySQLRepository(db *sql.DB) *UserBlockMySQLRepository {
+	return &UserBlockMySQLRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *UserBlockMySQLRepository) Save(ctx context.Context, block *model.UserBlock) (*model.UserBlock, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	err = q.CreateUserBlock(ctx, sqlc.CreateUserBlockParams{
+		TenantID:  tenantID.String(),
+		BlockerID: uuid.Bytes(block.BlockerID),
+		BlockedID: uuid.Bytes(block.BlockedID),
+		CreatedAt: block.CreatedAt,
+	})
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return nil, repository.ErrUserBlockAlreadyExists
+		}
+		return nil, err
+	}
+	return block, nil
+}
+
+func (r *UserBlockMySQLRepository) Exists(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	return q.ExistsUserBlock(ctx, sqlc.ExistsUserBlockParams{
+		TenantID:  tenantID.String(),
+		BlockerID: uuid.Bytes(blockerID),
+		BlockedID: uuid.Bytes(blockedID),
+	})
+}
+
+func (r *UserBlockMySQLRepository) ExistsForUpdate(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	count, err := q.CountUserBlocksForUpdate(ctx, sqlc.CountUserBlocksForUpdateParams{
+		TenantID:  tenantID.String(),
+		BlockerID: uuid.Bytes(blockerID),
+		BlockedID: uuid.Bytes(blockedID),
+	})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *UserBlockMySQLRepository) FindAllBetween(ctx context.Context, userID1, userID2 uuid.UUID) ([]*model.UserBlock, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	blocks, err := q.ListUserBlocksBetween(ctx, sqlc.ListUserBlocksBetweenParams{
+		TenantID:     tenantID.String(),
+		FirstUserID:  uuid.Bytes(userID1),
+		SecondUserID: uuid.Bytes(userID2),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.UserBlock, len(blocks))
+	for i, b := range blocks {
+		result[i] = toUserBlockModel(b)
+	}
+	return result, nil
+}
+
+func (r *UserBlockMySQLRepository) FindAllByBlocker(ctx context.Context, blockerID uuid.UUID, limit, offset int) ([]*model.UserBlock, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	blocks, err := q.ListUserBlocksByBlocker(ctx, sqlc.ListUserBlocksByBlockerParams{
+		TenantID:  tenantID.String(),
+		BlockerID: uuid.Bytes(blockerID),
+		Limit:     int32(limit),
+		Offset:    int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.UserBlock, len(blocks))
+	for i, b := range blocks {
+		result[i] = toUserBlockModel(b)
+	}
+	return result, nil
+}
+
+func (r *UserBlockMySQLRepository) Remove(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	q := transaction.GetQueries(ctx, r.queries)
+	result, err := q.DeleteUserBlock(ctx, sqlc.DeleteUserBlockParams{
+		TenantID:  tenantID.String(),
+		BlockerID: uuid.Bytes(blockerID),
+		BlockedID: uuid.Bytes(blockedID),
+	})
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func toUserBlockModel(block sqlc.UserBlock) *model.UserBlock {
+	return &model.UserBlock{
+		BlockerID: uuid.MustFromBytes(block.BlockerID),
+		BlockedID: uuid.MustFromBytes(block.BlockedID),
+		CreatedAt: block.CreatedAt,
+	}
+}
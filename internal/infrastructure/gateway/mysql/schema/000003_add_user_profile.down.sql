@@ -0,0 +1,8 @@
+ALTER TABLE `user`
+    DROP INDEX user_status_idx,
+    DROP COLUMN `status`,
+    DROP COLUMN locale,
+    DROP COLUMN bio,
+    DROP COLUMN gender,
+    DROP COLUMN birthdate,
+    DROP COLUMN display_name;
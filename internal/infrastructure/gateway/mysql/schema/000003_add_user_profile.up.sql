@@ -0,0 +1,8 @@
+ALTER TABLE `user`
+    ADD COLUMN display_name VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN birthdate DATE NULL,
+    ADD COLUMN gender VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN bio VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN locale VARCHAR(35) NOT NULL DEFAULT '',
+    ADD COLUMN `status` VARCHAR(16) NOT NULL DEFAULT 'active',
+    ADD INDEX user_status_idx (`status`);
@@ -0,0 +1,9 @@
+ALTER TABLE `matching`
+    DROP FOREIGN KEY fk_matching_me_id,
+    DROP FOREIGN KEY fk_matching_partner_id,
+    ADD CONSTRAINT matching_ibfk_1 FOREIGN KEY (me_id) REFERENCES `user`(id),
+    ADD CONSTRAINT matching_ibfk_2 FOREIGN KEY (partner_id) REFERENCES `user`(id);
+
+ALTER TABLE `user`
+    DROP INDEX user_deleted_at_idx,
+    DROP COLUMN deleted_at;
@@ -0,0 +1,10 @@
+ALTER TABLE `user`
+    ADD COLUMN deleted_at DATETIME NULL,
+    ADD INDEX user_deleted_at_idx (deleted_at);
+
+-- Matchings are removed together with the permanently deleted user
+ALTER TABLE `matching`
+    DROP FOREIGN KEY matching_ibfk_1,
+    DROP FOREIGN KEY matching_ibfk_2,
+    ADD CONSTRAINT fk_matching_me_id FOREIGN KEY (me_id) REFERENCES `user`(id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_matching_partner_id FOREIGN KEY (partner_id) REFERENCES `user`(id) ON DELETE CASCADE;
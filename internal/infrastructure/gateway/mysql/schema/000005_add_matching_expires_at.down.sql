@@ -0,0 +1,7 @@
+UPDATE `matching`
+SET `status` = 'pending'
+WHERE `status` = 'expired';
+
+ALTER TABLE `matching`
+    DROP INDEX idx_matching_status_expires_at,
+    DROP COLUMN expires_at;
@@ -0,0 +1,7 @@
+ALTER TABLE `matching`
+    ADD COLUMN expires_at DATETIME NULL,
+    ADD INDEX idx_matching_status_expires_at (`status`, expires_at);
+
+UPDATE `matching`
+SET expires_at = DATE_ADD(created_at, INTERVAL 7 DAY)
+WHERE `status` = 'pending';
@@ -0,0 +1,3 @@
+ALTER TABLE `matching`
+    DROP INDEX uq_matching_pair_key,
+    DROP COLUMN pair_key;
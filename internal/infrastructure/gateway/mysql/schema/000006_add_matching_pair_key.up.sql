@@ -0,0 +1,22 @@
+-- pair_key identifies the unordered pair of users, so that A->B and B->A share the same key
+ALTER TABLE `matching`
+    ADD COLUMN pair_key CHAR(73) NULL AFTER partner_id;
+
+UPDATE `matching`
+SET pair_key = CONCAT(LEAST(me_id, partner_id), ':', GREATEST(me_id, partner_id));
+
+-- Keep only the most advanced matching of each pair before enforcing uniqueness: an accepted one first, then a pending one,
+-- then the oldest. An accepted matching may already have a history and a conversation, so it is never the one deleted
+DELETE m1 FROM `matching` m1
+JOIN `matching` m2
+    ON m1.pair_key = m2.pair_key
+    AND (
+        (CASE m1.`status` WHEN 'accepted' THEN 0 WHEN 'pending' THEN 1 ELSE 2 END,
+            m1.created_at, m1.id)
+        > (CASE m2.`status` WHEN 'accepted' THEN 0 WHEN 'pending' THEN 1 ELSE 2 END,
+            m2.created_at, m2.id)
+    );
+
+ALTER TABLE `matching`
+    MODIFY COLUMN pair_key CHAR(73) NOT NULL,
+    ADD UNIQUE INDEX uq_matching_pair_key (pair_key);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS matching_history (
+    id CHAR(36) NOT NULL,
+    matching_id CHAR(36) NOT NULL,
+    actor_id CHAR(36) NULL,
+    action VARCHAR(16) NOT NULL,
+    from_status VARCHAR(16) NOT NULL DEFAULT '',
+    to_status VARCHAR(16) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (id),
+    INDEX idx_matching_history_matching_id_created_at (matching_id, created_at),
+    CONSTRAINT fk_matching_history_matching_id FOREIGN KEY (matching_id) REFERENCES matching(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS user_block (
+    blocker_id CHAR(36) NOT NULL,
+    blocked_id CHAR(36) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blocker_id, blocked_id),
+    INDEX idx_user_block_blocked_id (blocked_id),
+    CONSTRAINT fk_user_block_blocker_id FOREIGN KEY (blocker_id) REFERENCES user(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_block_blocked_id FOREIGN KEY (blocked_id) REFERENCES user(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS report (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    reporter_id CHAR(36) NOT NULL,
+    reported_id CHAR(36) NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    comment VARCHAR(1000) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    assignee_id CHAR(36) NULL,
+    resolution VARCHAR(16) NULL,
+    resolution_note VARCHAR(1000) NOT NULL DEFAULT '',
+    resolved_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_report_status_created_at (status, created_at),
+    INDEX idx_report_assignee_id (assignee_id),
+    INDEX idx_report_reported_id (reported_id),
+    CONSTRAINT fk_report_reporter_id FOREIGN KEY (reporter_id) REFERENCES user(id) ON DELETE CASCADE,
+    CONSTRAINT fk_report_reported_id FOREIGN KEY (reported_id) REFERENCES user(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
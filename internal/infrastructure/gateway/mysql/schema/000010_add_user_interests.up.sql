@@ -0,0 +1,3 @@
+-- Interests are stored as comma separated normalized tags, which cannot contain a comma.
+ALTER TABLE `user`
+    ADD COLUMN interests VARCHAR(1000) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS matching_quota_usage;
+DROP TABLE IF EXISTS user_plan;
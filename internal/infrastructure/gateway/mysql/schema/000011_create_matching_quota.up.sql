@@ -0,0 +1,20 @@
+-- user_plan holds the plan of the users who are not on the free plan.
+CREATE TABLE IF NOT EXISTS user_plan (
+    user_id CHAR(36) NOT NULL PRIMARY KEY,
+    plan VARCHAR(16) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_plan_user_id FOREIGN KEY (user_id) REFERENCES user(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- matching_quota_usage is the audit record of the matching requests sent per user and UTC day.
+CREATE TABLE IF NOT EXISTS matching_quota_usage (
+    user_id CHAR(36) NOT NULL,
+    day DATE NOT NULL,
+    used INT NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, day),
+    INDEX idx_matching_quota_usage_day (day),
+    CONSTRAINT fk_matching_quota_usage_user_id FOREIGN KEY (user_id) REFERENCES user(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS email_verification;
+
+ALTER TABLE `user`
+    DROP COLUMN email_verified_at;
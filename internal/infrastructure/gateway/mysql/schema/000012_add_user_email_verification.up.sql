@@ -0,0 +1,16 @@
+ALTER TABLE `user`
+    ADD COLUMN email_verified_at DATETIME NULL;
+
+-- Users registered before the verification was introduced keep matching
+UPDATE `user` SET email_verified_at = created_at;
+
+CREATE TABLE IF NOT EXISTS email_verification (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_email_verification_user_id (user_id),
+    CONSTRAINT fk_email_verification_user_id FOREIGN KEY (user_id) REFERENCES user(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
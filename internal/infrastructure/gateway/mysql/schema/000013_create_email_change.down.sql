@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS email_change;
+
+ALTER TABLE `user`
+    DROP COLUMN pending_email;
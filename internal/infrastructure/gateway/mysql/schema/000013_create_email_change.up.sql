@@ -0,0 +1,17 @@
+-- The pending email is not unique, since the unique email is only taken on confirmation
+ALTER TABLE `user`
+    ADD COLUMN pending_email VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS email_change (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    old_email VARCHAR(255) NOT NULL,
+    new_email VARCHAR(255) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    undo_expires_at DATETIME NOT NULL,
+    confirmed_at DATETIME NULL,
+    reverted_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_email_change_user_id (user_id),
+    CONSTRAINT fk_email_change_user_id FOREIGN KEY (user_id) REFERENCES user(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,12 @@
+-- Messages are written in the transaction of the state change and published to the queue by the relay
+CREATE TABLE IF NOT EXISTS outbox (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    destination VARCHAR(64) NOT NULL,
+    body TEXT NOT NULL,
+    attributes JSON NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(1000) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at DATETIME NULL,
+    INDEX idx_outbox_sent_at_created_at (sent_at, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,16 @@
+-- Audit logs are append-only: they are written in the transaction of the change, and no query updates or deletes them
+CREATE TABLE IF NOT EXISTS audit_log (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    actor_id CHAR(36) NULL,
+    action VARCHAR(64) NOT NULL,
+    target_type VARCHAR(32) NOT NULL,
+    target_id VARCHAR(73) NOT NULL,
+    diff JSON NOT NULL,
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    source VARCHAR(16) NOT NULL,
+    -- Microseconds keep the logs of a request in order
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_audit_log_actor_id_created_at (actor_id, created_at),
+    INDEX idx_audit_log_target_created_at (target_type, target_id, created_at),
+    INDEX idx_audit_log_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
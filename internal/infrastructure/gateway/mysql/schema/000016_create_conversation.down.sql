@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS chat_message;
+DROP TABLE IF EXISTS conversation;
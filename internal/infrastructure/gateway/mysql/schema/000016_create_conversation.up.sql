@@ -0,0 +1,25 @@
+-- A conversation belongs to an accepted matching, and is archived when the users unmatch
+CREATE TABLE IF NOT EXISTS conversation (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    matching_id CHAR(36) NOT NULL,
+    user1_id CHAR(36) NOT NULL,
+    user2_id CHAR(36) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    archived_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_conversation_matching_id (matching_id),
+    CONSTRAINT fk_conversation_matching_id FOREIGN KEY (matching_id) REFERENCES matching(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS chat_message (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    conversation_id CHAR(36) NOT NULL,
+    sender_id CHAR(36) NOT NULL,
+    body VARCHAR(2000) NOT NULL,
+    read_at DATETIME NULL,
+    -- Microseconds order the messages, together with the ID for the ones sent at the same time
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_chat_message_conversation_id_created_at (conversation_id, created_at, id),
+    CONSTRAINT fk_chat_message_conversation_id FOREIGN KEY (conversation_id) REFERENCES conversation(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_preference;
+DROP TABLE IF EXISTS notification;
@@ -0,0 +1,23 @@
+-- The inbox of the users, written by the in-app channel of the notification subscriber
+CREATE TABLE IF NOT EXISTS notification (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body VARCHAR(1000) NOT NULL,
+    data JSON NOT NULL,
+    read_at DATETIME NULL,
+    -- Microseconds keep the notifications of the same second in order
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_notification_user_id_created_at (user_id, created_at, id),
+    INDEX idx_notification_user_id_read_at (user_id, read_at),
+    CONSTRAINT fk_notification_user_id FOREIGN KEY (user_id) REFERENCES `user`(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- A user without a row receives notifications through every channel
+CREATE TABLE IF NOT EXISTS notification_preference (
+    user_id CHAR(36) NOT NULL PRIMARY KEY,
+    channels JSON NOT NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    CONSTRAINT fk_notification_preference_user_id FOREIGN KEY (user_id) REFERENCES `user`(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
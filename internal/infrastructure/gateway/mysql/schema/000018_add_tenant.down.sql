@@ -0,0 +1,9 @@
+ALTER TABLE `matching`
+    DROP INDEX idx_matching_tenant_id_status_expires_at,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE `user`
+    DROP INDEX idx_user_tenant_id_created_at,
+    DROP INDEX uq_user_tenant_id_email,
+    ADD UNIQUE INDEX email (email),
+    DROP COLUMN tenant_id;
@@ -0,0 +1,24 @@
+-- Every user and matching belongs to a tenant, the white-label app it was created in.
+-- The rows created before multi-tenancy belong to the default tenant.
+ALTER TABLE `user`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id;
+
+ALTER TABLE `matching`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id;
+
+-- Drop the defaults once backfilled, so that a row can never be written without its tenant.
+-- The email is unique within a tenant, since the same person can sign up to several apps.
+ALTER TABLE `user`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL,
+    DROP INDEX email,
+    ADD UNIQUE INDEX uq_user_tenant_id_email (tenant_id, email),
+    ADD INDEX idx_user_tenant_id_created_at (tenant_id, created_at);
+
+ALTER TABLE `matching`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL,
+    ADD INDEX idx_matching_tenant_id_status_expires_at (tenant_id, `status`, expires_at);
+
+-- The outbox messages waiting for the relay were written in the default tenant.
+UPDATE `outbox`
+SET attributes = JSON_SET(attributes, '$.tenantId', 'default')
+WHERE sent_at IS NULL;
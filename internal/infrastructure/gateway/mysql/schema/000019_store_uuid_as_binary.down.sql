@@ -0,0 +1,202 @@
+-- The foreign keys are dropped while both sides of them change type.
+ALTER TABLE `matching`
+    DROP FOREIGN KEY fk_matching_me_id,
+    DROP FOREIGN KEY fk_matching_partner_id;
+
+ALTER TABLE `matching_history`
+    DROP FOREIGN KEY fk_matching_history_matching_id;
+
+ALTER TABLE `user_block`
+    DROP FOREIGN KEY fk_user_block_blocker_id,
+    DROP FOREIGN KEY fk_user_block_blocked_id;
+
+ALTER TABLE `report`
+    DROP FOREIGN KEY fk_report_reporter_id,
+    DROP FOREIGN KEY fk_report_reported_id;
+
+ALTER TABLE `user_plan`
+    DROP FOREIGN KEY fk_user_plan_user_id;
+
+ALTER TABLE `matching_quota_usage`
+    DROP FOREIGN KEY fk_matching_quota_usage_user_id;
+
+ALTER TABLE `email_verification`
+    DROP FOREIGN KEY fk_email_verification_user_id;
+
+ALTER TABLE `email_change`
+    DROP FOREIGN KEY fk_email_change_user_id;
+
+ALTER TABLE `conversation`
+    DROP FOREIGN KEY fk_conversation_matching_id;
+
+ALTER TABLE `chat_message`
+    DROP FOREIGN KEY fk_chat_message_conversation_id;
+
+ALTER TABLE `notification`
+    DROP FOREIGN KEY fk_notification_user_id;
+
+ALTER TABLE `notification_preference`
+    DROP FOREIGN KEY fk_notification_preference_user_id;
+
+ALTER TABLE `user`
+    MODIFY COLUMN id VARBINARY(36) NOT NULL;
+UPDATE `user` SET id = BIN_TO_UUID(id);
+ALTER TABLE `user`
+    MODIFY COLUMN id CHAR(36) NOT NULL;
+
+ALTER TABLE `matching`
+    MODIFY COLUMN id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN me_id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN partner_id VARBINARY(36) NOT NULL;
+UPDATE `matching` SET id = BIN_TO_UUID(id), me_id = BIN_TO_UUID(me_id), partner_id = BIN_TO_UUID(partner_id);
+ALTER TABLE `matching`
+    MODIFY COLUMN id CHAR(36) NOT NULL,
+    MODIFY COLUMN me_id CHAR(36) NOT NULL,
+    MODIFY COLUMN partner_id CHAR(36) NOT NULL;
+
+ALTER TABLE `matching_history`
+    MODIFY COLUMN id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN matching_id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN actor_id VARBINARY(36) NULL;
+UPDATE `matching_history` SET id = BIN_TO_UUID(id), matching_id = BIN_TO_UUID(matching_id), actor_id = BIN_TO_UUID(actor_id);
+ALTER TABLE `matching_history`
+    MODIFY COLUMN id CHAR(36) NOT NULL,
+    MODIFY COLUMN matching_id CHAR(36) NOT NULL,
+    MODIFY COLUMN actor_id CHAR(36) NULL;
+
+ALTER TABLE `user_block`
+    MODIFY COLUMN blocker_id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN blocked_id VARBINARY(36) NOT NULL;
+UPDATE `user_block` SET blocker_id = BIN_TO_UUID(blocker_id), blocked_id = BIN_TO_UUID(blocked_id);
+ALTER TABLE `user_block`
+    MODIFY COLUMN blocker_id CHAR(36) NOT NULL,
+    MODIFY COLUMN blocked_id CHAR(36) NOT NULL;
+
+ALTER TABLE `report`
+    MODIFY COLUMN id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN reporter_id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN reported_id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN assignee_id VARBINARY(36) NULL;
+UPDATE `report` SET id = BIN_TO_UUID(id), reporter_id = BIN_TO_UUID(reporter_id), reported_id = BIN_TO_UUID(reported_id), assignee_id = BIN_TO_UUID(assignee_id);
+ALTER TABLE `report`
+    MODIFY COLUMN id CHAR(36) NOT NULL,
+    MODIFY COLUMN reporter_id CHAR(36) NOT NULL,
+    MODIFY COLUMN reported_id CHAR(36) NOT NULL,
+    MODIFY COLUMN assignee_id CHAR(36) NULL;
+
+ALTER TABLE `user_plan`
+    MODIFY COLUMN user_id VARBINARY(36) NOT NULL;
+UPDATE `user_plan` SET user_id = BIN_TO_UUID(user_id);
+ALTER TABLE `user_plan`
+    MODIFY COLUMN user_id CHAR(36) NOT NULL;
+
+ALTER TABLE `matching_quota_usage`
+    MODIFY COLUMN user_id VARBINARY(36) NOT NULL;
+UPDATE `matching_quota_usage` SET user_id = BIN_TO_UUID(user_id);
+ALTER TABLE `matching_quota_usage`
+    MODIFY COLUMN user_id CHAR(36) NOT NULL;
+
+ALTER TABLE `email_verification`
+    MODIFY COLUMN id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN user_id VARBINARY(36) NOT NULL;
+UPDATE `email_verification` SET id = BIN_TO_UUID(id), user_id = BIN_TO_UUID(user_id);
+ALTER TABLE `email_verification`
+    MODIFY COLUMN id CHAR(36) NOT NULL,
+    MODIFY COLUMN user_id CHAR(36) NOT NULL;
+
+ALTER TABLE `email_change`
+    MODIFY COLUMN id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN user_id VARBINARY(36) NOT NULL;
+UPDATE `email_change` SET id = BIN_TO_UUID(id), user_id = BIN_TO_UUID(user_id);
+ALTER TABLE `email_change`
+    MODIFY COLUMN id CHAR(36) NOT NULL,
+    MODIFY COLUMN user_id CHAR(36) NOT NULL;
+
+ALTER TABLE `outbox`
+    MODIFY COLUMN id VARBINARY(36) NOT NULL;
+UPDATE `outbox` SET id = BIN_TO_UUID(id);
+ALTER TABLE `outbox`
+    MODIFY COLUMN id CHAR(36) NOT NULL;
+
+ALTER TABLE `audit_log`
+    MODIFY COLUMN id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN actor_id VARBINARY(36) NULL;
+UPDATE `audit_log` SET id = BIN_TO_UUID(id), actor_id = BIN_TO_UUID(actor_id);
+ALTER TABLE `audit_log`
+    MODIFY COLUMN id CHAR(36) NOT NULL,
+    MODIFY COLUMN actor_id CHAR(36) NULL;
+
+ALTER TABLE `conversation`
+    MODIFY COLUMN id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN matching_id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN user1_id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN user2_id VARBINARY(36) NOT NULL;
+UPDATE `conversation` SET id = BIN_TO_UUID(id), matching_id = BIN_TO_UUID(matching_id), user1_id = BIN_TO_UUID(user1_id), user2_id = BIN_TO_UUID(user2_id);
+ALTER TABLE `conversation`
+    MODIFY COLUMN id CHAR(36) NOT NULL,
+    MODIFY COLUMN matching_id CHAR(36) NOT NULL,
+    MODIFY COLUMN user1_id CHAR(36) NOT NULL,
+    MODIFY COLUMN user2_id CHAR(36) NOT NULL;
+
+ALTER TABLE `chat_message`
+    MODIFY COLUMN id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN conversation_id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN sender_id VARBINARY(36) NOT NULL;
+UPDATE `chat_message` SET id = BIN_TO_UUID(id), conversation_id = BIN_TO_UUID(conversation_id), sender_id = BIN_TO_UUID(sender_id);
+ALTER TABLE `chat_message`
+    MODIFY COLUMN id CHAR(36) NOT NULL,
+    MODIFY COLUMN conversation_id CHAR(36) NOT NULL,
+    MODIFY COLUMN sender_id CHAR(36) NOT NULL;
+
+ALTER TABLE `notification`
+    MODIFY COLUMN id VARBINARY(36) NOT NULL,
+    MODIFY COLUMN user_id VARBINARY(36) NOT NULL;
+UPDATE `notification` SET id = BIN_TO_UUID(id), user_id = BIN_TO_UUID(user_id);
+ALTER TABLE `notification`
+    MODIFY COLUMN id CHAR(36) NOT NULL,
+    MODIFY COLUMN user_id CHAR(36) NOT NULL;
+
+ALTER TABLE `notification_preference`
+    MODIFY COLUMN user_id VARBINARY(36) NOT NULL;
+UPDATE `notification_preference` SET user_id = BIN_TO_UUID(user_id);
+ALTER TABLE `notification_preference`
+    MODIFY COLUMN user_id CHAR(36) NOT NULL;
+
+ALTER TABLE `matching`
+    ADD CONSTRAINT fk_matching_me_id FOREIGN KEY (me_id) REFERENCES `user`(id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_matching_partner_id FOREIGN KEY (partner_id) REFERENCES `user`(id) ON DELETE CASCADE;
+
+ALTER TABLE `matching_history`
+    ADD CONSTRAINT fk_matching_history_matching_id FOREIGN KEY (matching_id) REFERENCES `matching`(id) ON DELETE CASCADE;
+
+ALTER TABLE `user_block`
+    ADD CONSTRAINT fk_user_block_blocker_id FOREIGN KEY (blocker_id) REFERENCES `user`(id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_user_block_blocked_id FOREIGN KEY (blocked_id) REFERENCES `user`(id) ON DELETE CASCADE;
+
+ALTER TABLE `report`
+    ADD CONSTRAINT fk_report_reporter_id FOREIGN KEY (reporter_id) REFERENCES `user`(id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_report_reported_id FOREIGN KEY (reported_id) REFERENCES `user`(id) ON DELETE CASCADE;
+
+ALTER TABLE `user_plan`
+    ADD CONSTRAINT fk_user_plan_user_id FOREIGN KEY (user_id) REFERENCES `user`(id) ON DELETE CASCADE;
+
+ALTER TABLE `matching_quota_usage`
+    ADD CONSTRAINT fk_matching_quota_usage_user_id FOREIGN KEY (user_id) REFERENCES `user`(id) ON DELETE CASCADE;
+
+ALTER TABLE `email_verification`
+    ADD CONSTRAINT fk_email_verification_user_id FOREIGN KEY (user_id) REFERENCES `user`(id) ON DELETE CASCADE;
+
+ALTER TABLE `email_change`
+    ADD CONSTRAINT fk_email_change_user_id FOREIGN KEY (user_id) REFERENCES `user`(id) ON DELETE CASCADE;
+
+ALTER TABLE `conversation`
+    ADD CONSTRAINT fk_conversation_matching_id FOREIGN KEY (matching_id) REFERENCES `matching`(id) ON DELETE CASCADE;
+
+ALTER TABLE `chat_message`
+    ADD CONSTRAINT fk_chat_message_conversation_id FOREIGN KEY (conversation_id) REFERENCES `conversation`(id) ON DELETE CASCADE;
+
+ALTER TABLE `notification`
+    ADD CONSTRAINT fk_notification_user_id FOREIGN KEY (user_id) REFERENCES `user`(id) ON DELETE CASCADE;
+
+ALTER TABLE `notification_preference`
+    ADD CONSTRAINT fk_notification_preference_user_id FOREIGN KEY (user_id) REFERENCES `user`(id) ON DELETE CASCADE;
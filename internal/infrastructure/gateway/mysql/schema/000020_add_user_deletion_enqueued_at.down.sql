@@ -0,0 +1,2 @@
+ALTER TABLE `user`
+    DROP COLUMN deletion_enqueued_at;
@@ -0,0 +1,4 @@
+-- The permanent deletion of a withdrawn user is enqueued once, and again only if it has not happened a while later,
+-- so that the outbox does not grow with every run of the task while the subscriber lags.
+ALTER TABLE `user`
+    ADD COLUMN deletion_enqueued_at DATETIME NULL;
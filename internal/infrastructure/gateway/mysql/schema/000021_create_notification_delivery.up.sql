@@ -0,0 +1,12 @@
+-- The delivery of each notification to each channel, so that a notification failed on a channel is retried on that channel only,
+-- and given up after a few attempts instead of being redelivered to every channel forever.
+-- There is no foreign key to the notification, which is only written by the in-app channel.
+CREATE TABLE IF NOT EXISTS notification_delivery (
+    notification_id BINARY(16) NOT NULL,
+    channel VARCHAR(32) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(1000) NOT NULL DEFAULT '',
+    delivered_at DATETIME NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (notification_id, channel)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,40 @@
+ALTER TABLE `notification_delivery`
+    DROP COLUMN tenant_id;
+
+ALTER TABLE `notification_preference`
+    DROP COLUMN tenant_id;
+
+ALTER TABLE `notification`
+    DROP COLUMN tenant_id;
+
+ALTER TABLE `chat_message`
+    DROP COLUMN tenant_id;
+
+ALTER TABLE `conversation`
+    DROP COLUMN tenant_id;
+
+ALTER TABLE `audit_log`
+    DROP INDEX idx_audit_log_tenant_id_created_at,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE `email_change`
+    DROP COLUMN tenant_id;
+
+ALTER TABLE `email_verification`
+    DROP COLUMN tenant_id;
+
+ALTER TABLE `matching_quota_usage`
+    DROP COLUMN tenant_id;
+
+ALTER TABLE `user_plan`
+    DROP COLUMN tenant_id;
+
+ALTER TABLE `report`
+    DROP INDEX idx_report_tenant_id_status_created_at,
+    DROP COLUMN tenant_id;
+
+ALTER TABLE `user_block`
+    DROP COLUMN tenant_id;
+
+ALTER TABLE `matching_history`
+    DROP COLUMN tenant_id;
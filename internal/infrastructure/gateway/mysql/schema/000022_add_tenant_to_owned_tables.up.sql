@@ -0,0 +1,109 @@
+-- Every row owned by a user or a matching belongs to its tenant too, so that each query is scoped to the tenant
+-- instead of relying on the user or the matching having been checked first.
+-- The outbox stays global: the relay publishes for every tenant, and each message keeps its tenant in its attributes.
+ALTER TABLE `matching_history`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id;
+
+ALTER TABLE `user_block`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' FIRST;
+
+ALTER TABLE `report`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id;
+
+ALTER TABLE `user_plan`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' FIRST;
+
+ALTER TABLE `matching_quota_usage`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' FIRST;
+
+ALTER TABLE `email_verification`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id;
+
+ALTER TABLE `email_change`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id;
+
+ALTER TABLE `audit_log`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id;
+
+ALTER TABLE `conversation`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id;
+
+ALTER TABLE `chat_message`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id;
+
+ALTER TABLE `notification`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' AFTER id;
+
+ALTER TABLE `notification_preference`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' FIRST;
+
+ALTER TABLE `notification_delivery`
+    ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default' FIRST;
+
+-- The rows take the tenant of their owner, which may have been created in another tenant than the default since 000018.
+UPDATE `matching_history` h JOIN `matching` m ON m.id = h.matching_id SET h.tenant_id = m.tenant_id;
+UPDATE `user_block` b JOIN `user` u ON u.id = b.blocker_id SET b.tenant_id = u.tenant_id;
+UPDATE `report` r JOIN `user` u ON u.id = r.reporter_id SET r.tenant_id = u.tenant_id;
+UPDATE `user_plan` p JOIN `user` u ON u.id = p.user_id SET p.tenant_id = u.tenant_id;
+UPDATE `matching_quota_usage` q JOIN `user` u ON u.id = q.user_id SET q.tenant_id = u.tenant_id;
+UPDATE `email_verification` v JOIN `user` u ON u.id = v.user_id SET v.tenant_id = u.tenant_id;
+UPDATE `email_change` c JOIN `user` u ON u.id = c.user_id SET c.tenant_id = u.tenant_id;
+UPDATE `conversation` c JOIN `matching` m ON m.id = c.matching_id SET c.tenant_id = m.tenant_id;
+UPDATE `chat_message` cm JOIN `conversation` c ON c.id = cm.conversation_id SET cm.tenant_id = c.tenant_id;
+UPDATE `notification` n JOIN `user` u ON u.id = n.user_id SET n.tenant_id = u.tenant_id;
+UPDATE `notification_preference` p JOIN `user` u ON u.id = p.user_id SET p.tenant_id = u.tenant_id;
+UPDATE `notification_delivery` d JOIN `notification` n ON n.id = d.notification_id SET d.tenant_id = n.tenant_id;
+
+-- The audit logs take the tenant of their target. The target ID is text, so the binary IDs are rendered to compare,
+-- and a block is identified by its blocker. The logs of the targets deleted since stay in the default tenant.
+UPDATE `audit_log` a JOIN `user` u ON a.target_type = 'user' AND a.target_id = BIN_TO_UUID(u.id)
+SET a.tenant_id = u.tenant_id;
+UPDATE `audit_log` a JOIN `matching` m ON a.target_type = 'matching' AND a.target_id = BIN_TO_UUID(m.id)
+SET a.tenant_id = m.tenant_id;
+UPDATE `audit_log` a JOIN `report` r ON a.target_type = 'report' AND a.target_id = BIN_TO_UUID(r.id)
+SET a.tenant_id = r.tenant_id;
+UPDATE `audit_log` a JOIN `user` u ON a.target_type = 'user_block' AND SUBSTRING_INDEX(a.target_id, ':', 1) = BIN_TO_UUID(u.id)
+SET a.tenant_id = u.tenant_id;
+
+-- Drop the defaults once backfilled, so that a row can never be written without its tenant.
+-- The lists of the admin pages are scanned by tenant.
+ALTER TABLE `matching_history`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL;
+
+ALTER TABLE `user_block`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL;
+
+ALTER TABLE `report`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL,
+    ADD INDEX idx_report_tenant_id_status_created_at (tenant_id, `status`, created_at);
+
+ALTER TABLE `user_plan`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL;
+
+ALTER TABLE `matching_quota_usage`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL;
+
+ALTER TABLE `email_verification`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL;
+
+ALTER TABLE `email_change`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL;
+
+ALTER TABLE `audit_log`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL,
+    ADD INDEX idx_audit_log_tenant_id_created_at (tenant_id, created_at);
+
+ALTER TABLE `conversation`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL;
+
+ALTER TABLE `chat_message`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL;
+
+ALTER TABLE `notification`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL;
+
+ALTER TABLE `notification_preference`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL;
+
+ALTER TABLE `notification_delivery`
+    MODIFY COLUMN tenant_id VARCHAR(64) NOT NULL;
@@ -0,0 +1,9 @@
+// Package schema embeds the migrations of the MySQL schema, so that the binary migrates the database it serves.
+package schema
+
+import "embed"
+
+// FS holds the migrations, named NNNNNN_name.up.sql and NNNNNN_name.down.sql.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,132 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: audit_log.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+const CreateAuditLog = `-- name: CreateAuditLog :exec
+
+INSERT INTO ` + "`" + `audit_log` + "`" + ` (
+    id,
+    tenant_id,
+    actor_id,
+    action,
+    target_type,
+    target_id,
+    diff,
+    request_id,
+    source,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateAuditLogParams struct {
+	ID         []byte          `json:"id"`
+	TenantID   string          `json:"tenant_id"`
+	ActorID    []byte          `json:"actor_id"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	Diff       json.RawMessage `json:"diff"`
+	RequestID  string          `json:"request_id"`
+	Source     string          `json:"source"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// Every query is scoped to the tenant, so that the admins of a tenant only see the logs of their tenant
+func (q *Queries) CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error {
+	_, err := q.db.ExecContext(ctx, CreateAuditLog,
+		arg.ID,
+		arg.TenantID,
+		arg.ActorID,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Diff,
+		arg.RequestID,
+		arg.Source,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const SearchAuditLogs = `-- name: SearchAuditLogs :many
+SELECT action, target_type, target_id, diff, request_id, source, created_at, id, actor_id, tenant_id FROM ` + "`" + `audit_log` + "`" + `
+WHERE tenant_id = ?
+    AND (? IS NULL OR actor_id = ?)
+    AND (? IS NULL OR target_type = ?)
+    AND (? IS NULL OR target_id = ?)
+    AND (? IS NULL OR created_at >= ?)
+    AND (? IS NULL OR created_at < ?)
+ORDER BY created_at DESC, id DESC
+LIMIT ? OFFSET ?
+`
+
+type SearchAuditLogsParams struct {
+	TenantID   string         `json:"tenant_id"`
+	ActorID    []byte         `json:"actor_id"`
+	TargetType sql.NullString `json:"target_type"`
+	TargetID   sql.NullString `json:"target_id"`
+	From       sql.NullTime   `json:"from"`
+	To         sql.NullTime   `json:"to"`
+	Limit      int32          `json:"limit"`
+	Offset     int32          `json:"offset"`
+}
+
+func (q *Queries) SearchAuditLogs(ctx context.Context, arg SearchAuditLogsParams) ([]AuditLog, error) {
+	rows, err := q.db.QueryContext(ctx, SearchAuditLogs,
+		arg.TenantID,
+		arg.ActorID,
+		arg.ActorID,
+		arg.TargetType,
+		arg.TargetType,
+		arg.TargetID,
+		arg.TargetID,
+		arg.From,
+		arg.From,
+		arg.To,
+		arg.To,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuditLog{}
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.Action,
+			&i.TargetType,
+			&i.TargetID,
+			&i.Diff,
+			&i.RequestID,
+			&i.Source,
+			&i.CreatedAt,
+			&i.ID,
+			&i.ActorID,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
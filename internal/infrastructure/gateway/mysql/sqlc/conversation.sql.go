@@ -0,0 +1,284 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: conversation.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const CreateChatMessage = `-- name: CreateChatMessage :exec
+INSERT INTO ` + "`" + `chat_message` + "`" + ` (
+    id,
+    tenant_id,
+    conversation_id,
+    sender_id,
+    body,
+    read_at,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateChatMessageParams struct {
+	ID             []byte       `json:"id"`
+	TenantID       string       `json:"tenant_id"`
+	ConversationID []byte       `json:"conversation_id"`
+	SenderID       []byte       `json:"sender_id"`
+	Body           string       `json:"body"`
+	ReadAt         sql.NullTime `json:"read_at"`
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+func (q *Queries) CreateChatMessage(ctx context.Context, arg CreateChatMessageParams) error {
+	_, err := q.db.ExecContext(ctx, CreateChatMessage,
+		arg.ID,
+		arg.TenantID,
+		arg.ConversationID,
+		arg.SenderID,
+		arg.Body,
+		arg.ReadAt,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const CreateConversation = `-- name: CreateConversation :exec
+
+INSERT INTO ` + "`" + `conversation` + "`" + ` (
+    id,
+    tenant_id,
+    matching_id,
+    user1_id,
+    user2_id,
+    status,
+    archived_at,
+    created_at,
+    updated_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateConversationParams struct {
+	ID         []byte       `json:"id"`
+	TenantID   string       `json:"tenant_id"`
+	MatchingID []byte       `json:"matching_id"`
+	User1ID    []byte       `json:"user1_id"`
+	User2ID    []byte       `json:"user2_id"`
+	Status     string       `json:"status"`
+	ArchivedAt sql.NullTime `json:"archived_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// Every query is scoped to the tenant, so that a conversation or a message of another tenant is never found
+func (q *Queries) CreateConversation(ctx context.Context, arg CreateConversationParams) error {
+	_, err := q.db.ExecContext(ctx, CreateConversation,
+		arg.ID,
+		arg.TenantID,
+		arg.MatchingID,
+		arg.User1ID,
+		arg.User2ID,
+		arg.Status,
+		arg.ArchivedAt,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const ExistsConversation = `-- name: ExistsConversation :one
+SELECT EXISTS(
+    SELECT 1 FROM ` + "`" + `conversation` + "`" + ` WHERE tenant_id = ? AND id = ?
+)
+`
+
+type ExistsConversationParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) ExistsConversation(ctx context.Context, arg ExistsConversationParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, ExistsConversation, arg.TenantID, arg.ID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const GetChatMessage = `-- name: GetChatMessage :one
+SELECT body, read_at, created_at, id, conversation_id, sender_id, tenant_id FROM ` + "`" + `chat_message` + "`" + `
+WHERE tenant_id = ? AND id = ? LIMIT 1
+`
+
+type GetChatMessageParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) GetChatMessage(ctx context.Context, arg GetChatMessageParams) (ChatMessage, error) {
+	row := q.db.QueryRowContext(ctx, GetChatMessage, arg.TenantID, arg.ID)
+	var i ChatMessage
+	err := row.Scan(
+		&i.Body,
+		&i.ReadAt,
+		&i.CreatedAt,
+		&i.ID,
+		&i.ConversationID,
+		&i.SenderID,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const GetConversationByMatchingID = `-- name: GetConversationByMatchingID :one
+SELECT status, archived_at, created_at, updated_at, id, matching_id, user1_id, user2_id, tenant_id FROM ` + "`" + `conversation` + "`" + `
+WHERE tenant_id = ? AND matching_id = ? LIMIT 1
+`
+
+type GetConversationByMatchingIDParams struct {
+	TenantID   string `json:"tenant_id"`
+	MatchingID []byte `json:"matching_id"`
+}
+
+func (q *Queries) GetConversationByMatchingID(ctx context.Context, arg GetConversationByMatchingIDParams) (Conversation, error) {
+	row := q.db.QueryRowContext(ctx, GetConversationByMatchingID, arg.TenantID, arg.MatchingID)
+	var i Conversation
+	err := row.Scan(
+		&i.Status,
+		&i.ArchivedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ID,
+		&i.MatchingID,
+		&i.User1ID,
+		&i.User2ID,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const ListChatMessages = `-- name: ListChatMessages :many
+SELECT body, read_at, created_at, id, conversation_id, sender_id, tenant_id FROM ` + "`" + `chat_message` + "`" + `
+WHERE tenant_id = ?
+    AND conversation_id = ?
+    AND (
+        ? IS NULL
+        OR created_at < ?
+        OR (created_at = ? AND id < ?)
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT ?
+`
+
+type ListChatMessagesParams struct {
+	TenantID        string       `json:"tenant_id"`
+	ConversationID  []byte       `json:"conversation_id"`
+	CursorCreatedAt sql.NullTime `json:"cursor_created_at"`
+	CursorID        []byte       `json:"cursor_id"`
+	Limit           int32        `json:"limit"`
+}
+
+func (q *Queries) ListChatMessages(ctx context.Context, arg ListChatMessagesParams) ([]ChatMessage, error) {
+	rows, err := q.db.QueryContext(ctx, ListChatMessages,
+		arg.TenantID,
+		arg.ConversationID,
+		arg.CursorCreatedAt,
+		arg.CursorCreatedAt,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ChatMessage{}
+	for rows.Next() {
+		var i ChatMessage
+		if err := rows.Scan(
+			&i.Body,
+			&i.ReadAt,
+			&i.CreatedAt,
+			&i.ID,
+			&i.ConversationID,
+			&i.SenderID,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const MarkChatMessagesRead = `-- name: MarkChatMessagesRead :execrows
+UPDATE ` + "`" + `chat_message` + "`" + `
+SET read_at = ?
+WHERE tenant_id = ?
+    AND conversation_id = ?
+    AND sender_id = ?
+    AND read_at IS NULL
+    AND created_at <= ?
+`
+
+type MarkChatMessagesReadParams struct {
+	ReadAt         sql.NullTime `json:"read_at"`
+	TenantID       string       `json:"tenant_id"`
+	ConversationID []byte       `json:"conversation_id"`
+	SenderID       []byte       `json:"sender_id"`
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+func (q *Queries) MarkChatMessagesRead(ctx context.Context, arg MarkChatMessagesReadParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, MarkChatMessagesRead,
+		arg.ReadAt,
+		arg.TenantID,
+		arg.ConversationID,
+		arg.SenderID,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const UpdateConversation = `-- name: UpdateConversation :exec
+UPDATE ` + "`" + `conversation` + "`" + `
+SET
+    status = ?,
+    archived_at = ?,
+    updated_at = ?
+WHERE tenant_id = ? AND id = ?
+`
+
+type UpdateConversationParams struct {
+	Status     string       `json:"status"`
+	ArchivedAt sql.NullTime `json:"archived_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+	TenantID   string       `json:"tenant_id"`
+	ID         []byte       `json:"id"`
+}
+
+func (q *Queries) UpdateConversation(ctx context.Context, arg UpdateConversationParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateConversation,
+		arg.Status,
+		arg.ArchivedAt,
+		arg.UpdatedAt,
+		arg.TenantID,
+		arg.ID,
+	)
+	return err
+}
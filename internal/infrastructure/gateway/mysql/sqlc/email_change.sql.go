@@ -0,0 +1,132 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: email_change.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const CreateEmailChange = `-- name: CreateEmailChange :exec
+
+INSERT INTO ` + "`" + `email_change` + "`" + ` (
+    id,
+    tenant_id,
+    user_id,
+    old_email,
+    new_email,
+    expires_at,
+    undo_expires_at,
+    confirmed_at,
+    reverted_at,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateEmailChangeParams struct {
+	ID            []byte       `json:"id"`
+	TenantID      string       `json:"tenant_id"`
+	UserID        []byte       `json:"user_id"`
+	OldEmail      string       `json:"old_email"`
+	NewEmail      string       `json:"new_email"`
+	ExpiresAt     time.Time    `json:"expires_at"`
+	UndoExpiresAt time.Time    `json:"undo_expires_at"`
+	ConfirmedAt   sql.NullTime `json:"confirmed_at"`
+	RevertedAt    sql.NullTime `json:"reverted_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+// Every query is scoped to the tenant, so that a change of another tenant is never found
+func (q *Queries) CreateEmailChange(ctx context.Context, arg CreateEmailChangeParams) error {
+	_, err := q.db.ExecContext(ctx, CreateEmailChange,
+		arg.ID,
+		arg.TenantID,
+		arg.UserID,
+		arg.OldEmail,
+		arg.NewEmail,
+		arg.ExpiresAt,
+		arg.UndoExpiresAt,
+		arg.ConfirmedAt,
+		arg.RevertedAt,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const ExistsEmailChange = `-- name: ExistsEmailChange :one
+SELECT EXISTS(
+    SELECT 1 FROM ` + "`" + `email_change` + "`" + ` WHERE tenant_id = ? AND id = ?
+)
+`
+
+type ExistsEmailChangeParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) ExistsEmailChange(ctx context.Context, arg ExistsEmailChangeParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, ExistsEmailChange, arg.TenantID, arg.ID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const GetEmailChangeForUpdate = `-- name: GetEmailChangeForUpdate :one
+SELECT old_email, new_email, expires_at, undo_expires_at, confirmed_at, reverted_at, created_at, id, user_id, tenant_id FROM ` + "`" + `email_change` + "`" + `
+WHERE tenant_id = ? AND id = ? LIMIT 1
+FOR UPDATE
+`
+
+type GetEmailChangeForUpdateParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) GetEmailChangeForUpdate(ctx context.Context, arg GetEmailChangeForUpdateParams) (EmailChange, error) {
+	row := q.db.QueryRowContext(ctx, GetEmailChangeForUpdate, arg.TenantID, arg.ID)
+	var i EmailChange
+	err := row.Scan(
+		&i.OldEmail,
+		&i.NewEmail,
+		&i.ExpiresAt,
+		&i.UndoExpiresAt,
+		&i.ConfirmedAt,
+		&i.RevertedAt,
+		&i.CreatedAt,
+		&i.ID,
+		&i.UserID,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const UpdateEmailChange = `-- name: UpdateEmailChange :exec
+UPDATE ` + "`" + `email_change` + "`" + `
+SET
+    confirmed_at = ?,
+    reverted_at = ?
+WHERE tenant_id = ? AND id = ?
+`
+
+type UpdateEmailChangeParams struct {
+	ConfirmedAt sql.NullTime `json:"confirmed_at"`
+	RevertedAt  sql.NullTime `json:"reverted_at"`
+	TenantID    string       `json:"tenant_id"`
+	ID          []byte       `json:"id"`
+}
+
+func (q *Queries) UpdateEmailChange(ctx context.Context, arg UpdateEmailChangeParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateEmailChange,
+		arg.ConfirmedAt,
+		arg.RevertedAt,
+		arg.TenantID,
+		arg.ID,
+	)
+	return err
+}
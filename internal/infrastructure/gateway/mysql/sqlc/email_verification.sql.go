@@ -0,0 +1,133 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: email_verification.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const CountEmailVerificationsByUserSince = `-- name: CountEmailVerificationsByUserSince :one
+SELECT COUNT(*) FROM ` + "`" + `email_verification` + "`" + `
+WHERE tenant_id = ? AND user_id = ? AND created_at >= ?
+FOR UPDATE
+`
+
+type CountEmailVerificationsByUserSinceParams struct {
+	TenantID  string    `json:"tenant_id"`
+	UserID    []byte    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// The locking read also locks the gap of the user in the index, so that concurrent requests of the user are counted one at a time
+func (q *Queries) CountEmailVerificationsByUserSince(ctx context.Context, arg CountEmailVerificationsByUserSinceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, CountEmailVerificationsByUserSince, arg.TenantID, arg.UserID, arg.CreatedAt)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CreateEmailVerification = `-- name: CreateEmailVerification :exec
+
+INSERT INTO ` + "`" + `email_verification` + "`" + ` (
+    id,
+    tenant_id,
+    user_id,
+    email,
+    expires_at,
+    used_at,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateEmailVerificationParams struct {
+	ID        []byte       `json:"id"`
+	TenantID  string       `json:"tenant_id"`
+	UserID    []byte       `json:"user_id"`
+	Email     string       `json:"email"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// Every query is scoped to the tenant, so that a verification of another tenant is never found
+func (q *Queries) CreateEmailVerification(ctx context.Context, arg CreateEmailVerificationParams) error {
+	_, err := q.db.ExecContext(ctx, CreateEmailVerification,
+		arg.ID,
+		arg.TenantID,
+		arg.UserID,
+		arg.Email,
+		arg.ExpiresAt,
+		arg.UsedAt,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const ExistsEmailVerification = `-- name: ExistsEmailVerification :one
+SELECT EXISTS(
+    SELECT 1 FROM ` + "`" + `email_verification` + "`" + ` WHERE tenant_id = ? AND id = ?
+)
+`
+
+type ExistsEmailVerificationParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) ExistsEmailVerification(ctx context.Context, arg ExistsEmailVerificationParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, ExistsEmailVerification, arg.TenantID, arg.ID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const GetEmailVerificationForUpdate = `-- name: GetEmailVerificationForUpdate :one
+SELECT email, expires_at, used_at, created_at, id, user_id, tenant_id FROM ` + "`" + `email_verification` + "`" + `
+WHERE tenant_id = ? AND id = ? LIMIT 1
+FOR UPDATE
+`
+
+type GetEmailVerificationForUpdateParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) GetEmailVerificationForUpdate(ctx context.Context, arg GetEmailVerificationForUpdateParams) (EmailVerification, error) {
+	row := q.db.QueryRowContext(ctx, GetEmailVerificationForUpdate, arg.TenantID, arg.ID)
+	var i EmailVerification
+	err := row.Scan(
+		&i.Email,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedAt,
+		&i.ID,
+		&i.UserID,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const UpdateEmailVerification = `-- name: UpdateEmailVerification :exec
+UPDATE ` + "`" + `email_verification` + "`" + `
+SET
+    used_at = ?
+WHERE tenant_id = ? AND id = ?
+`
+
+type UpdateEmailVerificationParams struct {
+	UsedAt   sql.NullTime `json:"used_at"`
+	TenantID string       `json:"tenant_id"`
+	ID       []byte       `json:"id"`
+}
+
+func (q *Queries) UpdateEmailVerification(ctx context.Context, arg UpdateEmailVerificationParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateEmailVerification, arg.UsedAt, arg.TenantID, arg.ID)
+	return err
+}
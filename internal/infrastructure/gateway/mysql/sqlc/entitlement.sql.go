@@ -0,0 +1,65 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: entitlement.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+)
+
+const GetUserPlan = `-- name: GetUserPlan :one
+
+SELECT plan FROM ` + "`" + `user_plan` + "`" + `
+WHERE tenant_id = ? AND user_id = ? LIMIT 1
+`
+
+type GetUserPlanParams struct {
+	TenantID string `json:"tenant_id"`
+	UserID   []byte `json:"user_id"`
+}
+
+// Every query is scoped to the tenant, so that a plan of another tenant is never found
+func (q *Queries) GetUserPlan(ctx context.Context, arg GetUserPlanParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, GetUserPlan, arg.TenantID, arg.UserID)
+	var plan string
+	err := row.Scan(&plan)
+	return plan, err
+}
+
+const UpsertUserPlan = `-- name: UpsertUserPlan :exec
+INSERT INTO ` + "`" + `user_plan` + "`" + ` (
+    tenant_id,
+    user_id,
+    plan,
+    created_at,
+    updated_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+ON DUPLICATE KEY UPDATE
+    plan = IF(tenant_id = VALUES(tenant_id), VALUES(plan), plan),
+    updated_at = IF(tenant_id = VALUES(tenant_id), VALUES(updated_at), updated_at)
+`
+
+type UpsertUserPlanParams struct {
+	TenantID  string    `json:"tenant_id"`
+	UserID    []byte    `json:"user_id"`
+	Plan      string    `json:"plan"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// The row of another tenant is left as it is
+func (q *Queries) UpsertUserPlan(ctx context.Context, arg UpsertUserPlanParams) error {
+	_, err := q.db.ExecContext(ctx, UpsertUserPlan,
+		arg.TenantID,
+		arg.UserID,
+		arg.Plan,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
@@ -14,31 +14,40 @@ import (
 const CreateMatching = `-- name: CreateMatching :execresult
 INSERT INTO ` + "`" + `matching` + "`" + ` (
     id,
+    tenant_id,
     me_id,
     partner_id,
+    pair_key,
     ` + "`" + `status` + "`" + `,
+    expires_at,
     created_at,
     updated_at
 ) VALUES (
-    ?, ?, ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
 )
 `
 
 type CreateMatchingParams struct {
-	ID        string    `json:"id"`
-	MeID      string    `json:"me_id"`
-	PartnerID string    `json:"partner_id"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        []byte       `json:"id"`
+	TenantID  string       `json:"tenant_id"`
+	MeID      []byte       `json:"me_id"`
+	PartnerID []byte       `json:"partner_id"`
+	PairKey   string       `json:"pair_key"`
+	Status    string       `json:"status"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
 }
 
 func (q *Queries) CreateMatching(ctx context.Context, arg CreateMatchingParams) (sql.Result, error) {
 	return q.db.ExecContext(ctx, CreateMatching,
 		arg.ID,
+		arg.TenantID,
 		arg.MeID,
 		arg.PartnerID,
+		arg.PairKey,
 		arg.Status,
+		arg.ExpiresAt,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
@@ -46,86 +55,234 @@ func (q *Queries) CreateMatching(ctx context.Context, arg CreateMatchingParams)
 
 const DeleteMatching = `-- name: DeleteMatching :exec
 DELETE FROM ` + "`" + `matching` + "`" + `
-WHERE id = ?
+WHERE tenant_id = ? AND id = ?
 `
 
-func (q *Queries) DeleteMatching(ctx context.Context, id string) error {
-	_, err := q.db.ExecContext(ctx, DeleteMatching, id)
+type DeleteMatchingParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) DeleteMatching(ctx context.Context, arg DeleteMatchingParams) error {
+	_, err := q.db.ExecContext(ctx, DeleteMatching, arg.TenantID, arg.ID)
 	return err
 }
 
 const ExistsMatching = `-- name: ExistsMatching :one
 SELECT EXISTS(
-    SELECT 1 FROM ` + "`" + `matching` + "`" + ` WHERE id = ?
+    SELECT 1 FROM ` + "`" + `matching` + "`" + ` WHERE tenant_id = ? AND id = ?
 )
 `
 
-func (q *Queries) ExistsMatching(ctx context.Context, id string) (bool, error) {
-	row := q.db.QueryRowContext(ctx, ExistsMatching, id)
+type ExistsMatchingParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) ExistsMatching(ctx context.Context, arg ExistsMatchingParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, ExistsMatching, arg.TenantID, arg.ID)
 	var exists bool
 	err := row.Scan(&exists)
 	return exists, err
 }
 
 const GetMatching = `-- name: GetMatching :one
-SELECT id, me_id, partner_id, status, created_at, updated_at FROM ` + "`" + `matching` + "`" + `
-WHERE id = ? LIMIT 1
+
+SELECT status, created_at, updated_at, expires_at, pair_key, tenant_id, id, me_id, partner_id FROM ` + "`" + `matching` + "`" + `
+WHERE tenant_id = ? AND id = ? LIMIT 1
 `
 
-func (q *Queries) GetMatching(ctx context.Context, id string) (Matching, error) {
-	row := q.db.QueryRowContext(ctx, GetMatching, id)
+type GetMatchingParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+// Every query is scoped to the tenant, so that a matching of another tenant is never found
+func (q *Queries) GetMatching(ctx context.Context, arg GetMatchingParams) (Matching, error) {
+	row := q.db.QueryRowContext(ctx, GetMatching, arg.TenantID, arg.ID)
 	var i Matching
 	err := row.Scan(
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.PairKey,
+		&i.TenantID,
 		&i.ID,
 		&i.MeID,
 		&i.PartnerID,
+	)
+	return i, err
+}
+
+const GetMatchingByPairKey = `-- name: GetMatchingByPairKey :one
+SELECT status, created_at, updated_at, expires_at, pair_key, tenant_id, id, me_id, partner_id FROM ` + "`" + `matching` + "`" + `
+WHERE tenant_id = ? AND pair_key = ?
+LIMIT 1
+`
+
+type GetMatchingByPairKeyParams struct {
+	TenantID string `json:"tenant_id"`
+	PairKey  string `json:"pair_key"`
+}
+
+func (q *Queries) GetMatchingByPairKey(ctx context.Context, arg GetMatchingByPairKeyParams) (Matching, error) {
+	row := q.db.QueryRowContext(ctx, GetMatchingByPairKey, arg.TenantID, arg.PairKey)
+	var i Matching
+	err := row.Scan(
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.PairKey,
+		&i.TenantID,
+		&i.ID,
+		&i.MeID,
+		&i.PartnerID,
 	)
 	return i, err
 }
 
-const GetMatchingByParticipants = `-- name: GetMatchingByParticipants :one
-SELECT id, me_id, partner_id, status, created_at, updated_at FROM ` + "`" + `matching` + "`" + `
-WHERE me_id = ? AND partner_id = ?
+const GetMatchingByPairKeyForUpdate = `-- name: GetMatchingByPairKeyForUpdate :one
+SELECT status, created_at, updated_at, expires_at, pair_key, tenant_id, id, me_id, partner_id FROM ` + "`" + `matching` + "`" + `
+WHERE tenant_id = ? AND pair_key = ?
 LIMIT 1
+FOR UPDATE
 `
 
-type GetMatchingByParticipantsParams struct {
-	MeID      string `json:"me_id"`
-	PartnerID string `json:"partner_id"`
+type GetMatchingByPairKeyForUpdateParams struct {
+	TenantID string `json:"tenant_id"`
+	PairKey  string `json:"pair_key"`
 }
 
-func (q *Queries) GetMatchingByParticipants(ctx context.Context, arg GetMatchingByParticipantsParams) (Matching, error) {
-	row := q.db.QueryRowContext(ctx, GetMatchingByParticipants, arg.MeID, arg.PartnerID)
+func (q *Queries) GetMatchingByPairKeyForUpdate(ctx context.Context, arg GetMatchingByPairKeyForUpdateParams) (Matching, error) {
+	row := q.db.QueryRowContext(ctx, GetMatchingByPairKeyForUpdate, arg.TenantID, arg.PairKey)
 	var i Matching
 	err := row.Scan(
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.PairKey,
+		&i.TenantID,
 		&i.ID,
 		&i.MeID,
 		&i.PartnerID,
+	)
+	return i, err
+}
+
+const GetMatchingByParticipants = `-- name: GetMatchingByParticipants :one
+SELECT status, created_at, updated_at, expires_at, pair_key, tenant_id, id, me_id, partner_id FROM ` + "`" + `matching` + "`" + `
+WHERE tenant_id = ? AND me_id = ? AND partner_id = ?
+LIMIT 1
+`
+
+type GetMatchingByParticipantsParams struct {
+	TenantID  string `json:"tenant_id"`
+	MeID      []byte `json:"me_id"`
+	PartnerID []byte `json:"partner_id"`
+}
+
+func (q *Queries) GetMatchingByParticipants(ctx context.Context, arg GetMatchingByParticipantsParams) (Matching, error) {
+	row := q.db.QueryRowContext(ctx, GetMatchingByParticipants, arg.TenantID, arg.MeID, arg.PartnerID)
+	var i Matching
+	err := row.Scan(
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.PairKey,
+		&i.TenantID,
+		&i.ID,
+		&i.MeID,
+		&i.PartnerID,
 	)
 	return i, err
 }
 
 const ListMatchingsByUser = `-- name: ListMatchingsByUser :many
-SELECT id, me_id, partner_id, status, created_at, updated_at FROM ` + "`" + `matching` + "`" + `
-WHERE me_id = ? OR partner_id = ?
+
+SELECT status, created_at, updated_at, expires_at, pair_key, tenant_id, id, me_id, partner_id FROM ` + "`" + `matching` + "`" + `
+WHERE matching.tenant_id = ? AND (me_id = ? OR partner_id = ?)
+    AND NOT EXISTS (
+        SELECT 1 FROM ` + "`" + `user_block` + "`" + ` b
+        WHERE (b.blocker_id = matching.me_id AND b.blocked_id = matching.partner_id)
+            OR (b.blocker_id = matching.partner_id AND b.blocked_id = matching.me_id)
+    )
 LIMIT ? OFFSET ?
 `
 
 type ListMatchingsByUserParams struct {
-	MeID      string `json:"me_id"`
-	PartnerID string `json:"partner_id"`
+	TenantID  string `json:"tenant_id"`
+	MeID      []byte `json:"me_id"`
+	PartnerID []byte `json:"partner_id"`
 	Limit     int32  `json:"limit"`
 	Offset    int32  `json:"offset"`
 }
 
+// Matchings of pairs with a block in either direction are hidden from the lists
 func (q *Queries) ListMatchingsByUser(ctx context.Context, arg ListMatchingsByUserParams) ([]Matching, error) {
 	rows, err := q.db.QueryContext(ctx, ListMatchingsByUser,
+		arg.TenantID,
+		arg.MeID,
+		arg.PartnerID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Matching{}
+	for rows.Next() {
+		var i Matching
+		if err := rows.Scan(
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ExpiresAt,
+			&i.PairKey,
+			&i.TenantID,
+			&i.ID,
+			&i.MeID,
+			&i.PartnerID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListMutualMatchingsByUser = `-- name: ListMutualMatchingsByUser :many
+SELECT status, created_at, updated_at, expires_at, pair_key, tenant_id, id, me_id, partner_id FROM ` + "`" + `matching` + "`" + `
+WHERE matching.tenant_id = ? AND (me_id = ? OR partner_id = ?) AND ` + "`" + `status` + "`" + ` = 'accepted'
+    AND NOT EXISTS (
+        SELECT 1 FROM ` + "`" + `user_block` + "`" + ` b
+        WHERE (b.blocker_id = matching.me_id AND b.blocked_id = matching.partner_id)
+            OR (b.blocker_id = matching.partner_id AND b.blocked_id = matching.me_id)
+    )
+LIMIT ? OFFSET ?
+`
+
+type ListMutualMatchingsByUserParams struct {
+	TenantID  string `json:"tenant_id"`
+	MeID      []byte `json:"me_id"`
+	PartnerID []byte `json:"partner_id"`
+	Limit     int32  `json:"limit"`
+	Offset    int32  `json:"offset"`
+}
+
+func (q *Queries) ListMutualMatchingsByUser(ctx context.Context, arg ListMutualMatchingsByUserParams) ([]Matching, error) {
+	rows, err := q.db.QueryContext(ctx, ListMutualMatchingsByUser,
+		arg.TenantID,
 		arg.MeID,
 		arg.PartnerID,
 		arg.Limit,
@@ -139,12 +296,62 @@ func (q *Queries) ListMatchingsByUser(ctx context.Context, arg ListMatchingsByUs
 	for rows.Next() {
 		var i Matching
 		if err := rows.Scan(
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ExpiresAt,
+			&i.PairKey,
+			&i.TenantID,
 			&i.ID,
 			&i.MeID,
 			&i.PartnerID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListOverdueMatchings = `-- name: ListOverdueMatchings :many
+SELECT status, created_at, updated_at, expires_at, pair_key, tenant_id, id, me_id, partner_id FROM ` + "`" + `matching` + "`" + `
+WHERE tenant_id = ? AND ` + "`" + `status` + "`" + ` = 'pending' AND expires_at <= ?
+ORDER BY expires_at
+LIMIT ?
+FOR UPDATE SKIP LOCKED
+`
+
+type ListOverdueMatchingsParams struct {
+	TenantID  string       `json:"tenant_id"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	Limit     int32        `json:"limit"`
+}
+
+func (q *Queries) ListOverdueMatchings(ctx context.Context, arg ListOverdueMatchingsParams) ([]Matching, error) {
+	rows, err := q.db.QueryContext(ctx, ListOverdueMatchings, arg.TenantID, arg.ExpiresAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Matching{}
+	for rows.Next() {
+		var i Matching
+		if err := rows.Scan(
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ExpiresAt,
+			&i.PairKey,
+			&i.TenantID,
+			&i.ID,
+			&i.MeID,
+			&i.PartnerID,
 		); err != nil {
 			return nil, err
 		}
@@ -162,17 +369,32 @@ func (q *Queries) ListMatchingsByUser(ctx context.Context, arg ListMatchingsByUs
 const UpdateMatching = `-- name: UpdateMatching :execresult
 UPDATE ` + "`" + `matching` + "`" + `
 SET
+    me_id = ?,
+    partner_id = ?,
     ` + "`" + `status` + "`" + ` = ?,
+    expires_at = ?,
     updated_at = ?
-WHERE id = ?
+WHERE tenant_id = ? AND id = ?
 `
 
 type UpdateMatchingParams struct {
-	Status    string    `json:"status"`
-	UpdatedAt time.Time `json:"updated_at"`
-	ID        string    `json:"id"`
+	MeID      []byte       `json:"me_id"`
+	PartnerID []byte       `json:"partner_id"`
+	Status    string       `json:"status"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	TenantID  string       `json:"tenant_id"`
+	ID        []byte       `json:"id"`
 }
 
 func (q *Queries) UpdateMatching(ctx context.Context, arg UpdateMatchingParams) (sql.Result, error) {
-	return q.db.ExecContext(ctx, UpdateMatching, arg.Status, arg.UpdatedAt, arg.ID)
+	return q.db.ExecContext(ctx, UpdateMatching,
+		arg.MeID,
+		arg.PartnerID,
+		arg.Status,
+		arg.ExpiresAt,
+		arg.UpdatedAt,
+		arg.TenantID,
+		arg.ID,
+	)
 }
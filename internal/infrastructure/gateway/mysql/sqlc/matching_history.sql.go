@@ -0,0 +1,100 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: matching_history.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+)
+
+const CreateMatchingHistory = `-- name: CreateMatchingHistory :exec
+
+INSERT INTO ` + "`" + `matching_history` + "`" + ` (
+    id,
+    tenant_id,
+    matching_id,
+    actor_id,
+    action,
+    from_status,
+    to_status,
+    reason,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateMatchingHistoryParams struct {
+	ID         []byte    `json:"id"`
+	TenantID   string    `json:"tenant_id"`
+	MatchingID []byte    `json:"matching_id"`
+	ActorID    []byte    `json:"actor_id"`
+	Action     string    `json:"action"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Every query is scoped to the tenant, so that a history of another tenant is never found
+func (q *Queries) CreateMatchingHistory(ctx context.Context, arg CreateMatchingHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, CreateMatchingHistory,
+		arg.ID,
+		arg.TenantID,
+		arg.MatchingID,
+		arg.ActorID,
+		arg.Action,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Reason,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const ListMatchingHistoriesByMatching = `-- name: ListMatchingHistoriesByMatching :many
+SELECT action, from_status, to_status, reason, created_at, id, matching_id, actor_id, tenant_id FROM ` + "`" + `matching_history` + "`" + `
+WHERE tenant_id = ? AND matching_id = ?
+ORDER BY created_at, id
+`
+
+type ListMatchingHistoriesByMatchingParams struct {
+	TenantID   string `json:"tenant_id"`
+	MatchingID []byte `json:"matching_id"`
+}
+
+func (q *Queries) ListMatchingHistoriesByMatching(ctx context.Context, arg ListMatchingHistoriesByMatchingParams) ([]MatchingHistory, error) {
+	rows, err := q.db.QueryContext(ctx, ListMatchingHistoriesByMatching, arg.TenantID, arg.MatchingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []MatchingHistory{}
+	for rows.Next() {
+		var i MatchingHistory
+		if err := rows.Scan(
+			&i.Action,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Reason,
+			&i.CreatedAt,
+			&i.ID,
+			&i.MatchingID,
+			&i.ActorID,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,67 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: matching_quota.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+)
+
+const GetMatchingQuotaUsage = `-- name: GetMatchingQuotaUsage :one
+SELECT used FROM ` + "`" + `matching_quota_usage` + "`" + `
+WHERE tenant_id = ? AND user_id = ? AND day = ? LIMIT 1
+`
+
+type GetMatchingQuotaUsageParams struct {
+	TenantID string    `json:"tenant_id"`
+	UserID   []byte    `json:"user_id"`
+	Day      time.Time `json:"day"`
+}
+
+func (q *Queries) GetMatchingQuotaUsage(ctx context.Context, arg GetMatchingQuotaUsageParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, GetMatchingQuotaUsage, arg.TenantID, arg.UserID, arg.Day)
+	var used int32
+	err := row.Scan(&used)
+	return used, err
+}
+
+const IncrementMatchingQuotaUsage = `-- name: IncrementMatchingQuotaUsage :exec
+
+INSERT INTO ` + "`" + `matching_quota_usage` + "`" + ` (
+    tenant_id,
+    user_id,
+    day,
+    used,
+    created_at,
+    updated_at
+) VALUES (
+    ?, ?, ?, 1, ?, ?
+)
+ON DUPLICATE KEY UPDATE
+    used = IF(tenant_id = VALUES(tenant_id), used + 1, used),
+    updated_at = IF(tenant_id = VALUES(tenant_id), VALUES(updated_at), updated_at)
+`
+
+type IncrementMatchingQuotaUsageParams struct {
+	TenantID  string    `json:"tenant_id"`
+	UserID    []byte    `json:"user_id"`
+	Day       time.Time `json:"day"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Every query is scoped to the tenant, so that a usage of another tenant is never found
+// The row of another tenant is left as it is
+func (q *Queries) IncrementMatchingQuotaUsage(ctx context.Context, arg IncrementMatchingQuotaUsageParams) error {
+	_, err := q.db.ExecContext(ctx, IncrementMatchingQuotaUsage,
+		arg.TenantID,
+		arg.UserID,
+		arg.Day,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
@@ -5,21 +5,188 @@
 package sqlc
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+type AuditLog struct {
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	Diff       json.RawMessage `json:"diff"`
+	RequestID  string          `json:"request_id"`
+	Source     string          `json:"source"`
+	CreatedAt  time.Time       `json:"created_at"`
+	ID         []byte          `json:"id"`
+	ActorID    []byte          `json:"actor_id"`
+	TenantID   string          `json:"tenant_id"`
+}
+
+type ChatMessage struct {
+	Body           string       `json:"body"`
+	ReadAt         sql.NullTime `json:"read_at"`
+	CreatedAt      time.Time    `json:"created_at"`
+	ID             []byte       `json:"id"`
+	ConversationID []byte       `json:"conversation_id"`
+	SenderID       []byte       `json:"sender_id"`
+	TenantID       string       `json:"tenant_id"`
+}
+
+type Conversation struct {
+	Status     string       `json:"status"`
+	ArchivedAt sql.NullTime `json:"archived_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+	ID         []byte       `json:"id"`
+	MatchingID []byte       `json:"matching_id"`
+	User1ID    []byte       `json:"user1_id"`
+	User2ID    []byte       `json:"user2_id"`
+	TenantID   string       `json:"tenant_id"`
+}
+
+type EmailChange struct {
+	OldEmail      string       `json:"old_email"`
+	NewEmail      string       `json:"new_email"`
+	ExpiresAt     time.Time    `json:"expires_at"`
+	UndoExpiresAt time.Time    `json:"undo_expires_at"`
+	ConfirmedAt   sql.NullTime `json:"confirmed_at"`
+	RevertedAt    sql.NullTime `json:"reverted_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+	ID            []byte       `json:"id"`
+	UserID        []byte       `json:"user_id"`
+	TenantID      string       `json:"tenant_id"`
+}
+
+type EmailVerification struct {
+	Email     string       `json:"email"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+	ID        []byte       `json:"id"`
+	UserID    []byte       `json:"user_id"`
+	TenantID  string       `json:"tenant_id"`
+}
+
 type Matching struct {
-	ID        string    `json:"id"`
-	MeID      string    `json:"me_id"`
-	PartnerID string    `json:"partner_id"`
-	Status    string    `json:"status"`
+	Status    string       `json:"status"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	PairKey   string       `json:"pair_key"`
+	TenantID  string       `json:"tenant_id"`
+	ID        []byte       `json:"id"`
+	MeID      []byte       `json:"me_id"`
+	PartnerID []byte       `json:"partner_id"`
+}
+
+type MatchingHistory struct {
+	Action     string    `json:"action"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+	ID         []byte    `json:"id"`
+	MatchingID []byte    `json:"matching_id"`
+	ActorID    []byte    `json:"actor_id"`
+	TenantID   string    `json:"tenant_id"`
+}
+
+type MatchingQuotaUsage struct {
+	Day       time.Time `json:"day"`
+	Used      int32     `json:"used"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	UserID    []byte    `json:"user_id"`
+	TenantID  string    `json:"tenant_id"`
+}
+
+type Notification struct {
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Data      json.RawMessage `json:"data"`
+	ReadAt    sql.NullTime    `json:"read_at"`
+	CreatedAt time.Time       `json:"created_at"`
+	ID        []byte          `json:"id"`
+	UserID    []byte          `json:"user_id"`
+	TenantID  string          `json:"tenant_id"`
+}
+
+type NotificationDelivery struct {
+	NotificationID []byte       `json:"notification_id"`
+	Channel        string       `json:"channel"`
+	Attempts       int32        `json:"attempts"`
+	LastError      string       `json:"last_error"`
+	DeliveredAt    sql.NullTime `json:"delivered_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+	TenantID       string       `json:"tenant_id"`
+}
+
+type NotificationPreference struct {
+	Channels  json.RawMessage `json:"channels"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	UserID    []byte          `json:"user_id"`
+	TenantID  string          `json:"tenant_id"`
+}
+
+type Outbox struct {
+	Destination string          `json:"destination"`
+	Body        string          `json:"body"`
+	Attributes  json.RawMessage `json:"attributes"`
+	Attempts    int32           `json:"attempts"`
+	LastError   string          `json:"last_error"`
+	CreatedAt   time.Time       `json:"created_at"`
+	SentAt      sql.NullTime    `json:"sent_at"`
+	ID          []byte          `json:"id"`
+}
+
+type Report struct {
+	Reason         string         `json:"reason"`
+	Comment        string         `json:"comment"`
+	Status         string         `json:"status"`
+	Resolution     sql.NullString `json:"resolution"`
+	ResolutionNote string         `json:"resolution_note"`
+	ResolvedAt     sql.NullTime   `json:"resolved_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	ID             []byte         `json:"id"`
+	ReporterID     []byte         `json:"reporter_id"`
+	ReportedID     []byte         `json:"reported_id"`
+	AssigneeID     []byte         `json:"assignee_id"`
+	TenantID       string         `json:"tenant_id"`
 }
 
 type User struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
+	Email              string       `json:"email"`
+	CreatedAt          time.Time    `json:"created_at"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+	DisplayName        string       `json:"display_name"`
+	Birthdate          sql.NullTime `json:"birthdate"`
+	Gender             string       `json:"gender"`
+	Bio                string       `json:"bio"`
+	Locale             string       `json:"locale"`
+	Status             string       `json:"status"`
+	DeletedAt          sql.NullTime `json:"deleted_at"`
+	Interests          string       `json:"interests"`
+	EmailVerifiedAt    sql.NullTime `json:"email_verified_at"`
+	PendingEmail       string       `json:"pending_email"`
+	TenantID           string       `json:"tenant_id"`
+	ID                 []byte       `json:"id"`
+	DeletionEnqueuedAt sql.NullTime `json:"deletion_enqueued_at"`
+}
+
+type UserBlock struct {
+	CreatedAt time.Time `json:"created_at"`
+	BlockerID []byte    `json:"blocker_id"`
+	BlockedID []byte    `json:"blocked_id"`
+	TenantID  string    `json:"tenant_id"`
+}
+
+type UserPlan struct {
+	Plan      string    `json:"plan"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	UserID    []byte    `json:"user_id"`
+	TenantID  string    `json:"tenant_id"`
 }
@@ -0,0 +1,375 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: notification.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+const CountUnreadNotificationsByUserID = `-- name: CountUnreadNotificationsByUserID :one
+SELECT COUNT(*) FROM ` + "`" + `notification` + "`" + `
+WHERE tenant_id = ? AND user_id = ? AND read_at IS NULL
+`
+
+type CountUnreadNotificationsByUserIDParams struct {
+	TenantID string `json:"tenant_id"`
+	UserID   []byte `json:"user_id"`
+}
+
+func (q *Queries) CountUnreadNotificationsByUserID(ctx context.Context, arg CountUnreadNotificationsByUserIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, CountUnreadNotificationsByUserID, arg.TenantID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CreateNotification = `-- name: CreateNotification :exec
+
+INSERT INTO ` + "`" + `notification` + "`" + ` (
+    id,
+    tenant_id,
+    user_id,
+    type,
+    title,
+    body,
+    data,
+    read_at,
+    created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateNotificationParams struct {
+	ID        []byte          `json:"id"`
+	TenantID  string          `json:"tenant_id"`
+	UserID    []byte          `json:"user_id"`
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Data      json.RawMessage `json:"data"`
+	ReadAt    sql.NullTime    `json:"read_at"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Every query is scoped to the tenant, so that a notification of another tenant is never found
+func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotificationParams) error {
+	_, err := q.db.ExecContext(ctx, CreateNotification,
+		arg.ID,
+		arg.TenantID,
+		arg.UserID,
+		arg.Type,
+		arg.Title,
+		arg.Body,
+		arg.Data,
+		arg.ReadAt,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const ExistsNotification = `-- name: ExistsNotification :one
+SELECT EXISTS(
+    SELECT 1 FROM ` + "`" + `notification` + "`" + ` WHERE tenant_id = ? AND id = ?
+)
+`
+
+type ExistsNotificationParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) ExistsNotification(ctx context.Context, arg ExistsNotificationParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, ExistsNotification, arg.TenantID, arg.ID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const GetNotification = `-- name: GetNotification :one
+SELECT type, title, body, data, read_at, created_at, id, user_id, tenant_id FROM ` + "`" + `notification` + "`" + `
+WHERE tenant_id = ? AND id = ? LIMIT 1
+`
+
+type GetNotificationParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) GetNotification(ctx context.Context, arg GetNotificationParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, GetNotification, arg.TenantID, arg.ID)
+	var i Notification
+	err := row.Scan(
+		&i.Type,
+		&i.Title,
+		&i.Body,
+		&i.Data,
+		&i.ReadAt,
+		&i.CreatedAt,
+		&i.ID,
+		&i.UserID,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const GetNotificationDelivery = `-- name: GetNotificationDelivery :one
+SELECT notification_id, channel, attempts, last_error, delivered_at, updated_at, tenant_id FROM ` + "`" + `notification_delivery` + "`" + `
+WHERE tenant_id = ? AND notification_id = ? AND channel = ? LIMIT 1
+`
+
+type GetNotificationDeliveryParams struct {
+	TenantID       string `json:"tenant_id"`
+	NotificationID []byte `json:"notification_id"`
+	Channel        string `json:"channel"`
+}
+
+func (q *Queries) GetNotificationDelivery(ctx context.Context, arg GetNotificationDeliveryParams) (NotificationDelivery, error) {
+	row := q.db.QueryRowContext(ctx, GetNotificationDelivery, arg.TenantID, arg.NotificationID, arg.Channel)
+	var i NotificationDelivery
+	err := row.Scan(
+		&i.NotificationID,
+		&i.Channel,
+		&i.Attempts,
+		&i.LastError,
+		&i.DeliveredAt,
+		&i.UpdatedAt,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const GetNotificationPreference = `-- name: GetNotificationPreference :one
+SELECT channels, updated_at, user_id, tenant_id FROM ` + "`" + `notification_preference` + "`" + `
+WHERE tenant_id = ? AND user_id = ? LIMIT 1
+`
+
+type GetNotificationPreferenceParams struct {
+	TenantID string `json:"tenant_id"`
+	UserID   []byte `json:"user_id"`
+}
+
+func (q *Queries) GetNotificationPreference(ctx context.Context, arg GetNotificationPreferenceParams) (NotificationPreference, error) {
+	row := q.db.QueryRowContext(ctx, GetNotificationPreference, arg.TenantID, arg.UserID)
+	var i NotificationPreference
+	err := row.Scan(
+		&i.Channels,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const ListNotificationsByUserID = `-- name: ListNotificationsByUserID :many
+SELECT type, title, body, data, read_at, created_at, id, user_id, tenant_id FROM ` + "`" + `notification` + "`" + `
+WHERE tenant_id = ? AND user_id = ?
+ORDER BY created_at DESC, id DESC
+LIMIT ? OFFSET ?
+`
+
+type ListNotificationsByUserIDParams struct {
+	TenantID string `json:"tenant_id"`
+	UserID   []byte `json:"user_id"`
+	Limit    int32  `json:"limit"`
+	Offset   int32  `json:"offset"`
+}
+
+func (q *Queries) ListNotificationsByUserID(ctx context.Context, arg ListNotificationsByUserIDParams) ([]Notification, error) {
+	rows, err := q.db.QueryContext(ctx, ListNotificationsByUserID,
+		arg.TenantID,
+		arg.UserID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Notification{}
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.Type,
+			&i.Title,
+			&i.Body,
+			&i.Data,
+			&i.ReadAt,
+			&i.CreatedAt,
+			&i.ID,
+			&i.UserID,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUnreadNotificationsByUserID = `-- name: ListUnreadNotificationsByUserID :many
+SELECT type, title, body, data, read_at, created_at, id, user_id, tenant_id FROM ` + "`" + `notification` + "`" + `
+WHERE tenant_id = ? AND user_id = ? AND read_at IS NULL
+ORDER BY created_at DESC, id DESC
+LIMIT ? OFFSET ?
+`
+
+type ListUnreadNotificationsByUserIDParams struct {
+	TenantID string `json:"tenant_id"`
+	UserID   []byte `json:"user_id"`
+	Limit    int32  `json:"limit"`
+	Offset   int32  `json:"offset"`
+}
+
+func (q *Queries) ListUnreadNotificationsByUserID(ctx context.Context, arg ListUnreadNotificationsByUserIDParams) ([]Notification, error) {
+	rows, err := q.db.QueryContext(ctx, ListUnreadNotificationsByUserID,
+		arg.TenantID,
+		arg.UserID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Notification{}
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.Type,
+			&i.Title,
+			&i.Body,
+			&i.Data,
+			&i.ReadAt,
+			&i.CreatedAt,
+			&i.ID,
+			&i.UserID,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const MarkNotificationsRead = `-- name: MarkNotificationsRead :execrows
+UPDATE ` + "`" + `notification` + "`" + `
+SET read_at = ?
+WHERE tenant_id = ?
+    AND user_id = ?
+    AND read_at IS NULL
+    AND (? IS NULL OR id = ?)
+`
+
+type MarkNotificationsReadParams struct {
+	ReadAt   sql.NullTime `json:"read_at"`
+	TenantID string       `json:"tenant_id"`
+	UserID   []byte       `json:"user_id"`
+	ID       []byte       `json:"id"`
+}
+
+func (q *Queries) MarkNotificationsRead(ctx context.Context, arg MarkNotificationsReadParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, MarkNotificationsRead,
+		arg.ReadAt,
+		arg.TenantID,
+		arg.UserID,
+		arg.ID,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const UpsertNotificationDelivery = `-- name: UpsertNotificationDelivery :exec
+INSERT INTO ` + "`" + `notification_delivery` + "`" + ` (
+    tenant_id,
+    notification_id,
+    channel,
+    attempts,
+    last_error,
+    delivered_at,
+    updated_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+) ON DUPLICATE KEY UPDATE
+    attempts = IF(tenant_id = VALUES(tenant_id), VALUES(attempts), attempts),
+    last_error = IF(tenant_id = VALUES(tenant_id), VALUES(last_error), last_error),
+    delivered_at = IF(tenant_id = VALUES(tenant_id), VALUES(delivered_at), delivered_at),
+    updated_at = IF(tenant_id = VALUES(tenant_id), VALUES(updated_at), updated_at)
+`
+
+type UpsertNotificationDeliveryParams struct {
+	TenantID       string       `json:"tenant_id"`
+	NotificationID []byte       `json:"notification_id"`
+	Channel        string       `json:"channel"`
+	Attempts       int32        `json:"attempts"`
+	LastError      string       `json:"last_error"`
+	DeliveredAt    sql.NullTime `json:"delivered_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// The row of another tenant is left as it is
+func (q *Queries) UpsertNotificationDelivery(ctx context.Context, arg UpsertNotificationDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, UpsertNotificationDelivery,
+		arg.TenantID,
+		arg.NotificationID,
+		arg.Channel,
+		arg.Attempts,
+		arg.LastError,
+		arg.DeliveredAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const UpsertNotificationPreference = `-- name: UpsertNotificationPreference :exec
+INSERT INTO ` + "`" + `notification_preference` + "`" + ` (
+    tenant_id,
+    user_id,
+    channels,
+    updated_at
+) VALUES (
+    ?, ?, ?, ?
+) ON DUPLICATE KEY UPDATE
+    channels = IF(tenant_id = VALUES(tenant_id), VALUES(channels), channels),
+    updated_at = IF(tenant_id = VALUES(tenant_id), VALUES(updated_at), updated_at)
+`
+
+type UpsertNotificationPreferenceParams struct {
+	TenantID  string          `json:"tenant_id"`
+	UserID    []byte          `json:"user_id"`
+	Channels  json.RawMessage `json:"channels"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// The row of another tenant is left as it is
+func (q *Queries) UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) error {
+	_, err := q.db.ExecContext(ctx, UpsertNotificationPreference,
+		arg.TenantID,
+		arg.UserID,
+		arg.Channels,
+		arg.UpdatedAt,
+	)
+	return err
+}
@@ -0,0 +1,137 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: outbox.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+const CreateOutboxMessage = `-- name: CreateOutboxMessage :exec
+INSERT INTO ` + "`" + `outbox` + "`" + ` (
+    id,
+    destination,
+    body,
+    attributes,
+    attempts,
+    last_error,
+    created_at,
+    sent_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateOutboxMessageParams struct {
+	ID          []byte          `json:"id"`
+	Destination string          `json:"destination"`
+	Body        string          `json:"body"`
+	Attributes  json.RawMessage `json:"attributes"`
+	Attempts    int32           `json:"attempts"`
+	LastError   string          `json:"last_error"`
+	CreatedAt   time.Time       `json:"created_at"`
+	SentAt      sql.NullTime    `json:"sent_at"`
+}
+
+func (q *Queries) CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) error {
+	_, err := q.db.ExecContext(ctx, CreateOutboxMessage,
+		arg.ID,
+		arg.Destination,
+		arg.Body,
+		arg.Attributes,
+		arg.Attempts,
+		arg.LastError,
+		arg.CreatedAt,
+		arg.SentAt,
+	)
+	return err
+}
+
+const ExistsOutboxMessage = `-- name: ExistsOutboxMessage :one
+SELECT EXISTS(
+    SELECT 1 FROM ` + "`" + `outbox` + "`" + ` WHERE id = ?
+)
+`
+
+func (q *Queries) ExistsOutboxMessage(ctx context.Context, id []byte) (bool, error) {
+	row := q.db.QueryRowContext(ctx, ExistsOutboxMessage, id)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const ListUnsentOutboxMessagesForUpdate = `-- name: ListUnsentOutboxMessagesForUpdate :many
+SELECT destination, body, attributes, attempts, last_error, created_at, sent_at, id FROM ` + "`" + `outbox` + "`" + `
+WHERE sent_at IS NULL AND attempts < ?
+ORDER BY created_at, id
+LIMIT ?
+FOR UPDATE SKIP LOCKED
+`
+
+type ListUnsentOutboxMessagesForUpdateParams struct {
+	Attempts int32 `json:"attempts"`
+	Limit    int32 `json:"limit"`
+}
+
+func (q *Queries) ListUnsentOutboxMessagesForUpdate(ctx context.Context, arg ListUnsentOutboxMessagesForUpdateParams) ([]Outbox, error) {
+	rows, err := q.db.QueryContext(ctx, ListUnsentOutboxMessagesForUpdate, arg.Attempts, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Outbox{}
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.Destination,
+			&i.Body,
+			&i.Attributes,
+			&i.Attempts,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.SentAt,
+			&i.ID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const UpdateOutboxMessage = `-- name: UpdateOutboxMessage :exec
+UPDATE ` + "`" + `outbox` + "`" + `
+SET
+    attempts = ?,
+    last_error = ?,
+    sent_at = ?
+WHERE id = ?
+`
+
+type UpdateOutboxMessageParams struct {
+	Attempts  int32        `json:"attempts"`
+	LastError string       `json:"last_error"`
+	SentAt    sql.NullTime `json:"sent_at"`
+	ID        []byte       `json:"id"`
+}
+
+func (q *Queries) UpdateOutboxMessage(ctx context.Context, arg UpdateOutboxMessageParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateOutboxMessage,
+		arg.Attempts,
+		arg.LastError,
+		arg.SentAt,
+		arg.ID,
+	)
+	return err
+}
@@ -10,21 +10,104 @@ import (
 )
 
 type Querier interface {
-	CountUsers(ctx context.Context) (int64, error)
+	// The locking read also locks the gap of the user in the index, so that concurrent requests of the user are counted one at a time
+	CountEmailVerificationsByUserSince(ctx context.Context, arg CountEmailVerificationsByUserSinceParams) (int64, error)
+	CountUnreadNotificationsByUserID(ctx context.Context, arg CountUnreadNotificationsByUserIDParams) (int64, error)
+	CountUserBlocksForUpdate(ctx context.Context, arg CountUserBlocksForUpdateParams) (int64, error)
+	CountUsers(ctx context.Context, tenantID string) (int64, error)
+	// Every query is scoped to the tenant, so that the admins of a tenant only see the logs of their tenant
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error
+	CreateChatMessage(ctx context.Context, arg CreateChatMessageParams) error
+	// Every query is scoped to the tenant, so that a conversation or a message of another tenant is never found
+	CreateConversation(ctx context.Context, arg CreateConversationParams) error
+	// Every query is scoped to the tenant, so that a change of another tenant is never found
+	CreateEmailChange(ctx context.Context, arg CreateEmailChangeParams) error
+	// Every query is scoped to the tenant, so that a verification of another tenant is never found
+	CreateEmailVerification(ctx context.Context, arg CreateEmailVerificationParams) error
 	CreateMatching(ctx context.Context, arg CreateMatchingParams) (sql.Result, error)
+	// Every query is scoped to the tenant, so that a history of another tenant is never found
+	CreateMatchingHistory(ctx context.Context, arg CreateMatchingHistoryParams) error
+	// Every query is scoped to the tenant, so that a notification of another tenant is never found
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) error
+	CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) error
+	// Every query is scoped to the tenant, so that a report of another tenant is never found,
+	// and the moderators of a tenant only see the reports of their tenant
+	CreateReport(ctx context.Context, arg CreateReportParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) (sql.Result, error)
-	DeleteMatching(ctx context.Context, id string) error
-	DeleteUser(ctx context.Context, id string) error
-	ExistsMatching(ctx context.Context, id string) (bool, error)
-	ExistsUser(ctx context.Context, id string) (bool, error)
-	GetMatching(ctx context.Context, id string) (Matching, error)
+	// Every query is scoped to the tenant, so that a block of another tenant is never found
+	CreateUserBlock(ctx context.Context, arg CreateUserBlockParams) error
+	DeleteMatching(ctx context.Context, arg DeleteMatchingParams) error
+	DeleteUser(ctx context.Context, arg DeleteUserParams) error
+	DeleteUserBlock(ctx context.Context, arg DeleteUserBlockParams) (sql.Result, error)
+	ExistsConversation(ctx context.Context, arg ExistsConversationParams) (bool, error)
+	ExistsEmailChange(ctx context.Context, arg ExistsEmailChangeParams) (bool, error)
+	ExistsEmailVerification(ctx context.Context, arg ExistsEmailVerificationParams) (bool, error)
+	ExistsMatching(ctx context.Context, arg ExistsMatchingParams) (bool, error)
+	ExistsNotification(ctx context.Context, arg ExistsNotificationParams) (bool, error)
+	ExistsOutboxMessage(ctx context.Context, id []byte) (bool, error)
+	ExistsReport(ctx context.Context, arg ExistsReportParams) (bool, error)
+	ExistsUser(ctx context.Context, arg ExistsUserParams) (bool, error)
+	ExistsUserBlock(ctx context.Context, arg ExistsUserBlockParams) (bool, error)
+	ExistsUserByEmail(ctx context.Context, arg ExistsUserByEmailParams) (bool, error)
+	GetChatMessage(ctx context.Context, arg GetChatMessageParams) (ChatMessage, error)
+	GetConversationByMatchingID(ctx context.Context, arg GetConversationByMatchingIDParams) (Conversation, error)
+	GetEmailChangeForUpdate(ctx context.Context, arg GetEmailChangeForUpdateParams) (EmailChange, error)
+	GetEmailVerificationForUpdate(ctx context.Context, arg GetEmailVerificationForUpdateParams) (EmailVerification, error)
+	// Every query is scoped to the tenant, so that a matching of another tenant is never found
+	GetMatching(ctx context.Context, arg GetMatchingParams) (Matching, error)
+	GetMatchingByPairKey(ctx context.Context, arg GetMatchingByPairKeyParams) (Matching, error)
+	GetMatchingByPairKeyForUpdate(ctx context.Context, arg GetMatchingByPairKeyForUpdateParams) (Matching, error)
 	GetMatchingByParticipants(ctx context.Context, arg GetMatchingByParticipantsParams) (Matching, error)
-	GetUser(ctx context.Context, id string) (User, error)
+	GetMatchingQuotaUsage(ctx context.Context, arg GetMatchingQuotaUsageParams) (int32, error)
+	GetNotification(ctx context.Context, arg GetNotificationParams) (Notification, error)
+	GetNotificationDelivery(ctx context.Context, arg GetNotificationDeliveryParams) (NotificationDelivery, error)
+	GetNotificationPreference(ctx context.Context, arg GetNotificationPreferenceParams) (NotificationPreference, error)
+	GetReport(ctx context.Context, arg GetReportParams) (Report, error)
+	GetReportForUpdate(ctx context.Context, arg GetReportForUpdateParams) (Report, error)
+	// Every query is scoped to the tenant, so that a user of another tenant is never found
+	GetUser(ctx context.Context, arg GetUserParams) (User, error)
+	// Every query is scoped to the tenant, so that a plan of another tenant is never found
+	GetUserPlan(ctx context.Context, arg GetUserPlanParams) (string, error)
+	GetUserWithDeleted(ctx context.Context, arg GetUserWithDeletedParams) (User, error)
+	GetUserWithDeletedForUpdate(ctx context.Context, arg GetUserWithDeletedForUpdateParams) (User, error)
+	// Every query is scoped to the tenant, so that a usage of another tenant is never found
+	// The row of another tenant is left as it is
+	IncrementMatchingQuotaUsage(ctx context.Context, arg IncrementMatchingQuotaUsageParams) error
+	ListChatMessages(ctx context.Context, arg ListChatMessagesParams) ([]ChatMessage, error)
+	ListMatchingHistoriesByMatching(ctx context.Context, arg ListMatchingHistoriesByMatchingParams) ([]MatchingHistory, error)
+	// Matchings of pairs with a block in either direction are hidden from the lists
 	ListMatchingsByUser(ctx context.Context, arg ListMatchingsByUserParams) ([]Matching, error)
+	ListMutualMatchingsByUser(ctx context.Context, arg ListMutualMatchingsByUserParams) ([]Matching, error)
+	ListNotificationsByUserID(ctx context.Context, arg ListNotificationsByUserIDParams) ([]Notification, error)
+	ListOverdueMatchings(ctx context.Context, arg ListOverdueMatchingsParams) ([]Matching, error)
+	ListRecommendationCandidates(ctx context.Context, arg ListRecommendationCandidatesParams) ([]User, error)
+	ListRecommendationCandidatesByIDs(ctx context.Context, arg ListRecommendationCandidatesByIDsParams) ([]User, error)
+	ListReports(ctx context.Context, arg ListReportsParams) ([]Report, error)
+	ListUnreadNotificationsByUserID(ctx context.Context, arg ListUnreadNotificationsByUserIDParams) ([]Notification, error)
+	ListUnsentOutboxMessagesForUpdate(ctx context.Context, arg ListUnsentOutboxMessagesForUpdateParams) ([]Outbox, error)
+	ListUserBlocksBetween(ctx context.Context, arg ListUserBlocksBetweenParams) ([]UserBlock, error)
+	ListUserBlocksByBlocker(ctx context.Context, arg ListUserBlocksByBlockerParams) ([]UserBlock, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListUsersAfter(ctx context.Context, arg ListUsersAfterParams) ([]User, error)
+	ListUsersByIDs(ctx context.Context, arg ListUsersByIDsParams) ([]User, error)
+	ListUsersDeletedBefore(ctx context.Context, arg ListUsersDeletedBeforeParams) ([]User, error)
+	MarkChatMessagesRead(ctx context.Context, arg MarkChatMessagesReadParams) (int64, error)
+	MarkNotificationsRead(ctx context.Context, arg MarkNotificationsReadParams) (int64, error)
 	Ping(ctx context.Context) (int32, error)
+	SearchAuditLogs(ctx context.Context, arg SearchAuditLogsParams) ([]AuditLog, error)
+	UpdateConversation(ctx context.Context, arg UpdateConversationParams) error
+	UpdateEmailChange(ctx context.Context, arg UpdateEmailChangeParams) error
+	UpdateEmailVerification(ctx context.Context, arg UpdateEmailVerificationParams) error
 	UpdateMatching(ctx context.Context, arg UpdateMatchingParams) (sql.Result, error)
+	UpdateOutboxMessage(ctx context.Context, arg UpdateOutboxMessageParams) error
+	UpdateReport(ctx context.Context, arg UpdateReportParams) error
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (sql.Result, error)
+	// The row of another tenant is left as it is
+	UpsertNotificationDelivery(ctx context.Context, arg UpsertNotificationDeliveryParams) error
+	// The row of another tenant is left as it is
+	UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) error
+	// The row of another tenant is left as it is
+	UpsertUserPlan(ctx context.Context, arg UpsertUserPlanParams) error
 }
 
 var _ Querier = (*Queries)(nil)
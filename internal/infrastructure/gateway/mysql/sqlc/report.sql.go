@@ -0,0 +1,250 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: report.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const CreateReport = `-- name: CreateReport :exec
+
+INSERT INTO ` + "`" + `report` + "`" + ` (
+    id,
+    tenant_id,
+    reporter_id,
+    reported_id,
+    reason,
+    comment,
+    status,
+    assignee_id,
+    resolution,
+    resolution_note,
+    resolved_at,
+    created_at,
+    updated_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateReportParams struct {
+	ID             []byte         `json:"id"`
+	TenantID       string         `json:"tenant_id"`
+	ReporterID     []byte         `json:"reporter_id"`
+	ReportedID     []byte         `json:"reported_id"`
+	Reason         string         `json:"reason"`
+	Comment        string         `json:"comment"`
+	Status         string         `json:"status"`
+	AssigneeID     []byte         `json:"assignee_id"`
+	Resolution     sql.NullString `json:"resolution"`
+	ResolutionNote string         `json:"resolution_note"`
+	ResolvedAt     sql.NullTime   `json:"resolved_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// Every query is scoped to the tenant, so that a report of another tenant is never found,
+// and the moderators of a tenant only see the reports of their tenant
+func (q *Queries) CreateReport(ctx context.Context, arg CreateReportParams) error {
+	_, err := q.db.ExecContext(ctx, CreateReport,
+		arg.ID,
+		arg.TenantID,
+		arg.ReporterID,
+		arg.ReportedID,
+		arg.Reason,
+		arg.Comment,
+		arg.Status,
+		arg.AssigneeID,
+		arg.Resolution,
+		arg.ResolutionNote,
+		arg.ResolvedAt,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const ExistsReport = `-- name: ExistsReport :one
+SELECT EXISTS(
+    SELECT 1 FROM ` + "`" + `report` + "`" + ` WHERE tenant_id = ? AND id = ?
+)
+`
+
+type ExistsReportParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) ExistsReport(ctx context.Context, arg ExistsReportParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, ExistsReport, arg.TenantID, arg.ID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const GetReport = `-- name: GetReport :one
+SELECT reason, comment, status, resolution, resolution_note, resolved_at, created_at, updated_at, id, reporter_id, reported_id, assignee_id, tenant_id FROM ` + "`" + `report` + "`" + `
+WHERE tenant_id = ? AND id = ? LIMIT 1
+`
+
+type GetReportParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) GetReport(ctx context.Context, arg GetReportParams) (Report, error) {
+	row := q.db.QueryRowContext(ctx, GetReport, arg.TenantID, arg.ID)
+	var i Report
+	err := row.Scan(
+		&i.Reason,
+		&i.Comment,
+		&i.Status,
+		&i.Resolution,
+		&i.ResolutionNote,
+		&i.ResolvedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ID,
+		&i.ReporterID,
+		&i.ReportedID,
+		&i.AssigneeID,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const GetReportForUpdate = `-- name: GetReportForUpdate :one
+SELECT reason, comment, status, resolution, resolution_note, resolved_at, created_at, updated_at, id, reporter_id, reported_id, assignee_id, tenant_id FROM ` + "`" + `report` + "`" + `
+WHERE tenant_id = ? AND id = ? LIMIT 1
+FOR UPDATE
+`
+
+type GetReportForUpdateParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) GetReportForUpdate(ctx context.Context, arg GetReportForUpdateParams) (Report, error) {
+	row := q.db.QueryRowContext(ctx, GetReportForUpdate, arg.TenantID, arg.ID)
+	var i Report
+	err := row.Scan(
+		&i.Reason,
+		&i.Comment,
+		&i.Status,
+		&i.Resolution,
+		&i.ResolutionNote,
+		&i.ResolvedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ID,
+		&i.ReporterID,
+		&i.ReportedID,
+		&i.AssigneeID,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const ListReports = `-- name: ListReports :many
+SELECT reason, comment, status, resolution, resolution_note, resolved_at, created_at, updated_at, id, reporter_id, reported_id, assignee_id, tenant_id FROM ` + "`" + `report` + "`" + `
+WHERE tenant_id = ?
+    AND (? IS NULL OR status = ?)
+    AND (? IS NULL OR assignee_id = ?)
+ORDER BY created_at ASC
+LIMIT ? OFFSET ?
+`
+
+type ListReportsParams struct {
+	TenantID   string         `json:"tenant_id"`
+	Status     sql.NullString `json:"status"`
+	AssigneeID []byte         `json:"assignee_id"`
+	Limit      int32          `json:"limit"`
+	Offset     int32          `json:"offset"`
+}
+
+func (q *Queries) ListReports(ctx context.Context, arg ListReportsParams) ([]Report, error) {
+	rows, err := q.db.QueryContext(ctx, ListReports,
+		arg.TenantID,
+		arg.Status,
+		arg.Status,
+		arg.AssigneeID,
+		arg.AssigneeID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Report{}
+	for rows.Next() {
+		var i Report
+		if err := rows.Scan(
+			&i.Reason,
+			&i.Comment,
+			&i.Status,
+			&i.Resolution,
+			&i.ResolutionNote,
+			&i.ResolvedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ID,
+			&i.ReporterID,
+			&i.ReportedID,
+			&i.AssigneeID,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const UpdateReport = `-- name: UpdateReport :exec
+UPDATE ` + "`" + `report` + "`" + `
+SET
+    status = ?,
+    assignee_id = ?,
+    resolution = ?,
+    resolution_note = ?,
+    resolved_at = ?,
+    updated_at = ?
+WHERE tenant_id = ? AND id = ?
+`
+
+type UpdateReportParams struct {
+	Status         string         `json:"status"`
+	AssigneeID     []byte         `json:"assignee_id"`
+	Resolution     sql.NullString `json:"resolution"`
+	ResolutionNote string         `json:"resolution_note"`
+	ResolvedAt     sql.NullTime   `json:"resolved_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	TenantID       string         `json:"tenant_id"`
+	ID             []byte         `json:"id"`
+}
+
+func (q *Queries) UpdateReport(ctx context.Context, arg UpdateReportParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateReport,
+		arg.Status,
+		arg.AssigneeID,
+		arg.Resolution,
+		arg.ResolutionNote,
+		arg.ResolvedAt,
+		arg.UpdatedAt,
+		arg.TenantID,
+		arg.ID,
+	)
+	return err
+}
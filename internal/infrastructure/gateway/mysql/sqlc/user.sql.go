@@ -8,15 +8,17 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 )
 
 const CountUsers = `-- name: CountUsers :one
 SELECT COUNT(*) FROM ` + "`" + `user` + "`" + `
+WHERE tenant_id = ? AND deleted_at IS NULL
 `
 
-func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
-	row := q.db.QueryRowContext(ctx, CountUsers)
+func (q *Queries) CountUsers(ctx context.Context, tenantID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, CountUsers, tenantID)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -25,83 +27,394 @@ func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
 const CreateUser = `-- name: CreateUser :execresult
 INSERT INTO ` + "`" + `user` + "`" + ` (
     id,
+    tenant_id,
     email,
+    pending_email,
+    display_name,
+    birthdate,
+    gender,
+    bio,
+    locale,
+    interests,
+    ` + "`" + `status` + "`" + `,
+    email_verified_at,
     created_at,
-    updated_at
+    updated_at,
+    deleted_at,
+    deletion_enqueued_at
 ) VALUES (
-    ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 )
 `
 
 type CreateUserParams struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                 []byte       `json:"id"`
+	TenantID           string       `json:"tenant_id"`
+	Email              string       `json:"email"`
+	PendingEmail       string       `json:"pending_email"`
+	DisplayName        string       `json:"display_name"`
+	Birthdate          sql.NullTime `json:"birthdate"`
+	Gender             string       `json:"gender"`
+	Bio                string       `json:"bio"`
+	Locale             string       `json:"locale"`
+	Interests          string       `json:"interests"`
+	Status             string       `json:"status"`
+	EmailVerifiedAt    sql.NullTime `json:"email_verified_at"`
+	CreatedAt          time.Time    `json:"created_at"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+	DeletedAt          sql.NullTime `json:"deleted_at"`
+	DeletionEnqueuedAt sql.NullTime `json:"deletion_enqueued_at"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (sql.Result, error) {
 	return q.db.ExecContext(ctx, CreateUser,
 		arg.ID,
+		arg.TenantID,
 		arg.Email,
+		arg.PendingEmail,
+		arg.DisplayName,
+		arg.Birthdate,
+		arg.Gender,
+		arg.Bio,
+		arg.Locale,
+		arg.Interests,
+		arg.Status,
+		arg.EmailVerifiedAt,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.DeletedAt,
+		arg.DeletionEnqueuedAt,
 	)
 }
 
 const DeleteUser = `-- name: DeleteUser :exec
 DELETE FROM ` + "`" + `user` + "`" + `
-WHERE id = ?
+WHERE tenant_id = ? AND id = ?
 `
 
-func (q *Queries) DeleteUser(ctx context.Context, id string) error {
-	_, err := q.db.ExecContext(ctx, DeleteUser, id)
+type DeleteUserParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) DeleteUser(ctx context.Context, arg DeleteUserParams) error {
+	_, err := q.db.ExecContext(ctx, DeleteUser, arg.TenantID, arg.ID)
 	return err
 }
 
 const ExistsUser = `-- name: ExistsUser :one
 SELECT EXISTS(
-    SELECT 1 FROM ` + "`" + `user` + "`" + ` WHERE id = ?
+    SELECT 1 FROM ` + "`" + `user` + "`" + ` WHERE tenant_id = ? AND id = ?
+)
+`
+
+type ExistsUserParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) ExistsUser(ctx context.Context, arg ExistsUserParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, ExistsUser, arg.TenantID, arg.ID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const ExistsUserByEmail = `-- name: ExistsUserByEmail :one
+SELECT EXISTS(
+    SELECT 1 FROM ` + "`" + `user` + "`" + ` WHERE tenant_id = ? AND email = ?
 )
 `
 
-func (q *Queries) ExistsUser(ctx context.Context, id string) (bool, error) {
-	row := q.db.QueryRowContext(ctx, ExistsUser, id)
+type ExistsUserByEmailParams struct {
+	TenantID string `json:"tenant_id"`
+	Email    string `json:"email"`
+}
+
+func (q *Queries) ExistsUserByEmail(ctx context.Context, arg ExistsUserByEmailParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, ExistsUserByEmail, arg.TenantID, arg.Email)
 	var exists bool
 	err := row.Scan(&exists)
 	return exists, err
 }
 
 const GetUser = `-- name: GetUser :one
-SELECT id, email, created_at, updated_at FROM ` + "`" + `user` + "`" + `
-WHERE id = ? LIMIT 1
+
+SELECT email, created_at, updated_at, display_name, birthdate, gender, bio, locale, status, deleted_at, interests, email_verified_at, pending_email, tenant_id, id, deletion_enqueued_at FROM ` + "`" + `user` + "`" + `
+WHERE tenant_id = ? AND id = ? AND deleted_at IS NULL LIMIT 1
+`
+
+type GetUserParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+// Every query is scoped to the tenant, so that a user of another tenant is never found
+func (q *Queries) GetUser(ctx context.Context, arg GetUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, GetUser, arg.TenantID, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DisplayName,
+		&i.Birthdate,
+		&i.Gender,
+		&i.Bio,
+		&i.Locale,
+		&i.Status,
+		&i.DeletedAt,
+		&i.Interests,
+		&i.EmailVerifiedAt,
+		&i.PendingEmail,
+		&i.TenantID,
+		&i.ID,
+		&i.DeletionEnqueuedAt,
+	)
+	return i, err
+}
+
+const GetUserWithDeleted = `-- name: GetUserWithDeleted :one
+SELECT email, created_at, updated_at, display_name, birthdate, gender, bio, locale, status, deleted_at, interests, email_verified_at, pending_email, tenant_id, id, deletion_enqueued_at FROM ` + "`" + `user` + "`" + `
+WHERE tenant_id = ? AND id = ? LIMIT 1
 `
 
-func (q *Queries) GetUser(ctx context.Context, id string) (User, error) {
-	row := q.db.QueryRowContext(ctx, GetUser, id)
+type GetUserWithDeletedParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) GetUserWithDeleted(ctx context.Context, arg GetUserWithDeletedParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, GetUserWithDeleted, arg.TenantID, arg.ID)
 	var i User
 	err := row.Scan(
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DisplayName,
+		&i.Birthdate,
+		&i.Gender,
+		&i.Bio,
+		&i.Locale,
+		&i.Status,
+		&i.DeletedAt,
+		&i.Interests,
+		&i.EmailVerifiedAt,
+		&i.PendingEmail,
+		&i.TenantID,
 		&i.ID,
+		&i.DeletionEnqueuedAt,
+	)
+	return i, err
+}
+
+const GetUserWithDeletedForUpdate = `-- name: GetUserWithDeletedForUpdate :one
+SELECT email, created_at, updated_at, display_name, birthdate, gender, bio, locale, status, deleted_at, interests, email_verified_at, pending_email, tenant_id, id, deletion_enqueued_at FROM ` + "`" + `user` + "`" + `
+WHERE tenant_id = ? AND id = ? LIMIT 1
+FOR UPDATE
+`
+
+type GetUserWithDeletedForUpdateParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       []byte `json:"id"`
+}
+
+func (q *Queries) GetUserWithDeletedForUpdate(ctx context.Context, arg GetUserWithDeletedForUpdateParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, GetUserWithDeletedForUpdate, arg.TenantID, arg.ID)
+	var i User
+	err := row.Scan(
 		&i.Email,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DisplayName,
+		&i.Birthdate,
+		&i.Gender,
+		&i.Bio,
+		&i.Locale,
+		&i.Status,
+		&i.DeletedAt,
+		&i.Interests,
+		&i.EmailVerifiedAt,
+		&i.PendingEmail,
+		&i.TenantID,
+		&i.ID,
+		&i.DeletionEnqueuedAt,
 	)
 	return i, err
 }
 
+const ListRecommendationCandidates = `-- name: ListRecommendationCandidates :many
+SELECT u.email, u.created_at, u.updated_at, u.display_name, u.birthdate, u.gender, u.bio, u.locale, u.status, u.deleted_at, u.interests, u.email_verified_at, u.pending_email, u.tenant_id, u.id, u.deletion_enqueued_at FROM ` + "`" + `user` + "`" + ` u
+WHERE u.tenant_id = ?
+    AND u.id <> ?
+    AND u.deleted_at IS NULL
+    AND u.` + "`" + `status` + "`" + ` = 'active'
+    AND u.email_verified_at IS NOT NULL
+    AND NOT EXISTS (
+        SELECT 1 FROM ` + "`" + `matching` + "`" + ` m
+        WHERE (m.me_id = ? AND m.partner_id = u.id)
+            OR (m.me_id = u.id AND m.partner_id = ?)
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM ` + "`" + `user_block` + "`" + ` b
+        WHERE (b.blocker_id = ? AND b.blocked_id = u.id)
+            OR (b.blocker_id = u.id AND b.blocked_id = ?)
+    )
+ORDER BY u.updated_at DESC
+LIMIT ?
+`
+
+type ListRecommendationCandidatesParams struct {
+	TenantID string `json:"tenant_id"`
+	UserID   []byte `json:"user_id"`
+	Limit    int32  `json:"limit"`
+}
+
+func (q *Queries) ListRecommendationCandidates(ctx context.Context, arg ListRecommendationCandidatesParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, ListRecommendationCandidates,
+		arg.TenantID,
+		arg.UserID,
+		arg.UserID,
+		arg.UserID,
+		arg.UserID,
+		arg.UserID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DisplayName,
+			&i.Birthdate,
+			&i.Gender,
+			&i.Bio,
+			&i.Locale,
+			&i.Status,
+			&i.DeletedAt,
+			&i.Interests,
+			&i.EmailVerifiedAt,
+			&i.PendingEmail,
+			&i.TenantID,
+			&i.ID,
+			&i.DeletionEnqueuedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListRecommendationCandidatesByIDs = `-- name: ListRecommendationCandidatesByIDs :many
+SELECT u.email, u.created_at, u.updated_at, u.display_name, u.birthdate, u.gender, u.bio, u.locale, u.status, u.deleted_at, u.interests, u.email_verified_at, u.pending_email, u.tenant_id, u.id, u.deletion_enqueued_at FROM ` + "`" + `user` + "`" + ` u
+WHERE u.tenant_id = ?
+    AND u.id IN (/*SLICE:ids*/?)
+    AND u.id <> ?
+    AND u.deleted_at IS NULL
+    AND u.` + "`" + `status` + "`" + ` = 'active'
+    AND u.email_verified_at IS NOT NULL
+    AND NOT EXISTS (
+        SELECT 1 FROM ` + "`" + `matching` + "`" + ` m
+        WHERE (m.me_id = ? AND m.partner_id = u.id)
+            OR (m.me_id = u.id AND m.partner_id = ?)
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM ` + "`" + `user_block` + "`" + ` b
+        WHERE (b.blocker_id = ? AND b.blocked_id = u.id)
+            OR (b.blocker_id = u.id AND b.blocked_id = ?)
+    )
+`
+
+type ListRecommendationCandidatesByIDsParams struct {
+	TenantID string   `json:"tenant_id"`
+	Ids      [][]byte `json:"ids"`
+	UserID   []byte   `json:"user_id"`
+}
+
+func (q *Queries) ListRecommendationCandidatesByIDs(ctx context.Context, arg ListRecommendationCandidatesByIDsParams) ([]User, error) {
+	query := ListRecommendationCandidatesByIDs
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.TenantID)
+	if len(arg.Ids) > 0 {
+		for _, v := range arg.Ids {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:ids*/?", strings.Repeat(",?", len(arg.Ids))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:ids*/?", "NULL", 1)
+	}
+	queryParams = append(queryParams, arg.UserID)
+	queryParams = append(queryParams, arg.UserID)
+	queryParams = append(queryParams, arg.UserID)
+	queryParams = append(queryParams, arg.UserID)
+	queryParams = append(queryParams, arg.UserID)
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DisplayName,
+			&i.Birthdate,
+			&i.Gender,
+			&i.Bio,
+			&i.Locale,
+			&i.Status,
+			&i.DeletedAt,
+			&i.Interests,
+			&i.EmailVerifiedAt,
+			&i.PendingEmail,
+			&i.TenantID,
+			&i.ID,
+			&i.DeletionEnqueuedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListUsers = `-- name: ListUsers :many
-SELECT id, email, created_at, updated_at FROM ` + "`" + `user` + "`" + `
-ORDER BY created_at DESC
+SELECT email, created_at, updated_at, display_name, birthdate, gender, bio, locale, status, deleted_at, interests, email_verified_at, pending_email, tenant_id, id, deletion_enqueued_at FROM ` + "`" + `user` + "`" + `
+WHERE tenant_id = ? AND deleted_at IS NULL
+ORDER BY created_at DESC, id DESC
 LIMIT ? OFFSET ?
 `
 
 type ListUsersParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	TenantID string `json:"tenant_id"`
+	Limit    int32  `json:"limit"`
+	Offset   int32  `json:"offset"`
 }
 
 func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
-	rows, err := q.db.QueryContext(ctx, ListUsers, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, ListUsers, arg.TenantID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -110,10 +423,211 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 	for rows.Next() {
 		var i User
 		if err := rows.Scan(
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DisplayName,
+			&i.Birthdate,
+			&i.Gender,
+			&i.Bio,
+			&i.Locale,
+			&i.Status,
+			&i.DeletedAt,
+			&i.Interests,
+			&i.EmailVerifiedAt,
+			&i.PendingEmail,
+			&i.TenantID,
 			&i.ID,
+			&i.DeletionEnqueuedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUsersAfter = `-- name: ListUsersAfter :many
+SELECT email, created_at, updated_at, display_name, birthdate, gender, bio, locale, status, deleted_at, interests, email_verified_at, pending_email, tenant_id, id, deletion_enqueued_at FROM ` + "`" + `user` + "`" + `
+WHERE tenant_id = ?
+    AND deleted_at IS NULL
+    AND (
+        ? IS NULL
+        OR created_at > ?
+        OR (created_at = ? AND id > ?)
+    )
+ORDER BY created_at, id
+LIMIT ?
+`
+
+type ListUsersAfterParams struct {
+	TenantID        string       `json:"tenant_id"`
+	CursorCreatedAt sql.NullTime `json:"cursor_created_at"`
+	CursorID        []byte       `json:"cursor_id"`
+	Limit           int32        `json:"limit"`
+}
+
+func (q *Queries) ListUsersAfter(ctx context.Context, arg ListUsersAfterParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, ListUsersAfter,
+		arg.TenantID,
+		arg.CursorCreatedAt,
+		arg.CursorCreatedAt,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
 			&i.Email,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DisplayName,
+			&i.Birthdate,
+			&i.Gender,
+			&i.Bio,
+			&i.Locale,
+			&i.Status,
+			&i.DeletedAt,
+			&i.Interests,
+			&i.EmailVerifiedAt,
+			&i.PendingEmail,
+			&i.TenantID,
+			&i.ID,
+			&i.DeletionEnqueuedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUsersByIDs = `-- name: ListUsersByIDs :many
+SELECT email, created_at, updated_at, display_name, birthdate, gender, bio, locale, status, deleted_at, interests, email_verified_at, pending_email, tenant_id, id, deletion_enqueued_at FROM ` + "`" + `user` + "`" + `
+WHERE tenant_id = ? AND id IN (/*SLICE:ids*/?) AND deleted_at IS NULL
+`
+
+type ListUsersByIDsParams struct {
+	TenantID string   `json:"tenant_id"`
+	Ids      [][]byte `json:"ids"`
+}
+
+func (q *Queries) ListUsersByIDs(ctx context.Context, arg ListUsersByIDsParams) ([]User, error) {
+	query := ListUsersByIDs
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.TenantID)
+	if len(arg.Ids) > 0 {
+		for _, v := range arg.Ids {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:ids*/?", strings.Repeat(",?", len(arg.Ids))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DisplayName,
+			&i.Birthdate,
+			&i.Gender,
+			&i.Bio,
+			&i.Locale,
+			&i.Status,
+			&i.DeletedAt,
+			&i.Interests,
+			&i.EmailVerifiedAt,
+			&i.PendingEmail,
+			&i.TenantID,
+			&i.ID,
+			&i.DeletionEnqueuedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUsersDeletedBefore = `-- name: ListUsersDeletedBefore :many
+SELECT email, created_at, updated_at, display_name, birthdate, gender, bio, locale, status, deleted_at, interests, email_verified_at, pending_email, tenant_id, id, deletion_enqueued_at FROM ` + "`" + `user` + "`" + `
+WHERE tenant_id = ?
+    AND deleted_at IS NOT NULL AND deleted_at <= ?
+    AND (deletion_enqueued_at IS NULL OR deletion_enqueued_at <= ?)
+ORDER BY deleted_at
+LIMIT ?
+`
+
+type ListUsersDeletedBeforeParams struct {
+	TenantID       string       `json:"tenant_id"`
+	DeletedBefore  sql.NullTime `json:"deleted_before"`
+	EnqueuedBefore sql.NullTime `json:"enqueued_before"`
+	Limit          int32        `json:"limit"`
+}
+
+func (q *Queries) ListUsersDeletedBefore(ctx context.Context, arg ListUsersDeletedBeforeParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, ListUsersDeletedBefore,
+		arg.TenantID,
+		arg.DeletedBefore,
+		arg.EnqueuedBefore,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.Email,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DisplayName,
+			&i.Birthdate,
+			&i.Gender,
+			&i.Bio,
+			&i.Locale,
+			&i.Status,
+			&i.DeletedAt,
+			&i.Interests,
+			&i.EmailVerifiedAt,
+			&i.PendingEmail,
+			&i.TenantID,
+			&i.ID,
+			&i.DeletionEnqueuedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -132,16 +646,55 @@ const UpdateUser = `-- name: UpdateUser :execresult
 UPDATE ` + "`" + `user` + "`" + `
 SET
     email = ?,
-    updated_at = ?
-WHERE id = ?
+    pending_email = ?,
+    display_name = ?,
+    birthdate = ?,
+    gender = ?,
+    bio = ?,
+    locale = ?,
+    interests = ?,
+    ` + "`" + `status` + "`" + ` = ?,
+    email_verified_at = ?,
+    updated_at = ?,
+    deleted_at = ?,
+    deletion_enqueued_at = ?
+WHERE tenant_id = ? AND id = ?
 `
 
 type UpdateUserParams struct {
-	Email     string    `json:"email"`
-	UpdatedAt time.Time `json:"updated_at"`
-	ID        string    `json:"id"`
+	Email              string       `json:"email"`
+	PendingEmail       string       `json:"pending_email"`
+	DisplayName        string       `json:"display_name"`
+	Birthdate          sql.NullTime `json:"birthdate"`
+	Gender             string       `json:"gender"`
+	Bio                string       `json:"bio"`
+	Locale             string       `json:"locale"`
+	Interests          string       `json:"interests"`
+	Status             string       `json:"status"`
+	EmailVerifiedAt    sql.NullTime `json:"email_verified_at"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+	DeletedAt          sql.NullTime `json:"deleted_at"`
+	DeletionEnqueuedAt sql.NullTime `json:"deletion_enqueued_at"`
+	TenantID           string       `json:"tenant_id"`
+	ID                 []byte       `json:"id"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (sql.Result, error) {
-	return q.db.ExecContext(ctx, UpdateUser, arg.Email, arg.UpdatedAt, arg.ID)
+	return q.db.ExecContext(ctx, UpdateUser,
+		arg.Email,
+		arg.PendingEmail,
+		arg.DisplayName,
+		arg.Birthdate,
+		arg.Gender,
+		arg.Bio,
+		arg.Locale,
+		arg.Interests,
+		arg.Status,
+		arg.EmailVerifiedAt,
+		arg.UpdatedAt,
+		arg.DeletedAt,
+		arg.DeletionEnqueuedAt,
+		arg.TenantID,
+		arg.ID,
+	)
 }
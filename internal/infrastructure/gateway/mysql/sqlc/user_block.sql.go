@@ -0,0 +1,191 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: user_block.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const CountUserBlocksForUpdate = `-- name: CountUserBlocksForUpdate :one
+SELECT COUNT(*) FROM ` + "`" + `user_block` + "`" + `
+WHERE tenant_id = ? AND blocker_id = ? AND blocked_id = ?
+FOR UPDATE
+`
+
+type CountUserBlocksForUpdateParams struct {
+	TenantID  string `json:"tenant_id"`
+	BlockerID []byte `json:"blocker_id"`
+	BlockedID []byte `json:"blocked_id"`
+}
+
+func (q *Queries) CountUserBlocksForUpdate(ctx context.Context, arg CountUserBlocksForUpdateParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, CountUserBlocksForUpdate, arg.TenantID, arg.BlockerID, arg.BlockedID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CreateUserBlock = `-- name: CreateUserBlock :exec
+
+INSERT INTO ` + "`" + `user_block` + "`" + ` (
+    tenant_id,
+    blocker_id,
+    blocked_id,
+    created_at
+) VALUES (
+    ?, ?, ?, ?
+)
+`
+
+type CreateUserBlockParams struct {
+	TenantID  string    `json:"tenant_id"`
+	BlockerID []byte    `json:"blocker_id"`
+	BlockedID []byte    `json:"blocked_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Every query is scoped to the tenant, so that a block of another tenant is never found
+func (q *Queries) CreateUserBlock(ctx context.Context, arg CreateUserBlockParams) error {
+	_, err := q.db.ExecContext(ctx, CreateUserBlock,
+		arg.TenantID,
+		arg.BlockerID,
+		arg.BlockedID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const DeleteUserBlock = `-- name: DeleteUserBlock :execresult
+DELETE FROM ` + "`" + `user_block` + "`" + `
+WHERE tenant_id = ? AND blocker_id = ? AND blocked_id = ?
+`
+
+type DeleteUserBlockParams struct {
+	TenantID  string `json:"tenant_id"`
+	BlockerID []byte `json:"blocker_id"`
+	BlockedID []byte `json:"blocked_id"`
+}
+
+func (q *Queries) DeleteUserBlock(ctx context.Context, arg DeleteUserBlockParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, DeleteUserBlock, arg.TenantID, arg.BlockerID, arg.BlockedID)
+}
+
+const ExistsUserBlock = `-- name: ExistsUserBlock :one
+SELECT EXISTS(
+    SELECT 1 FROM ` + "`" + `user_block` + "`" + ` WHERE tenant_id = ? AND blocker_id = ? AND blocked_id = ?
+)
+`
+
+type ExistsUserBlockParams struct {
+	TenantID  string `json:"tenant_id"`
+	BlockerID []byte `json:"blocker_id"`
+	BlockedID []byte `json:"blocked_id"`
+}
+
+func (q *Queries) ExistsUserBlock(ctx context.Context, arg ExistsUserBlockParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, ExistsUserBlock, arg.TenantID, arg.BlockerID, arg.BlockedID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const ListUserBlocksBetween = `-- name: ListUserBlocksBetween :many
+SELECT created_at, blocker_id, blocked_id, tenant_id FROM ` + "`" + `user_block` + "`" + `
+WHERE tenant_id = ?
+    AND (
+        (blocker_id = ? AND blocked_id = ?)
+        OR (blocker_id = ? AND blocked_id = ?)
+    )
+`
+
+type ListUserBlocksBetweenParams struct {
+	TenantID     string `json:"tenant_id"`
+	FirstUserID  []byte `json:"first_user_id"`
+	SecondUserID []byte `json:"second_user_id"`
+}
+
+func (q *Queries) ListUserBlocksBetween(ctx context.Context, arg ListUserBlocksBetweenParams) ([]UserBlock, error) {
+	rows, err := q.db.QueryContext(ctx, ListUserBlocksBetween,
+		arg.TenantID,
+		arg.FirstUserID,
+		arg.SecondUserID,
+		arg.SecondUserID,
+		arg.FirstUserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserBlock{}
+	for rows.Next() {
+		var i UserBlock
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.BlockerID,
+			&i.BlockedID,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUserBlocksByBlocker = `-- name: ListUserBlocksByBlocker :many
+SELECT created_at, blocker_id, blocked_id, tenant_id FROM ` + "`" + `user_block` + "`" + `
+WHERE tenant_id = ? AND blocker_id = ?
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?
+`
+
+type ListUserBlocksByBlockerParams struct {
+	TenantID  string `json:"tenant_id"`
+	BlockerID []byte `json:"blocker_id"`
+	Limit     int32  `json:"limit"`
+	Offset    int32  `json:"offset"`
+}
+
+func (q *Queries) ListUserBlocksByBlocker(ctx context.Context, arg ListUserBlocksByBlockerParams) ([]UserBlock, error) {
+	rows, err := q.db.QueryContext(ctx, ListUserBlocksByBlocker,
+		arg.TenantID,
+		arg.BlockerID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserBlock{}
+	for rows.Next() {
+		var i UserBlock
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.BlockerID,
+			&i.BlockedID,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -16,6 +16,11 @@ func NewMySQLTransactionManager(db *sql.DB) *mysqlTransactionManager {
 }
 
 func (m *mysqlTransactionManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// A nested transaction joins the outer one, which commits or rolls back the whole work
+	if GetTx(ctx) != nil {
+		return fn(ctx)
+	}
+
 	tx, err := m.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/redis/entity"
+)
+
+const (
+	ChatEventTypeMessage = "message"
+	ChatEventTypeReceipt = "receipt"
+)
+
+func ToChatMessageEvent(message *model.ChatMessage) *entity.ChatEventEntity {
+	return &entity.ChatEventEntity{
+		Type: ChatEventTypeMessage,
+		Message: &entity.ChatMessageEntity{
+			ID:             message.ID.String(),
+			ConversationID: message.ConversationID.String(),
+			SenderID:       message.SenderID.String(),
+			Body:           message.Body,
+			CreatedAt:      message.CreatedAt,
+		},
+	}
+}
+
+func ToChatReceiptEvent(receipt *model.ChatMessageReceipt) *entity.ChatEventEntity {
+	return &entity.ChatEventEntity{
+		Type: ChatEventTypeReceipt,
+		Receipt: &entity.ChatReceiptEntity{
+			ConversationID: receipt.ConversationID.String(),
+			ReaderID:       receipt.ReaderID.String(),
+			UpTo:           receipt.UpTo,
+			ReadAt:         receipt.ReadAt,
+		},
+	}
+}
+
+func ChatEventToJSON(event *entity.ChatEventEntity) (string, error) {
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/redis/entity"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func ToRecommendationModels(userID uuid.UUID, entities []*entity.RecommendationEntity) ([]*model.Recommendation, error) {
+	recommendations := make([]*model.Recommendation, len(entities))
+	for i, entity := range entities {
+		if !uuid.IsValid(entity.CandidateID) {
+			return nil, errors.New("invalid UUID format")
+		}
+		recommendations[i] = &model.Recommendation{
+			UserID:      userID,
+			CandidateID: uuid.MustParse(entity.CandidateID),
+			Score:       entity.Score,
+			Scores:      entity.Scores,
+			GeneratedAt: entity.GeneratedAt,
+		}
+	}
+	return recommendations, nil
+}
+
+func ToRecommendationEntities(models []*model.Recommendation) []*entity.RecommendationEntity {
+	entities := make([]*entity.RecommendationEntity, len(models))
+	for i, model := range models {
+		entities[i] = &entity.RecommendationEntity{
+			CandidateID: model.CandidateID.String(),
+			Score:       model.Score,
+			Scores:      model.Scores,
+			GeneratedAt: model.GeneratedAt,
+		}
+	}
+	return entities
+}
+
+func RecommendationsToJSON(entities []*entity.RecommendationEntity) (string, error) {
+	bytes, err := json.Marshal(entities)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+func RecommendationsFromJSON(data string) ([]*entity.RecommendationEntity, error) {
+	var entities []*entity.RecommendationEntity
+	if err := json.Unmarshal([]byte(data), &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
@@ -10,15 +10,31 @@ import (
 )
 
 func ToUserModel(entity *entity.UserEntity) (*model.User, error) {
-	if !uuid.IsValidUUIDv7(entity.ID) {
-		return nil, errors.New("invalid UUIDv7 format")
+	// The users created before UUIDv7 keep their v4 ID
+	if !uuid.IsValid(entity.ID) {
+		return nil, errors.New("invalid UUID format")
+	}
+
+	// Entries cached before the status was introduced belong to active users
+	status := model.UserStatus(entity.Status)
+	if status == "" {
+		status = model.UserStatusActive
 	}
 
 	return &model.User{
-		ID:        uuid.MustParse(entity.ID),
-		Email:     entity.Email,
-		CreatedAt: entity.CreatedAt,
-		UpdatedAt: entity.UpdatedAt,
+		ID:              uuid.MustParse(entity.ID),
+		Email:           entity.Email,
+		PendingEmail:    entity.PendingEmail,
+		DisplayName:     entity.DisplayName,
+		Birthdate:       entity.Birthdate,
+		Gender:          model.UserGender(entity.Gender),
+		Bio:             entity.Bio,
+		Locale:          entity.Locale,
+		Interests:       entity.Interests,
+		Status:          status,
+		EmailVerifiedAt: entity.EmailVerifiedAt,
+		CreatedAt:       entity.CreatedAt,
+		UpdatedAt:       entity.UpdatedAt,
 	}, nil
 }
 
@@ -36,10 +52,19 @@ func ToUserModels(entities []*entity.UserEntity) ([]*model.User, error) {
 
 func ToUserEntity(model *model.User) *entity.UserEntity {
 	return &entity.UserEntity{
-		ID:        model.ID.String(),
-		Email:     model.Email,
-		CreatedAt: model.CreatedAt,
-		UpdatedAt: model.UpdatedAt,
+		ID:              model.ID.String(),
+		Email:           model.Email,
+		PendingEmail:    model.PendingEmail,
+		DisplayName:     model.DisplayName,
+		Birthdate:       model.Birthdate,
+		Gender:          string(model.Gender),
+		Bio:             model.Bio,
+		Locale:          model.Locale,
+		Interests:       model.Interests,
+		Status:          string(model.Status),
+		EmailVerifiedAt: model.EmailVerifiedAt,
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
 	}
 }
 
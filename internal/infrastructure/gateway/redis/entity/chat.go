@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+)
+
+// ChatEventEntity is the payload published to the channel of a user. Either Message or Receipt is set, according to Type.
+type ChatEventEntity struct {
+	Type    string             `json:"type"`
+	Message *ChatMessageEntity `json:"message,omitempty"`
+	Receipt *ChatReceiptEntity `json:"receipt,omitempty"`
+}
+
+type ChatMessageEntity struct {
+	ID             string    `json:"id"`
+	ConversationID string    `json:"conversation_id"`
+	SenderID       string    `json:"sender_id"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ChatReceiptEntity struct {
+	ConversationID string    `json:"conversation_id"`
+	ReaderID       string    `json:"reader_id"`
+	UpTo           time.Time `json:"up_to"`
+	ReadAt         time.Time `json:"read_at"`
+}
@@ -0,0 +1,12 @@
+package entity
+
+import (
+	"time"
+)
+
+type RecommendationEntity struct {
+	CandidateID string             `json:"candidate_id"`
+	Score       float64            `json:"score"`
+	Scores      map[string]float64 `json:"scores"`
+	GeneratedAt time.Time          `json:"generated_at"`
+}
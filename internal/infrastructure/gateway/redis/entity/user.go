@@ -5,8 +5,17 @@ import (
 )
 
 type UserEntity struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              string    `json:"id"`
+	Email           string    `json:"email"`
+	PendingEmail    string    `json:"pending_email,omitempty"`
+	DisplayName     string    `json:"display_name"`
+	Birthdate       time.Time `json:"birthdate"`
+	Gender          string    `json:"gender"`
+	Bio             string    `json:"bio"`
+	Locale          string    `json:"locale"`
+	Interests       []string  `json:"interests,omitempty"`
+	Status          string    `json:"status"`
+	EmailVerifiedAt time.Time `json:"email_verified_at,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/redis/dto"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/redis/entity"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// chatChannelPrefix is followed by the user ID, in the namespace of the tenant. The real-time gateway subscribes to the channel of each connected user.
+const chatChannelPrefix = "chat:user:"
+
+type ChatRedisPublisher struct {
+	client *redis.Client
+}
+
+func NewChatRedisPublisher(client *redis.Client) ChatRedisPublisher {
+	return ChatRedisPublisher{client: client}
+}
+
+// ChatChannel returns the Pub/Sub channel of the user of the tenant.
+func ChatChannel(tenantID tenant.ID, userID uuid.UUID) string {
+	return tenantKeyPrefix + tenantID.String() + ":" + chatChannelPrefix + userID.String()
+}
+
+func (p ChatRedisPublisher) PublishMessage(ctx context.Context, userID uuid.UUID, message *model.ChatMessage) error {
+	return p.publish(ctx, userID, dto.ToChatMessageEvent(message))
+}
+
+func (p ChatRedisPublisher) PublishReceipt(ctx context.Context, userID uuid.UUID, receipt *model.ChatMessageReceipt) error {
+	return p.publish(ctx, userID, dto.ToChatReceiptEvent(receipt))
+}
+
+func (p ChatRedisPublisher) publish(ctx context.Context, userID uuid.UUID, event *entity.ChatEventEntity) error {
+	jsonData, err := dto.ChatEventToJSON(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat event: %w", err)
+	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if err := p.client.Publish(ctx, ChatChannel(tenantID, userID), jsonData).Err(); err != nil {
+		return fmt.Errorf("failed to publish chat event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+const matchingQuotaKeyPrefix = "matching_quota:"
+
+type MatchingQuotaRedisRepository struct {
+	client *redis.Client
+}
+
+func NewMatchingQuotaRedisRepository(client *redis.Client) MatchingQuotaRedisRepository {
+	return MatchingQuotaRedisRepository{client: client}
+}
+
+// Add increments the counter and sets its expiry in a single MULTI/EXEC, so the counter never outlives the day.
+func (c MatchingQuotaRedisRepository) Add(ctx context.Context, userID uuid.UUID, day time.Time, delta int, expireAt time.Time) (int, error) {
+	key, err := tenantKey(ctx, matchingQuotaKeyPrefix+userID.String()+":"+day.Format(time.DateOnly))
+	if err != nil {
+		return 0, err
+	}
+	var incr *redis.IntCmd
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.IncrBy(ctx, key, int64(delta))
+		pipe.ExpireAt(ctx, key, expireAt)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to update matching quota counter: %w", err)
+	}
+	return int(incr.Val()), nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/redis/dto"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+const recommendationKeyPrefix = "recommendation:"
+
+type RecommendationRedisRepository struct {
+	client *redis.Client
+}
+
+func NewRecommendationRedisRepository(client *redis.Client) RecommendationRedisRepository {
+	return RecommendationRedisRepository{client: client}
+}
+
+func (c RecommendationRedisRepository) Store(ctx context.Context, userID uuid.UUID, recommendations []*model.Recommendation, ttl time.Duration) error {
+	jsonData, err := dto.RecommendationsToJSON(dto.ToRecommendationEntities(recommendations))
+	if err != nil {
+		return fmt.Errorf("failed to marshal recommendations: %w", err)
+	}
+
+	key, err := tenantKey(ctx, recommendationKeyPrefix+userID.String())
+	if err != nil {
+		return err
+	}
+	if err := c.client.Set(ctx, key, jsonData, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set recommendation cache: %w", err)
+	}
+
+	return nil
+}
+
+func (c RecommendationRedisRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Recommendation, error) {
+	key, err := tenantKey(ctx, recommendationKeyPrefix+userID.String())
+	if err != nil {
+		return nil, err
+	}
+	jsonData, err := c.client.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get recommendations from cache: %w", err)
+	}
+
+	entities, err := dto.RecommendationsFromJSON(jsonData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal recommendations: %w", err)
+	}
+
+	return dto.ToRecommendationModels(userID, entities)
+}
+
+func (c RecommendationRedisRepository) Remove(ctx context.Context, userID uuid.UUID) error {
+	key, err := tenantKey(ctx, recommendationKeyPrefix+userID.String())
+	if err != nil {
+		return err
+	}
+	if err := c.client.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("failed to delete recommendation cache: %w", err)
+	}
+	return nil
+}
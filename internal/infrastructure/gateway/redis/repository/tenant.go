@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+)
+
+// tenantKeyPrefix is followed by the tenant ID and the key, as in "tenant:brand-a:user:{id}".
+const tenantKeyPrefix = "tenant:"
+
+// tenantKey namespaces the key with the tenant of the context, so that a tenant never reads the keys of another.
+func tenantKey(ctx context.Context, key string) (string, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return tenantKeyPrefix + tenantID.String() + ":" + key, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
 )
 
+// userKeyPrefix is followed by the user ID, in the namespace of the tenant.
 const userKeyPrefix = "user:"
 
 type UserRedisRepository struct {
@@ -29,7 +30,10 @@ func (c UserRedisRepository) Store(ctx context.Context, user *model.User, ttl ti
 		return fmt.Errorf("failed to marshal user: %w", err)
 	}
 
-	key := userKeyPrefix + user.ID.String()
+	key, err := tenantKey(ctx, userKeyPrefix+user.ID.String())
+	if err != nil {
+		return err
+	}
 	if err := c.client.Set(ctx, key, jsonData, ttl).Err(); err != nil {
 		return fmt.Errorf("failed to set user cache: %w", err)
 	}
@@ -38,7 +42,10 @@ func (c UserRedisRepository) Store(ctx context.Context, user *model.User, ttl ti
 }
 
 func (c UserRedisRepository) FindById(ctx context.Context, id uuid.UUID) (*model.User, error) {
-	key := userKeyPrefix + id.String()
+	key, err := tenantKey(ctx, userKeyPrefix+id.String())
+	if err != nil {
+		return nil, err
+	}
 	jsonData, err := c.client.Get(ctx, key).Result()
 	if err != nil {
 		if err == redis.Nil {
@@ -55,7 +62,69 @@ func (c UserRedisRepository) FindById(ctx context.Context, id uuid.UUID) (*model
 	return dto.ToUserModel(entity)
 }
 
+// FindByIds fetches the cached users with a single MGET and returns only the hits.
+func (c UserRedisRepository) FindByIds(ctx context.Context, ids []uuid.UUID) ([]*model.User, error) {
+	if len(ids) == 0 {
+		return []*model.User{}, nil
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		key, err := tenantKey(ctx, userKeyPrefix+id.String())
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users from cache: %w", err)
+	}
+
+	users := make([]*model.User, 0, len(values))
+	for _, value := range values {
+		jsonData, ok := value.(string)
+		if !ok {
+			continue
+		}
+		entity, err := dto.FromJSON(jsonData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal user: %w", err)
+		}
+		user, err := dto.ToUserModel(entity)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (c UserRedisRepository) StoreMany(ctx context.Context, users []*model.User, ttl time.Duration) error {
+	if len(users) == 0 {
+		return nil
+	}
+	pipe := c.client.Pipeline()
+	for _, user := range users {
+		jsonData, err := dto.ToJSON(dto.ToUserEntity(user))
+		if err != nil {
+			return fmt.Errorf("failed to marshal user: %w", err)
+		}
+		key, err := tenantKey(ctx, userKeyPrefix+user.ID.String())
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, key, jsonData, ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to set user cache: %w", err)
+	}
+	return nil
+}
+
 func (c UserRedisRepository) Remove(ctx context.Context, id uuid.UUID) error {
-	key := userKeyPrefix + id.String()
+	key, err := tenantKey(ctx, userKeyPrefix+id.String())
+	if err != nil {
+		return err
+	}
 	return c.client.Del(ctx, key).Err()
 }
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+)
+
+type SMTPConfig struct {
+	Host string
+	Port string
+	// Username and Password are optional. A local stand-in like MailHog accepts mails without authentication.
+	Username string
+	Password string
+	From     string
+}
+
+type MailerSMTPRepository struct {
+	cfg   SMTPConfig
+	clock clock.Clock
+}
+
+func NewMailerSMTPRepository(cfg SMTPConfig, clock clock.Clock) *MailerSMTPRepository {
+	return &MailerSMTPRepository{cfg: cfg, clock: clock}
+}
+
+func (r *MailerSMTPRepository) Send(ctx context.Context, mail *model.Mail) error {
+	var auth smtp.Auth
+	if r.cfg.Username != "" {
+		auth = smtp.PlainAuth("", r.cfg.Username, r.cfg.Password, r.cfg.Host)
+	}
+	addr := net.JoinHostPort(r.cfg.Host, r.cfg.Port)
+	if err := smtp.SendMail(addr, auth, r.cfg.From, []string{mail.To}, r.message(mail)); err != nil {
+		return fmt.Errorf("failed to send mail to %s: %w", mail.To, err)
+	}
+	return nil
+}
+
+func (r *MailerSMTPRepository) message(mail *model.Mail) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", r.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", mail.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mail.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", r.clock.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(mail.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
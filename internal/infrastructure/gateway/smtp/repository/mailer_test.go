@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+)
+
+// TestMailerSMTPRepository_Send sends to the MailHog of compose.yml, whose web UI shows the received mails.
+func TestMailerSMTPRepository_Send(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SMTPConfig
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			config: SMTPConfig{
+				Host: "localhost",
+				Port: "1025",
+				From: "no-reply@example.com",
+			},
+			wantErr: false,
+		},
+		{
+			name: "NG",
+			config: SMTPConfig{
+				Host: "nonexistent",
+				Port: "1025",
+				From: "no-reply@example.com",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mailer := NewMailerSMTPRepository(tt.config, clock.New())
+			err := mailer.Send(context.Background(), &model.Mail{
+				To:      "test@example.com",
+				Subject: "Test",
+				Body:    "This is a test.\nSecond line.",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
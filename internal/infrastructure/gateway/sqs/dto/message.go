@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
 )
 
@@ -16,6 +18,6 @@ func ToMessageEntity(message *model.Message) *MessageEntity {
 	}
 }
 
-func ToMessageModel(entity *MessageEntity) *model.Message {
-	return model.NewMessage(entity.Body, entity.MessageAttributes)
+func ToMessageModel(entity *MessageEntity, now time.Time) *model.Message {
+	return model.NewMessage(entity.Body, entity.MessageAttributes, now)
 }
@@ -14,7 +14,10 @@ type Key string
 
 // 実際のキューIDとキュー名のマップにすることで環境差異を吸収
 const (
-	SQSKeySample Key = "sample"
+	SQSKeySample       Key = "sample"
+	SQSKeyModeration   Key = "moderation"
+	SQSKeyMail         Key = "mail"
+	SQSKeyNotification Key = "notification"
 )
 
 type SQSConfig struct {
@@ -10,35 +10,61 @@ import (
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/sqs/dto"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
 )
 
 // SQSRepository はAWS SQSを使用したメッセージキューリポジトリの実装です
 type SQSRepository struct {
 	sqs       *sqs.Client
 	queueName string
+	clock     clock.Clock
 }
 
 // NewSQSRepository は新しいSQSRepositoryインスタンスを作成します
-func NewSQSRepository(sqs *sqs.Client, queueName string) repository.MessageQueueRepository {
+func NewSQSRepository(sqs *sqs.Client, queueName string, clock clock.Clock) repository.MessageQueueRepository {
 	return &SQSRepository{
 		sqs:       sqs,
 		queueName: queueName,
+		clock:     clock,
 	}
 }
 
 // Send はメッセージをSQSキューに送信します
+// メッセージにはテナントの属性を付与し、属性もコンテキストのテナントもない場合は送信しません
 func (r *SQSRepository) Send(ctx context.Context, message *model.Message) error {
 	messageDTO := message.Send()
+	attributes, err := withTenantAttribute(ctx, messageDTO.MessageAttributes)
+	if err != nil {
+		return err
+	}
 	input := &sqs.SendMessageInput{
 		QueueUrl:          aws.String(r.queueName),
 		MessageBody:       aws.String(messageDTO.Body),
-		MessageAttributes: dto.ToSQSMessageAttributes(messageDTO.MessageAttributes),
+		MessageAttributes: dto.ToSQSMessageAttributes(attributes),
 	}
 
-	_, err := r.sqs.SendMessage(ctx, input)
+	_, err = r.sqs.SendMessage(ctx, input)
 	return err
 }
 
+// withTenantAttribute はテナントの属性を付与した属性を返します
+// 中継されるメッセージのように既に属性を持つ場合はその値を優先します
+func withTenantAttribute(ctx context.Context, attributes model.MessageAttributes) (model.MessageAttributes, error) {
+	if _, ok := attributes[tenant.MessageAttribute]; ok {
+		return attributes, nil
+	}
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := model.MessageAttributes{tenant.MessageAttribute: tenantID.String()}
+	for k, v := range attributes {
+		result[k] = v
+	}
+	return result, nil
+}
+
 // Receive はSQSキューからメッセージを受信します
 func (r *SQSRepository) Receive(ctx context.Context, opts *repository.ReceiveMessageOptions) ([]*model.Message, error) {
 	if opts == nil {
@@ -70,9 +96,10 @@ func (r *SQSRepository) Receive(ctx context.Context, opts *repository.ReceiveMes
 		return nil, err
 	}
 
+	now := r.clock.Now()
 	messages := make([]*model.Message, len(output.Messages))
 	for i, msg := range output.Messages {
-		message := dto.ToMessageModel(dto.FromSQSMessage(msg))
+		message := dto.ToMessageModel(dto.FromSQSMessage(msg), now)
 		message.SetReceiptHandle(*msg.ReceiptHandle)
 		messages[i] = message
 	}
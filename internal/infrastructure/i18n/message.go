@@ -0,0 +1,370 @@
+// Package i18n holds the messages shown to the clients, in the languages they accept.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+)
+
+// Languages are the languages of the messages, the first is the fallback.
+var Languages = []language.Tag{language.English, language.Japanese}
+
+var languageMatcher = language.NewMatcher(Languages)
+
+var (
+	en = language.English
+	ja = language.Japanese
+)
+
+// messages are the templates of the error messages by reason and language. A template refers to the details of the error as {name}.
+var messages = map[domainerr.Reason]map[language.Tag]string{
+	domainerr.ReasonInternal: {
+		en: "Internal server error",
+		ja: "サーバー内部でエラーが発生しました",
+	},
+
+	domainerr.ReasonRequestBodyInvalid: {
+		en: "Invalid request body",
+		ja: "リクエストボディが不正です",
+	},
+	domainerr.ReasonParameterInvalid: {
+		en: "Invalid {parameter}",
+		ja: "{parameter} が不正です",
+	},
+	domainerr.ReasonParameterRequired: {
+		en: "Missing {parameter}",
+		ja: "{parameter} を指定してください",
+	},
+	domainerr.ReasonIncludePathUnsupported: {
+		en: "Unsupported include path {include}",
+		ja: "include に {include} は指定できません",
+	},
+
+	domainerr.ReasonAuthRequired: {
+		en: "Authentication is required",
+		ja: "認証が必要です",
+	},
+	domainerr.ReasonAuthTokenInvalid: {
+		en: "Invalid token",
+		ja: "トークンが不正です",
+	},
+	domainerr.ReasonAuthRoleDenied: {
+		en: "The {role} role is required",
+		ja: "{role} 権限が必要です",
+	},
+	domainerr.ReasonAuthUserDenied: {
+		en: "Only the user {id} can access this resource",
+		ja: "このリソースにはユーザー {id} のみアクセスできます",
+	},
+	domainerr.ReasonTenantRequired: {
+		en: "Tenant is required",
+		ja: "テナントの指定が必要です",
+	},
+	domainerr.ReasonTenantUnknown: {
+		en: "Unknown tenant",
+		ja: "テナントが存在しません",
+	},
+	domainerr.ReasonTenantMismatch: {
+		en: "Tenant does not match the token",
+		ja: "テナントがトークンと一致しません",
+	},
+
+	domainerr.ReasonUserNotFound: {
+		en: "User not found",
+		ja: "ユーザーが見つかりません",
+	},
+	domainerr.ReasonUsersBatchSizeInvalid: {
+		en: "ids must contain between 1 and {max} items",
+		ja: "ids は 1 件以上 {max} 件以下で指定してください",
+	},
+	domainerr.ReasonUserCannotMatch: {
+		en: "User cannot match",
+		ja: "このユーザーはマッチングできません",
+	},
+	domainerr.ReasonUserCannotBeReactivated: {
+		en: "User cannot be reactivated",
+		ja: "このユーザーは復帰できません",
+	},
+	domainerr.ReasonUserNotActive: {
+		en: "User is not active",
+		ja: "ユーザーは有効ではありません",
+	},
+	domainerr.ReasonUserSuspended: {
+		en: "User is suspended",
+		ja: "ユーザーは利用停止中です",
+	},
+	domainerr.ReasonUserNotSuspended: {
+		en: "User is not suspended",
+		ja: "ユーザーは利用停止されていません",
+	},
+	domainerr.ReasonUserWithdrawn: {
+		en: "User is withdrawn",
+		ja: "ユーザーは退会済みです",
+	},
+	domainerr.ReasonUserNotWithdrawn: {
+		en: "User is not withdrawn",
+		ja: "ユーザーは退会していません",
+	},
+	domainerr.ReasonUserGracePeriodExpired: {
+		en: "User withdrawal grace period has expired",
+		ja: "退会の猶予期間が過ぎています",
+	},
+	domainerr.ReasonUserEmailVerified: {
+		en: "Email is already verified",
+		ja: "メールアドレスは認証済みです",
+	},
+	domainerr.ReasonUserEmailEmpty: {
+		en: "Email is empty",
+		ja: "メールアドレスが空です",
+	},
+	domainerr.ReasonUserEmailUnchanged: {
+		en: "Email is unchanged",
+		ja: "メールアドレスが変更されていません",
+	},
+	domainerr.ReasonUserEmailChangeNotPending: {
+		en: "Email change is not pending",
+		ja: "メールアドレスの変更は申請されていません",
+	},
+	domainerr.ReasonEmailAlreadyUsed: {
+		en: "Email is already used",
+		ja: "メールアドレスはすでに使われています",
+	},
+	domainerr.ReasonEmailCannotBeChanged: {
+		en: "Email cannot be changed",
+		ja: "メールアドレスを変更できません",
+	},
+	domainerr.ReasonEmailTokenInvalid: {
+		en: "Token is invalid",
+		ja: "トークンが不正です",
+	},
+
+	domainerr.ReasonEmailVerificationNotFound: {
+		en: "Verification not found",
+		ja: "メール認証が見つかりません",
+	},
+	domainerr.ReasonEmailVerificationUnusable: {
+		en: "Verification token cannot be used",
+		ja: "認証トークンは使用できません",
+	},
+	domainerr.ReasonEmailVerificationUsed: {
+		en: "Email verification is already used",
+		ja: "メール認証はすでに使用されています",
+	},
+	domainerr.ReasonEmailVerificationExpired: {
+		en: "Email verification is expired",
+		ja: "メール認証の有効期限が切れています",
+	},
+	domainerr.ReasonEmailVerificationEmailMismatch: {
+		en: "Email verification was issued for another email",
+		ja: "メール認証は別のメールアドレスに対して発行されています",
+	},
+	domainerr.ReasonEmailVerificationTooFrequent: {
+		en: "Email verification was requested {limit} times recently, try again later",
+		ja: "メール認証が最近 {limit} 回リクエストされています。しばらくしてから再度お試しください",
+	},
+
+	domainerr.ReasonEmailChangeNotFound: {
+		en: "Email change not found",
+		ja: "メールアドレスの変更が見つかりません",
+	},
+	domainerr.ReasonEmailChangeUnconfirmable: {
+		en: "Email change cannot be confirmed",
+		ja: "メールアドレスの変更を確定できません",
+	},
+	domainerr.ReasonEmailChangeUnrevertable: {
+		en: "Email change cannot be undone",
+		ja: "メールアドレスの変更を取り消せません",
+	},
+	domainerr.ReasonEmailChangeSuperseded: {
+		en: "Email change was superseded or undone",
+		ja: "メールアドレスの変更は置き換えられたか取り消されています",
+	},
+	domainerr.ReasonEmailChangeConfirmed: {
+		en: "Email change is already confirmed",
+		ja: "メールアドレスの変更は確定済みです",
+	},
+	domainerr.ReasonEmailChangeReverted: {
+		en: "Email change is already undone",
+		ja: "メールアドレスの変更は取り消し済みです",
+	},
+	domainerr.ReasonEmailChangeExpired: {
+		en: "Email change is expired",
+		ja: "メールアドレスの変更の有効期限が切れています",
+	},
+	domainerr.ReasonEmailChangeUndoExpired: {
+		en: "Email change can no longer be undone",
+		ja: "メールアドレスの変更はもう取り消せません",
+	},
+
+	domainerr.ReasonMatchingNotFound: {
+		en: "Matching not found",
+		ja: "マッチングが見つかりません",
+	},
+	domainerr.ReasonMatchingAlreadyExists: {
+		en: "Matching already exists",
+		ja: "マッチングはすでに存在します",
+	},
+	domainerr.ReasonMatchingNotAllowed: {
+		en: "Matching with the user is not allowed",
+		ja: "このユーザーとはマッチングできません",
+	},
+	domainerr.ReasonMatchingMeOrPartnerIDRequired: {
+		en: "Matching users are required",
+		ja: "マッチングするユーザーの指定が必要です",
+	},
+	domainerr.ReasonMatchingStatusRequired: {
+		en: "Matching status is required",
+		ja: "マッチングの状態の指定が必要です",
+	},
+	domainerr.ReasonMatchingStatusInvalid: {
+		en: "Matching status is invalid",
+		ja: "マッチングの状態が不正です",
+	},
+	domainerr.ReasonMatchingNotPending: {
+		en: "Matching is not pending",
+		ja: "マッチングは承認待ちではありません",
+	},
+	domainerr.ReasonMatchingExpired: {
+		en: "Matching is expired",
+		ja: "マッチングの有効期限が切れています",
+	},
+	domainerr.ReasonMatchingNotOverdue: {
+		en: "Matching is not overdue",
+		ja: "マッチングはまだ期限切れではありません",
+	},
+	domainerr.ReasonMatchingTransitionNotAllowed: {
+		en: "Matching status does not allow the action",
+		ja: "現在のマッチングの状態ではこの操作はできません",
+	},
+	domainerr.ReasonMatchingActorNotAllowed: {
+		en: "Matching action is not allowed for the user",
+		ja: "このユーザーはマッチングに対してこの操作を行えません",
+	},
+	domainerr.ReasonMatchingQuotaExhausted: {
+		en: "Daily matching quota of {limit} is exhausted",
+		ja: "1日のマッチング上限（{limit} 件）に達しました",
+	},
+
+	domainerr.ReasonUserBlockNotFound: {
+		en: "Block not found",
+		ja: "ブロックが見つかりません",
+	},
+	domainerr.ReasonUserBlockAlreadyExists: {
+		en: "User is already blocked",
+		ja: "ユーザーはすでにブロックされています",
+	},
+	domainerr.ReasonUserBlockSelf: {
+		en: "User cannot block themselves",
+		ja: "自分自身をブロックすることはできません",
+	},
+
+	domainerr.ReasonReportNotFound: {
+		en: "Report not found",
+		ja: "通報が見つかりません",
+	},
+	domainerr.ReasonReportInvalid: {
+		en: "Invalid report",
+		ja: "通報の内容が不正です",
+	},
+	domainerr.ReasonReportNotChangeable: {
+		en: "Report cannot be changed in its current status",
+		ja: "現在の通報の状態では変更できません",
+	},
+	domainerr.ReasonReportSelf: {
+		en: "User cannot report themselves",
+		ja: "自分自身を通報することはできません",
+	},
+	domainerr.ReasonReportResolved: {
+		en: "Report is already resolved",
+		ja: "通報は対応済みです",
+	},
+	domainerr.ReasonReportNotAssigned: {
+		en: "Report is not assigned",
+		ja: "通報に担当者が割り当てられていません",
+	},
+	domainerr.ReasonReportAssigneeInvalid: {
+		en: "Report assignee is invalid",
+		ja: "通報の担当者が不正です",
+	},
+	domainerr.ReasonReportResolutionInvalid: {
+		en: "Report resolution is invalid",
+		ja: "通報の対応内容が不正です",
+	},
+
+	domainerr.ReasonConversationNotFound: {
+		en: "Conversation not found",
+		ja: "会話が見つかりません",
+	},
+	domainerr.ReasonConversationArchived: {
+		en: "Conversation is archived",
+		ja: "会話はアーカイブされています",
+	},
+	domainerr.ReasonConversationNotMutual: {
+		en: "Matching is not accepted",
+		ja: "マッチングが成立していません",
+	},
+	domainerr.ReasonConversationNotParticipant: {
+		en: "User is not a participant of the conversation",
+		ja: "ユーザーは会話の参加者ではありません",
+	},
+	domainerr.ReasonMessagingNotAllowed: {
+		en: "Messaging the user is not allowed",
+		ja: "このユーザーにはメッセージを送れません",
+	},
+	domainerr.ReasonChatMessageNotFound: {
+		en: "Message not found",
+		ja: "メッセージが見つかりません",
+	},
+	domainerr.ReasonChatMessageInvalid: {
+		en: "Invalid message",
+		ja: "メッセージが不正です",
+	},
+	domainerr.ReasonChatMessageCursorInvalid: {
+		en: "Invalid cursor",
+		ja: "カーソルが不正です",
+	},
+
+	domainerr.ReasonNotificationNotFound: {
+		en: "Notification not found",
+		ja: "通知が見つかりません",
+	},
+	domainerr.ReasonNotificationTypeUnknown: {
+		en: "Unknown notification type",
+		ja: "通知の種類が不正です",
+	},
+	domainerr.ReasonNotificationChannelUnknown: {
+		en: "Invalid notification channel",
+		ja: "通知チャネルが不正です",
+	},
+}
+
+// MatchLanguage returns the language of the messages that best fits an Accept-Language header.
+func MatchLanguage(acceptLanguage string) language.Tag {
+	_, index := language.MatchStrings(languageMatcher, acceptLanguage)
+	return Languages[index]
+}
+
+// ErrorMessage returns the message of the error in the language, or the message it was created with
+// when its reason has no template.
+func ErrorMessage(err *domainerr.DomainError, lang language.Tag) string {
+	templates, ok := messages[err.Reason]
+	if !ok {
+		return err.Message
+	}
+	message, ok := templates[lang]
+	if !ok {
+		message = templates[Languages[0]]
+	}
+	if len(err.Details) == 0 || !strings.Contains(message, "{") {
+		return message
+	}
+	replacements := make([]string, 0, len(err.Details)*2)
+	for name, value := range err.Details {
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(message)
+}
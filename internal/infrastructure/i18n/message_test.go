@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+)
+
+func TestMessages(t *testing.T) {
+	for _, reason := range domainerr.Reasons() {
+		for _, lang := range Languages {
+			if messages[reason][lang] == "" {
+				t.Errorf("messages[%s] has no %s message", reason, lang)
+			}
+		}
+	}
+}
+
+func TestMatchLanguage(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		want           language.Tag
+	}{
+		{acceptLanguage: "", want: language.English},
+		{acceptLanguage: "ja", want: language.Japanese},
+		{acceptLanguage: "ja-JP,ja;q=0.9,en;q=0.8", want: language.Japanese},
+		{acceptLanguage: "fr-FR,en;q=0.5", want: language.English},
+		{acceptLanguage: "de", want: language.English},
+		{acceptLanguage: "invalid;;", want: language.English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			if got := MatchLanguage(tt.acceptLanguage); got != tt.want {
+				t.Errorf("MatchLanguage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	err := domainerr.New(domainerr.ReasonMatchingQuotaExhausted, nil, map[string]interface{}{"limit": 10})
+	if want := "Daily matching quota of 10 is exhausted"; ErrorMessage(err, language.English) != want {
+		t.Errorf("ErrorMessage() = %q, want %q", ErrorMessage(err, language.English), want)
+	}
+	if want := "1日のマッチング上限（10 件）に達しました"; ErrorMessage(err, language.Japanese) != want {
+		t.Errorf("ErrorMessage() = %q, want %q", ErrorMessage(err, language.Japanese), want)
+	}
+
+	adhoc := domainerr.NewDomainError(domainerr.InvalidArgument, "Ad hoc", nil, nil)
+	if got := ErrorMessage(adhoc, language.Japanese); got != "Ad hoc" {
+		t.Errorf("ErrorMessage() without reason = %q", got)
+	}
+}
@@ -0,0 +1,28 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type metadataKey struct{}
+
+// Metadata tells who made a change and from where. The controllers put it into the context.
+type Metadata struct {
+	// ActorID is the user making the change. Nil means the system, or an anonymous caller.
+	ActorID   uuid.UUID
+	RequestID string
+	Source    model.AuditSource
+}
+
+func WithMetadata(ctx context.Context, metadata Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+// MetadataFromContext returns the metadata in the context, or the zero value when there is none.
+func MetadataFromContext(ctx context.Context) Metadata {
+	metadata, _ := ctx.Value(metadataKey{}).(Metadata)
+	return metadata
+}
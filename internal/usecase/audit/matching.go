@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// MatchingInteractor records an audit log of the mutating matching usecases. The others pass through to the decorated one.
+// Expiring overdue matchings is a batch of the system and is not audited one by one.
+type MatchingInteractor struct {
+	port.MatchingUsecase
+	recorder     recorder
+	matchingRepo repository.MatchingRepository
+}
+
+func NewMatchingInteractor(
+	matchingUsecase port.MatchingUsecase,
+	txManager transaction.Manager,
+	auditRepo repository.AuditLogRepository,
+	matchingRepo repository.MatchingRepository,
+	clock clock.Clock,
+) MatchingInteractor {
+	return MatchingInteractor{
+		MatchingUsecase: matchingUsecase,
+		recorder:        recorder{txManager: txManager, auditRepo: auditRepo, clock: clock},
+		matchingRepo:    matchingRepo,
+	}
+}
+
+func (i MatchingInteractor) Create(ctx context.Context, input *port.CreateMatchingInput) (*port.CreateMatchingOutput, error) {
+	return record(ctx, i.recorder, "matching.create", i.MatchingUsecase.Create, input,
+		func(ctx context.Context) ([]locked, error) { return i.lock(ctx, input.MeID, input.PartnerID) },
+		func(output *port.CreateMatchingOutput) []target { return i.targets(output.Matching) },
+	)
+}
+
+func (i MatchingInteractor) Accept(ctx context.Context, input *port.AcceptMatchingInput) (*port.AcceptMatchingOutput, error) {
+	return record(ctx, i.recorder, "matching.accept", i.MatchingUsecase.Accept, input,
+		func(ctx context.Context) ([]locked, error) { return i.lock(ctx, input.MeID, input.PartnerID) },
+		func(output *port.AcceptMatchingOutput) []target { return i.targets(output.Matching) },
+	)
+}
+
+func (i MatchingInteractor) Reject(ctx context.Context, input *port.RejectMatchingInput) (*port.RejectMatchingOutput, error) {
+	return record(ctx, i.recorder, "matching.reject", i.MatchingUsecase.Reject, input,
+		func(ctx context.Context) ([]locked, error) { return i.lock(ctx, input.MeID, input.PartnerID) },
+		func(output *port.RejectMatchingOutput) []target { return i.targets(output.Matching) },
+	)
+}
+
+func (i MatchingInteractor) Cancel(ctx context.Context, input *port.CancelMatchingInput) (*port.CancelMatchingOutput, error) {
+	return record(ctx, i.recorder, "matching.cancel", i.MatchingUsecase.Cancel, input,
+		func(ctx context.Context) ([]locked, error) { return i.lock(ctx, input.MeID, input.PartnerID) },
+		func(output *port.CancelMatchingOutput) []target { return i.targets(output.Matching) },
+	)
+}
+
+func (i MatchingInteractor) Unmatch(ctx context.Context, input *port.UnmatchMatchingInput) (*port.UnmatchMatchingOutput, error) {
+	return record(ctx, i.recorder, "matching.unmatch", i.MatchingUsecase.Unmatch, input,
+		func(ctx context.Context) ([]locked, error) { return i.lock(ctx, input.MeID, input.PartnerID) },
+		func(output *port.UnmatchMatchingOutput) []target { return i.targets(output.Matching) },
+	)
+}
+
+// lock locks the matching of the pair, which Create also changes when the pair already has one.
+func (i MatchingInteractor) lock(ctx context.Context, meID, partnerID uuid.UUID) ([]locked, error) {
+	return lockMatching(ctx, i.matchingRepo, meID, partnerID)
+}
+
+func (i MatchingInteractor) targets(matching *model.Matching) []target {
+	return []target{matchingTarget(i.matchingRepo, matching.ID)}
+}
@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+)
+
+// target is an aggregate changed by a usecase, with how to take its snapshot.
+type target struct {
+	typ      model.AuditTargetType
+	id       string
+	snapshot func(ctx context.Context) (model.AuditSnapshot, error)
+}
+
+// locked is the snapshot of an aggregate before a usecase changes it, taken with a locking read in its transaction.
+type locked struct {
+	typ      model.AuditTargetType
+	id       string
+	snapshot model.AuditSnapshot
+}
+
+type recorder struct {
+	txManager transaction.Manager
+	auditRepo repository.AuditLogRepository
+	clock     clock.Clock
+}
+
+// record runs the usecase in a transaction and appends an audit log for each of its targets in the same transaction,
+// so that a change is never committed without its log. The decorated interactor joins the transaction,
+// so its side effects go through the outbox or the event hooks, which wait for this transaction to commit or roll back.
+//
+// lock takes the snapshots before the change with locking reads, so that no other transaction changes the targets
+// until this one ends. A target without a snapshot before the change is created by the usecase.
+// The log keeps only the fields that changed, each with its value before and after.
+func record[In, Out any](
+	ctx context.Context,
+	r recorder,
+	action string,
+	do func(ctx context.Context, input *In) (*Out, error),
+	input *In,
+	lock func(ctx context.Context) ([]locked, error),
+	targets func(output *Out) []target,
+) (*Out, error) {
+	metadata := MetadataFromContext(ctx)
+	var output *Out
+	err := r.txManager.Do(ctx, func(txCtx context.Context) error {
+		befores := map[string]model.AuditSnapshot{}
+		if lock != nil {
+			locks, err := lock(txCtx)
+			if err != nil {
+				return err
+			}
+			for _, l := range locks {
+				befores[targetKey(l.typ, l.id)] = l.snapshot
+			}
+		}
+		var err error
+		if output, err = do(txCtx, input); err != nil {
+			return err
+		}
+		for _, t := range targets(output) {
+			before := befores[targetKey(t.typ, t.id)]
+			after, err := t.snapshot(txCtx)
+			if err != nil {
+				return err
+			}
+			log := model.NewAuditLog(model.InputAuditLogParams{
+				ActorID:    metadata.ActorID,
+				Action:     action,
+				TargetType: t.typ,
+				TargetID:   t.id,
+				Before:     before,
+				After:      after,
+				RequestID:  metadata.RequestID,
+				Source:     metadata.Source,
+			}, r.clock.Now())
+			if _, err := r.auditRepo.Save(txCtx, log); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func targetKey(typ model.AuditTargetType, id string) string {
+	return string(typ) + "/" + id
+}
+
+// formatTime renders a time of a snapshot, where the zero time is empty.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+)
+
+// ReportInteractor records an audit log of the mutating report usecases. The others pass through to the decorated one.
+type ReportInteractor struct {
+	port.ReportUsecase
+	recorder   recorder
+	reportRepo repository.ReportRepository
+	userRepo   repository.UserRepository
+}
+
+func NewReportInteractor(
+	reportUsecase port.ReportUsecase,
+	txManager transaction.Manager,
+	auditRepo repository.AuditLogRepository,
+	reportRepo repository.ReportRepository,
+	userRepo repository.UserRepository,
+	clock clock.Clock,
+) ReportInteractor {
+	return ReportInteractor{
+		ReportUsecase: reportUsecase,
+		recorder:      recorder{txManager: txManager, auditRepo: auditRepo, clock: clock},
+		reportRepo:    reportRepo,
+		userRepo:      userRepo,
+	}
+}
+
+func (i ReportInteractor) Create(ctx context.Context, input *port.CreateReportInput) (*port.CreateReportOutput, error) {
+	return record(ctx, i.recorder, "report.create", i.ReportUsecase.Create, input, nil,
+		func(output *port.CreateReportOutput) []target {
+			return []target{reportTarget(i.reportRepo, output.Report.ID)}
+		},
+	)
+}
+
+func (i ReportInteractor) Assign(ctx context.Context, input *port.AssignReportInput) (*port.AssignReportOutput, error) {
+	return record(ctx, i.recorder, "report.assign", i.ReportUsecase.Assign, input,
+		func(ctx context.Context) ([]locked, error) {
+			_, locks, err := lockReport(ctx, i.reportRepo, input.ID)
+			return locks, err
+		},
+		func(output *port.AssignReportOutput) []target {
+			return []target{reportTarget(i.reportRepo, output.Report.ID)}
+		},
+	)
+}
+
+// Resolve also records the reported user suspended by the resolution, if any.
+func (i ReportInteractor) Resolve(ctx context.Context, input *port.ResolveReportInput) (*port.ResolveReportOutput, error) {
+	return record(ctx, i.recorder, "report.resolve", i.ReportUsecase.Resolve, input,
+		func(ctx context.Context) ([]locked, error) {
+			report, locks, err := lockReport(ctx, i.reportRepo, input.ID)
+			if err != nil || report == nil {
+				return nil, err
+			}
+			user, err := lockUser(ctx, i.userRepo, report.ReportedID)
+			if err != nil {
+				return nil, err
+			}
+			return append(locks, user...), nil
+		},
+		func(output *port.ResolveReportOutput) []target {
+			targets := []target{reportTarget(i.reportRepo, output.Report.ID)}
+			if output.SuspendedUser != nil {
+				targets = append(targets, userTarget(i.userRepo, output.SuspendedUser.ID))
+			}
+			return targets
+		},
+	)
+}
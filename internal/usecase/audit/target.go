@@ -0,0 +1,158 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func userTarget(userRepo repository.UserRepository, id uuid.UUID) target {
+	return target{
+		typ: model.AuditTargetUser,
+		id:  id.String(),
+		snapshot: func(ctx context.Context) (model.AuditSnapshot, error) {
+			user, err := userRepo.FindByIdWithDeleted(ctx, id)
+			if err != nil || user == nil {
+				return nil, err
+			}
+			return userSnapshot(user), nil
+		},
+	}
+}
+
+func lockUser(ctx context.Context, userRepo repository.UserRepository, id uuid.UUID) ([]locked, error) {
+	user, err := userRepo.FindByIdWithDeletedForUpdate(ctx, id)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return []locked{{typ: model.AuditTargetUser, id: id.String(), snapshot: userSnapshot(user)}}, nil
+}
+
+func userSnapshot(user *model.User) model.AuditSnapshot {
+	return model.AuditSnapshot{
+		"email":           user.Email,
+		"pendingEmail":    user.PendingEmail,
+		"displayName":     user.DisplayName,
+		"birthdate":       formatTime(user.Birthdate),
+		"gender":          string(user.Gender),
+		"bio":             user.Bio,
+		"locale":          user.Locale,
+		"interests":       append([]string{}, user.Interests...),
+		"status":          string(user.Status),
+		"emailVerifiedAt": formatTime(user.EmailVerifiedAt),
+		"deletedAt":       formatTime(user.DeletedAt),
+	}
+}
+
+func matchingTarget(matchingRepo repository.MatchingRepository, id uuid.UUID) target {
+	return target{
+		typ: model.AuditTargetMatching,
+		id:  id.String(),
+		snapshot: func(ctx context.Context) (model.AuditSnapshot, error) {
+			matching, err := matchingRepo.FindById(ctx, id)
+			if err != nil || matching == nil {
+				return nil, err
+			}
+			return matchingSnapshot(matching), nil
+		},
+	}
+}
+
+// lockMatching locks the matching of the pair in either direction, which the matching usecases find by the pair.
+func lockMatching(ctx context.Context, matchingRepo repository.MatchingRepository, userID1, userID2 uuid.UUID) ([]locked, error) {
+	matching, err := matchingRepo.FindByPairForUpdate(ctx, userID1, userID2)
+	if err != nil || matching == nil {
+		return nil, err
+	}
+	return []locked{{typ: model.AuditTargetMatching, id: matching.ID.String(), snapshot: matchingSnapshot(matching)}}, nil
+}
+
+func matchingSnapshot(matching *model.Matching) model.AuditSnapshot {
+	return model.AuditSnapshot{
+		"meId":      matching.MeID.String(),
+		"partnerId": matching.PartnerID.String(),
+		"status":    string(matching.Status),
+		"expiresAt": formatTime(matching.ExpiresAt),
+	}
+}
+
+// userBlockTarget identifies a block, which has no ID of its own, by the pair of users.
+func userBlockTarget(blockRepo repository.UserBlockRepository, blockerID, blockedID uuid.UUID) target {
+	return target{
+		typ: model.AuditTargetUserBlock,
+		id:  userBlockID(blockerID, blockedID),
+		snapshot: func(ctx context.Context) (model.AuditSnapshot, error) {
+			exists, err := blockRepo.Exists(ctx, blockerID, blockedID)
+			if err != nil || !exists {
+				return nil, err
+			}
+			return userBlockSnapshot(blockerID, blockedID), nil
+		},
+	}
+}
+
+func lockUserBlock(ctx context.Context, blockRepo repository.UserBlockRepository, blockerID, blockedID uuid.UUID) ([]locked, error) {
+	exists, err := blockRepo.ExistsForUpdate(ctx, blockerID, blockedID)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return []locked{{
+		typ:      model.AuditTargetUserBlock,
+		id:       userBlockID(blockerID, blockedID),
+		snapshot: userBlockSnapshot(blockerID, blockedID),
+	}}, nil
+}
+
+func userBlockID(blockerID, blockedID uuid.UUID) string {
+	return blockerID.String() + ":" + blockedID.String()
+}
+
+func userBlockSnapshot(blockerID, blockedID uuid.UUID) model.AuditSnapshot {
+	return model.AuditSnapshot{
+		"blockerId": blockerID.String(),
+		"blockedId": blockedID.String(),
+	}
+}
+
+func reportTarget(reportRepo repository.ReportRepository, id uuid.UUID) target {
+	return target{
+		typ: model.AuditTargetReport,
+		id:  id.String(),
+		snapshot: func(ctx context.Context) (model.AuditSnapshot, error) {
+			report, err := reportRepo.FindById(ctx, id)
+			if err != nil || report == nil {
+				return nil, err
+			}
+			return reportSnapshot(report), nil
+		},
+	}
+}
+
+// lockReport also returns the report, whose reported user the resolution may change.
+func lockReport(ctx context.Context, reportRepo repository.ReportRepository, id uuid.UUID) (*model.Report, []locked, error) {
+	report, err := reportRepo.FindByIdForUpdate(ctx, id)
+	if err != nil || report == nil {
+		return nil, nil, err
+	}
+	return report, []locked{{typ: model.AuditTargetReport, id: id.String(), snapshot: reportSnapshot(report)}}, nil
+}
+
+func reportSnapshot(report *model.Report) model.AuditSnapshot {
+	assigneeID := ""
+	if report.AssigneeID != uuid.Nil() {
+		assigneeID = report.AssigneeID.String()
+	}
+	return model.AuditSnapshot{
+		"reporterId":     report.ReporterID.String(),
+		"reportedId":     report.ReportedID.String(),
+		"reason":         string(report.Reason),
+		"comment":        report.Comment,
+		"status":         string(report.Status),
+		"assigneeId":     assigneeID,
+		"resolution":     string(report.Resolution),
+		"resolutionNote": report.ResolutionNote,
+		"resolvedAt":     formatTime(report.ResolvedAt),
+	}
+}
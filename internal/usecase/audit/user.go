@@ -0,0 +1,177 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/token"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// UserInteractor records an audit log of the mutating user usecases. The others pass through to the decorated one.
+// The email token usecases change the user the token was mailed to, which the interactor finds with the emailSigner.
+type UserInteractor struct {
+	port.UserUsecase
+	recorder         recorder
+	userRepo         repository.UserRepository
+	verificationRepo repository.EmailVerificationRepository
+	changeRepo       repository.EmailChangeRepository
+	emailSigner      *token.Signer
+}
+
+func NewUserInteractor(
+	userUsecase port.UserUsecase,
+	txManager transaction.Manager,
+	auditRepo repository.AuditLogRepository,
+	userRepo repository.UserRepository,
+	verificationRepo repository.EmailVerificationRepository,
+	changeRepo repository.EmailChangeRepository,
+	emailSigner *token.Signer,
+	clock clock.Clock,
+) UserInteractor {
+	return UserInteractor{
+		UserUsecase:      userUsecase,
+		recorder:         recorder{txManager: txManager, auditRepo: auditRepo, clock: clock},
+		userRepo:         userRepo,
+		verificationRepo: verificationRepo,
+		changeRepo:       changeRepo,
+		emailSigner:      emailSigner,
+	}
+}
+
+func (i UserInteractor) Create(ctx context.Context, input *port.CreateUserInput) (*port.CreateUserOutput, error) {
+	return record(ctx, i.recorder, "user.create", i.UserUsecase.Create, input, nil,
+		func(output *port.CreateUserOutput) []target { return []target{userTarget(i.userRepo, output.User.ID)} },
+	)
+}
+
+func (i UserInteractor) Update(ctx context.Context, input *port.UpdateUserInput) (*port.UpdateUserOutput, error) {
+	return record(ctx, i.recorder, "user.update", i.UserUsecase.Update, input,
+		func(ctx context.Context) ([]locked, error) { return lockUser(ctx, i.userRepo, input.ID) },
+		func(output *port.UpdateUserOutput) []target { return []target{userTarget(i.userRepo, output.User.ID)} },
+	)
+}
+
+func (i UserInteractor) Delete(ctx context.Context, input *port.DeleteUserInput) (*port.DeleteUserOutput, error) {
+	return record(ctx, i.recorder, "user.delete", i.UserUsecase.Delete, input,
+		func(ctx context.Context) ([]locked, error) { return lockUser(ctx, i.userRepo, input.ID) },
+		func(*port.DeleteUserOutput) []target { return []target{userTarget(i.userRepo, input.ID)} },
+	)
+}
+
+func (i UserInteractor) Reactivate(ctx context.Context, input *port.ReactivateUserInput) (*port.ReactivateUserOutput, error) {
+	return record(ctx, i.recorder, "user.reactivate", i.UserUsecase.Reactivate, input,
+		func(ctx context.Context) ([]locked, error) { return lockUser(ctx, i.userRepo, input.ID) },
+		func(output *port.ReactivateUserOutput) []target {
+			return []target{userTarget(i.userRepo, output.User.ID)}
+		},
+	)
+}
+
+func (i UserInteractor) VerifyEmail(ctx context.Context, input *port.VerifyEmailInput) (*port.VerifyEmailOutput, error) {
+	return record(ctx, i.recorder, "user.verify_email", i.UserUsecase.VerifyEmail, input,
+		func(ctx context.Context) ([]locked, error) { return i.lockUserOfVerification(ctx, input.Token) },
+		func(output *port.VerifyEmailOutput) []target { return []target{userTarget(i.userRepo, output.User.ID)} },
+	)
+}
+
+func (i UserInteractor) RequestEmailChange(ctx context.Context, input *port.RequestEmailChangeInput) (*port.RequestEmailChangeOutput, error) {
+	return record(ctx, i.recorder, "user.request_email_change", i.UserUsecase.RequestEmailChange, input,
+		func(ctx context.Context) ([]locked, error) { return lockUser(ctx, i.userRepo, input.ID) },
+		func(output *port.RequestEmailChangeOutput) []target {
+			return []target{userTarget(i.userRepo, output.ID)}
+		},
+	)
+}
+
+func (i UserInteractor) ConfirmEmailChange(ctx context.Context, input *port.ConfirmEmailChangeInput) (*port.ConfirmEmailChangeOutput, error) {
+	return record(ctx, i.recorder, "user.confirm_email_change", i.UserUsecase.ConfirmEmailChange, input,
+		func(ctx context.Context) ([]locked, error) {
+			return i.lockUserOfChange(ctx, input.Token, model.EmailTokenPurposeChange)
+		},
+		func(output *port.ConfirmEmailChangeOutput) []target {
+			return []target{userTarget(i.userRepo, output.User.ID)}
+		},
+	)
+}
+
+func (i UserInteractor) EnqueueUserDeletion(ctx context.Context, input *port.EnqueueUserDeletionInput) (*port.EnqueueUserDeletionOutput, error) {
+	return record(ctx, i.recorder, "user.enqueue_deletion", i.UserUsecase.EnqueueUserDeletion, input,
+		func(ctx context.Context) ([]locked, error) { return lockUser(ctx, i.userRepo, input.ID) },
+		func(output *port.EnqueueUserDeletionOutput) []target {
+			return []target{userTarget(i.userRepo, output.ID)}
+		},
+	)
+}
+
+// DequeueAndDeleteUser has each dequeued user deleted by DeleteUserPermanently of the decorator,
+// so that every deletion is recorded in its own transaction rather than the whole batch in one.
+func (i UserInteractor) DequeueAndDeleteUser(ctx context.Context, input *port.DequeueAndDeleteUserInput) (*port.DequeueAndDeleteUserOutput, error) {
+	decorated := *input
+	decorated.DeleteUser = i.DeleteUserPermanently
+	return i.UserUsecase.DequeueAndDeleteUser(ctx, &decorated)
+}
+
+// DeleteUserPermanently records nothing when the user was not deleted.
+func (i UserInteractor) DeleteUserPermanently(ctx context.Context, input *port.DeleteUserPermanentlyInput) (*port.DeleteUserPermanentlyOutput, error) {
+	return record(ctx, i.recorder, "user.delete_permanently", i.UserUsecase.DeleteUserPermanently, input,
+		func(ctx context.Context) ([]locked, error) { return lockUser(ctx, i.userRepo, input.ID) },
+		func(output *port.DeleteUserPermanentlyOutput) []target {
+			if !output.Deleted {
+				return nil
+			}
+			return []target{userTarget(i.userRepo, output.ID)}
+		},
+	)
+}
+
+func (i UserInteractor) UndoEmailChange(ctx context.Context, input *port.UndoEmailChangeInput) (*port.UndoEmailChangeOutput, error) {
+	return record(ctx, i.recorder, "user.undo_email_change", i.UserUsecase.UndoEmailChange, input,
+		func(ctx context.Context) ([]locked, error) {
+			return i.lockUserOfChange(ctx, input.Token, model.EmailTokenPurposeChangeUndo)
+		},
+		func(output *port.UndoEmailChangeOutput) []target {
+			return []target{userTarget(i.userRepo, output.User.ID)}
+		},
+	)
+}
+
+// lockUserOfVerification locks the user the verification token was mailed to.
+// An invalid token locks nothing, and the usecase fails on it.
+func (i UserInteractor) lockUserOfVerification(ctx context.Context, token string) ([]locked, error) {
+	id, ok := i.parseEmailToken(token, model.EmailTokenPurposeVerification)
+	if !ok {
+		return nil, nil
+	}
+	verification, err := i.verificationRepo.FindByIdForUpdate(ctx, id)
+	if err != nil || verification == nil {
+		return nil, err
+	}
+	return lockUser(ctx, i.userRepo, verification.UserID)
+}
+
+// lockUserOfChange locks the user whose email change the token was mailed for.
+// An invalid token locks nothing, and the usecase fails on it.
+func (i UserInteractor) lockUserOfChange(ctx context.Context, token, purpose string) ([]locked, error) {
+	id, ok := i.parseEmailToken(token, purpose)
+	if !ok {
+		return nil, nil
+	}
+	change, err := i.changeRepo.FindByIdForUpdate(ctx, id)
+	if err != nil || change == nil {
+		return nil, err
+	}
+	return lockUser(ctx, i.userRepo, change.UserID)
+}
+
+func (i UserInteractor) parseEmailToken(token, purpose string) (uuid.UUID, bool) {
+	payload, err := i.emailSigner.Verify(token)
+	if err != nil {
+		return uuid.Nil(), false
+	}
+	return model.ParseEmailTokenPayload(payload, purpose)
+}
@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+)
+
+// UserBlockInteractor records an audit log of the mutating user block usecases. The others pass through to the decorated one.
+type UserBlockInteractor struct {
+	port.UserBlockUsecase
+	recorder     recorder
+	blockRepo    repository.UserBlockRepository
+	matchingRepo repository.MatchingRepository
+}
+
+func NewUserBlockInteractor(
+	userBlockUsecase port.UserBlockUsecase,
+	txManager transaction.Manager,
+	auditRepo repository.AuditLogRepository,
+	blockRepo repository.UserBlockRepository,
+	matchingRepo repository.MatchingRepository,
+	clock clock.Clock,
+) UserBlockInteractor {
+	return UserBlockInteractor{
+		UserBlockUsecase: userBlockUsecase,
+		recorder:         recorder{txManager: txManager, auditRepo: auditRepo, clock: clock},
+		blockRepo:        blockRepo,
+		matchingRepo:     matchingRepo,
+	}
+}
+
+// Block also records the pending matching rejected by the block, if any.
+func (i UserBlockInteractor) Block(ctx context.Context, input *port.BlockUserInput) (*port.BlockUserOutput, error) {
+	return record(ctx, i.recorder, "user_block.block", i.UserBlockUsecase.Block, input,
+		func(ctx context.Context) ([]locked, error) {
+			block, err := lockUserBlock(ctx, i.blockRepo, input.BlockerID, input.BlockedID)
+			if err != nil {
+				return nil, err
+			}
+			matching, err := lockMatching(ctx, i.matchingRepo, input.BlockerID, input.BlockedID)
+			if err != nil {
+				return nil, err
+			}
+			return append(block, matching...), nil
+		},
+		func(output *port.BlockUserOutput) []target {
+			targets := []target{userBlockTarget(i.blockRepo, input.BlockerID, input.BlockedID)}
+			if output.RejectedMatching != nil {
+				targets = append(targets, matchingTarget(i.matchingRepo, output.RejectedMatching.ID))
+			}
+			return targets
+		},
+	)
+}
+
+func (i UserBlockInteractor) Unblock(ctx context.Context, input *port.UnblockUserInput) (*port.UnblockUserOutput, error) {
+	return record(ctx, i.recorder, "user_block.unblock", i.UserBlockUsecase.Unblock, input,
+		func(ctx context.Context) ([]locked, error) {
+			return lockUserBlock(ctx, i.blockRepo, input.BlockerID, input.BlockedID)
+		},
+		func(*port.UnblockUserOutput) []target {
+			return []target{userBlockTarget(i.blockRepo, input.BlockerID, input.BlockedID)}
+		},
+	)
+}
@@ -0,0 +1,74 @@
+package event
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+)
+
+// Handler reacts to a domain event. A handler subscribed by Subscribe runs after the transaction has committed,
+// so an error cannot undo the usecase and is only logged. A handler subscribed by SubscribeInTransaction runs
+// before the transaction commits, and an error rolls the usecase back.
+type Handler func(ctx context.Context, event model.DomainEvent) error
+
+// Dispatcher delivers domain events to the in-process handlers subscribed to them.
+type Dispatcher struct {
+	mu         sync.RWMutex
+	handlers   map[model.EventName][]Handler
+	txHandlers map[model.EventName][]Handler
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		handlers:   map[model.EventName][]Handler{},
+		txHandlers: map[model.EventName][]Handler{},
+	}
+}
+
+// Subscribe registers the handler for the events of the names. Handlers of an event run in the order of subscription.
+func (d *Dispatcher) Subscribe(handler Handler, names ...model.EventName) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, name := range names {
+		d.handlers[name] = append(d.handlers[name], handler)
+	}
+}
+
+// SubscribeInTransaction registers the handler for the events of the names, to run in the transaction of the usecase,
+// such as to write a message to the outbox along with the change.
+func (d *Dispatcher) SubscribeInTransaction(handler Handler, names ...model.EventName) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, name := range names {
+		d.txHandlers[name] = append(d.txHandlers[name], handler)
+	}
+}
+
+// dispatchInTransaction delivers the events to the handlers subscribed by SubscribeInTransaction, and stops at the first error.
+func (d *Dispatcher) dispatchInTransaction(ctx context.Context, events []model.DomainEvent) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, event := range events {
+		for _, handler := range d.txHandlers[event.EventName()] {
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Dispatch delivers the events in the order they were recorded. A failing handler does not stop the others.
+func (d *Dispatcher) Dispatch(ctx context.Context, events []model.DomainEvent) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, event := range events {
+		for _, handler := range d.handlers[event.EventName()] {
+			if err := handler(ctx, event); err != nil {
+				log.Printf("failed to handle event %s of %s: %v\n", event.EventName(), event.AggregateID(), err)
+			}
+		}
+	}
+}
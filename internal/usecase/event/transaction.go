@@ -0,0 +1,73 @@
+package event
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/transaction"
+)
+
+type collectorKey struct{}
+
+// collector holds the events collected in a transaction until it commits, and the hooks to run if it rolls back.
+type collector struct {
+	events     []model.DomainEvent
+	onRollback []func(ctx context.Context)
+}
+
+// Collect pulls the events recorded by the aggregates into the current transaction.
+// They are dispatched to the handlers subscribed in the transaction before it commits, and to the others once it commits.
+// They are dropped when it rolls back.
+// Call it after the aggregates are saved, inside a transaction of the manager returned by NewTransactionManager.
+func Collect(ctx context.Context, aggregates ...model.EventRecorder) {
+	c, ok := ctx.Value(collectorKey{}).(*collector)
+	for _, aggregate := range aggregates {
+		events := aggregate.PullEvents()
+		if ok {
+			c.events = append(c.events, events...)
+		}
+	}
+}
+
+// AfterRollback runs fn once the outermost transaction rolls back, to undo a change made outside the database.
+// Outside a transaction of the manager returned by NewTransactionManager, fn never runs.
+func AfterRollback(ctx context.Context, fn func(ctx context.Context)) {
+	if c, ok := ctx.Value(collectorKey{}).(*collector); ok {
+		c.onRollback = append(c.onRollback, fn)
+	}
+}
+
+type transactionManager struct {
+	txManager  transaction.Manager
+	dispatcher *Dispatcher
+}
+
+// NewTransactionManager wraps the transaction manager to dispatch the events collected in a transaction
+// to the handlers subscribed in the transaction before it commits, and to the others after it commits,
+// and to run the hooks registered by AfterRollback when it rolls back.
+func NewTransactionManager(txManager transaction.Manager, dispatcher *Dispatcher) *transactionManager {
+	return &transactionManager{txManager: txManager, dispatcher: dispatcher}
+}
+
+func (m *transactionManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// A nested transaction leaves the events to the outermost one
+	if _, ok := ctx.Value(collectorKey{}).(*collector); ok {
+		return m.txManager.Do(ctx, fn)
+	}
+
+	c := &collector{}
+	err := m.txManager.Do(context.WithValue(ctx, collectorKey{}, c), func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+		return m.dispatcher.dispatchInTransaction(ctx, c.events)
+	})
+	if err != nil {
+		for _, hook := range c.onRollback {
+			hook(ctx)
+		}
+		return err
+	}
+	m.dispatcher.Dispatch(ctx, c.events)
+	return nil
+}
@@ -0,0 +1,188 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// fakeTransactionManager runs the function without a database, failing like a rollback when fn fails.
+type fakeTransactionManager struct{}
+
+func (fakeTransactionManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestTransactionManager_Do(t *testing.T) {
+	errRollback := errors.New("rollback")
+
+	tests := []struct {
+		name string
+		fn   func(ctx context.Context, user *model.User) error
+		want []model.EventName
+	}{
+		{
+			name: "OK_DispatchAfterCommit",
+			fn: func(ctx context.Context, user *model.User) error {
+				user.UpdateProfile(model.InputUserParams{DisplayName: "updated"}, time.Now())
+				Collect(ctx, user)
+				return nil
+			},
+			want: []model.EventName{model.EventUserCreated, model.EventUserProfileUpdated},
+		},
+		{
+			name: "OK_DropOnRollback",
+			fn: func(ctx context.Context, user *model.User) error {
+				Collect(ctx, user)
+				return errRollback
+			},
+			want: nil,
+		},
+		{
+			name: "OK_DispatchOnceInNestedTransaction",
+			fn: func(ctx context.Context, user *model.User) error {
+				Collect(ctx, user)
+				return NewTransactionManager(fakeTransactionManager{}, NewDispatcher()).Do(ctx, func(ctx context.Context) error {
+					Collect(ctx, user)
+					return nil
+				})
+			},
+			want: []model.EventName{model.EventUserCreated},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []model.EventName
+			dispatcher := NewDispatcher()
+			dispatcher.Subscribe(func(ctx context.Context, event model.DomainEvent) error {
+				got = append(got, event.EventName())
+				return nil
+			}, model.UserEventNames...)
+			txManager := NewTransactionManager(fakeTransactionManager{}, dispatcher)
+
+			user := model.NewUser(model.InputUserParams{Email: "test@example.com"}, time.Now())
+			err := txManager.Do(context.Background(), func(ctx context.Context) error {
+				// Nothing is dispatched before the commit
+				if len(got) > 0 {
+					t.Errorf("Do() dispatched %v before commit", got)
+				}
+				return tt.fn(ctx, user)
+			})
+			if err != nil && !errors.Is(err, errRollback) {
+				t.Fatalf("Do() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Do() dispatched mismatching (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTransactionManager_DoInTransaction(t *testing.T) {
+	errHandler := errors.New("handler failed")
+
+	tests := []struct {
+		name       string
+		handlerErr error
+		want       []string
+	}{
+		{name: "OK_BeforeCommit", handlerErr: nil, want: []string{"in transaction", "after commit"}},
+		{name: "NG_RollbackOnError", handlerErr: errHandler, want: []string{"in transaction"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			dispatcher := NewDispatcher()
+			dispatcher.SubscribeInTransaction(func(ctx context.Context, event model.DomainEvent) error {
+				got = append(got, "in transaction")
+				return tt.handlerErr
+			}, model.EventUserCreated)
+			dispatcher.Subscribe(func(ctx context.Context, event model.DomainEvent) error {
+				got = append(got, "after commit")
+				return nil
+			}, model.EventUserCreated)
+			txManager := NewTransactionManager(fakeTransactionManager{}, dispatcher)
+
+			user := model.NewUser(model.InputUserParams{Email: "test@example.com"}, time.Now())
+			err := txManager.Do(context.Background(), func(ctx context.Context) error {
+				Collect(ctx, user)
+				return nil
+			})
+			if !errors.Is(err, tt.handlerErr) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.handlerErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Do() dispatched mismatching (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAfterRollback(t *testing.T) {
+	errRollback := errors.New("rollback")
+
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{name: "OK_NotRunOnCommit", err: nil, want: []string{"in transaction"}},
+		{name: "OK_RunOnRollback", err: errRollback, want: []string{"in transaction", "rollback"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			txManager := NewTransactionManager(fakeTransactionManager{}, NewDispatcher())
+			err := txManager.Do(context.Background(), func(ctx context.Context) error {
+				// The hooks of a nested transaction wait for the outermost one
+				err := txManager.Do(ctx, func(ctx context.Context) error {
+					AfterRollback(ctx, func(ctx context.Context) { got = append(got, "rollback") })
+					return nil
+				})
+				got = append(got, "in transaction")
+				if err != nil {
+					return err
+				}
+				return tt.err
+			})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Do() ran hooks mismatching (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	var got []string
+	dispatcher := NewDispatcher()
+	dispatcher.Subscribe(func(ctx context.Context, event model.DomainEvent) error {
+		got = append(got, "failing")
+		return errors.New("handler failed")
+	}, model.EventMatchingAccepted)
+	dispatcher.Subscribe(func(ctx context.Context, event model.DomainEvent) error {
+		got = append(got, "next")
+		return nil
+	}, model.EventMatchingAccepted)
+
+	dispatcher.Dispatch(context.Background(), []model.DomainEvent{
+		model.MatchingEvent{Name: model.EventMatchingAccepted, MatchingID: uuid.New()},
+		model.MatchingEvent{Name: model.EventMatchingRejected, MatchingID: uuid.New()},
+	})
+
+	// A failing handler does not stop the next one, and an event without handlers is ignored
+	if diff := cmp.Diff([]string{"failing", "next"}, got); diff != "" {
+		t.Errorf("Dispatch() mismatching (-want +got):\n%s", diff)
+	}
+}
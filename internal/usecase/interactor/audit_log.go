@@ -0,0 +1,36 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+)
+
+// AuditLogInteractor reads the audit logs. They are written by the decorators of the audit package.
+type AuditLogInteractor struct {
+	auditRepo repository.AuditLogRepository
+}
+
+func NewAuditLogInteractor(auditRepo repository.AuditLogRepository) AuditLogInteractor {
+	return AuditLogInteractor{
+		auditRepo: auditRepo,
+	}
+}
+
+func (i AuditLogInteractor) Search(ctx context.Context, input *port.SearchAuditLogsInput) (*port.SearchAuditLogsOutput, error) {
+	logs, err := i.auditRepo.Search(ctx, repository.AuditLogFilter{
+		ActorID:    input.ActorID,
+		TargetType: model.AuditTargetType(input.TargetType),
+		TargetID:   input.TargetID,
+		From:       input.From,
+		To:         input.To,
+		Limit:      input.Limit,
+		Offset:     input.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &port.SearchAuditLogsOutput{AuditLogs: logs}, nil
+}
@@ -0,0 +1,162 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/audit"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/testhelper"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func SetupTestAuditLogInteractor(ctx context.Context, gw *testhelper.Gateway) (AuditLogInteractor, port.UserUsecase) {
+	auditRepo := repository.NewAuditLogMySQLRepository(gw.MySQLClient)
+	userInteractor := audit.NewUserInteractor(
+		SetupTestUserInteractor(ctx, gw),
+		SetupTestTxManager(gw),
+		auditRepo,
+		repository.NewUserMySQLRepository(gw.MySQLClient),
+		repository.NewEmailVerificationMySQLRepository(gw.MySQLClient),
+		repository.NewEmailChangeMySQLRepository(gw.MySQLClient),
+		testEmailVerificationSigner,
+		clock.New(),
+	)
+	return NewAuditLogInteractor(auditRepo), userInteractor
+}
+
+func TestAuditLogInteractor_Search(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	auditLogInteractor, userInteractor := SetupTestAuditLogInteractor(ctx, gw)
+
+	actorID := uuid.New()
+	ctx = audit.WithMetadata(ctx, audit.Metadata{ActorID: actorID, RequestID: "req-1", Source: model.AuditSourceHTTP})
+	start := time.Now().Add(-time.Second)
+
+	created, err := userInteractor.Create(ctx, &port.CreateUserInput{Email: "audit@example.com", DisplayName: "audit"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	userID := created.User.ID
+	if _, err := userInteractor.Update(ctx, &port.UpdateUserInput{ID: userID, Email: "audit@example.com", DisplayName: "audit", Bio: "hello"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	// A failed usecase rolls back its audit log with it
+	if _, err := userInteractor.Update(ctx, &port.UpdateUserInput{ID: userID, Email: "invalid"}); err == nil {
+		t.Fatalf("Update() error = nil, want error")
+	}
+
+	t.Run("ByTarget", func(t *testing.T) {
+		got, err := auditLogInteractor.Search(ctx, &port.SearchAuditLogsInput{TargetType: string(model.AuditTargetUser), TargetID: userID.String(), Limit: 10})
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if len(got.AuditLogs) != 2 {
+			t.Fatalf("Search() = %d logs, want 2", len(got.AuditLogs))
+		}
+		update, create := got.AuditLogs[0], got.AuditLogs[1]
+		if update.Action != "user.update" || create.Action != "user.create" {
+			t.Errorf("Search() actions = %s, %s, want newest first", update.Action, create.Action)
+		}
+		if update.ActorID != actorID || update.RequestID != "req-1" || update.Source != model.AuditSourceHTTP {
+			t.Errorf("Search() metadata = %+v", update)
+		}
+		if len(update.Diff) != 1 || update.Diff["bio"].Before != "" || update.Diff["bio"].After != "hello" {
+			t.Errorf("Search() update diff = %+v, want only bio", update.Diff)
+		}
+		if create.Diff["email"].Before != nil || create.Diff["email"].After != "audit@example.com" {
+			t.Errorf("Search() create diff = %+v", create.Diff)
+		}
+	})
+
+	t.Run("ByActorAndTime", func(t *testing.T) {
+		got, err := auditLogInteractor.Search(ctx, &port.SearchAuditLogsInput{ActorID: actorID, From: start, To: time.Now().Add(time.Second), Limit: 10})
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if len(got.AuditLogs) != 2 {
+			t.Errorf("Search() = %d logs, want 2", len(got.AuditLogs))
+		}
+
+		got, err = auditLogInteractor.Search(ctx, &port.SearchAuditLogsInput{ActorID: uuid.New(), Limit: 10})
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if len(got.AuditLogs) != 0 {
+			t.Errorf("Search() by another actor = %d logs, want 0", len(got.AuditLogs))
+		}
+	})
+}
+
+func TestAuditLogInteractor_SearchUserDeletion(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	auditRepo := repository.NewAuditLogMySQLRepository(gw.MySQLClient)
+	auditLogInteractor := NewAuditLogInteractor(auditRepo)
+	// The grace period has already passed as soon as the user is withdrawn
+	userInteractor := audit.NewUserInteractor(
+		setupTestUserInteractorWithGracePeriod(ctx, gw, 0),
+		SetupTestTxManager(gw),
+		auditRepo,
+		repository.NewUserMySQLRepository(gw.MySQLClient),
+		repository.NewEmailVerificationMySQLRepository(gw.MySQLClient),
+		repository.NewEmailChangeMySQLRepository(gw.MySQLClient),
+		testEmailVerificationSigner,
+		clock.New(),
+	)
+	outboxInteractor := SetupTestOutboxInteractor(ctx, gw)
+
+	created, err := userInteractor.Create(ctx, &port.CreateUserInput{Email: "audit-deletion@example.com"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	userID := created.User.ID
+	if _, err := userInteractor.Delete(ctx, &port.DeleteUserInput{ID: userID}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := userInteractor.EnqueueUserDeletion(ctx, &port.EnqueueUserDeletionInput{ID: userID}); err != nil {
+		t.Fatalf("EnqueueUserDeletion() error = %v", err)
+	}
+	if _, err := outboxInteractor.Relay(ctx, &port.RelayOutboxInput{}); err != nil {
+		t.Fatalf("Relay() error = %v", err)
+	}
+	deleted, err := userInteractor.DequeueAndDeleteUser(ctx, &port.DequeueAndDeleteUserInput{BatchSize: 10})
+	if err != nil {
+		t.Fatalf("DequeueAndDeleteUser() error = %v", err)
+	}
+	if deleted.DeletedCount != 1 {
+		t.Fatalf("DequeueAndDeleteUser() deleted %d users, want 1", deleted.DeletedCount)
+	}
+
+	got, err := auditLogInteractor.Search(ctx, &port.SearchAuditLogsInput{TargetType: string(model.AuditTargetUser), TargetID: userID.String(), Limit: 10})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	var actions []string
+	for _, log := range got.AuditLogs {
+		actions = append(actions, log.Action)
+	}
+	want := []string{"user.delete_permanently", "user.enqueue_deletion", "user.delete", "user.create"}
+	if diff := cmp.Diff(want, actions); diff != "" {
+		t.Errorf("Search() actions mismatching (-want +got):\n%s", diff)
+	}
+	if purge := got.AuditLogs[0]; purge.Diff["email"].Before != "audit-deletion@example.com" || purge.Diff["email"].After != nil {
+		t.Errorf("Search() permanent deletion diff = %+v", purge.Diff)
+	}
+}
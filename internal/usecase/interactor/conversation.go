@@ -0,0 +1,252 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// MaxChatMessagesLimit caps the page size of the messages.
+const MaxChatMessagesLimit = 100
+
+type ConversationInteractor struct {
+	txManager        transaction.Manager
+	conversationRepo repository.ConversationRepository
+	messageRepo      repository.ChatMessageRepository
+	matchingRepo     repository.MatchingRepository
+	blockRepo        repository.UserBlockRepository
+	userRepo         repository.UserRepository
+	publisher        repository.ChatMessagePublisher
+	clock            clock.Clock
+}
+
+func NewConversationInteractor(
+	txManager transaction.Manager,
+	conversationRepo repository.ConversationRepository,
+	messageRepo repository.ChatMessageRepository,
+	matchingRepo repository.MatchingRepository,
+	blockRepo repository.UserBlockRepository,
+	userRepo repository.UserRepository,
+	publisher repository.ChatMessagePublisher,
+	clock clock.Clock,
+) ConversationInteractor {
+	return ConversationInteractor{
+		txManager:        txManager,
+		conversationRepo: conversationRepo,
+		messageRepo:      messageRepo,
+		matchingRepo:     matchingRepo,
+		blockRepo:        blockRepo,
+		userRepo:         userRepo,
+		publisher:        publisher,
+		clock:            clock,
+	}
+}
+
+// Send posts the message to the conversation of the accepted matching of the pair, opening the conversation on the first message.
+// Both users have to be active, so that a suspended or withdrawn user neither sends nor receives messages.
+// The message is pushed to the partner in real time after it is committed.
+func (i ConversationInteractor) Send(ctx context.Context, input *port.SendChatMessageInput) (*port.SendChatMessageOutput, error) {
+	output := &port.SendChatMessageOutput{}
+	now := i.clock.Now()
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := i.checkUsers(ctx, input.SenderID, input.PartnerID); err != nil {
+			return err
+		}
+		// Lock the matching so that the first messages of both users open a single conversation
+		matching, err := i.matchingRepo.FindByPairForUpdate(ctx, input.SenderID, input.PartnerID)
+		if err != nil {
+			return err
+		}
+		if matching == nil {
+			return domainerr.New(
+				domainerr.ReasonMatchingNotFound,
+				nil,
+				map[string]interface{}{"meId": input.SenderID, "partnerId": input.PartnerID},
+			)
+		}
+		blocks, err := i.blockRepo.FindAllBetween(ctx, input.SenderID, input.PartnerID)
+		if err != nil {
+			return err
+		}
+		if len(blocks) > 0 {
+			return domainerr.New(domainerr.ReasonMessagingNotAllowed, nil, nil)
+		}
+
+		conversation, err := i.conversationRepo.FindByMatchingID(ctx, matching.ID)
+		if err != nil {
+			return err
+		}
+		var changed bool
+		switch {
+		case conversation == nil:
+			if conversation, err = model.NewConversation(matching, now); err != nil {
+				return domainerr.New(domainerr.ReasonConversationNotMutual, err, map[string]interface{}{"status": matching.Status})
+			}
+			changed = true
+		case matching.IsMutual():
+			// The users may have matched again since the conversation was archived
+			changed = conversation.Restore(now)
+		default:
+			changed = conversation.Archive(now)
+		}
+		if err := conversation.CanPost(input.SenderID); err != nil {
+			if errors.Is(err, model.ErrConversationIsArchived) {
+				return domainerr.New(domainerr.ReasonConversationArchived, err, map[string]interface{}{"id": conversation.ID})
+			}
+			return domainerr.New(domainerr.ReasonConversationNotParticipant, err, nil)
+		}
+		if changed {
+			if conversation, err = i.conversationRepo.Save(ctx, conversation); err != nil {
+				return err
+			}
+		}
+		output.Conversation = conversation
+
+		message := model.NewChatMessage(model.InputChatMessageParams{
+			ConversationID: conversation.ID,
+			SenderID:       input.SenderID,
+			Body:           input.Body,
+		}, now)
+		if err := message.Validate(); err != nil {
+			return domainerr.New(domainerr.ReasonChatMessageInvalid, err, nil)
+		}
+		output.Message, err = i.messageRepo.Save(ctx, message)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The message is already stored, and the client fetches it on the next list if the push is lost
+	if err := i.publisher.PublishMessage(ctx, input.PartnerID, output.Message); err != nil {
+		log.Printf("Failed to push message %s: %v", output.Message.ID, err)
+	}
+	return output, nil
+}
+
+// checkUsers fails unless the sender and the partner are both active.
+// The status of the partner is not disclosed, so any partner who cannot receive messages is refused the same way.
+func (i ConversationInteractor) checkUsers(ctx context.Context, senderID, partnerID uuid.UUID) error {
+	sender, err := i.userRepo.FindById(ctx, senderID)
+	if err != nil {
+		return err
+	}
+	if sender == nil {
+		return domainerr.New(domainerr.ReasonUserNotFound, nil, map[string]interface{}{"id": senderID})
+	}
+	if !sender.IsActive() {
+		return domainerr.New(domainerr.ReasonUserNotActive, model.ErrUserStatusIsNotActive, map[string]interface{}{"id": senderID})
+	}
+	partner, err := i.userRepo.FindById(ctx, partnerID)
+	if err != nil {
+		return err
+	}
+	if partner == nil || !partner.IsActive() {
+		return domainerr.New(domainerr.ReasonMessagingNotAllowed, nil, nil)
+	}
+	return nil
+}
+
+// List returns the messages of the conversation of the pair, newest first, a page at a time.
+// An archived conversation can still be read.
+func (i ConversationInteractor) List(ctx context.Context, input *port.ListChatMessagesInput) (*port.ListChatMessagesOutput, error) {
+	var cursor *model.ChatMessageCursor
+	if input.Cursor != "" {
+		var err error
+		if cursor, err = model.ParseChatMessageCursor(input.Cursor); err != nil {
+			return nil, domainerr.New(domainerr.ReasonChatMessageCursorInvalid, err, map[string]interface{}{"cursor": input.Cursor})
+		}
+	}
+	limit := input.Limit
+	if limit <= 0 || limit > MaxChatMessagesLimit {
+		limit = MaxChatMessagesLimit
+	}
+
+	conversation, err := i.findConversation(ctx, input.UserID, input.PartnerID)
+	if err != nil {
+		return nil, err
+	}
+	output := &port.ListChatMessagesOutput{Conversation: conversation, Messages: []*model.ChatMessage{}}
+	if conversation == nil {
+		return output, nil
+	}
+
+	// Fetch one more message to know whether there is a next page
+	messages, err := i.messageRepo.FindAllByConversationID(ctx, conversation.ID, cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) > limit {
+		messages = messages[:limit]
+		output.NextCursor = model.NewChatMessageCursor(messages[limit-1]).String()
+	}
+	output.Messages = messages
+	return output, nil
+}
+
+// MarkRead marks the messages received from the partner as read, up to the given message, and pushes the receipt to the partner.
+func (i ConversationInteractor) MarkRead(ctx context.Context, input *port.MarkChatMessagesReadInput) (*port.MarkChatMessagesReadOutput, error) {
+	conversation, err := i.findConversation(ctx, input.UserID, input.PartnerID)
+	if err != nil {
+		return nil, err
+	}
+	if conversation == nil {
+		return nil, domainerr.New(
+			domainerr.ReasonConversationNotFound,
+			nil,
+			map[string]interface{}{"meId": input.UserID, "partnerId": input.PartnerID},
+		)
+	}
+
+	now := i.clock.Now()
+	receipt := &model.ChatMessageReceipt{
+		ConversationID: conversation.ID,
+		ReaderID:       input.UserID,
+		UpTo:           now,
+		ReadAt:         now,
+	}
+	if input.UpToMessageID != uuid.Nil() {
+		message, err := i.messageRepo.FindById(ctx, input.UpToMessageID)
+		if err != nil {
+			return nil, err
+		}
+		if message == nil || message.ConversationID != conversation.ID {
+			return nil, domainerr.New(domainerr.ReasonChatMessageNotFound, nil, map[string]interface{}{"id": input.UpToMessageID})
+		}
+		receipt.UpTo = message.CreatedAt
+	}
+	if receipt.Count, err = i.messageRepo.MarkRead(ctx, conversation.ID, input.PartnerID, receipt.UpTo, receipt.ReadAt); err != nil {
+		return nil, err
+	}
+
+	if receipt.Count > 0 {
+		if err := i.publisher.PublishReceipt(ctx, input.PartnerID, receipt); err != nil {
+			log.Printf("Failed to push read receipt of conversation %s: %v", conversation.ID, err)
+		}
+	}
+	return &port.MarkChatMessagesReadOutput{ReadCount: receipt.Count, ReadAt: receipt.ReadAt}, nil
+}
+
+// findConversation returns the conversation of the pair, or nil when no message has been sent yet.
+func (i ConversationInteractor) findConversation(ctx context.Context, userID, partnerID uuid.UUID) (*model.Conversation, error) {
+	matching, err := i.matchingRepo.FindByPair(ctx, userID, partnerID)
+	if err != nil {
+		return nil, err
+	}
+	if matching == nil {
+		return nil, domainerr.New(
+			domainerr.ReasonMatchingNotFound,
+			nil,
+			map[string]interface{}{"meId": userID, "partnerId": partnerID},
+		)
+	}
+	return i.conversationRepo.FindByMatchingID(ctx, matching.ID)
+}
@@ -0,0 +1,26 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/event"
+)
+
+// NewConversationArchiver returns the handler that archives the conversation of a matching when the users unmatch.
+// Subscribe it to model.EventMatchingUnmatched. Sending a message checks the matching again, so a missed event only delays the archive.
+// The conversation is archived as of the unmatch.
+func NewConversationArchiver(conversationRepo repository.ConversationRepository) event.Handler {
+	return func(ctx context.Context, e model.DomainEvent) error {
+		conversation, err := conversationRepo.FindByMatchingID(ctx, e.AggregateID())
+		if err != nil || conversation == nil {
+			return err
+		}
+		if !conversation.Archive(e.OccurredAt()) {
+			return nil
+		}
+		_, err = conversationRepo.Save(ctx, conversation)
+		return err
+	}
+}
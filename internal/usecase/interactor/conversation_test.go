@@ -0,0 +1,212 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/repository"
+	redisRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/redis/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/testhelper"
+)
+
+func SetupTestConversationInteractor(ctx context.Context, gw *testhelper.Gateway) ConversationInteractor {
+	return NewConversationInteractor(
+		SetupTestTxManager(gw),
+		repository.NewConversationMySQLRepository(gw.MySQLClient),
+		repository.NewChatMessageMySQLRepository(gw.MySQLClient),
+		repository.NewMatchingMySQLRepository(gw.MySQLClient),
+		repository.NewUserBlockMySQLRepository(gw.MySQLClient),
+		repository.NewUserMySQLRepository(gw.MySQLClient),
+		redisRepo.NewChatRedisPublisher(gw.RedisClient),
+		clock.New(),
+	)
+}
+
+// createTestMutualMatching creates the users of an accepted matching.
+func createTestMutualMatching(ctx context.Context, t *testing.T, matchingInteractor MatchingInteractor, userRepo *repository.UserMySQLRepository) (*model.User, *model.User) {
+	me := createTestUser(ctx, t, userRepo)
+	partner := createTestUser(ctx, t, userRepo)
+	if _, err := matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: me.ID, PartnerID: partner.ID}); err != nil {
+		t.Fatalf("Failed to create test matching: %v", err)
+	}
+	if _, err := matchingInteractor.Accept(ctx, &port.AcceptMatchingInput{MeID: partner.ID, PartnerID: me.ID}); err != nil {
+		t.Fatalf("Failed to accept test matching: %v", err)
+	}
+	return me, partner
+}
+
+func TestConversationInteractor_Send(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	conversationInteractor := SetupTestConversationInteractor(ctx, gw)
+	matchingInteractor, userRepo := SetupTestMatchingInteractor(ctx, gw)
+
+	me, partner := createTestMutualMatching(ctx, t, matchingInteractor, userRepo)
+	pending := createTestUser(ctx, t, userRepo)
+	if _, err := matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: me.ID, PartnerID: pending.ID}); err != nil {
+		t.Fatalf("Failed to create test matching: %v", err)
+	}
+	stranger := createTestUser(ctx, t, userRepo)
+
+	// The partner is connected to the real-time channel
+	sub := gw.RedisClient.Subscribe(ctx, redisRepo.ChatChannel(tenant.DefaultID, partner.ID))
+	defer sub.Close()
+	if _, err := sub.Receive(ctx); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    *port.SendChatMessageInput
+		wantCode domainerr.ErrorCode
+	}{
+		{name: "OK", input: &port.SendChatMessageInput{SenderID: me.ID, PartnerID: partner.ID, Body: "hello"}},
+		{name: "OK_Reply", input: &port.SendChatMessageInput{SenderID: partner.ID, PartnerID: me.ID, Body: "hi"}},
+		{name: "NG_EmptyBody", input: &port.SendChatMessageInput{SenderID: me.ID, PartnerID: partner.ID, Body: "  "}, wantCode: domainerr.InvalidArgument},
+		{name: "NG_PendingMatching", input: &port.SendChatMessageInput{SenderID: me.ID, PartnerID: pending.ID, Body: "hello"}, wantCode: domainerr.PreconditionFailed},
+		{name: "NG_NoMatching", input: &port.SendChatMessageInput{SenderID: stranger.ID, PartnerID: partner.ID, Body: "hello"}, wantCode: domainerr.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := conversationInteractor.Send(ctx, tt.input)
+			if tt.wantCode != "" {
+				var domainErr *domainerr.DomainError
+				if !errors.As(err, &domainErr) || domainErr.Code != tt.wantCode {
+					t.Errorf("Send() error = %v, want %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			if got.Message.SenderID != tt.input.SenderID || got.Message.Body != tt.input.Body || got.Conversation.IsArchived() {
+				t.Errorf("Send() = %+v, %+v", got.Message, got.Conversation)
+			}
+		})
+	}
+
+	t.Run("Pushed", func(t *testing.T) {
+		msg, err := sub.ReceiveTimeout(ctx, 3*time.Second)
+		if err != nil {
+			t.Fatalf("ReceiveTimeout() error = %v", err)
+		}
+		pushed, ok := msg.(*redis.Message)
+		if !ok || !strings.Contains(pushed.Payload, `"body":"hello"`) {
+			t.Errorf("pushed = %v", msg)
+		}
+	})
+
+	t.Run("NG_SuspendedUser", func(t *testing.T) {
+		active, suspended := createTestMutualMatching(ctx, t, matchingInteractor, userRepo)
+		if err := suspended.Suspend(time.Now()); err != nil {
+			t.Fatalf("Suspend() error = %v", err)
+		}
+		if _, err := userRepo.Save(ctx, suspended); err != nil {
+			t.Fatalf("Failed to save test user: %v", err)
+		}
+		for _, tc := range []struct {
+			input    *port.SendChatMessageInput
+			wantCode domainerr.ErrorCode
+		}{
+			{input: &port.SendChatMessageInput{SenderID: active.ID, PartnerID: suspended.ID, Body: "hello"}, wantCode: domainerr.PermissionDenied},
+			{input: &port.SendChatMessageInput{SenderID: suspended.ID, PartnerID: active.ID, Body: "hello"}, wantCode: domainerr.PreconditionFailed},
+		} {
+			_, err := conversationInteractor.Send(ctx, tc.input)
+			var domainErr *domainerr.DomainError
+			if !errors.As(err, &domainErr) || domainErr.Code != tc.wantCode {
+				t.Errorf("Send() from %s error = %v, want %s", tc.input.SenderID, err, tc.wantCode)
+			}
+		}
+	})
+
+	t.Run("ArchivedOnUnmatch", func(t *testing.T) {
+		if _, err := matchingInteractor.Unmatch(ctx, &port.UnmatchMatchingInput{MeID: me.ID, PartnerID: partner.ID}); err != nil {
+			t.Fatalf("Unmatch() error = %v", err)
+		}
+		listed, err := conversationInteractor.List(ctx, &port.ListChatMessagesInput{UserID: me.ID, PartnerID: partner.ID})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if !listed.Conversation.IsArchived() || len(listed.Messages) != 2 {
+			t.Errorf("List() after unmatch = %+v with %d messages, want archived with 2", listed.Conversation, len(listed.Messages))
+		}
+		_, err = conversationInteractor.Send(ctx, &port.SendChatMessageInput{SenderID: me.ID, PartnerID: partner.ID, Body: "still there?"})
+		var domainErr *domainerr.DomainError
+		if !errors.As(err, &domainErr) || domainErr.Code != domainerr.PreconditionFailed {
+			t.Errorf("Send() after unmatch error = %v, want %s", err, domainerr.PreconditionFailed)
+		}
+	})
+}
+
+func TestConversationInteractor_ListAndMarkRead(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	conversationInteractor := SetupTestConversationInteractor(ctx, gw)
+	matchingInteractor, userRepo := SetupTestMatchingInteractor(ctx, gw)
+
+	me, partner := createTestMutualMatching(ctx, t, matchingInteractor, userRepo)
+	var sent []*model.ChatMessage
+	for _, body := range []string{"one", "two", "three"} {
+		output, err := conversationInteractor.Send(ctx, &port.SendChatMessageInput{SenderID: me.ID, PartnerID: partner.ID, Body: body})
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+		sent = append(sent, output.Message)
+	}
+
+	first, err := conversationInteractor.List(ctx, &port.ListChatMessagesInput{UserID: partner.ID, PartnerID: me.ID, Limit: 2})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(first.Messages) != 2 || first.Messages[0].Body != "three" || first.Messages[1].Body != "two" || first.NextCursor == "" {
+		t.Fatalf("List() first page = %d messages, cursor %q", len(first.Messages), first.NextCursor)
+	}
+	second, err := conversationInteractor.List(ctx, &port.ListChatMessagesInput{UserID: partner.ID, PartnerID: me.ID, Cursor: first.NextCursor, Limit: 2})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(second.Messages) != 1 || second.Messages[0].Body != "one" || second.NextCursor != "" {
+		t.Errorf("List() second page = %d messages, cursor %q", len(second.Messages), second.NextCursor)
+	}
+	if _, err := conversationInteractor.List(ctx, &port.ListChatMessagesInput{UserID: partner.ID, PartnerID: me.ID, Cursor: "broken"}); err == nil {
+		t.Error("List() with an invalid cursor error = nil")
+	}
+
+	// The sender cannot mark its own messages as read
+	read, err := conversationInteractor.MarkRead(ctx, &port.MarkChatMessagesReadInput{UserID: me.ID, PartnerID: partner.ID})
+	if err != nil || read.ReadCount != 0 {
+		t.Errorf("MarkRead() by the sender = %+v, %v, want 0", read, err)
+	}
+	read, err = conversationInteractor.MarkRead(ctx, &port.MarkChatMessagesReadInput{UserID: partner.ID, PartnerID: me.ID, UpToMessageID: sent[1].ID})
+	if err != nil || read.ReadCount != 2 {
+		t.Errorf("MarkRead() up to the second = %+v, %v, want 2", read, err)
+	}
+
+	listed, err := conversationInteractor.List(ctx, &port.ListChatMessagesInput{UserID: me.ID, PartnerID: partner.ID})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, message := range listed.Messages {
+		if wantRead := message.Body != "three"; message.IsRead() != wantRead {
+			t.Errorf("message %q read = %v, want %v", message.Body, message.IsRead(), wantRead)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+)
+
+// MailMessage is the message of the mail queue. Mails are sent by the subscriber, so that a request never waits for the mail server.
+type MailMessage struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+type MailInteractor struct {
+	mailQueue repository.MessageQueueRepository
+	mailer    repository.Mailer
+}
+
+func NewMailInteractor(
+	mailQueue repository.MessageQueueRepository,
+	mailer repository.Mailer,
+) MailInteractor {
+	return MailInteractor{
+		mailQueue: mailQueue,
+		mailer:    mailer,
+	}
+}
+
+// enqueueMail writes the mail to the outbox in the transaction of ctx, from which the relay publishes it to the mail queue.
+// The mail is only sent when the transaction commits.
+func enqueueMail(ctx context.Context, outboxRepo repository.OutboxRepository, mail *model.Mail, now time.Time) error {
+	body, err := json.Marshal(MailMessage{
+		To:      mail.To,
+		Subject: mail.Subject,
+		Body:    mail.Body,
+	})
+	if err != nil {
+		return err
+	}
+	msg := &model.Message{
+		Body: string(body),
+		Attributes: map[string]string{
+			"messageType": "mail",
+		},
+	}
+	_, err = outboxRepo.Save(ctx, model.NewOutboxMessage(model.OutboxDestinationMail, msg, now))
+	return err
+}
+
+// DequeueAndSend sends the mails in the queue. A mail failed to send stays in the queue and is retried once it becomes visible again.
+func (i MailInteractor) DequeueAndSend(ctx context.Context, input *port.DequeueAndSendMailInput) (*port.DequeueAndSendMailOutput, error) {
+	batchSize := int32(input.BatchSize)
+	if batchSize > 10 {
+		batchSize = 10
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	msgs, err := i.mailQueue.Receive(ctx, &repository.ReceiveMessageOptions{
+		MaxNumberOfMessages: batchSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sentCount := 0
+	for _, msg := range msgs {
+		var mail MailMessage
+		if err := json.Unmarshal([]byte(msg.Body), &mail); err != nil {
+			// A malformed message can never be sent, so it is dropped instead of retried
+			log.Printf("Failed to unmarshal mail from message: %v", err)
+			if err := i.mailQueue.Delete(ctx, msg); err != nil {
+				log.Printf("Failed to delete malformed mail message: %v", err)
+			}
+			continue
+		}
+
+		if err := i.mailer.Send(ctx, &model.Mail{To: mail.To, Subject: mail.Subject, Body: mail.Body}); err != nil {
+			log.Printf("Failed to send mail to %s: %v", mail.To, err)
+			continue
+		}
+
+		if err := i.mailQueue.Delete(ctx, msg); err != nil {
+			log.Printf("Failed to delete message for mail to %s: %v", mail.To, err)
+			continue
+		}
+		sentCount++
+	}
+
+	return &port.DequeueAndSendMailOutput{
+		SentCount: sentCount,
+	}, nil
+}
@@ -2,32 +2,161 @@ package interactor
 
 import (
 	"context"
+	"errors"
+	"log"
+	"time"
 
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/service"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/event"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+const (
+	// DefaultExpireMatchingsBatchSize is used when no batch size is given to ExpireOverdue.
+	DefaultExpireMatchingsBatchSize = 100
+
+	matchingReasonMutualLike = "partner liked back"
+	matchingReasonExpired    = "pending matching expired"
 )
 
 type MatchingInteractor struct {
 	txManager    transaction.Manager
 	matchingRepo repository.MatchingRepository
+	historyRepo  repository.MatchingHistoryRepository
 	userRepo     repository.UserRepository
-	matchingSvc  *service.MatchingDomainService
+	blockRepo    repository.UserBlockRepository
+	// entitlementRepo, quotaUsageRepo and quotaCounter enforce the daily matching quota
+	entitlementRepo repository.EntitlementRepository
+	quotaUsageRepo  repository.MatchingQuotaUsageRepository
+	quotaCounter    repository.MatchingQuotaCounterRepository
+	matchingSvc     *service.MatchingDomainService
+	clock           clock.Clock
 }
 
-func NewMatchingInteractor(txManager transaction.Manager, matchingRepo repository.MatchingRepository, userRepo repository.UserRepository, matchingSvc *service.MatchingDomainService) MatchingInteractor {
+func NewMatchingInteractor(
+	txManager transaction.Manager,
+	matchingRepo repository.MatchingRepository,
+	historyRepo repository.MatchingHistoryRepository,
+	userRepo repository.UserRepository,
+	blockRepo repository.UserBlockRepository,
+	entitlementRepo repository.EntitlementRepository,
+	quotaUsageRepo repository.MatchingQuotaUsageRepository,
+	quotaCounter repository.MatchingQuotaCounterRepository,
+	matchingSvc *service.MatchingDomainService,
+	clock clock.Clock,
+) MatchingInteractor {
 	return MatchingInteractor{
-		txManager:    txManager,
-		matchingRepo: matchingRepo,
-		userRepo:     userRepo,
-		matchingSvc:  matchingSvc,
+		txManager:       txManager,
+		matchingRepo:    matchingRepo,
+		historyRepo:     historyRepo,
+		userRepo:        userRepo,
+		blockRepo:       blockRepo,
+		entitlementRepo: entitlementRepo,
+		quotaUsageRepo:  quotaUsageRepo,
+		quotaCounter:    quotaCounter,
+		matchingSvc:     matchingSvc,
+		clock:           clock,
 	}
 }
 
+// Create likes the partner. If the partner already likes me and is waiting for an answer,
+// the existing matching becomes mutual instead of creating another one for the same pair.
+// A closed matching of the pair is reopened when the transition table allows it.
+// Sending a new or reopened request uses up the daily matching quota of me, while liking back does not.
 func (i MatchingInteractor) Create(ctx context.Context, input *port.CreateMatchingInput) (*port.CreateMatchingOutput, error) {
-	var createdMatching *model.Matching
+	quota, reserved, err := i.reserveQuota(ctx, input.MeID)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := i.create(ctx, input, quota)
+	// A concurrent request may have created the pair in the meantime, so retry once to find it
+	if errors.Is(err, repository.ErrMatchingPairAlreadyExists) {
+		output, err = i.create(ctx, input, quota)
+	}
+	if reserved {
+		if err != nil || output.Mutual {
+			i.releaseQuota(ctx, quota)
+		} else {
+			// The request is only used up once an outer transaction, such as the audit log's, commits too
+			event.AfterRollback(ctx, func(ctx context.Context) { i.releaseQuota(ctx, quota) })
+		}
+	}
+	if errors.Is(err, repository.ErrMatchingPairAlreadyExists) {
+		return nil, domainerr.New(domainerr.ReasonMatchingAlreadyExists, err, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !output.Mutual {
+		output.Quota = quota
+	}
+	return output, nil
+}
+
+// reserveQuota counts the request against the daily quota of the user, and fails when the quota is exhausted.
+// reserved is false when the counter is unavailable and the quota was checked against the audit record instead.
+func (i MatchingInteractor) reserveQuota(ctx context.Context, userID uuid.UUID) (*model.MatchingQuota, bool, error) {
+	entitlement, err := i.entitlementRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	quota := model.NewMatchingQuota(entitlement, i.clock.Now())
+
+	reserved := true
+	used, err := i.quotaCounter.Add(ctx, userID, quota.Day, 1, quota.ResetAt())
+	if err != nil {
+		log.Printf("failed to update matching quota counter: %v\n", err)
+		if used, err = i.quotaUsageRepo.Count(ctx, userID, quota.Day); err != nil {
+			return nil, false, err
+		}
+		used++
+		reserved = false
+	} else if used == 1 {
+		// A fresh counter may have been evicted, so catch up with the audit record
+		audited, err := i.quotaUsageRepo.Count(ctx, userID, quota.Day)
+		if err != nil {
+			i.releaseQuota(ctx, &model.MatchingQuota{UserID: userID, Day: quota.Day, Used: used})
+			return nil, false, err
+		}
+		if audited > 0 {
+			if used, err = i.quotaCounter.Add(ctx, userID, quota.Day, audited, quota.ResetAt()); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	quota.Used = used
+
+	if err := quota.Check(); err != nil {
+		if reserved {
+			i.releaseQuota(ctx, quota)
+		}
+		return nil, false, domainerr.New(
+			domainerr.ReasonMatchingQuotaExhausted,
+			err,
+			map[string]interface{}{"plan": entitlement.Plan, "limit": quota.Limit, "resetAt": quota.ResetAt()},
+		)
+	}
+	return quota, reserved, nil
+}
+
+// releaseQuota gives back the request reserved in the counter. A failure is only logged,
+// since the counter is then ahead of the audit record until the day ends.
+func (i MatchingInteractor) releaseQuota(ctx context.Context, quota *model.MatchingQuota) {
+	if _, err := i.quotaCounter.Add(ctx, quota.UserID, quota.Day, -1, quota.ResetAt()); err != nil {
+		log.Printf("failed to release matching quota: %v\n", err)
+	}
+}
+
+func (i MatchingInteractor) create(ctx context.Context, input *port.CreateMatchingInput, quota *model.MatchingQuota) (*port.CreateMatchingOutput, error) {
+	var matching *model.Matching
+	now := i.clock.Now()
 	err := i.txManager.Do(ctx, func(ctx context.Context) error {
 		me, err := i.userRepo.FindById(ctx, input.MeID)
 		if err != nil {
@@ -37,66 +166,214 @@ func (i MatchingInteractor) Create(ctx context.Context, input *port.CreateMatchi
 		if err != nil {
 			return err
 		}
-		if err := i.matchingSvc.Validate(ctx, me, partner); err != nil {
+		blocks, err := i.blockRepo.FindAllBetween(ctx, input.MeID, input.PartnerID)
+		if err != nil {
 			return err
 		}
-		createdMatching = model.NewMatching(model.InputMatchingParams{
-			MeID:      input.MeID,
-			PartnerID: input.PartnerID,
-		})
-		createdMatching, err = i.matchingRepo.Save(ctx, createdMatching)
-		return err
+		if err := i.matchingSvc.Validate(ctx, me, partner, blocks); err != nil {
+			if errors.Is(err, service.ErrMatchingUserIsBlocked) ||
+				errors.Is(err, service.ErrMatchingUserIsSuspended) ||
+				errors.Is(err, service.ErrMatchingUserIsUnverified) {
+				return domainerr.New(domainerr.ReasonMatchingNotAllowed, err, nil)
+			}
+			return err
+		}
+
+		existing, err := i.matchingRepo.FindByPairForUpdate(ctx, input.MeID, input.PartnerID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			matching = model.NewMatching(model.InputMatchingParams{
+				MeID:      input.MeID,
+				PartnerID: input.PartnerID,
+				Status:    string(model.MatchingStatusPending),
+			}, now)
+			if matching, err = i.matchingRepo.Save(ctx, matching); err != nil {
+				return err
+			}
+			event.Collect(ctx, matching)
+			if _, err = i.historyRepo.Save(ctx, model.NewMatchingCreatedHistory(matching)); err != nil {
+				return err
+			}
+			return i.quotaUsageRepo.Increment(ctx, input.MeID, quota.Day)
+		}
+
+		var action model.MatchingAction
+		var reason string
+		switch {
+		case existing.IsAnswerableBy(input.MeID, now):
+			action, reason = model.MatchingActionAccept, matchingReasonMutualLike
+		case existing.CanTransition(model.MatchingActionReopen, input.MeID, now) == nil:
+			action = model.MatchingActionReopen
+		default:
+			return domainerr.New(
+				domainerr.ReasonMatchingAlreadyExists,
+				nil,
+				map[string]interface{}{"id": existing.ID, "status": existing.Status},
+			)
+		}
+		if matching, err = i.saveTransition(ctx, existing, action, input.MeID, reason, now); err != nil {
+			return err
+		}
+		if action == model.MatchingActionReopen {
+			return i.quotaUsageRepo.Increment(ctx, input.MeID, quota.Day)
+		}
+		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &port.CreateMatchingOutput{Matching: createdMatching}, nil
+	return &port.CreateMatchingOutput{Matching: matching, Mutual: matching.IsMutual()}, nil
 }
 
+// Accept answers the pending matching of the partner. MeID is the user who was liked.
 func (i MatchingInteractor) Accept(ctx context.Context, input *port.AcceptMatchingInput) (*port.AcceptMatchingOutput, error) {
-	matching, err := i.matchingRepo.FindByParticipants(ctx, input.MeID, input.PartnerID)
+	matching, err := i.transition(ctx, input.MeID, input.PartnerID, model.MatchingActionAccept, input.Reason)
 	if err != nil {
 		return nil, err
 	}
-	if err := matching.Accept(); err != nil {
-		return nil, err
-	}
+	return &port.AcceptMatchingOutput{Matching: matching}, nil
+}
 
-	var updatedMatching *model.Matching
-	err = i.txManager.Do(ctx, func(ctx context.Context) error {
-		updatedMatching, err = i.matchingRepo.Save(ctx, matching)
-		return err
-	})
+// Reject answers the pending matching of the partner. MeID is the user who was liked.
+func (i MatchingInteractor) Reject(ctx context.Context, input *port.RejectMatchingInput) (*port.RejectMatchingOutput, error) {
+	matching, err := i.transition(ctx, input.MeID, input.PartnerID, model.MatchingActionReject, input.Reason)
 	if err != nil {
 		return nil, err
 	}
-	return &port.AcceptMatchingOutput{Matching: updatedMatching}, nil
+	return &port.RejectMatchingOutput{Matching: matching}, nil
 }
 
-func (i MatchingInteractor) Reject(ctx context.Context, input *port.RejectMatchingInput) (*port.RejectMatchingOutput, error) {
-	matching, err := i.matchingRepo.FindByParticipants(ctx, input.MeID, input.PartnerID)
+// Cancel withdraws my pending like before the partner answers it.
+func (i MatchingInteractor) Cancel(ctx context.Context, input *port.CancelMatchingInput) (*port.CancelMatchingOutput, error) {
+	matching, err := i.transition(ctx, input.MeID, input.PartnerID, model.MatchingActionCancel, input.Reason)
 	if err != nil {
 		return nil, err
 	}
-	if err := matching.Reject(); err != nil {
+	return &port.CancelMatchingOutput{Matching: matching}, nil
+}
+
+// Unmatch ends an accepted matching. Either user can unmatch.
+func (i MatchingInteractor) Unmatch(ctx context.Context, input *port.UnmatchMatchingInput) (*port.UnmatchMatchingOutput, error) {
+	matching, err := i.transition(ctx, input.MeID, input.PartnerID, model.MatchingActionUnmatch, input.Reason)
+	if err != nil {
 		return nil, err
 	}
+	return &port.UnmatchMatchingOutput{Matching: matching}, nil
+}
 
+// transition performs the action on the matching of the pair as the actor.
+func (i MatchingInteractor) transition(ctx context.Context, actorID, partnerID uuid.UUID, action model.MatchingAction, reason string) (*model.Matching, error) {
 	var updatedMatching *model.Matching
-	err = i.txManager.Do(ctx, func(ctx context.Context) error {
-		updatedMatching, err = i.matchingRepo.Save(ctx, matching)
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		matching, err := i.matchingRepo.FindByPairForUpdate(ctx, actorID, partnerID)
+		if err != nil {
+			return err
+		}
+		if matching == nil {
+			return domainerr.New(
+				domainerr.ReasonMatchingNotFound,
+				nil,
+				map[string]interface{}{"meId": actorID, "partnerId": partnerID},
+			)
+		}
+		updatedMatching, err = i.saveTransition(ctx, matching, action, actorID, reason, i.clock.Now())
 		return err
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &port.RejectMatchingOutput{Matching: updatedMatching}, nil
+	return updatedMatching, nil
+}
+
+// saveTransition performs the action and saves the matching with its history in the current transaction.
+func (i MatchingInteractor) saveTransition(ctx context.Context, matching *model.Matching, action model.MatchingAction, actorID uuid.UUID, reason string, now time.Time) (*model.Matching, error) {
+	history, err := matching.Transition(action, actorID, reason, now)
+	if err != nil {
+		return nil, toMatchingTransitionError(err, matching, action)
+	}
+	saved, err := i.matchingRepo.Save(ctx, matching)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := i.historyRepo.Save(ctx, history); err != nil {
+		return nil, err
+	}
+	event.Collect(ctx, matching)
+	return saved, nil
+}
+
+func toMatchingTransitionError(err error, matching *model.Matching, action model.MatchingAction) error {
+	details := map[string]interface{}{"id": matching.ID, "status": matching.Status, "action": action}
+	return domainerr.Wrap(err, domainerr.ReasonMatchingTransitionNotAllowed, details)
 }
 
 func (i MatchingInteractor) ListByMeID(ctx context.Context, input *port.ListMatchingByMeIDInput) (*port.ListMatchingByMeIDOutput, error) {
-	matchings, err := i.matchingRepo.FindAllByUser(ctx, input.MeID, input.Limit, input.Offset)
+	var matchings []*model.Matching
+	var err error
+	if input.MutualOnly {
+		matchings, err = i.matchingRepo.FindAllMutualByUser(ctx, input.MeID, input.Limit, input.Offset)
+	} else {
+		matchings, err = i.matchingRepo.FindAllByUser(ctx, input.MeID, input.Limit, input.Offset)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &port.ListMatchingByMeIDOutput{Matchings: matchings}, nil
 }
+
+// Timeline returns the matching of the pair with every recorded transition, oldest first.
+func (i MatchingInteractor) Timeline(ctx context.Context, input *port.GetMatchingTimelineInput) (*port.GetMatchingTimelineOutput, error) {
+	matching, err := i.matchingRepo.FindByPair(ctx, input.MeID, input.PartnerID)
+	if err != nil {
+		return nil, err
+	}
+	if matching == nil {
+		return nil, domainerr.New(
+			domainerr.ReasonMatchingNotFound,
+			nil,
+			map[string]interface{}{"meId": input.MeID, "partnerId": input.PartnerID},
+		)
+	}
+	histories, err := i.historyRepo.FindAllByMatchingID(ctx, matching.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &port.GetMatchingTimelineOutput{Matching: matching, Histories: histories}, nil
+}
+
+// ExpireOverdue expires the overdue pending matchings batch by batch, committing each batch separately.
+func (i MatchingInteractor) ExpireOverdue(ctx context.Context, input *port.ExpireOverdueMatchingsInput) (*port.ExpireOverdueMatchingsOutput, error) {
+	batchSize := input.BatchSize
+	if batchSize < 1 {
+		batchSize = DefaultExpireMatchingsBatchSize
+	}
+
+	now := i.clock.Now()
+	expiredCount := 0
+	for {
+		var count int
+		err := i.txManager.Do(ctx, func(ctx context.Context) error {
+			matchings, err := i.matchingRepo.FindAllOverdue(ctx, now, batchSize)
+			if err != nil {
+				return err
+			}
+			for _, matching := range matchings {
+				if _, err := i.saveTransition(ctx, matching, model.MatchingActionExpire, uuid.Nil(), matchingReasonExpired, now); err != nil {
+					return err
+				}
+			}
+			count = len(matchings)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		expiredCount += count
+		if count < batchSize {
+			break
+		}
+	}
+	return &port.ExpireOverdueMatchingsOutput{ExpiredCount: expiredCount}, nil
+}
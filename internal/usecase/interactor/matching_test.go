@@ -2,32 +2,54 @@ package interactor
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/service"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/repository"
-	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	redisRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/redis/repository"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/testhelper"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
 )
 
+// testMatchingPlanLimits are small enough to exhaust the daily matching quota in a test
+var testMatchingPlanLimits = model.PlanLimits{model.PlanFree: 3, model.PlanPremium: 5}
+
 func SetupTestMatchingInteractor(ctx context.Context, gw *testhelper.Gateway) (MatchingInteractor, *repository.UserMySQLRepository) {
+	return setupTestMatchingInteractorWithClock(ctx, gw, clock.New())
+}
+
+func setupTestMatchingInteractorWithClock(ctx context.Context, gw *testhelper.Gateway, clk clock.Clock) (MatchingInteractor, *repository.UserMySQLRepository) {
 	return NewMatchingInteractor(
-		transaction.NewMySQLTransactionManager(gw.MySQLClient),
+		SetupTestTxManager(gw),
 		repository.NewMatchingMySQLRepository(gw.MySQLClient),
+		repository.NewMatchingHistoryMySQLRepository(gw.MySQLClient),
 		repository.NewUserMySQLRepository(gw.MySQLClient),
+		repository.NewUserBlockMySQLRepository(gw.MySQLClient),
+		repository.NewEntitlementMySQLRepository(gw.MySQLClient, testMatchingPlanLimits, clk),
+		repository.NewMatchingQuotaUsageMySQLRepository(gw.MySQLClient, clk),
+		redisRepo.NewMatchingQuotaRedisRepository(gw.RedisClient),
 		&service.MatchingDomainService{},
+		clk,
 	), repository.NewUserMySQLRepository(gw.MySQLClient)
 }
 
 func createTestUser(ctx context.Context, t *testing.T, userRepo *repository.UserMySQLRepository) *model.User {
 	id := uuid.New()
+	now := time.Now()
 	user := model.NewUser(model.InputUserParams{
 		ID:    id,
 		Email: id.String() + "@example.com",
-	})
+	}, now)
+	// Only users with a verified email can match
+	if err := user.VerifyEmail(now); err != nil {
+		t.Fatalf("Failed to verify test user: %v", err)
+	}
 	createdUser, err := userRepo.Save(ctx, user)
 	if err != nil {
 		t.Fatalf("Failed to create test user: %v", err)
@@ -36,7 +58,7 @@ func createTestUser(ctx context.Context, t *testing.T, userRepo *repository.User
 }
 
 func TestMatchingInteractor_Create(t *testing.T) {
-	ctx := context.Background()
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
 	gw, err := testhelper.Setup(ctx)
 	if err != nil {
 		t.Fatalf("Failed to setup test: %v", err)
@@ -47,10 +69,12 @@ func TestMatchingInteractor_Create(t *testing.T) {
 	user1 := createTestUser(ctx, t, userRepo)
 	user2 := createTestUser(ctx, t, userRepo)
 
+	// The cases run in order and share the pair of users
 	tests := []struct {
-		name    string
-		input   *port.CreateMatchingInput
-		wantErr bool
+		name       string
+		input      *port.CreateMatchingInput
+		wantMutual bool
+		wantErr    bool
 	}{
 		{
 			name: "OK",
@@ -60,6 +84,31 @@ func TestMatchingInteractor_Create(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "NG_SameDirectionDuplicate",
+			input: &port.CreateMatchingInput{
+				MeID:      user1.ID,
+				PartnerID: user2.ID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "OK_PartnerLikesBackBecomesMutual",
+			input: &port.CreateMatchingInput{
+				MeID:      user2.ID,
+				PartnerID: user1.ID,
+			},
+			wantMutual: true,
+			wantErr:    false,
+		},
+		{
+			name: "NG_AlreadyMutual",
+			input: &port.CreateMatchingInput{
+				MeID:      user2.ID,
+				PartnerID: user1.ID,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -76,9 +125,259 @@ func TestMatchingInteractor_Create(t *testing.T) {
 				t.Error("Create() got nil matching")
 				return
 			}
-			if got.Matching.MeID != tt.input.MeID || got.Matching.PartnerID != tt.input.PartnerID {
+			if got.Mutual != tt.wantMutual {
+				t.Errorf("Create() mutual = %v, want %v", got.Mutual, tt.wantMutual)
+			}
+			if got.Matching.PairKey() != model.MatchingPairKey(tt.input.MeID, tt.input.PartnerID) {
 				t.Errorf("Create() got = %v, want meID: %v, partnerID: %v", got.Matching, tt.input.MeID, tt.input.PartnerID)
 			}
 		})
 	}
 }
+
+func TestMatchingInteractor_CreateQuota(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	matchingInteractor, userRepo := SetupTestMatchingInteractor(ctx, gw)
+	entitlementRepo := repository.NewEntitlementMySQLRepository(gw.MySQLClient, testMatchingPlanLimits, clock.New())
+
+	me := createTestUser(ctx, t, userRepo)
+	send := func() (*port.CreateMatchingOutput, error) {
+		partner := createTestUser(ctx, t, userRepo)
+		return matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: me.ID, PartnerID: partner.ID})
+	}
+
+	t.Run("OK_WithinFreeLimit", func(t *testing.T) {
+		for n := 1; n <= testMatchingPlanLimits[model.PlanFree]; n++ {
+			got, err := send()
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if got.Quota == nil || got.Quota.Used != n {
+				t.Errorf("Create() quota = %v, want used %v", got.Quota, n)
+			}
+		}
+	})
+
+	t.Run("OK_LikingBackIsFree", func(t *testing.T) {
+		liker := createTestUser(ctx, t, userRepo)
+		if _, err := matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: liker.ID, PartnerID: me.ID}); err != nil {
+			t.Fatalf("Failed to create test matching: %v", err)
+		}
+		got, err := matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: me.ID, PartnerID: liker.ID})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if !got.Mutual || got.Quota != nil {
+			t.Errorf("Create() mutual = %v, quota = %v, want mutual without quota", got.Mutual, got.Quota)
+		}
+	})
+
+	t.Run("NG_FreeLimitExhausted", func(t *testing.T) {
+		if _, err := send(); !errors.Is(err, model.ErrMatchingQuotaExhausted) {
+			t.Errorf("Create() error = %v, want %v", err, model.ErrMatchingQuotaExhausted)
+		}
+	})
+
+	t.Run("OK_PremiumRaisesLimit", func(t *testing.T) {
+		if err := entitlementRepo.SavePlan(ctx, me.ID, model.PlanPremium); err != nil {
+			t.Fatalf("Failed to save plan: %v", err)
+		}
+		got, err := send()
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if got.Quota.Limit != testMatchingPlanLimits[model.PlanPremium] {
+			t.Errorf("Create() quota limit = %v, want %v", got.Quota.Limit, testMatchingPlanLimits[model.PlanPremium])
+		}
+	})
+}
+
+func TestMatchingInteractor_ExpireOverdue(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	clk := clock.NewFake(time.Now())
+	matchingInteractor, userRepo := setupTestMatchingInteractorWithClock(ctx, gw, clk)
+	matchingRepo := repository.NewMatchingMySQLRepository(gw.MySQLClient)
+
+	createTestMatching := func() *model.Matching {
+		matching := model.NewMatching(model.InputMatchingParams{
+			MeID:      createTestUser(ctx, t, userRepo).ID,
+			PartnerID: createTestUser(ctx, t, userRepo).ID,
+			Status:    string(model.MatchingStatusPending),
+		}, clk.Now())
+		created, err := matchingRepo.Save(ctx, matching)
+		if err != nil {
+			t.Fatalf("Failed to create test matching: %v", err)
+		}
+		return created
+	}
+
+	overdue := []*model.Matching{createTestMatching(), createTestMatching(), createTestMatching()}
+	clk.Advance(time.Hour)
+	notOverdue := createTestMatching()
+	// The first matchings are just past their expiry, while the last one has an hour left
+	clk.Advance(model.MatchingPendingTTL - time.Hour + time.Second)
+
+	// A batch size smaller than the overdue count checks that every batch is processed
+	got, err := matchingInteractor.ExpireOverdue(ctx, &port.ExpireOverdueMatchingsInput{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("ExpireOverdue() error = %v", err)
+	}
+	if got.ExpiredCount != len(overdue) {
+		t.Errorf("ExpireOverdue() got = %v, want %v", got.ExpiredCount, len(overdue))
+	}
+
+	for _, m := range overdue {
+		found, err := matchingRepo.FindById(ctx, m.ID)
+		if err != nil {
+			t.Fatalf("Failed to find matching: %v", err)
+		}
+		if found.Status != model.MatchingStatusExpired {
+			t.Errorf("ExpireOverdue() status = %v, want %v", found.Status, model.MatchingStatusExpired)
+		}
+	}
+	found, err := matchingRepo.FindById(ctx, notOverdue.ID)
+	if err != nil {
+		t.Fatalf("Failed to find matching: %v", err)
+	}
+	if found.Status != model.MatchingStatusPending {
+		t.Errorf("ExpireOverdue() status = %v, want %v", found.Status, model.MatchingStatusPending)
+	}
+}
+
+func TestMatchingInteractor_ListByMeID(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	matchingInteractor, userRepo := SetupTestMatchingInteractor(ctx, gw)
+
+	me := createTestUser(ctx, t, userRepo)
+	pendingPartner := createTestUser(ctx, t, userRepo)
+	mutualPartner := createTestUser(ctx, t, userRepo)
+	for _, input := range []*port.CreateMatchingInput{
+		{MeID: me.ID, PartnerID: pendingPartner.ID},
+		{MeID: me.ID, PartnerID: mutualPartner.ID},
+		{MeID: mutualPartner.ID, PartnerID: me.ID},
+	} {
+		if _, err := matchingInteractor.Create(ctx, input); err != nil {
+			t.Fatalf("Failed to create test matching: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		input *port.ListMatchingByMeIDInput
+		want  int
+	}{
+		{
+			name:  "OK_All",
+			input: &port.ListMatchingByMeIDInput{MeID: me.ID, Limit: 10},
+			want:  2,
+		},
+		{
+			name:  "OK_MutualOnly",
+			input: &port.ListMatchingByMeIDInput{MeID: me.ID, Limit: 10, MutualOnly: true},
+			want:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchingInteractor.ListByMeID(ctx, tt.input)
+			if err != nil {
+				t.Fatalf("ListByMeID() error = %v", err)
+			}
+			if len(got.Matchings) != tt.want {
+				t.Errorf("ListByMeID() got = %v, want %v", len(got.Matchings), tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchingInteractor_Timeline(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	matchingInteractor, userRepo := SetupTestMatchingInteractor(ctx, gw)
+
+	me := createTestUser(ctx, t, userRepo)
+	partner := createTestUser(ctx, t, userRepo)
+
+	// like -> cancel -> like again (reopen) -> like back (mutual) -> unmatch
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"create", func() error {
+			_, err := matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: me.ID, PartnerID: partner.ID})
+			return err
+		}},
+		{"cancel", func() error {
+			_, err := matchingInteractor.Cancel(ctx, &port.CancelMatchingInput{MeID: me.ID, PartnerID: partner.ID, Reason: "changed my mind"})
+			return err
+		}},
+		{"reopen", func() error {
+			_, err := matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: me.ID, PartnerID: partner.ID})
+			return err
+		}},
+		{"mutual", func() error {
+			_, err := matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: partner.ID, PartnerID: me.ID})
+			return err
+		}},
+		{"unmatch", func() error {
+			_, err := matchingInteractor.Unmatch(ctx, &port.UnmatchMatchingInput{MeID: partner.ID, PartnerID: me.ID})
+			return err
+		}},
+	}
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			t.Fatalf("Failed to %s: %v", step.name, err)
+		}
+	}
+
+	if _, err := matchingInteractor.Cancel(ctx, &port.CancelMatchingInput{MeID: me.ID, PartnerID: partner.ID}); err == nil {
+		t.Error("Cancel() of unmatched matching succeeded")
+	}
+
+	got, err := matchingInteractor.Timeline(ctx, &port.GetMatchingTimelineInput{MeID: partner.ID, PartnerID: me.ID})
+	if err != nil {
+		t.Fatalf("Timeline() error = %v", err)
+	}
+	if got.Matching.Status != model.MatchingStatusUnmatched {
+		t.Errorf("Timeline() status = %v, want %v", got.Matching.Status, model.MatchingStatusUnmatched)
+	}
+	wantActions := []model.MatchingAction{
+		model.MatchingActionCreate,
+		model.MatchingActionCancel,
+		model.MatchingActionReopen,
+		model.MatchingActionAccept,
+		model.MatchingActionUnmatch,
+	}
+	if len(got.Histories) != len(wantActions) {
+		t.Fatalf("Timeline() got %d histories, want %d", len(got.Histories), len(wantActions))
+	}
+	for i, want := range wantActions {
+		if got.Histories[i].Action != want {
+			t.Errorf("Timeline() history[%d] = %v, want %v", i, got.Histories[i].Action, want)
+		}
+	}
+
+	if _, err := matchingInteractor.Timeline(ctx, &port.GetMatchingTimelineInput{MeID: me.ID, PartnerID: uuid.New()}); err == nil {
+		t.Error("Timeline() of unknown pair succeeded")
+	}
+}
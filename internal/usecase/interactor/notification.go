@@ -0,0 +1,310 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// MaxNotificationsLimit caps the page size of the inbox.
+const MaxNotificationsLimit = 100
+
+// NotificationMessage is the message of the notification queue. The subscriber renders the notification and fans it out to the channels.
+type NotificationMessage struct {
+	// ID identifies the notification across retries, so that the inbox keeps a single entry.
+	ID     uuid.UUID              `json:"id"`
+	UserID uuid.UUID              `json:"userId"`
+	Type   model.NotificationType `json:"type"`
+	Data   map[string]string      `json:"data"`
+}
+
+type NotificationInteractor struct {
+	notificationRepo  repository.NotificationRepository
+	preferenceRepo    repository.NotificationPreferenceRepository
+	deliveryRepo      repository.NotificationDeliveryRepository
+	userRepo          repository.UserRepository
+	notificationQueue repository.MessageQueueRepository
+	deliverers        []repository.NotificationDeliverer
+	clock             clock.Clock
+}
+
+func NewNotificationInteractor(
+	notificationRepo repository.NotificationRepository,
+	preferenceRepo repository.NotificationPreferenceRepository,
+	deliveryRepo repository.NotificationDeliveryRepository,
+	userRepo repository.UserRepository,
+	notificationQueue repository.MessageQueueRepository,
+	clock clock.Clock,
+	deliverers ...repository.NotificationDeliverer,
+) NotificationInteractor {
+	return NotificationInteractor{
+		notificationRepo:  notificationRepo,
+		preferenceRepo:    preferenceRepo,
+		deliveryRepo:      deliveryRepo,
+		userRepo:          userRepo,
+		notificationQueue: notificationQueue,
+		deliverers:        deliverers,
+		clock:             clock,
+	}
+}
+
+// enqueueNotification writes the notification to the outbox in the transaction of ctx, from which the relay publishes it to the notification queue.
+func enqueueNotification(ctx context.Context, outboxRepo repository.OutboxRepository, userID uuid.UUID, typ model.NotificationType, data map[string]string, now time.Time) error {
+	body, err := json.Marshal(NotificationMessage{
+		ID:     uuid.New(),
+		UserID: userID,
+		Type:   typ,
+		Data:   data,
+	})
+	if err != nil {
+		return err
+	}
+	msg := &model.Message{
+		Body: string(body),
+		Attributes: map[string]string{
+			"messageType": "notification",
+		},
+	}
+	_, err = outboxRepo.Save(ctx, model.NewOutboxMessage(model.OutboxDestinationNotification, msg, now))
+	return err
+}
+
+// List returns the inbox of the user, newest first, with the number of unread notifications.
+func (i NotificationInteractor) List(ctx context.Context, input *port.ListNotificationsInput) (*port.ListNotificationsOutput, error) {
+	if err := i.checkUser(ctx, input.UserID); err != nil {
+		return nil, err
+	}
+	limit := input.Limit
+	if limit <= 0 || limit > MaxNotificationsLimit {
+		limit = MaxNotificationsLimit
+	}
+	notifications, err := i.notificationRepo.FindAllByUserID(ctx, input.UserID, input.UnreadOnly, limit, input.Offset)
+	if err != nil {
+		return nil, err
+	}
+	unreadCount, err := i.notificationRepo.CountUnreadByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &port.ListNotificationsOutput{
+		Notifications: notifications,
+		UnreadCount:   unreadCount,
+	}, nil
+}
+
+func (i NotificationInteractor) CountUnread(ctx context.Context, input *port.CountUnreadNotificationsInput) (*port.CountUnreadNotificationsOutput, error) {
+	if err := i.checkUser(ctx, input.UserID); err != nil {
+		return nil, err
+	}
+	unreadCount, err := i.notificationRepo.CountUnreadByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &port.CountUnreadNotificationsOutput{UnreadCount: unreadCount}, nil
+}
+
+// MarkRead marks the notification of the user as read, or all of them when no notification is given.
+func (i NotificationInteractor) MarkRead(ctx context.Context, input *port.MarkNotificationsReadInput) (*port.MarkNotificationsReadOutput, error) {
+	if err := i.checkUser(ctx, input.UserID); err != nil {
+		return nil, err
+	}
+	if input.NotificationID != uuid.Nil() {
+		notification, err := i.notificationRepo.FindById(ctx, input.NotificationID)
+		if err != nil {
+			return nil, err
+		}
+		if notification == nil || notification.UserID != input.UserID {
+			return nil, domainerr.New(domainerr.ReasonNotificationNotFound, nil, map[string]interface{}{"id": input.NotificationID})
+		}
+	}
+
+	readAt := i.clock.Now()
+	count, err := i.notificationRepo.MarkRead(ctx, input.UserID, input.NotificationID, readAt)
+	if err != nil {
+		return nil, err
+	}
+	return &port.MarkNotificationsReadOutput{ReadCount: count, ReadAt: readAt}, nil
+}
+
+// GetPreference returns the channels of the user, with every channel on until the user chooses.
+func (i NotificationInteractor) GetPreference(ctx context.Context, input *port.GetNotificationPreferenceInput) (*port.GetNotificationPreferenceOutput, error) {
+	if err := i.checkUser(ctx, input.UserID); err != nil {
+		return nil, err
+	}
+	preference, err := i.findPreference(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &port.GetNotificationPreferenceOutput{Preference: preference}, nil
+}
+
+func (i NotificationInteractor) UpdatePreference(ctx context.Context, input *port.UpdateNotificationPreferenceInput) (*port.UpdateNotificationPreferenceOutput, error) {
+	if err := i.checkUser(ctx, input.UserID); err != nil {
+		return nil, err
+	}
+
+	preference, err := i.findPreference(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := preference.Set(input.Channels, i.clock.Now()); err != nil {
+		return nil, domainerr.New(domainerr.ReasonNotificationChannelUnknown, err, nil)
+	}
+	if preference, err = i.preferenceRepo.Save(ctx, preference); err != nil {
+		return nil, err
+	}
+	return &port.UpdateNotificationPreferenceOutput{Preference: preference}, nil
+}
+
+// checkUser fails unless the user is in the tenant of the context, so that a user of another tenant
+// is reported as not found instead of as an empty inbox.
+func (i NotificationInteractor) checkUser(ctx context.Context, userID uuid.UUID) error {
+	user, err := i.userRepo.FindById(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domainerr.New(domainerr.ReasonUserNotFound, nil, map[string]interface{}{"id": userID})
+	}
+	return nil
+}
+
+func (i NotificationInteractor) findPreference(ctx context.Context, userID uuid.UUID) (*model.NotificationPreference, error) {
+	preference, err := i.preferenceRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if preference == nil {
+		return model.NewNotificationPreference(userID, i.clock.Now()), nil
+	}
+	return preference, nil
+}
+
+// DequeueAndDispatch delivers the notifications in the queue through the channels the users allow.
+// A notification failed on a channel stays in the queue and is delivered again to that channel once it becomes visible again,
+// until the channel has failed NotificationDeliveryMaxAttempts times.
+func (i NotificationInteractor) DequeueAndDispatch(ctx context.Context, input *port.DequeueAndDispatchNotificationsInput) (*port.DequeueAndDispatchNotificationsOutput, error) {
+	batchSize := int32(input.BatchSize)
+	if batchSize > 10 {
+		batchSize = 10
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	msgs, err := i.notificationQueue.Receive(ctx, &repository.ReceiveMessageOptions{
+		MaxNumberOfMessages: batchSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dispatchedCount := 0
+	for _, msg := range msgs {
+		var message NotificationMessage
+		if err := json.Unmarshal([]byte(msg.Body), &message); err != nil {
+			// A malformed message can never be delivered, so it is dropped instead of retried
+			log.Printf("Failed to unmarshal notification from message: %v", err)
+			if err := i.notificationQueue.Delete(ctx, msg); err != nil {
+				log.Printf("Failed to delete malformed notification message: %v", err)
+			}
+			continue
+		}
+		tenantCtx, err := messageContext(ctx, msg)
+		if err != nil {
+			log.Printf("Dropped notification %s without a tenant: %v", message.ID, err)
+			if err := i.notificationQueue.Delete(ctx, msg); err != nil {
+				log.Printf("Failed to delete notification message without a tenant: %v", err)
+			}
+			continue
+		}
+
+		if err := i.dispatch(tenantCtx, &message); err != nil {
+			log.Printf("Failed to dispatch notification %s: %v", message.ID, err)
+			continue
+		}
+
+		if err := i.notificationQueue.Delete(ctx, msg); err != nil {
+			log.Printf("Failed to delete message for notification %s: %v", message.ID, err)
+			continue
+		}
+		dispatchedCount++
+	}
+
+	return &port.DequeueAndDispatchNotificationsOutput{
+		DispatchedCount: dispatchedCount,
+	}, nil
+}
+
+// dispatch delivers the notification to every channel allowed by the user, skipping the channels already delivered or given up.
+// It fails while a channel is to be retried. The notification is dropped when the user is no longer active.
+func (i NotificationInteractor) dispatch(ctx context.Context, message *NotificationMessage) error {
+	user, err := i.userRepo.FindById(ctx, message.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.IsActive() {
+		return nil
+	}
+	notification, err := model.NewNotification(message.ID, message.UserID, message.Type, message.Data, i.clock.Now())
+	if err != nil {
+		log.Printf("Dropped notification %s: %v", message.ID, err)
+		return nil
+	}
+	preference, err := i.findPreference(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, deliverer := range i.deliverers {
+		if !preference.Allows(deliverer.Channel()) {
+			continue
+		}
+		if err := i.deliver(ctx, deliverer, user, notification); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", deliverer.Channel(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliver delivers the notification through the channel unless its delivery is recorded as done or given up,
+// and records the attempt. It fails when the channel is to be retried.
+func (i NotificationInteractor) deliver(ctx context.Context, deliverer repository.NotificationDeliverer, user *model.User, notification *model.Notification) error {
+	delivery, err := i.deliveryRepo.FindByNotificationIDAndChannel(ctx, notification.ID, deliverer.Channel())
+	if err != nil {
+		return err
+	}
+	now := i.clock.Now()
+	if delivery == nil {
+		delivery = model.NewNotificationDelivery(notification.ID, deliverer.Channel(), now)
+	}
+	if delivery.IsDelivered() || delivery.IsGivenUp() {
+		return nil
+	}
+
+	deliverErr := deliverer.Deliver(ctx, user, notification)
+	if deliverErr != nil {
+		delivery.MarkFailed(deliverErr, now)
+	} else {
+		delivery.MarkDelivered(now)
+	}
+	if _, err := i.deliveryRepo.Save(ctx, delivery); err != nil {
+		return err
+	}
+	if delivery.IsGivenUp() {
+		log.Printf("Gave up notification %s on %s after %d attempts: %v", notification.ID, delivery.Channel, delivery.Attempts, deliverErr)
+		return nil
+	}
+	return deliverErr
+}
@@ -0,0 +1,53 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+)
+
+type inAppNotificationDeliverer struct {
+	notificationRepo repository.NotificationRepository
+}
+
+// NewInAppNotificationDeliverer returns the channel that puts the notifications in the inbox of the user.
+func NewInAppNotificationDeliverer(notificationRepo repository.NotificationRepository) repository.NotificationDeliverer {
+	return inAppNotificationDeliverer{notificationRepo: notificationRepo}
+}
+
+func (d inAppNotificationDeliverer) Channel() model.NotificationChannel {
+	return model.NotificationChannelInApp
+}
+
+func (d inAppNotificationDeliverer) Deliver(ctx context.Context, user *model.User, notification *model.Notification) error {
+	_, err := d.notificationRepo.Save(ctx, notification)
+	return err
+}
+
+type emailNotificationDeliverer struct {
+	mailer repository.Mailer
+}
+
+// NewEmailNotificationDeliverer returns the channel that mails the notifications.
+// The notification subscriber already runs outside of a request, so it sends with the mailer instead of the mail queue.
+func NewEmailNotificationDeliverer(mailer repository.Mailer) repository.NotificationDeliverer {
+	return emailNotificationDeliverer{mailer: mailer}
+}
+
+func (d emailNotificationDeliverer) Channel() model.NotificationChannel {
+	return model.NotificationChannelEmail
+}
+
+// Deliver skips the users who have not verified their email, so that nothing is mailed to an address the user may not own.
+func (d emailNotificationDeliverer) Deliver(ctx context.Context, user *model.User, notification *model.Notification) error {
+	if !user.IsEmailVerified() {
+		return nil
+	}
+	return d.mailer.Send(ctx, &model.Mail{
+		To:      user.Email,
+		Subject: notification.Title,
+		Body:    fmt.Sprintf("%s\n\nOpen the app to see the details.\n", notification.Body),
+	})
+}
@@ -0,0 +1,36 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/event"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+)
+
+// NewMatchingNotifier returns the handler that enqueues the notifications of the matchings through the outbox:
+// the partner is told about a like, and the requester about the acceptance.
+// Subscribe it in the transaction to model.EventMatchingCreated and model.EventMatchingAccepted,
+// so that the notification is written along with the matching.
+func NewMatchingNotifier(outboxRepo repository.OutboxRepository, clock clock.Clock) event.Handler {
+	return func(ctx context.Context, e model.DomainEvent) error {
+		matchingEvent, ok := e.(model.MatchingEvent)
+		if !ok {
+			return nil
+		}
+		switch matchingEvent.Name {
+		case model.EventMatchingCreated:
+			return enqueueNotification(ctx, outboxRepo, matchingEvent.PartnerID, model.NotificationTypeMatchingCreated, map[string]string{
+				"matchingId": matchingEvent.MatchingID.String(),
+				"userId":     matchingEvent.MeID.String(),
+			}, clock.Now())
+		case model.EventMatchingAccepted:
+			return enqueueNotification(ctx, outboxRepo, matchingEvent.MeID, model.NotificationTypeMatchingAccepted, map[string]string{
+				"matchingId": matchingEvent.MatchingID.String(),
+				"userId":     matchingEvent.PartnerID.String(),
+			}, clock.Now())
+		}
+		return nil
+	}
+}
@@ -0,0 +1,266 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	fileRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/file/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/sqs"
+	sqsRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/sqs/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/testhelper"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// SetupTestNotificationInteractor writes the mails and the pushes to files in a temporary directory, returned with the interactor.
+func SetupTestNotificationInteractor(ctx context.Context, t *testing.T, gw *testhelper.Gateway) (NotificationInteractor, string) {
+	dir := t.TempDir()
+	mailer, err := fileRepo.NewMailerFileRepository(filepath.Join(dir, "mail.log"), clock.New())
+	if err != nil {
+		t.Fatalf("Failed to create mailer: %v", err)
+	}
+	push, err := fileRepo.NewNotificationPushFileRepository(filepath.Join(dir, "push.log"), clock.New())
+	if err != nil {
+		t.Fatalf("Failed to create push channel: %v", err)
+	}
+	notificationRepo := repository.NewNotificationMySQLRepository(gw.MySQLClient)
+	return NewNotificationInteractor(
+		notificationRepo,
+		repository.NewNotificationPreferenceMySQLRepository(gw.MySQLClient),
+		repository.NewNotificationDeliveryMySQLRepository(gw.MySQLClient),
+		repository.NewUserMySQLRepository(gw.MySQLClient),
+		sqsRepo.NewSQSRepository(gw.SQSClient.Client, gw.SQSClient.QueueURLs[sqs.SQSKeyNotification], clock.New()),
+		clock.New(),
+		NewInAppNotificationDeliverer(notificationRepo),
+		NewEmailNotificationDeliverer(mailer),
+		push,
+	), dir
+}
+
+// dispatchTestNotifications relays the outbox to the queue, and delivers the notifications in the queue until it is empty.
+func dispatchTestNotifications(ctx context.Context, t *testing.T, gw *testhelper.Gateway, notificationInteractor NotificationInteractor) int {
+	if _, err := SetupTestOutboxInteractor(ctx, gw).Relay(ctx, &port.RelayOutboxInput{}); err != nil {
+		t.Fatalf("Relay() error = %v", err)
+	}
+	total := 0
+	for {
+		output, err := notificationInteractor.DequeueAndDispatch(ctx, &port.DequeueAndDispatchNotificationsInput{BatchSize: 10})
+		if err != nil {
+			t.Fatalf("DequeueAndDispatch() error = %v", err)
+		}
+		if output.DispatchedCount == 0 {
+			return total
+		}
+		total += output.DispatchedCount
+	}
+}
+
+func TestNotificationInteractor_DequeueAndDispatch(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	notificationInteractor, dir := SetupTestNotificationInteractor(ctx, t, gw)
+	matchingInteractor, userRepo := SetupTestMatchingInteractor(ctx, gw)
+
+	me := createTestUser(ctx, t, userRepo)
+	partner := createTestUser(ctx, t, userRepo)
+	// The partner does not want emails
+	if _, err := notificationInteractor.UpdatePreference(ctx, &port.UpdateNotificationPreferenceInput{
+		UserID:   partner.ID,
+		Channels: map[model.NotificationChannel]bool{model.NotificationChannelEmail: false},
+	}); err != nil {
+		t.Fatalf("UpdatePreference() error = %v", err)
+	}
+
+	// The like notifies the partner, and the acceptance notifies me
+	if _, err := matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: me.ID, PartnerID: partner.ID}); err != nil {
+		t.Fatalf("Failed to create test matching: %v", err)
+	}
+	if _, err := matchingInteractor.Accept(ctx, &port.AcceptMatchingInput{MeID: partner.ID, PartnerID: me.ID}); err != nil {
+		t.Fatalf("Failed to accept test matching: %v", err)
+	}
+	if got := dispatchTestNotifications(ctx, t, gw, notificationInteractor); got != 2 {
+		t.Errorf("DequeueAndDispatch() dispatched = %d, want 2", got)
+	}
+
+	tests := []struct {
+		name     string
+		user     *model.User
+		wantType model.NotificationType
+	}{
+		{name: "Partner", user: partner, wantType: model.NotificationTypeMatchingCreated},
+		{name: "Me", user: me, wantType: model.NotificationTypeMatchingAccepted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := notificationInteractor.List(ctx, &port.ListNotificationsInput{UserID: tt.user.ID})
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(got.Notifications) != 1 || got.Notifications[0].Type != tt.wantType || got.UnreadCount != 1 {
+				t.Errorf("List() got = %+v, want a single unread %s", got, tt.wantType)
+			}
+		})
+	}
+
+	mails, err := os.ReadFile(filepath.Join(dir, "mail.log"))
+	if err != nil {
+		t.Fatalf("Failed to read mails: %v", err)
+	}
+	if !strings.Contains(string(mails), me.Email) || strings.Contains(string(mails), partner.Email) {
+		t.Errorf("mails = %s, want a mail to %s only", mails, me.Email)
+	}
+	pushes, err := os.ReadFile(filepath.Join(dir, "push.log"))
+	if err != nil {
+		t.Fatalf("Failed to read pushes: %v", err)
+	}
+	if strings.Count(string(pushes), "\n") != 2 {
+		t.Errorf("pushes = %s, want 2", pushes)
+	}
+}
+
+// countingNotificationDeliverer counts the deliveries through its channel, and fails them while failing is set.
+type countingNotificationDeliverer struct {
+	channel   model.NotificationChannel
+	failing   bool
+	delivered *int
+}
+
+func (d countingNotificationDeliverer) Channel() model.NotificationChannel {
+	return d.channel
+}
+
+func (d countingNotificationDeliverer) Deliver(ctx context.Context, user *model.User, notification *model.Notification) error {
+	*d.delivered++
+	if d.failing {
+		return errors.New("channel is down")
+	}
+	return nil
+}
+
+func TestNotificationInteractor_DispatchRetry(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	_, userRepo := SetupTestMatchingInteractor(ctx, gw)
+	user := createTestUser(ctx, t, userRepo)
+
+	var inApp, push int
+	notificationInteractor := NewNotificationInteractor(
+		repository.NewNotificationMySQLRepository(gw.MySQLClient),
+		repository.NewNotificationPreferenceMySQLRepository(gw.MySQLClient),
+		repository.NewNotificationDeliveryMySQLRepository(gw.MySQLClient),
+		userRepo,
+		sqsRepo.NewSQSRepository(gw.SQSClient.Client, gw.SQSClient.QueueURLs[sqs.SQSKeyNotification], clock.New()),
+		clock.New(),
+		countingNotificationDeliverer{channel: model.NotificationChannelInApp, delivered: &inApp},
+		countingNotificationDeliverer{channel: model.NotificationChannelPush, failing: true, delivered: &push},
+	)
+	message := &NotificationMessage{ID: uuid.New(), UserID: user.ID, Type: model.NotificationTypeMatchingCreated}
+
+	// Only the failed channel is retried, until it is given up
+	for attempt := 1; attempt <= model.NotificationDeliveryMaxAttempts; attempt++ {
+		err := notificationInteractor.dispatch(ctx, message)
+		if attempt < model.NotificationDeliveryMaxAttempts && err == nil {
+			t.Fatalf("dispatch() attempt %d error = nil, want the push to be retried", attempt)
+		}
+		if attempt == model.NotificationDeliveryMaxAttempts && err != nil {
+			t.Fatalf("dispatch() attempt %d error = %v, want the push given up", attempt, err)
+		}
+	}
+	if err := notificationInteractor.dispatch(ctx, message); err != nil {
+		t.Fatalf("dispatch() after giving up error = %v", err)
+	}
+	if inApp != 1 || push != model.NotificationDeliveryMaxAttempts {
+		t.Errorf("dispatch() delivered in-app %d times and push %d times, want 1 and %d", inApp, push, model.NotificationDeliveryMaxAttempts)
+	}
+}
+
+func TestNotificationInteractor_MarkRead(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	notificationInteractor, _ := SetupTestNotificationInteractor(ctx, t, gw)
+	userRepo := repository.NewUserMySQLRepository(gw.MySQLClient)
+	notificationRepo := repository.NewNotificationMySQLRepository(gw.MySQLClient)
+
+	user := createTestUser(ctx, t, userRepo)
+	other := createTestUser(ctx, t, userRepo)
+	var notifications []*model.Notification
+	for _, userID := range []uuid.UUID{user.ID, user.ID, user.ID, other.ID} {
+		notification, err := model.NewNotification(uuid.New(), userID, model.NotificationTypeMatchingCreated, nil, time.Now())
+		if err != nil {
+			t.Fatalf("Failed to create test notification: %v", err)
+		}
+		if _, err := notificationRepo.Save(ctx, notification); err != nil {
+			t.Fatalf("Failed to save test notification: %v", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	tests := []struct {
+		name          string
+		input         *port.MarkNotificationsReadInput
+		wantReadCount int
+		wantUnread    int
+		wantCode      domainerr.ErrorCode
+	}{
+		{name: "OK_One", input: &port.MarkNotificationsReadInput{UserID: user.ID, NotificationID: notifications[0].ID}, wantReadCount: 1, wantUnread: 2},
+		{name: "OK_AlreadyRead", input: &port.MarkNotificationsReadInput{UserID: user.ID, NotificationID: notifications[0].ID}, wantReadCount: 0, wantUnread: 2},
+		{name: "NG_OtherUser", input: &port.MarkNotificationsReadInput{UserID: user.ID, NotificationID: notifications[3].ID}, wantCode: domainerr.NotFound},
+		{name: "OK_All", input: &port.MarkNotificationsReadInput{UserID: user.ID}, wantReadCount: 2, wantUnread: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := notificationInteractor.MarkRead(ctx, tt.input)
+			if tt.wantCode != "" {
+				var domainErr *domainerr.DomainError
+				if !errors.As(err, &domainErr) || domainErr.Code != tt.wantCode {
+					t.Errorf("MarkRead() error = %v, want %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MarkRead() error = %v", err)
+			}
+			if got.ReadCount != tt.wantReadCount {
+				t.Errorf("MarkRead() read = %d, want %d", got.ReadCount, tt.wantReadCount)
+			}
+			unread, err := notificationInteractor.CountUnread(ctx, &port.CountUnreadNotificationsInput{UserID: user.ID})
+			if err != nil {
+				t.Fatalf("CountUnread() error = %v", err)
+			}
+			if unread.UnreadCount != tt.wantUnread {
+				t.Errorf("CountUnread() = %d, want %d", unread.UnreadCount, tt.wantUnread)
+			}
+		})
+	}
+
+	// The notification of the other user is untouched
+	unread, err := notificationInteractor.CountUnread(ctx, &port.CountUnreadNotificationsInput{UserID: other.ID})
+	if err != nil {
+		t.Fatalf("CountUnread() error = %v", err)
+	}
+	if unread.UnreadCount != 1 {
+		t.Errorf("CountUnread() of other user = %d, want 1", unread.UnreadCount)
+	}
+}
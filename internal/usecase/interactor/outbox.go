@@ -0,0 +1,81 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+)
+
+// DefaultRelayOutboxBatchSize is used when no batch size is given to Relay.
+const DefaultRelayOutboxBatchSize = 100
+
+type OutboxInteractor struct {
+	txManager  transaction.Manager
+	outboxRepo repository.OutboxRepository
+	queues     map[model.OutboxDestination]repository.MessageQueueRepository
+	clock      clock.Clock
+}
+
+func NewOutboxInteractor(
+	txManager transaction.Manager,
+	outboxRepo repository.OutboxRepository,
+	queues map[model.OutboxDestination]repository.MessageQueueRepository,
+	clock clock.Clock,
+) OutboxInteractor {
+	return OutboxInteractor{
+		txManager:  txManager,
+		outboxRepo: outboxRepo,
+		queues:     queues,
+		clock:      clock,
+	}
+}
+
+// Relay publishes a batch of unsent outbox messages to their queues and marks them sent in the same transaction.
+// The rows stay locked while they are published, so concurrent relays share the work without publishing a row twice.
+// A message published but not marked sent because the commit failed is published again, so delivery is at least once.
+func (i OutboxInteractor) Relay(ctx context.Context, input *port.RelayOutboxInput) (*port.RelayOutboxOutput, error) {
+	batchSize := input.BatchSize
+	if batchSize < 1 {
+		batchSize = DefaultRelayOutboxBatchSize
+	}
+
+	output := &port.RelayOutboxOutput{}
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		messages, err := i.outboxRepo.FindUnsentForUpdate(ctx, batchSize)
+		if err != nil {
+			return err
+		}
+		for _, message := range messages {
+			if err := i.publish(ctx, message); err != nil {
+				log.Printf("failed to relay outbox message %s: %v\n", message.ID, err)
+				message.MarkFailed(err)
+				output.FailedCount++
+			} else {
+				message.MarkSent(i.clock.Now())
+				output.SentCount++
+			}
+			if _, err := i.outboxRepo.Save(ctx, message); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (i OutboxInteractor) publish(ctx context.Context, message *model.OutboxMessage) error {
+	queue, ok := i.queues[message.Destination]
+	if !ok {
+		return fmt.Errorf("unknown outbox destination: %s", message.Destination)
+	}
+	return queue.Send(ctx, message.Message())
+}
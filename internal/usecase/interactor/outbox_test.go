@@ -0,0 +1,93 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	domainRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/sqs"
+	sqsRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/sqs/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/testhelper"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func SetupTestOutboxInteractor(ctx context.Context, gw *testhelper.Gateway) OutboxInteractor {
+	return NewOutboxInteractor(
+		SetupTestTxManager(gw),
+		repository.NewOutboxMySQLRepository(gw.MySQLClient),
+		map[model.OutboxDestination]domainRepo.MessageQueueRepository{
+			model.OutboxDestinationUserDeletion: sqsRepo.NewSQSRepository(gw.SQSClient.Client, gw.SQSClient.QueueURLs[sqs.SQSKeySample], clock.New()),
+			model.OutboxDestinationMail:         sqsRepo.NewSQSRepository(gw.SQSClient.Client, gw.SQSClient.QueueURLs[sqs.SQSKeyMail], clock.New()),
+			model.OutboxDestinationModeration:   sqsRepo.NewSQSRepository(gw.SQSClient.Client, gw.SQSClient.QueueURLs[sqs.SQSKeyModeration], clock.New()),
+			model.OutboxDestinationNotification: sqsRepo.NewSQSRepository(gw.SQSClient.Client, gw.SQSClient.QueueURLs[sqs.SQSKeyNotification], clock.New()),
+		},
+		clock.New(),
+	)
+}
+
+func TestOutboxInteractor_Relay(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	userInteractor := SetupTestUserInteractor(ctx, gw)
+	outboxInteractor := SetupTestOutboxInteractor(ctx, gw)
+	outboxRepo := repository.NewOutboxMySQLRepository(gw.MySQLClient)
+
+	// The cases run in order and share the outbox
+	tests := []struct {
+		name       string
+		setup      func() error
+		wantSent   int
+		wantFailed int
+	}{
+		{
+			name: "OK_RelayEnqueuedMessages",
+			setup: func() error {
+				for i := 0; i < 2; i++ {
+					if _, err := userInteractor.EnqueueUserDeletion(ctx, &port.EnqueueUserDeletionInput{ID: uuid.New()}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantSent: 2,
+		},
+		{
+			name:     "OK_NothingLeftToRelay",
+			setup:    func() error { return nil },
+			wantSent: 0,
+		},
+		{
+			name: "OK_UnknownDestinationIsRetried",
+			setup: func() error {
+				_, err := outboxRepo.Save(ctx, model.NewOutboxMessage("unknown", &model.Message{Body: "{}"}, time.Now()))
+				return err
+			},
+			wantFailed: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.setup(); err != nil {
+				t.Fatalf("Failed to setup test: %v", err)
+			}
+			got, err := outboxInteractor.Relay(ctx, &port.RelayOutboxInput{})
+			if err != nil {
+				t.Fatalf("Relay() error = %v", err)
+			}
+			if got.SentCount != tt.wantSent || got.FailedCount != tt.wantFailed {
+				t.Errorf("Relay() got = %+v, want sent %v failed %v", got, tt.wantSent, tt.wantFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package interactor
+
+import (
+	"context"
+	"log"
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/service"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+const (
+	// MaxRecommendations is the number of recommendations precomputed for each user.
+	MaxRecommendations = 50
+	// DefaultListRecommendationsLimit is used when no limit is given to List.
+	DefaultListRecommendationsLimit = 10
+	// RecommendationCandidatePoolSize is how many recently updated candidates are scored for each user.
+	RecommendationCandidatePoolSize = 500
+	// DefaultRefreshRecommendationsBatchSize is used when no batch size is given to RefreshAll.
+	DefaultRefreshRecommendationsBatchSize = 100
+)
+
+type RecommendationInteractor struct {
+	userRepo          repository.UserRepository
+	recommendationSvc *service.RecommendationDomainService
+	cache             repository.RecommendationCacheRepository
+	cacheTTL          time.Duration
+	clock             clock.Clock
+}
+
+func NewRecommendationInteractor(
+	userRepo repository.UserRepository,
+	recommendationSvc *service.RecommendationDomainService,
+	cache repository.RecommendationCacheRepository,
+	cacheTTL time.Duration,
+	clock clock.Clock,
+) RecommendationInteractor {
+	return RecommendationInteractor{
+		userRepo:          userRepo,
+		recommendationSvc: recommendationSvc,
+		cache:             cache,
+		cacheTTL:          cacheTTL,
+		clock:             clock,
+	}
+}
+
+// List returns the cached recommendations of the user, generating them on a cache miss.
+// The candidates are checked again on read, so that users matched, blocked or suspended
+// since the recommendations were generated are not returned.
+func (i RecommendationInteractor) List(ctx context.Context, input *port.ListRecommendationsInput) (*port.ListRecommendationsOutput, error) {
+	limit := input.Limit
+	if limit < 1 {
+		limit = DefaultListRecommendationsLimit
+	}
+	if limit > MaxRecommendations {
+		limit = MaxRecommendations
+	}
+
+	user, err := i.userRepo.FindById(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domainerr.New(domainerr.ReasonUserNotFound, nil, map[string]interface{}{"id": input.UserID})
+	}
+	if !user.IsActive() {
+		return nil, domainerr.New(domainerr.ReasonUserCannotMatch, model.ErrUserStatusIsNotActive, map[string]interface{}{"id": input.UserID})
+	}
+
+	recommendations, err := i.cache.FindByUserID(ctx, user.ID)
+	if err != nil {
+		log.Printf("failed to get cache: %v\n", err)
+	}
+	if recommendations == nil {
+		if recommendations, err = i.refresh(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]uuid.UUID, len(recommendations))
+	for j, recommendation := range recommendations {
+		ids[j] = recommendation.CandidateID
+	}
+	candidates, err := i.userRepo.FindRecommendationCandidatesByIds(ctx, user.ID, ids)
+	if err != nil {
+		return nil, err
+	}
+	candidateByID := make(map[uuid.UUID]*model.User, len(candidates))
+	for _, candidate := range candidates {
+		candidateByID[candidate.ID] = candidate
+	}
+
+	output := &port.ListRecommendationsOutput{Recommendations: make([]*port.RecommendedUser, 0, limit)}
+	for _, recommendation := range recommendations {
+		candidate, ok := candidateByID[recommendation.CandidateID]
+		if !ok {
+			continue
+		}
+		output.Recommendations = append(output.Recommendations, &port.RecommendedUser{
+			User:           candidate,
+			Recommendation: recommendation,
+		})
+		if len(output.Recommendations) == limit {
+			break
+		}
+	}
+	return output, nil
+}
+
+// RefreshAll regenerates the cached recommendations of every active user, page by page from the oldest user.
+func (i RecommendationInteractor) RefreshAll(ctx context.Context, input *port.RefreshRecommendationsInput) (*port.RefreshRecommendationsOutput, error) {
+	batchSize := input.BatchSize
+	if batchSize < 1 {
+		batchSize = DefaultRefreshRecommendationsBatchSize
+	}
+
+	refreshed := 0
+	var cursor *model.UserCursor
+	for {
+		users, err := i.userRepo.FindAllAfter(ctx, cursor, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			if !user.IsActive() {
+				continue
+			}
+			if _, err := i.refresh(ctx, user); err != nil {
+				return nil, err
+			}
+			refreshed++
+		}
+		if len(users) < batchSize {
+			break
+		}
+		cursor = model.NewUserCursor(users[len(users)-1])
+	}
+	return &port.RefreshRecommendationsOutput{RefreshedCount: refreshed}, nil
+}
+
+// refresh generates the recommendations of the user and caches them.
+// A cache failure is only logged, since the recommendations can still be returned.
+func (i RecommendationInteractor) refresh(ctx context.Context, user *model.User) ([]*model.Recommendation, error) {
+	candidates, err := i.userRepo.FindAllRecommendationCandidates(ctx, user.ID, RecommendationCandidatePoolSize)
+	if err != nil {
+		return nil, err
+	}
+	recommendations := i.recommendationSvc.Recommend(ctx, user, candidates, MaxRecommendations, i.clock.Now())
+	if err := i.cache.Store(ctx, user.ID, recommendations, i.cacheTTL); err != nil {
+		log.Printf("failed to set cache: %v\n", err)
+	}
+	return recommendations, nil
+}
@@ -0,0 +1,132 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/service"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/repository"
+	redisRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/redis/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/testhelper"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+const testRecommendationCacheTTL = time.Hour
+
+func SetupTestRecommendationInteractor(ctx context.Context, gw *testhelper.Gateway) RecommendationInteractor {
+	return NewRecommendationInteractor(
+		repository.NewUserMySQLRepository(gw.MySQLClient),
+		service.NewRecommendationDomainService(),
+		redisRepo.NewRecommendationRedisRepository(gw.RedisClient),
+		testRecommendationCacheTTL,
+		clock.New(),
+	)
+}
+
+func createTestUserWithInterests(ctx context.Context, t *testing.T, userRepo *repository.UserMySQLRepository, interests ...string) *model.User {
+	id := uuid.New()
+	now := time.Now()
+	user := model.NewUser(model.InputUserParams{
+		ID:        id,
+		Email:     id.String() + "@example.com",
+		Interests: interests,
+	}, now)
+	// Only users with a verified email can match
+	if err := user.VerifyEmail(now); err != nil {
+		t.Fatalf("Failed to verify test user: %v", err)
+	}
+	createdUser, err := userRepo.Save(ctx, user)
+	if err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	return createdUser
+}
+
+func TestRecommendationInteractor_List(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	recommendationInteractor := SetupTestRecommendationInteractor(ctx, gw)
+	matchingInteractor, userRepo := SetupTestMatchingInteractor(ctx, gw)
+	userBlockInteractor := SetupTestUserBlockInteractor(ctx, gw)
+
+	me := createTestUserWithInterests(ctx, t, userRepo, "hiking", "music")
+	bestFit := createTestUserWithInterests(ctx, t, userRepo, "hiking", "music")
+	otherFit := createTestUserWithInterests(ctx, t, userRepo, "movies")
+	matched := createTestUserWithInterests(ctx, t, userRepo, "hiking", "music")
+	blocked := createTestUserWithInterests(ctx, t, userRepo, "hiking", "music")
+	suspended := createTestUserWithInterests(ctx, t, userRepo, "hiking", "music")
+	if err := suspended.Suspend(time.Now()); err != nil {
+		t.Fatalf("Failed to suspend test user: %v", err)
+	}
+	if _, err := userRepo.Save(ctx, suspended); err != nil {
+		t.Fatalf("Failed to save test user: %v", err)
+	}
+	if _, err := matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: matched.ID, PartnerID: me.ID}); err != nil {
+		t.Fatalf("Failed to create test matching: %v", err)
+	}
+	if _, err := userBlockInteractor.Block(ctx, &port.BlockUserInput{BlockerID: blocked.ID, BlockedID: me.ID}); err != nil {
+		t.Fatalf("Failed to block test user: %v", err)
+	}
+
+	got, err := recommendationInteractor.List(ctx, &port.ListRecommendationsInput{UserID: me.ID, Limit: 10})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := []uuid.UUID{bestFit.ID, otherFit.ID}
+	if len(got.Recommendations) != len(want) {
+		t.Fatalf("List() got %d recommendations, want %d", len(got.Recommendations), len(want))
+	}
+	for i, recommended := range got.Recommendations {
+		if recommended.User.ID != want[i] {
+			t.Errorf("List()[%d] = %v, want %v", i, recommended.User.ID, want[i])
+		}
+	}
+
+	// A candidate blocked after the recommendations were cached is dropped on read
+	if _, err := userBlockInteractor.Block(ctx, &port.BlockUserInput{BlockerID: me.ID, BlockedID: bestFit.ID}); err != nil {
+		t.Fatalf("Failed to block test user: %v", err)
+	}
+	got, err = recommendationInteractor.List(ctx, &port.ListRecommendationsInput{UserID: me.ID, Limit: 10})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(got.Recommendations) != 1 || got.Recommendations[0].User.ID != otherFit.ID {
+		t.Errorf("List() after block got = %v, want only %v", got.Recommendations, otherFit.ID)
+	}
+
+	if _, err := recommendationInteractor.List(ctx, &port.ListRecommendationsInput{UserID: suspended.ID}); err == nil {
+		t.Error("List() for a suspended user succeeded")
+	}
+}
+
+func TestRecommendationInteractor_RefreshAll(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	recommendationInteractor := SetupTestRecommendationInteractor(ctx, gw)
+	_, userRepo := SetupTestMatchingInteractor(ctx, gw)
+
+	for i := 0; i < 3; i++ {
+		createTestUserWithInterests(ctx, t, userRepo, "hiking")
+	}
+
+	got, err := recommendationInteractor.RefreshAll(ctx, &port.RefreshRecommendationsInput{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("RefreshAll() error = %v", err)
+	}
+	if got.RefreshedCount != 3 {
+		t.Errorf("RefreshAll() refreshedCount = %d, want 3", got.RefreshedCount)
+	}
+}
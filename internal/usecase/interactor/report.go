@@ -0,0 +1,219 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/event"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+const (
+	messageTypeReportCreated  = "report_created"
+	messageTypeReportResolved = "report_resolved"
+)
+
+// ReportNotification is the message sent to the moderation team's queue.
+type ReportNotification struct {
+	ReportID   uuid.UUID              `json:"reportId"`
+	ReporterID uuid.UUID              `json:"reporterId"`
+	ReportedID uuid.UUID              `json:"reportedId"`
+	Reason     model.ReportReason     `json:"reason"`
+	Status     model.ReportStatus     `json:"status"`
+	AssigneeID *uuid.UUID             `json:"assigneeId,omitempty"`
+	Resolution model.ReportResolution `json:"resolution,omitempty"`
+}
+
+type ReportInteractor struct {
+	txManager  transaction.Manager
+	reportRepo repository.ReportRepository
+	userRepo   repository.UserRepository
+	outboxRepo repository.OutboxRepository
+	clock      clock.Clock
+}
+
+func NewReportInteractor(
+	txManager transaction.Manager,
+	reportRepo repository.ReportRepository,
+	userRepo repository.UserRepository,
+	outboxRepo repository.OutboxRepository,
+	clock clock.Clock,
+) ReportInteractor {
+	return ReportInteractor{
+		txManager:  txManager,
+		reportRepo: reportRepo,
+		userRepo:   userRepo,
+		outboxRepo: outboxRepo,
+		clock:      clock,
+	}
+}
+
+// Create puts the report into the moderation queue and notifies the moderation team.
+func (i ReportInteractor) Create(ctx context.Context, input *port.CreateReportInput) (*port.CreateReportOutput, error) {
+	now := i.clock.Now()
+	report := model.NewReport(model.InputReportParams{
+		ReporterID: input.ReporterID,
+		ReportedID: input.ReportedID,
+		Reason:     input.Reason,
+		Comment:    input.Comment,
+	}, now)
+	if err := report.Validate(); err != nil {
+		if errors.Is(err, model.ErrReportSelf) {
+			return nil, domainerr.New(domainerr.ReasonReportSelf, err, nil)
+		}
+		return nil, domainerr.New(domainerr.ReasonReportInvalid, err, nil)
+	}
+
+	var createdReport *model.Report
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		reporter, err := i.userRepo.FindById(ctx, input.ReporterID)
+		if err != nil {
+			return err
+		}
+		reported, err := i.userRepo.FindById(ctx, input.ReportedID)
+		if err != nil {
+			return err
+		}
+		if reporter == nil || reported == nil {
+			return domainerr.New(
+				domainerr.ReasonUserNotFound,
+				nil,
+				map[string]interface{}{"reporterId": input.ReporterID, "reportedId": input.ReportedID},
+			)
+		}
+		if createdReport, err = i.reportRepo.Save(ctx, report); err != nil {
+			return err
+		}
+		return i.notify(ctx, messageTypeReportCreated, createdReport, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &port.CreateReportOutput{Report: createdReport}, nil
+}
+
+func (i ReportInteractor) List(ctx context.Context, input *port.ListReportsInput) (*port.ListReportsOutput, error) {
+	reports, err := i.reportRepo.FindAll(ctx, repository.ReportFilter{
+		Status:     model.ReportStatus(input.Status),
+		AssigneeID: input.AssigneeID,
+		Limit:      input.Limit,
+		Offset:     input.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &port.ListReportsOutput{Reports: reports}, nil
+}
+
+func (i ReportInteractor) Assign(ctx context.Context, input *port.AssignReportInput) (*port.AssignReportOutput, error) {
+	var assignedReport *model.Report
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		report, err := i.findReportForUpdate(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		if err := report.Assign(input.AssigneeID, i.clock.Now()); err != nil {
+			return toReportWorkflowError(err, input.ID)
+		}
+		assignedReport, err = i.reportRepo.Save(ctx, report)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &port.AssignReportOutput{Report: assignedReport}, nil
+}
+
+// Resolve closes the report. A suspension resolution suspends the reported user in the same transaction,
+// which then fails MatchingDomainService.Validate.
+func (i ReportInteractor) Resolve(ctx context.Context, input *port.ResolveReportInput) (*port.ResolveReportOutput, error) {
+	output := &port.ResolveReportOutput{}
+	now := i.clock.Now()
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		report, err := i.findReportForUpdate(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		if err := report.Resolve(model.ReportResolution(input.Resolution), input.Note, now); err != nil {
+			return toReportWorkflowError(err, input.ID)
+		}
+		if output.Report, err = i.reportRepo.Save(ctx, report); err != nil {
+			return err
+		}
+		if err := i.notify(ctx, messageTypeReportResolved, output.Report, now); err != nil {
+			return err
+		}
+		if !report.SuspendsReported() {
+			return nil
+		}
+
+		// A user already suspended by another report or withdrawn meanwhile stays as is
+		user, err := i.userRepo.FindById(ctx, report.ReportedID)
+		if err != nil {
+			return err
+		}
+		if user == nil || !user.IsActive() {
+			return nil
+		}
+		if err := user.Suspend(now); err != nil {
+			return err
+		}
+		if output.SuspendedUser, err = i.userRepo.Save(ctx, user); err != nil {
+			return err
+		}
+		event.Collect(ctx, user)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (i ReportInteractor) findReportForUpdate(ctx context.Context, id uuid.UUID) (*model.Report, error) {
+	report, err := i.reportRepo.FindByIdForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, domainerr.New(domainerr.ReasonReportNotFound, nil, map[string]interface{}{"id": id})
+	}
+	return report, nil
+}
+
+// notify writes the report to the outbox in the transaction of ctx, from which the relay publishes it to the moderation team's queue.
+func (i ReportInteractor) notify(ctx context.Context, messageType string, report *model.Report, now time.Time) error {
+	notification := ReportNotification{
+		ReportID:   report.ID,
+		ReporterID: report.ReporterID,
+		ReportedID: report.ReportedID,
+		Reason:     report.Reason,
+		Status:     report.Status,
+		Resolution: report.Resolution,
+	}
+	if report.AssigneeID != uuid.Nil() {
+		notification.AssigneeID = &report.AssigneeID
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	msg := model.NewMessage(string(body), model.MessageAttributes{
+		"messageType": messageType,
+	}, now)
+	_, err = i.outboxRepo.Save(ctx, model.NewOutboxMessage(model.OutboxDestinationModeration, msg, now))
+	return err
+}
+
+func toReportWorkflowError(err error, id uuid.UUID) error {
+	details := map[string]interface{}{"id": id}
+	return domainerr.Wrap(err, domainerr.ReasonReportNotChangeable, details)
+}
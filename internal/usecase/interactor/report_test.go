@@ -0,0 +1,130 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/testhelper"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func SetupTestReportInteractor(ctx context.Context, gw *testhelper.Gateway) ReportInteractor {
+	return NewReportInteractor(
+		SetupTestTxManager(gw),
+		repository.NewReportMySQLRepository(gw.MySQLClient),
+		repository.NewUserMySQLRepository(gw.MySQLClient),
+		repository.NewOutboxMySQLRepository(gw.MySQLClient),
+		clock.New(),
+	)
+}
+
+func TestReportInteractor_Create(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	reportInteractor := SetupTestReportInteractor(ctx, gw)
+	_, userRepo := SetupTestMatchingInteractor(ctx, gw)
+
+	reporter := createTestUser(ctx, t, userRepo)
+	reported := createTestUser(ctx, t, userRepo)
+
+	tests := []struct {
+		name    string
+		input   *port.CreateReportInput
+		wantErr bool
+	}{
+		{
+			name:  "OK",
+			input: &port.CreateReportInput{ReporterID: reporter.ID, ReportedID: reported.ID, Reason: "spam", Comment: "sends links"},
+		},
+		{
+			name:    "NG_ReportSelf",
+			input:   &port.CreateReportInput{ReporterID: reporter.ID, ReportedID: reporter.ID, Reason: "spam"},
+			wantErr: true,
+		},
+		{
+			name:    "NG_InvalidReason",
+			input:   &port.CreateReportInput{ReporterID: reporter.ID, ReportedID: reported.ID, Reason: "boring"},
+			wantErr: true,
+		},
+		{
+			name:    "NG_UserNotFound",
+			input:   &port.CreateReportInput{ReporterID: reporter.ID, ReportedID: uuid.New(), Reason: "spam"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := reportInteractor.Create(ctx, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Report.Status != model.ReportStatusOpen {
+				t.Errorf("Create() status = %v, want %v", got.Report.Status, model.ReportStatusOpen)
+			}
+		})
+	}
+}
+
+func TestReportInteractor_Resolve(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	reportInteractor := SetupTestReportInteractor(ctx, gw)
+	matchingInteractor, userRepo := SetupTestMatchingInteractor(ctx, gw)
+
+	reporter := createTestUser(ctx, t, userRepo)
+	reported := createTestUser(ctx, t, userRepo)
+	moderatorID := uuid.New()
+	created, err := reportInteractor.Create(ctx, &port.CreateReportInput{ReporterID: reporter.ID, ReportedID: reported.ID, Reason: "harassment"})
+	if err != nil {
+		t.Fatalf("Failed to create test report: %v", err)
+	}
+
+	if _, err := reportInteractor.Resolve(ctx, &port.ResolveReportInput{ID: created.Report.ID, Resolution: "suspended"}); err == nil {
+		t.Error("Resolve() of an unassigned report succeeded")
+	}
+
+	if _, err := reportInteractor.Assign(ctx, &port.AssignReportInput{ID: created.Report.ID, AssigneeID: moderatorID}); err != nil {
+		t.Fatalf("Assign() error = %v", err)
+	}
+	listed, err := reportInteractor.List(ctx, &port.ListReportsInput{Status: string(model.ReportStatusAssigned), AssigneeID: moderatorID, Limit: 10})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(listed.Reports) != 1 || listed.Reports[0].ID != created.Report.ID {
+		t.Errorf("List() got = %v, want the assigned report", listed.Reports)
+	}
+
+	got, err := reportInteractor.Resolve(ctx, &port.ResolveReportInput{ID: created.Report.ID, Resolution: "suspended", Note: "confirmed"})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got.Report.Status != model.ReportStatusResolved {
+		t.Errorf("Resolve() status = %v, want %v", got.Report.Status, model.ReportStatusResolved)
+	}
+	if got.SuspendedUser == nil || got.SuspendedUser.Status != model.UserStatusSuspended {
+		t.Errorf("Resolve() suspendedUser = %v, want the suspended reported user", got.SuspendedUser)
+	}
+
+	// The suspended user can no longer match
+	if _, err := matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: reporter.ID, PartnerID: reported.ID}); err == nil {
+		t.Error("Create() with a suspended partner succeeded")
+	}
+	if _, err := reportInteractor.Resolve(ctx, &port.ResolveReportInput{ID: created.Report.ID, Resolution: "dismissed"}); err == nil {
+		t.Error("Resolve() of a resolved report succeeded")
+	}
+}
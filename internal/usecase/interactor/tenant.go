@@ -0,0 +1,18 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+)
+
+// messageContext returns the context of the tenant the message was sent in.
+// The subscribers run outside of any tenant, so a message without a valid tenant can never be handled.
+func messageContext(ctx context.Context, msg *model.Message) (context.Context, error) {
+	tenantID, err := tenant.Parse(msg.Attributes[tenant.MessageAttribute])
+	if err != nil {
+		return nil, err
+	}
+	return tenant.WithID(ctx, tenantID), nil
+}
@@ -0,0 +1,123 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/testhelper"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// TestTenantIsolation calls the usecases the way a handler without any ownership check would,
+// and proves that the data of a tenant cannot be read or written from another tenant.
+func TestTenantIsolation(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	otherCtx := tenant.WithID(context.Background(), "acme")
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	userInteractor := SetupTestUserInteractor(ctx, gw)
+	matchingInteractor, userRepo := SetupTestMatchingInteractor(ctx, gw)
+	notificationInteractor, _ := SetupTestNotificationInteractor(ctx, t, gw)
+
+	user1 := createTestUser(ctx, t, userRepo)
+	user2 := createTestUser(ctx, t, userRepo)
+	if _, err := matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: user1.ID, PartnerID: user2.ID}); err != nil {
+		t.Fatalf("Failed to create test matching: %v", err)
+	}
+	// Cache the user in its own tenant, so that the cache is checked too
+	if got, err := userInteractor.Get(ctx, &port.GetUserInput{ID: user1.ID}); err != nil || got.User == nil {
+		t.Fatalf("Get() in own tenant got = %v, error = %v", got, err)
+	}
+
+	t.Run("NG_GetUser", func(t *testing.T) {
+		got, err := userInteractor.Get(otherCtx, &port.GetUserInput{ID: user1.ID})
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.User != nil {
+			t.Errorf("Get() got = %v, want no user", got.User)
+		}
+	})
+	t.Run("NG_BatchGetUsers", func(t *testing.T) {
+		got, err := userInteractor.BatchGet(otherCtx, &port.BatchGetUsersInput{IDs: []uuid.UUID{user1.ID, user2.ID}})
+		if err != nil {
+			t.Fatalf("BatchGet() error = %v", err)
+		}
+		if len(got.Users) != 0 {
+			t.Errorf("BatchGet() got %d users, want none", len(got.Users))
+		}
+	})
+	t.Run("NG_ListUsers", func(t *testing.T) {
+		got, err := userInteractor.List(otherCtx, &port.ListUserInput{Limit: 10})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if len(got.Users) != 0 {
+			t.Errorf("List() got %d users, want none", len(got.Users))
+		}
+	})
+	t.Run("NG_UpdateUser", func(t *testing.T) {
+		if _, err := userInteractor.Update(otherCtx, &port.UpdateUserInput{ID: user1.ID, Email: user1.Email, DisplayName: "Hijacked"}); err == nil {
+			t.Error("Update() error = nil, want an error")
+		}
+	})
+	t.Run("NG_DeleteUser", func(t *testing.T) {
+		if _, err := userInteractor.Delete(otherCtx, &port.DeleteUserInput{ID: user1.ID}); err == nil {
+			t.Error("Delete() error = nil, want an error")
+		}
+	})
+	t.Run("NG_CreateMatching", func(t *testing.T) {
+		if _, err := matchingInteractor.Create(otherCtx, &port.CreateMatchingInput{MeID: user2.ID, PartnerID: user1.ID}); err == nil {
+			t.Error("Create() error = nil, want an error")
+		}
+	})
+	t.Run("NG_AcceptMatching", func(t *testing.T) {
+		if _, err := matchingInteractor.Accept(otherCtx, &port.AcceptMatchingInput{MeID: user2.ID, PartnerID: user1.ID}); err == nil {
+			t.Error("Accept() error = nil, want an error")
+		}
+	})
+	t.Run("NG_ListMatchings", func(t *testing.T) {
+		got, err := matchingInteractor.ListByMeID(otherCtx, &port.ListMatchingByMeIDInput{MeID: user1.ID, Limit: 10})
+		if err != nil {
+			t.Fatalf("ListByMeID() error = %v", err)
+		}
+		if len(got.Matchings) != 0 {
+			t.Errorf("ListByMeID() got %d matchings, want none", len(got.Matchings))
+		}
+	})
+	t.Run("NG_ListNotifications", func(t *testing.T) {
+		if _, err := notificationInteractor.List(otherCtx, &port.ListNotificationsInput{UserID: user2.ID}); err == nil {
+			t.Error("List() error = nil, want an error")
+		}
+	})
+	t.Run("OK_SameEmailInAnotherTenant", func(t *testing.T) {
+		got, err := userInteractor.Create(otherCtx, &port.CreateUserInput{Email: user1.Email})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if got.User.ID == user1.ID {
+			t.Error("Create() got the user of the other tenant")
+		}
+	})
+	t.Run("NG_WithoutTenant", func(t *testing.T) {
+		_, err := userInteractor.Update(context.Background(), &port.UpdateUserInput{ID: user1.ID, Email: user1.Email})
+		if !errors.Is(err, tenant.ErrMissing) {
+			t.Errorf("Update() error = %v, want %v", err, tenant.ErrMissing)
+		}
+	})
+
+	// The user is untouched in its own tenant
+	got, err := userInteractor.Get(ctx, &port.GetUserInput{ID: user1.ID})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.User == nil || got.User.DisplayName == "Hijacked" || !got.User.IsActive() {
+		t.Errorf("Get() got = %v, want the user unchanged", got.User)
+	}
+}
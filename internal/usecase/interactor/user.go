@@ -3,21 +3,56 @@ package interactor
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"time"
 
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/event"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/token"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
 )
 
+const (
+	// MaxBatchGetUsers is the maximum number of IDs accepted by BatchGet.
+	MaxBatchGetUsers = 100
+	// DefaultEnqueueExpiredUserDeletionsLimit is used when no limit is given to EnqueueExpiredUserDeletions.
+	DefaultEnqueueExpiredUserDeletionsLimit = 100
+	// UserDeletionRetryInterval is how long EnqueueExpiredUserDeletions waits for a deletion it enqueued before enqueuing it again,
+	// in case the message was lost.
+	UserDeletionRetryInterval = 24 * time.Hour
+)
+
+// UserEmailConfig configures the tokens mailed to verify and change the email of users.
+type UserEmailConfig struct {
+	Signer *token.Signer
+	// VerificationTTL and ChangeTTL are how long the tokens to verify and to confirm an email are valid.
+	VerificationTTL time.Duration
+	ChangeTTL       time.Duration
+	// VerificationLimit is how many verification mails a user can be sent within VerificationLimitWindow,
+	// so that the endpoint cannot be used to flood an address.
+	VerificationLimit       int
+	VerificationLimitWindow time.Duration
+	// ChangeUndoPeriod is how long the old email can undo a change, counted from the request.
+	ChangeUndoPeriod time.Duration
+}
+
 type UserInteractor struct {
-	txManager transaction.Manager
-	userRepo  repository.UserRepository
-	userCache repository.UserCacheRepository
-	msgQueue  repository.MessageQueueRepository
+	txManager        transaction.Manager
+	userRepo         repository.UserRepository
+	userCache        repository.UserCacheRepository
+	msgQueue         repository.MessageQueueRepository
+	outboxRepo       repository.OutboxRepository
+	verificationRepo repository.EmailVerificationRepository
+	emailChangeRepo  repository.EmailChangeRepository
+	emailConfig      UserEmailConfig
+	gracePeriod      time.Duration
+	clock            clock.Clock
 }
 
 func NewUserInteractor(
@@ -25,17 +60,40 @@ func NewUserInteractor(
 	userRepo repository.UserRepository,
 	userCache repository.UserCacheRepository,
 	msgQueue repository.MessageQueueRepository,
+	outboxRepo repository.OutboxRepository,
+	verificationRepo repository.EmailVerificationRepository,
+	emailChangeRepo repository.EmailChangeRepository,
+	emailConfig UserEmailConfig,
+	gracePeriod time.Duration,
+	clock clock.Clock,
 ) UserInteractor {
 	return UserInteractor{
-		txManager: txManager,
-		userRepo:  userRepo,
-		userCache: userCache,
-		msgQueue:  msgQueue,
+		txManager:        txManager,
+		userRepo:         userRepo,
+		userCache:        userCache,
+		msgQueue:         msgQueue,
+		outboxRepo:       outboxRepo,
+		verificationRepo: verificationRepo,
+		emailChangeRepo:  emailChangeRepo,
+		emailConfig:      emailConfig,
+		gracePeriod:      gracePeriod,
+		clock:            clock,
 	}
 }
 
+// Create registers an unverified user, and mails a token to verify the email.
 func (i UserInteractor) Create(ctx context.Context, input *port.CreateUserInput) (*port.CreateUserOutput, error) {
-	user := model.NewUser(model.InputUserParams{ID: uuid.Nil(), Email: input.Email})
+	now := i.clock.Now()
+	user := model.NewUser(model.InputUserParams{
+		ID:          uuid.Nil(),
+		Email:       input.Email,
+		DisplayName: input.DisplayName,
+		Birthdate:   input.Birthdate,
+		Gender:      input.Gender,
+		Bio:         input.Bio,
+		Locale:      input.Locale,
+		Interests:   input.Interests,
+	}, now)
 	if err := user.Validate(); err != nil {
 		return nil, err
 	}
@@ -43,17 +101,22 @@ func (i UserInteractor) Create(ctx context.Context, input *port.CreateUserInput)
 	var createdUser *model.User
 	err := i.txManager.Do(ctx, func(ctx context.Context) error {
 		var err error
-		createdUser, err = i.userRepo.Save(ctx, user)
-		return err
+		if createdUser, err = i.userRepo.Save(ctx, user); err != nil {
+			if errors.Is(err, repository.ErrUserEmailAlreadyExists) {
+				return domainerr.New(domainerr.ReasonEmailAlreadyUsed, err, nil)
+			}
+			return err
+		}
+		event.Collect(ctx, user)
+		verification, err := i.verificationRepo.Save(ctx, model.NewEmailVerification(createdUser, i.emailConfig.VerificationTTL, now))
+		if err != nil {
+			return err
+		}
+		return i.sendEmailVerification(ctx, createdUser, verification)
 	})
 	if err != nil {
 		return nil, err
 	}
-	if createdUser != nil {
-		if err := i.userCache.Store(ctx, createdUser, 3600*time.Second); err != nil {
-			log.Printf("failed to set cache: %v\n", err)
-		}
-	}
 
 	return &port.CreateUserOutput{User: createdUser}, nil
 }
@@ -75,6 +138,67 @@ func (i UserInteractor) Get(ctx context.Context, input *port.GetUserInput) (*por
 	return &port.GetUserOutput{User: user}, nil
 }
 
+func (i UserInteractor) BatchGet(ctx context.Context, input *port.BatchGetUsersInput) (*port.BatchGetUsersOutput, error) {
+	if len(input.IDs) == 0 || len(input.IDs) > MaxBatchGetUsers {
+		return nil, domainerr.New(
+			domainerr.ReasonUsersBatchSizeInvalid,
+			nil,
+			map[string]interface{}{"count": len(input.IDs), "max": MaxBatchGetUsers},
+		)
+	}
+
+	ids := make([]uuid.UUID, 0, len(input.IDs))
+	seen := make(map[uuid.UUID]struct{}, len(input.IDs))
+	for _, id := range input.IDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	found := make(map[uuid.UUID]*model.User, len(ids))
+	cached, err := i.userCache.FindByIds(ctx, ids)
+	if err != nil {
+		log.Printf("failed to get cache: %v\n", err)
+	}
+	for _, user := range cached {
+		found[user.ID] = user
+	}
+
+	misses := make([]uuid.UUID, 0, len(ids)-len(found))
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			misses = append(misses, id)
+		}
+	}
+	if len(misses) > 0 {
+		users, err := i.userRepo.FindByIds(ctx, misses)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			found[user.ID] = user
+		}
+		if err := i.userCache.StoreMany(ctx, users, 3600*time.Second); err != nil {
+			log.Printf("failed to set cache: %v\n", err)
+		}
+	}
+
+	output := &port.BatchGetUsersOutput{
+		Users:      make([]*model.User, 0, len(ids)),
+		MissingIDs: []uuid.UUID{},
+	}
+	for _, id := range ids {
+		if user, ok := found[id]; ok {
+			output.Users = append(output.Users, user)
+		} else {
+			output.MissingIDs = append(output.MissingIDs, id)
+		}
+	}
+	return output, nil
+}
+
 func (i UserInteractor) List(ctx context.Context, input *port.ListUserInput) (*port.ListUserOutput, error) {
 	users, err := i.userRepo.FindAll(ctx, input.Limit, input.Offset)
 	if err != nil {
@@ -83,70 +207,263 @@ func (i UserInteractor) List(ctx context.Context, input *port.ListUserInput) (*p
 	return &port.ListUserOutput{Users: users}, nil
 }
 
+// Update replaces the profile. A different email is not swapped, but starts the email change to be confirmed from the new email.
 func (i UserInteractor) Update(ctx context.Context, input *port.UpdateUserInput) (*port.UpdateUserOutput, error) {
-	user := model.NewUser(model.InputUserParams{ID: input.ID, Email: input.Email})
-	if err := user.Validate(); err != nil {
+	var updatedUser *model.User
+	now := i.clock.Now()
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		user, err := i.userRepo.FindById(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return domainerr.New(domainerr.ReasonUserNotFound, nil, map[string]interface{}{"id": input.ID})
+		}
+		if input.Email != user.Email && input.Email != user.PendingEmail {
+			change, err := i.requestEmailChange(ctx, user, input.Email, now)
+			if err != nil {
+				return err
+			}
+			if err := i.sendEmailChange(ctx, change); err != nil {
+				return err
+			}
+		}
+		user.UpdateProfile(model.InputUserParams{
+			DisplayName: input.DisplayName,
+			Birthdate:   input.Birthdate,
+			Gender:      input.Gender,
+			Bio:         input.Bio,
+			Locale:      input.Locale,
+			Interests:   input.Interests,
+		}, now)
+		if err := user.Validate(); err != nil {
+			return err
+		}
+		if updatedUser, err = i.userRepo.Save(ctx, user); err != nil {
+			return err
+		}
+		event.Collect(ctx, user)
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
+	return &port.UpdateUserOutput{User: updatedUser}, nil
+}
 
-	var updatedUser *model.User
+// Delete withdraws the user. The user is hidden from reads at once and permanently deleted after the grace period.
+func (i UserInteractor) Delete(ctx context.Context, input *port.DeleteUserInput) (*port.DeleteUserOutput, error) {
+	var withdrawnUser *model.User
 	err := i.txManager.Do(ctx, func(ctx context.Context) error {
-		var err error
-		updatedUser, err = i.userRepo.Save(ctx, user)
-		return err
+		user, err := i.userRepo.FindById(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return domainerr.New(domainerr.ReasonUserNotFound, nil, map[string]interface{}{"id": input.ID})
+		}
+		if err := user.Withdraw(i.clock.Now()); err != nil {
+			return err
+		}
+		if withdrawnUser, err = i.userRepo.Save(ctx, user); err != nil {
+			return err
+		}
+		event.Collect(ctx, user)
+		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
-	if updatedUser != nil {
-		if err := i.userCache.Store(ctx, updatedUser, 3600*time.Second); err != nil {
-			log.Printf("failed to set cache: %v\n", err)
+	return &port.DeleteUserOutput{
+		ID:                  &withdrawnUser.ID,
+		PermanentDeletionAt: withdrawnUser.PermanentDeletionAt(i.gracePeriod),
+	}, nil
+}
+
+func (i UserInteractor) Reactivate(ctx context.Context, input *port.ReactivateUserInput) (*port.ReactivateUserOutput, error) {
+	var reactivatedUser *model.User
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		user, err := i.userRepo.FindByIdWithDeleted(ctx, input.ID)
+		if err != nil {
+			return err
 		}
+		if user == nil {
+			return domainerr.New(domainerr.ReasonUserNotFound, nil, map[string]interface{}{"id": input.ID})
+		}
+		if err := user.Reactivate(i.gracePeriod, i.clock.Now()); err != nil {
+			return domainerr.Wrap(err, domainerr.ReasonUserCannotBeReactivated, map[string]interface{}{"id": input.ID})
+		}
+		if reactivatedUser, err = i.userRepo.Save(ctx, user); err != nil {
+			return err
+		}
+		event.Collect(ctx, user)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return &port.UpdateUserOutput{User: updatedUser}, nil
+	return &port.ReactivateUserOutput{User: reactivatedUser}, nil
 }
 
-func (i UserInteractor) Delete(ctx context.Context, input *port.DeleteUserInput) (*port.DeleteUserOutput, error) {
-	var deletedID *uuid.UUID
+// RequestEmailVerification mails a new token to verify the email. Tokens issued before stay valid until they expire.
+func (i UserInteractor) RequestEmailVerification(ctx context.Context, input *port.RequestEmailVerificationInput) (*port.RequestEmailVerificationOutput, error) {
+	var user *model.User
+	var verification *model.EmailVerification
 	err := i.txManager.Do(ctx, func(ctx context.Context) error {
 		var err error
-		deletedID, err = i.userRepo.Remove(ctx, input.ID)
-		return err
+		user, err = i.userRepo.FindById(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return domainerr.New(domainerr.ReasonUserNotFound, nil, map[string]interface{}{"id": input.ID})
+		}
+		if user.IsEmailVerified() {
+			return domainerr.New(domainerr.ReasonUserEmailVerified, model.ErrUserEmailIsVerified, map[string]interface{}{"id": input.ID})
+		}
+		now := i.clock.Now()
+		count, err := i.verificationRepo.CountByUserIdSinceForUpdate(ctx, user.ID, now.Add(-i.emailConfig.VerificationLimitWindow))
+		if err != nil {
+			return err
+		}
+		if count >= i.emailConfig.VerificationLimit {
+			return domainerr.New(
+				domainerr.ReasonEmailVerificationTooFrequent,
+				nil,
+				map[string]interface{}{"id": input.ID, "limit": i.emailConfig.VerificationLimit},
+			)
+		}
+		if verification, err = i.verificationRepo.Save(ctx, model.NewEmailVerification(user, i.emailConfig.VerificationTTL, now)); err != nil {
+			return err
+		}
+		return i.sendEmailVerification(ctx, user, verification)
 	})
 	if err != nil {
 		return nil, err
 	}
-	if deletedID != nil {
-		if err := i.userCache.Remove(ctx, *deletedID); err != nil {
-			log.Printf("failed to delete cache: %v\n", err)
+	return &port.RequestEmailVerificationOutput{ID: user.ID, ExpiresAt: verification.ExpiresAt}, nil
+}
+
+// VerifyEmail uses the token mailed to the user to mark the email verified. Each token can be used only once.
+func (i UserInteractor) VerifyEmail(ctx context.Context, input *port.VerifyEmailInput) (*port.VerifyEmailOutput, error) {
+	verificationID, err := i.parseEmailToken(input.Token, model.EmailTokenPurposeVerification)
+	if err != nil {
+		return nil, err
+	}
+
+	var verifiedUser *model.User
+	now := i.clock.Now()
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		verification, err := i.verificationRepo.FindByIdForUpdate(ctx, verificationID)
+		if err != nil {
+			return err
+		}
+		if verification == nil {
+			return domainerr.New(domainerr.ReasonEmailVerificationNotFound, nil, nil)
+		}
+		user, err := i.userRepo.FindById(ctx, verification.UserID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return domainerr.New(domainerr.ReasonUserNotFound, nil, map[string]interface{}{"id": verification.UserID})
+		}
+		if err := verification.Use(user.Email, now); err != nil {
+			return domainerr.Wrap(err, domainerr.ReasonEmailVerificationUnusable, nil)
 		}
+		if err := user.VerifyEmail(now); err != nil {
+			return domainerr.New(domainerr.ReasonUserEmailVerified, err, map[string]interface{}{"id": user.ID})
+		}
+		if _, err := i.verificationRepo.Save(ctx, verification); err != nil {
+			return err
+		}
+		if verifiedUser, err = i.userRepo.Save(ctx, user); err != nil {
+			return err
+		}
+		event.Collect(ctx, user)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return &port.DeleteUserOutput{ID: deletedID}, nil
+	return &port.VerifyEmailOutput{User: verifiedUser}, nil
+}
+
+// sendEmailVerification signs the verification and mails the token to the user through the outbox, in the transaction of ctx.
+func (i UserInteractor) sendEmailVerification(ctx context.Context, user *model.User, verification *model.EmailVerification) error {
+	signed := i.signEmailToken(verification.ID, model.EmailTokenPurposeVerification)
+	return enqueueMail(ctx, i.outboxRepo, model.NewEmailVerificationMail(user, signed), i.clock.Now())
 }
 
+// EnqueueUserDeletion writes the deletion message to the outbox, from which the relay publishes it to the queue.
+// Called inside a transaction, the message is only published when the transaction commits.
 func (i UserInteractor) EnqueueUserDeletion(ctx context.Context, input *port.EnqueueUserDeletionInput) (*port.EnqueueUserDeletionOutput, error) {
-	userIDBytes, err := json.Marshal(input.ID)
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		return i.enqueueUserDeletion(ctx, input.ID)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	return &port.EnqueueUserDeletionOutput{
+		ID: input.ID,
+	}, nil
+}
+
+// enqueueUserDeletion writes the deletion message of the user to the outbox, in the transaction of ctx.
+func (i UserInteractor) enqueueUserDeletion(ctx context.Context, id uuid.UUID) error {
+	userIDBytes, err := json.Marshal(id)
+	if err != nil {
+		return err
+	}
+
 	msg := &model.Message{
 		Body: string(userIDBytes),
 		Attributes: map[string]string{
 			"messageType": "user_deletion",
 		},
 	}
+	_, err = i.outboxRepo.Save(ctx, model.NewOutboxMessage(model.OutboxDestinationUserDeletion, msg, i.clock.Now()))
+	return err
+}
+
+// EnqueueExpiredUserDeletions schedules the permanent deletion of users withdrawn longer than the grace period.
+func (i UserInteractor) EnqueueExpiredUserDeletions(ctx context.Context, input *port.EnqueueExpiredUserDeletionsInput) (*port.EnqueueExpiredUserDeletionsOutput, error) {
+	limit := input.Limit
+	if limit < 1 {
+		limit = DefaultEnqueueExpiredUserDeletionsLimit
+	}
 
-	if err := i.msgQueue.Send(ctx, msg); err != nil {
+	now := i.clock.Now()
+	users, err := i.userRepo.FindAllDeletedBefore(ctx, now.Add(-i.gracePeriod), now.Add(-UserDeletionRetryInterval), limit)
+	if err != nil {
 		return nil, err
 	}
 
-	return &port.EnqueueUserDeletionOutput{
-		ID: input.ID,
-	}, nil
+	// The users are marked with the message, so that the next runs skip them while the subscriber catches up
+	ids := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		err := i.txManager.Do(ctx, func(ctx context.Context) error {
+			if err := i.enqueueUserDeletion(ctx, user.ID); err != nil {
+				return err
+			}
+			user.MarkDeletionEnqueued(now)
+			_, err := i.userRepo.Save(ctx, user)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, user.ID)
+	}
+	return &port.EnqueueExpiredUserDeletionsOutput{IDs: ids}, nil
 }
 
 func (i UserInteractor) DequeueAndDeleteUser(ctx context.Context, input *port.DequeueAndDeleteUserInput) (*port.DequeueAndDeleteUserOutput, error) {
+	deleteUser := input.DeleteUser
+	if deleteUser == nil {
+		deleteUser = i.DeleteUserPermanently
+	}
 	batchSize := int32(input.BatchSize)
 	if batchSize > 10 {
 		batchSize = 10
@@ -172,17 +489,24 @@ func (i UserInteractor) DequeueAndDeleteUser(ctx context.Context, input *port.De
 	for _, msg := range msgs {
 		var userID uuid.UUID
 		if err := json.Unmarshal([]byte(msg.Body), &userID); err != nil {
+			// A malformed message can never be processed, so it is dropped instead of retried
 			log.Printf("Failed to unmarshal user ID from message: %v", err)
+			if err := i.msgQueue.Delete(ctx, msg); err != nil {
+				log.Printf("Failed to delete malformed user deletion message: %v", err)
+			}
 			continue
 		}
-
-		err := i.txManager.Do(ctx, func(ctx context.Context) error {
-			if _, err := i.userRepo.Remove(ctx, userID); err != nil {
-				return err
+		tenantCtx, err := messageContext(ctx, msg)
+		if err != nil {
+			// EnqueueExpiredUserDeletions enqueues the user again with its tenant if it is still to be deleted
+			log.Printf("Dropped deletion of user %s without a tenant: %v", userID, err)
+			if err := i.msgQueue.Delete(ctx, msg); err != nil {
+				log.Printf("Failed to delete user deletion message without a tenant: %v", err)
 			}
-			return nil
-		})
+			continue
+		}
 
+		output, err := deleteUser(tenantCtx, &port.DeleteUserPermanentlyInput{ID: userID})
 		if err != nil {
 			log.Printf("Failed to physically delete user %s: %v", userID, err)
 			continue
@@ -192,6 +516,10 @@ func (i UserInteractor) DequeueAndDeleteUser(ctx context.Context, input *port.De
 			log.Printf("Failed to delete message for user %s: %v", userID, err)
 			continue
 		}
+		if !output.Deleted {
+			log.Printf("Skipped physical deletion of user %s", userID)
+			continue
+		}
 		deletedCount++
 	}
 
@@ -199,3 +527,27 @@ func (i UserInteractor) DequeueAndDeleteUser(ctx context.Context, input *port.De
 		DeletedCount: deletedCount,
 	}, nil
 }
+
+// DeleteUserPermanently removes the user withdrawn longer than the grace period.
+// The user may have reactivated or already been deleted since the deletion was enqueued, which is not an error.
+func (i UserInteractor) DeleteUserPermanently(ctx context.Context, input *port.DeleteUserPermanentlyInput) (*port.DeleteUserPermanentlyOutput, error) {
+	output := &port.DeleteUserPermanentlyOutput{ID: input.ID}
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		user, err := i.userRepo.FindByIdWithDeleted(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		if user == nil || !user.IsPermanentlyDeletable(i.gracePeriod, i.clock.Now()) {
+			return nil
+		}
+		if _, err := i.userRepo.Remove(ctx, input.ID); err != nil {
+			return err
+		}
+		output.Deleted = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
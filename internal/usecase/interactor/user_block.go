@@ -0,0 +1,170 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/event"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+const matchingReasonBlocked = "user blocked"
+
+type UserBlockInteractor struct {
+	txManager    transaction.Manager
+	blockRepo    repository.UserBlockRepository
+	userRepo     repository.UserRepository
+	matchingRepo repository.MatchingRepository
+	historyRepo  repository.MatchingHistoryRepository
+	clock        clock.Clock
+}
+
+func NewUserBlockInteractor(
+	txManager transaction.Manager,
+	blockRepo repository.UserBlockRepository,
+	userRepo repository.UserRepository,
+	matchingRepo repository.MatchingRepository,
+	historyRepo repository.MatchingHistoryRepository,
+	clock clock.Clock,
+) UserBlockInteractor {
+	return UserBlockInteractor{
+		txManager:    txManager,
+		blockRepo:    blockRepo,
+		userRepo:     userRepo,
+		matchingRepo: matchingRepo,
+		historyRepo:  historyRepo,
+		clock:        clock,
+	}
+}
+
+// Block blocks the user and rejects the pending matching between the two users in the same transaction.
+func (i UserBlockInteractor) Block(ctx context.Context, input *port.BlockUserInput) (*port.BlockUserOutput, error) {
+	now := i.clock.Now()
+	block := model.NewUserBlock(model.InputUserBlockParams{
+		BlockerID: input.BlockerID,
+		BlockedID: input.BlockedID,
+	}, now)
+	if err := block.Validate(); err != nil {
+		if errors.Is(err, model.ErrUserBlockSelf) {
+			return nil, domainerr.New(domainerr.ReasonUserBlockSelf, err, nil)
+		}
+		return nil, err
+	}
+
+	output := &port.BlockUserOutput{}
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		blocker, err := i.userRepo.FindById(ctx, input.BlockerID)
+		if err != nil {
+			return err
+		}
+		blocked, err := i.userRepo.FindById(ctx, input.BlockedID)
+		if err != nil {
+			return err
+		}
+		if blocker == nil || blocked == nil {
+			return domainerr.New(
+				domainerr.ReasonUserNotFound,
+				nil,
+				map[string]interface{}{"blockerId": input.BlockerID, "blockedId": input.BlockedID},
+			)
+		}
+		exists, err := i.blockRepo.Exists(ctx, input.BlockerID, input.BlockedID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return domainerr.New(
+				domainerr.ReasonUserBlockAlreadyExists,
+				nil,
+				map[string]interface{}{"blockerId": input.BlockerID, "blockedId": input.BlockedID},
+			)
+		}
+		if output.Block, err = i.blockRepo.Save(ctx, block); err != nil {
+			return err
+		}
+
+		matching, err := i.matchingRepo.FindByPairForUpdate(ctx, input.BlockerID, input.BlockedID)
+		if err != nil {
+			return err
+		}
+		if matching == nil || matching.CanTransition(model.MatchingActionBlock, input.BlockerID, now) != nil {
+			return nil
+		}
+		history, err := matching.Transition(model.MatchingActionBlock, input.BlockerID, matchingReasonBlocked, now)
+		if err != nil {
+			return err
+		}
+		if output.RejectedMatching, err = i.matchingRepo.Save(ctx, matching); err != nil {
+			return err
+		}
+		if _, err = i.historyRepo.Save(ctx, history); err != nil {
+			return err
+		}
+		event.Collect(ctx, matching)
+		return nil
+	})
+	// A concurrent request may have saved the same block after the check above
+	if errors.Is(err, repository.ErrUserBlockAlreadyExists) {
+		return nil, domainerr.New(
+			domainerr.ReasonUserBlockAlreadyExists,
+			err,
+			map[string]interface{}{"blockerId": input.BlockerID, "blockedId": input.BlockedID},
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (i UserBlockInteractor) Unblock(ctx context.Context, input *port.UnblockUserInput) (*port.UnblockUserOutput, error) {
+	if err := i.checkBlocker(ctx, input.BlockerID); err != nil {
+		return nil, err
+	}
+	var removed bool
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		removed, err = i.blockRepo.Remove(ctx, input.BlockerID, input.BlockedID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, domainerr.New(
+			domainerr.ReasonUserBlockNotFound,
+			nil,
+			map[string]interface{}{"blockerId": input.BlockerID, "blockedId": input.BlockedID},
+		)
+	}
+	return &port.UnblockUserOutput{BlockerID: input.BlockerID, BlockedID: input.BlockedID}, nil
+}
+
+func (i UserBlockInteractor) List(ctx context.Context, input *port.ListUserBlocksInput) (*port.ListUserBlocksOutput, error) {
+	if err := i.checkBlocker(ctx, input.BlockerID); err != nil {
+		return nil, err
+	}
+	blocks, err := i.blockRepo.FindAllByBlocker(ctx, input.BlockerID, input.Limit, input.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return &port.ListUserBlocksOutput{Blocks: blocks}, nil
+}
+
+// checkBlocker fails unless the blocker is in the tenant of the context, so that a blocker of another tenant is reported as not found.
+func (i UserBlockInteractor) checkBlocker(ctx context.Context, blockerID uuid.UUID) error {
+	blocker, err := i.userRepo.FindById(ctx, blockerID)
+	if err != nil {
+		return err
+	}
+	if blocker == nil {
+		return domainerr.New(domainerr.ReasonUserNotFound, nil, map[string]interface{}{"id": blockerID})
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	domainRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/testhelper"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func SetupTestUserBlockInteractor(ctx context.Context, gw *testhelper.Gateway) UserBlockInteractor {
+	return NewUserBlockInteractor(
+		SetupTestTxManager(gw),
+		repository.NewUserBlockMySQLRepository(gw.MySQLClient),
+		repository.NewUserMySQLRepository(gw.MySQLClient),
+		repository.NewMatchingMySQLRepository(gw.MySQLClient),
+		repository.NewMatchingHistoryMySQLRepository(gw.MySQLClient),
+		clock.New(),
+	)
+}
+
+func TestUserBlockInteractor_Block(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	userBlockInteractor := SetupTestUserBlockInteractor(ctx, gw)
+	matchingInteractor, userRepo := SetupTestMatchingInteractor(ctx, gw)
+
+	me := createTestUser(ctx, t, userRepo)
+	partner := createTestUser(ctx, t, userRepo)
+	if _, err := matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: me.ID, PartnerID: partner.ID}); err != nil {
+		t.Fatalf("Failed to create test matching: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		input        *port.BlockUserInput
+		wantRejected bool
+		wantErr      bool
+	}{
+		{
+			name:         "OK_RejectsPendingMatching",
+			input:        &port.BlockUserInput{BlockerID: partner.ID, BlockedID: me.ID},
+			wantRejected: true,
+		},
+		{
+			name:    "NG_AlreadyBlocked",
+			input:   &port.BlockUserInput{BlockerID: partner.ID, BlockedID: me.ID},
+			wantErr: true,
+		},
+		{
+			name:    "NG_BlockSelf",
+			input:   &port.BlockUserInput{BlockerID: me.ID, BlockedID: me.ID},
+			wantErr: true,
+		},
+		{
+			name:    "NG_UserNotFound",
+			input:   &port.BlockUserInput{BlockerID: me.ID, BlockedID: uuid.New()},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := userBlockInteractor.Block(ctx, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Block() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if (got.RejectedMatching != nil) != tt.wantRejected {
+				t.Errorf("Block() rejectedMatching = %v, want %v", got.RejectedMatching, tt.wantRejected)
+			}
+			if got.RejectedMatching != nil && got.RejectedMatching.Status != model.MatchingStatusRejected {
+				t.Errorf("Block() status = %v, want %v", got.RejectedMatching.Status, model.MatchingStatusRejected)
+			}
+		})
+	}
+
+	// A concurrent block that passed the existence check is refused by the primary key
+	block := model.NewUserBlock(model.InputUserBlockParams{BlockerID: partner.ID, BlockedID: me.ID}, clock.New().Now())
+	if _, err := repository.NewUserBlockMySQLRepository(gw.MySQLClient).Save(ctx, block); !errors.Is(err, domainRepo.ErrUserBlockAlreadyExists) {
+		t.Errorf("Save() error = %v, want %v", err, domainRepo.ErrUserBlockAlreadyExists)
+	}
+
+	// The blocked user can neither like again nor see the matching in either list
+	if _, err := matchingInteractor.Create(ctx, &port.CreateMatchingInput{MeID: me.ID, PartnerID: partner.ID}); err == nil {
+		t.Error("Create() with a blocking partner succeeded")
+	}
+	for _, userID := range []uuid.UUID{me.ID, partner.ID} {
+		got, err := matchingInteractor.ListByMeID(ctx, &port.ListMatchingByMeIDInput{MeID: userID, Limit: 10})
+		if err != nil {
+			t.Fatalf("ListByMeID() error = %v", err)
+		}
+		if len(got.Matchings) != 0 {
+			t.Errorf("ListByMeID() got %d matchings with a blocked user", len(got.Matchings))
+		}
+	}
+}
+
+func TestUserBlockInteractor_Unblock(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	userBlockInteractor := SetupTestUserBlockInteractor(ctx, gw)
+	_, userRepo := SetupTestMatchingInteractor(ctx, gw)
+
+	me := createTestUser(ctx, t, userRepo)
+	blocked := createTestUser(ctx, t, userRepo)
+	if _, err := userBlockInteractor.Block(ctx, &port.BlockUserInput{BlockerID: me.ID, BlockedID: blocked.ID}); err != nil {
+		t.Fatalf("Failed to block test user: %v", err)
+	}
+
+	listed, err := userBlockInteractor.List(ctx, &port.ListUserBlocksInput{BlockerID: me.ID, Limit: 10})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(listed.Blocks) != 1 || listed.Blocks[0].BlockedID != blocked.ID {
+		t.Errorf("List() got = %v, want the blocked user", listed.Blocks)
+	}
+
+	tests := []struct {
+		name    string
+		input   *port.UnblockUserInput
+		wantErr bool
+	}{
+		{
+			name:  "OK",
+			input: &port.UnblockUserInput{BlockerID: me.ID, BlockedID: blocked.ID},
+		},
+		{
+			name:    "NG_NotBlocked",
+			input:   &port.UnblockUserInput{BlockerID: me.ID, BlockedID: blocked.ID},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := userBlockInteractor.Unblock(ctx, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Unblock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/event"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// RequestEmailChange keeps the new email pending, mails a token to confirm it to the new email,
+// and mails a notice with a token to undo it to the old email.
+func (i UserInteractor) RequestEmailChange(ctx context.Context, input *port.RequestEmailChangeInput) (*port.RequestEmailChangeOutput, error) {
+	var change *model.EmailChange
+	now := i.clock.Now()
+	err := i.txManager.Do(ctx, func(ctx context.Context) error {
+		user, err := i.userRepo.FindById(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return domainerr.New(domainerr.ReasonUserNotFound, nil, map[string]interface{}{"id": input.ID})
+		}
+		if change, err = i.requestEmailChange(ctx, user, input.Email, now); err != nil {
+			return err
+		}
+		if _, err = i.userRepo.Save(ctx, user); err != nil {
+			return err
+		}
+		event.Collect(ctx, user)
+		return i.sendEmailChange(ctx, change)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &port.RequestEmailChangeOutput{
+		ID:            input.ID,
+		PendingEmail:  change.NewEmail,
+		ExpiresAt:     change.ExpiresAt,
+		UndoExpiresAt: change.UndoExpiresAt,
+	}, nil
+}
+
+// ConfirmEmailChange swaps to the new email with the token mailed to it.
+// The unique email is only taken here, so a new email taken by someone else in the meantime is rejected.
+func (i UserInteractor) ConfirmEmailChange(ctx context.Context, input *port.ConfirmEmailChangeInput) (*port.ConfirmEmailChangeOutput, error) {
+	changeID, err := i.parseEmailToken(input.Token, model.EmailTokenPurposeChange)
+	if err != nil {
+		return nil, err
+	}
+
+	var confirmedUser *model.User
+	now := i.clock.Now()
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		change, user, err := i.findEmailChange(ctx, changeID)
+		if err != nil {
+			return err
+		}
+		if err := change.Confirm(now); err != nil {
+			return domainerr.Wrap(err, domainerr.ReasonEmailChangeUnconfirmable, nil)
+		}
+		if err := user.ConfirmEmailChange(change.NewEmail, now); err != nil {
+			return domainerr.New(domainerr.ReasonEmailChangeSuperseded, err, nil)
+		}
+		if _, err := i.emailChangeRepo.Save(ctx, change); err != nil {
+			return err
+		}
+		confirmedUser, err = i.saveChangedEmail(ctx, user)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &port.ConfirmEmailChangeOutput{User: confirmedUser}, nil
+}
+
+// UndoEmailChange restores the old email with the token mailed to it, whether the change is confirmed or not.
+func (i UserInteractor) UndoEmailChange(ctx context.Context, input *port.UndoEmailChangeInput) (*port.UndoEmailChangeOutput, error) {
+	changeID, err := i.parseEmailToken(input.Token, model.EmailTokenPurposeChangeUndo)
+	if err != nil {
+		return nil, err
+	}
+
+	var revertedUser *model.User
+	now := i.clock.Now()
+	err = i.txManager.Do(ctx, func(ctx context.Context) error {
+		change, user, err := i.findEmailChange(ctx, changeID)
+		if err != nil {
+			return err
+		}
+		if err := change.Revert(now); err != nil {
+			return domainerr.Wrap(err, domainerr.ReasonEmailChangeUnrevertable, nil)
+		}
+		if err := user.RevertEmailChange(change.OldEmail, change.NewEmail, now); err != nil {
+			return domainerr.New(domainerr.ReasonEmailChangeSuperseded, err, nil)
+		}
+		if _, err := i.emailChangeRepo.Save(ctx, change); err != nil {
+			return err
+		}
+		revertedUser, err = i.saveChangedEmail(ctx, user)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &port.UndoEmailChangeOutput{User: revertedUser}, nil
+}
+
+// requestEmailChange marks the email of the user pending and records the change. The caller saves the user.
+func (i UserInteractor) requestEmailChange(ctx context.Context, user *model.User, email string, now time.Time) (*model.EmailChange, error) {
+	if err := user.RequestEmailChange(email, now); err != nil {
+		return nil, domainerr.New(domainerr.ReasonEmailCannotBeChanged, err, map[string]interface{}{"email": email})
+	}
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+	// The unique index is checked again on confirmation, this only spares mailing a change that cannot succeed
+	exists, err := i.userRepo.ExistsByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domainerr.New(domainerr.ReasonEmailAlreadyUsed, repository.ErrUserEmailAlreadyExists, nil)
+	}
+	return i.emailChangeRepo.Save(ctx, model.NewEmailChange(user, email, i.emailConfig.ChangeTTL, i.emailConfig.ChangeUndoPeriod, now))
+}
+
+func (i UserInteractor) findEmailChange(ctx context.Context, id uuid.UUID) (*model.EmailChange, *model.User, error) {
+	change, err := i.emailChangeRepo.FindByIdForUpdate(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if change == nil {
+		return nil, nil, domainerr.New(domainerr.ReasonEmailChangeNotFound, nil, nil)
+	}
+	user, err := i.userRepo.FindById(ctx, change.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, domainerr.New(domainerr.ReasonUserNotFound, nil, map[string]interface{}{"id": change.UserID})
+	}
+	return change, user, nil
+}
+
+func (i UserInteractor) saveChangedEmail(ctx context.Context, user *model.User) (*model.User, error) {
+	saved, err := i.userRepo.Save(ctx, user)
+	if errors.Is(err, repository.ErrUserEmailAlreadyExists) {
+		return nil, domainerr.New(domainerr.ReasonEmailAlreadyUsed, err, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	event.Collect(ctx, user)
+	return saved, nil
+}
+
+// sendEmailChange mails the confirmation to the new email and the notice to the old email through the outbox, in the transaction of ctx.
+func (i UserInteractor) sendEmailChange(ctx context.Context, change *model.EmailChange) error {
+	now := i.clock.Now()
+	confirmation := model.NewEmailChangeConfirmationMail(change, i.signEmailToken(change.ID, model.EmailTokenPurposeChange))
+	if err := enqueueMail(ctx, i.outboxRepo, confirmation, now); err != nil {
+		return err
+	}
+	notice := model.NewEmailChangeNoticeMail(change, i.signEmailToken(change.ID, model.EmailTokenPurposeChangeUndo))
+	return enqueueMail(ctx, i.outboxRepo, notice, now)
+}
+
+func (i UserInteractor) signEmailToken(id uuid.UUID, purpose string) string {
+	return i.emailConfig.Signer.Sign(model.EmailTokenPayload(purpose, id))
+}
+
+// parseEmailToken returns the ID signed into a token of the purpose.
+func (i UserInteractor) parseEmailToken(token, purpose string) (uuid.UUID, error) {
+	invalid := domainerr.New(domainerr.ReasonEmailTokenInvalid, nil, nil)
+	payload, err := i.emailConfig.Signer.Verify(token)
+	if err != nil {
+		return uuid.Nil(), invalid
+	}
+	id, ok := model.ParseEmailTokenPayload(payload, purpose)
+	if !ok {
+		return uuid.Nil(), invalid
+	}
+	return id, nil
+}
@@ -0,0 +1,17 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/event"
+)
+
+// NewUserCacheInvalidator returns the handler that removes the cached user on any change of the user,
+// so that the next read loads it from the database. Subscribe it to model.UserEventNames.
+func NewUserCacheInvalidator(userCache repository.UserCacheRepository) event.Handler {
+	return func(ctx context.Context, e model.DomainEvent) error {
+		return userCache.Remove(ctx, e.AggregateID())
+	}
+}
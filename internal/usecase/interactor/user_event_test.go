@@ -0,0 +1,56 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	mysqlRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
+	redisRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/redis/repository"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/event"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	txport "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/transaction"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/testhelper"
+)
+
+// SetupTestTxManager returns the transaction manager wired with the same event subscribers as the application.
+func SetupTestTxManager(gw *testhelper.Gateway) txport.Manager {
+	dispatcher := event.NewDispatcher()
+	dispatcher.Subscribe(NewUserCacheInvalidator(redisRepo.NewUserRedisRepository(gw.RedisClient)), model.UserEventNames...)
+	dispatcher.Subscribe(NewConversationArchiver(mysqlRepo.NewConversationMySQLRepository(gw.MySQLClient)), model.EventMatchingUnmatched)
+	dispatcher.SubscribeInTransaction(NewMatchingNotifier(mysqlRepo.NewOutboxMySQLRepository(gw.MySQLClient), clock.New()), model.EventMatchingCreated, model.EventMatchingAccepted)
+	return event.NewTransactionManager(transaction.NewMySQLTransactionManager(gw.MySQLClient), dispatcher)
+}
+
+func TestNewUserCacheInvalidator(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	userInteractor := SetupTestUserInteractor(ctx, gw)
+
+	created, err := userInteractor.Create(ctx, &port.CreateUserInput{Email: "cache@example.com", DisplayName: "before"})
+	if err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	// Get caches the user
+	if _, err := userInteractor.Get(ctx, &port.GetUserInput{ID: created.User.ID}); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if _, err := userInteractor.Update(ctx, &port.UpdateUserInput{ID: created.User.ID, Email: created.User.Email, DisplayName: "after"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err := userInteractor.Get(ctx, &port.GetUserInput{ID: created.User.ID})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.User.DisplayName != "after" {
+		t.Errorf("Get() got = %v, want %v", got.User.DisplayName, "after")
+	}
+}
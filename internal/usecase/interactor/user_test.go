@@ -2,34 +2,71 @@ package interactor
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	domainerr "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/error"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/repository"
-	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/mysql/transaction"
 	redisRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/redis/repository"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/sqs"
 	sqsRepo "github.com/MoneyForest/go-clean-architecture-boilerplate/internal/infrastructure/gateway/sqs/repository"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/usecase/port"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/clock"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/testhelper"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/token"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
 )
 
+const (
+	testUserWithdrawalGracePeriod = 24 * time.Hour
+	testEmailVerificationTTL      = time.Hour
+	testEmailChangeTTL            = time.Hour
+	testEmailChangeUndoPeriod     = 24 * time.Hour
+	testEmailVerificationLimit    = 3
+)
+
+var testEmailVerificationSigner = token.NewSigner("test-secret")
+
 func SetupTestUserInteractor(ctx context.Context, gw *testhelper.Gateway) UserInteractor {
+	return setupTestUserInteractorWithGracePeriod(ctx, gw, testUserWithdrawalGracePeriod)
+}
+
+func setupTestUserInteractorWithGracePeriod(ctx context.Context, gw *testhelper.Gateway, gracePeriod time.Duration) UserInteractor {
+	return setupTestUserInteractorWithClock(ctx, gw, gracePeriod, clock.New())
+}
+
+func setupTestUserInteractorWithClock(ctx context.Context, gw *testhelper.Gateway, gracePeriod time.Duration, clk clock.Clock) UserInteractor {
 	return NewUserInteractor(
-		transaction.NewMySQLTransactionManager(gw.MySQLClient),
+		SetupTestTxManager(gw),
 		repository.NewUserMySQLRepository(gw.MySQLClient),
 		redisRepo.NewUserRedisRepository(gw.RedisClient),
-		sqsRepo.NewSQSRepository(gw.SQSClient.Client, gw.SQSClient.QueueURLs[sqs.SQSKeySample]),
+		sqsRepo.NewSQSRepository(gw.SQSClient.Client, gw.SQSClient.QueueURLs[sqs.SQSKeySample], clock.New()),
+		repository.NewOutboxMySQLRepository(gw.MySQLClient),
+		repository.NewEmailVerificationMySQLRepository(gw.MySQLClient),
+		repository.NewEmailChangeMySQLRepository(gw.MySQLClient),
+		UserEmailConfig{
+			Signer:                  testEmailVerificationSigner,
+			VerificationTTL:         testEmailVerificationTTL,
+			ChangeTTL:               testEmailChangeTTL,
+			ChangeUndoPeriod:        testEmailChangeUndoPeriod,
+			VerificationLimit:       testEmailVerificationLimit,
+			VerificationLimitWindow: time.Hour,
+		},
+		gracePeriod,
+		clk,
 	)
 }
 
 func TestUserInteractor_Create(t *testing.T) {
-	ctx := context.Background()
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
 	gw, err := testhelper.Setup(ctx)
 	if err != nil {
 		t.Fatalf("Failed to setup test: %v", err)
@@ -46,7 +83,28 @@ func TestUserInteractor_Create(t *testing.T) {
 		{
 			name:    "OK",
 			input:   &port.CreateUserInput{Email: "test@example.com"},
-			want:    &model.User{Email: "test@example.com"},
+			want:    &model.User{Email: "test@example.com", Status: model.UserStatusActive},
+			wantErr: false,
+		},
+		{
+			name: "OK_WithProfile",
+			input: &port.CreateUserInput{
+				Email:       "profile@example.com",
+				DisplayName: "Profile",
+				Birthdate:   time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC),
+				Gender:      "female",
+				Bio:         "Hello",
+				Locale:      "ja-JP",
+			},
+			want: &model.User{
+				Email:       "profile@example.com",
+				DisplayName: "Profile",
+				Birthdate:   time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC),
+				Gender:      model.UserGenderFemale,
+				Bio:         "Hello",
+				Locale:      "ja-JP",
+				Status:      model.UserStatusActive,
+			},
 			wantErr: false,
 		},
 		{
@@ -54,6 +112,14 @@ func TestUserInteractor_Create(t *testing.T) {
 			input:   &port.CreateUserInput{Email: ""},
 			wantErr: true,
 		},
+		{
+			name: "NG_UnderMinimumAge",
+			input: &port.CreateUserInput{
+				Email:     "young@example.com",
+				Birthdate: time.Now().AddDate(-model.UserMinimumAge+1, 0, 0),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -71,6 +137,7 @@ func TestUserInteractor_Create(t *testing.T) {
 				user,
 				tt.want,
 				cmpopts.IgnoreFields(model.User{}, "ID", "CreatedAt", "UpdatedAt"),
+				cmpopts.IgnoreUnexported(model.User{}),
 			)
 			if diff != "" {
 				t.Errorf("Create() mismatching (-want +got):\n%s", diff)
@@ -80,7 +147,7 @@ func TestUserInteractor_Create(t *testing.T) {
 }
 
 func TestUserInteractor_Get(t *testing.T) {
-	ctx := context.Background()
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
 	gw, err := testhelper.Setup(ctx)
 	if err != nil {
 		t.Fatalf("Failed to setup test: %v", err)
@@ -132,7 +199,8 @@ func TestUserInteractor_Get(t *testing.T) {
 				}
 				return
 			}
-			diff := cmp.Diff(got.User, tt.want)
+			// The user is read from the database, which stores the times in seconds
+			diff := cmp.Diff(got.User, tt.want, cmpopts.IgnoreUnexported(model.User{}), cmpopts.EquateApproxTime(time.Second))
 			if diff != "" {
 				t.Errorf("Get() mismatching (-want +got):\n%s", diff)
 			}
@@ -140,8 +208,94 @@ func TestUserInteractor_Get(t *testing.T) {
 	}
 }
 
+func TestUserInteractor_BatchGet(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	userInteractor := SetupTestUserInteractor(ctx, gw)
+
+	created := make([]*model.User, 3)
+	for i := range created {
+		output, err := userInteractor.Create(ctx, &port.CreateUserInput{
+			Email: fmt.Sprintf("batch%d@example.com", i),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test user: %v", err)
+		}
+		created[i] = output.User
+	}
+	// Evict one user so that the cache miss is filled from MySQL
+	if err := gw.RedisClient.Del(ctx, "user:"+created[1].ID.String()).Err(); err != nil {
+		t.Fatalf("Failed to evict cache: %v", err)
+	}
+	missingID := uuid.New()
+
+	tests := []struct {
+		name        string
+		input       *port.BatchGetUsersInput
+		wantIDs     []uuid.UUID
+		wantMissing []uuid.UUID
+		wantErr     bool
+	}{
+		{
+			name: "OK_PreservesInputOrder",
+			input: &port.BatchGetUsersInput{
+				IDs: []uuid.UUID{created[2].ID, created[0].ID, created[1].ID},
+			},
+			wantIDs:     []uuid.UUID{created[2].ID, created[0].ID, created[1].ID},
+			wantMissing: []uuid.UUID{},
+			wantErr:     false,
+		},
+		{
+			name: "OK_ReportsMissingIDs",
+			input: &port.BatchGetUsersInput{
+				IDs: []uuid.UUID{created[0].ID, missingID},
+			},
+			wantIDs:     []uuid.UUID{created[0].ID},
+			wantMissing: []uuid.UUID{missingID},
+			wantErr:     false,
+		},
+		{
+			name:    "NG_Empty",
+			input:   &port.BatchGetUsersInput{IDs: []uuid.UUID{}},
+			wantErr: true,
+		},
+		{
+			name:    "NG_TooMany",
+			input:   &port.BatchGetUsersInput{IDs: make([]uuid.UUID, MaxBatchGetUsers+1)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := userInteractor.BatchGet(ctx, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BatchGet() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			gotIDs := make([]uuid.UUID, len(got.Users))
+			for i, user := range got.Users {
+				gotIDs[i] = user.ID
+			}
+			if diff := cmp.Diff(gotIDs, tt.wantIDs); diff != "" {
+				t.Errorf("BatchGet() users mismatching (-got +want):\n%s", diff)
+			}
+			if diff := cmp.Diff(got.MissingIDs, tt.wantMissing); diff != "" {
+				t.Errorf("BatchGet() missing IDs mismatching (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestUserInteractor_List(t *testing.T) {
-	ctx := context.Background()
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
 	gw, err := testhelper.Setup(ctx)
 	if err != nil {
 		t.Fatalf("Failed to setup test: %v", err)
@@ -211,7 +365,7 @@ func TestUserInteractor_List(t *testing.T) {
 }
 
 func TestUserInteractor_Update(t *testing.T) {
-	ctx := context.Background()
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
 	gw, err := testhelper.Setup(ctx)
 	if err != nil {
 		t.Fatalf("Failed to setup test: %v", err)
@@ -234,8 +388,9 @@ func TestUserInteractor_Update(t *testing.T) {
 				})
 				return created.User, err
 			},
+			// The new email stays pending until it is confirmed
 			input:   &port.UpdateUserInput{Email: "updated@example.com"},
-			want:    "updated@example.com",
+			want:    "test@example.com",
 			wantErr: false,
 		},
 		{
@@ -285,13 +440,16 @@ func TestUserInteractor_Update(t *testing.T) {
 				if got.User.Email != tt.want {
 					t.Errorf("Update() got = %v, want %v", got.User.Email, tt.want)
 				}
+				if got.User.PendingEmail != tt.input.Email {
+					t.Errorf("Update() got pending = %v, want %v", got.User.PendingEmail, tt.input.Email)
+				}
 			}
 		})
 	}
 }
 
 func TestUserInteractor_Delete(t *testing.T) {
-	ctx := context.Background()
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
 	gw, err := testhelper.Setup(ctx)
 	if err != nil {
 		t.Fatalf("Failed to setup test: %v", err)
@@ -348,6 +506,9 @@ func TestUserInteractor_Delete(t *testing.T) {
 				if *got.ID != tt.input.ID {
 					t.Errorf("Delete() got = %v, want %v", got.ID, tt.input.ID)
 				}
+				if got.PermanentDeletionAt.Before(time.Now().Add(testUserWithdrawalGracePeriod - time.Minute)) {
+					t.Errorf("Delete() permanentDeletionAt = %v, want after the grace period", got.PermanentDeletionAt)
+				}
 				result, err := userInteractor.Get(ctx, &port.GetUserInput{ID: tt.input.ID})
 				if err != nil {
 					t.Errorf("Unexpected error checking deleted user: %v", err)
@@ -361,8 +522,91 @@ func TestUserInteractor_Delete(t *testing.T) {
 	}
 }
 
+func TestUserInteractor_Reactivate(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	userInteractor := SetupTestUserInteractor(ctx, gw)
+	expiredInteractor := setupTestUserInteractorWithGracePeriod(ctx, gw, 0)
+
+	withdraw := func(email string) (*model.User, error) {
+		created, err := userInteractor.Create(ctx, &port.CreateUserInput{Email: email})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := userInteractor.Delete(ctx, &port.DeleteUserInput{ID: created.User.ID}); err != nil {
+			return nil, err
+		}
+		return created.User, nil
+	}
+
+	tests := []struct {
+		name       string
+		interactor UserInteractor
+		setup      func() (*model.User, error)
+		wantErr    bool
+	}{
+		{
+			name:       "OK",
+			interactor: userInteractor,
+			setup:      func() (*model.User, error) { return withdraw("reactivate1@example.com") },
+		},
+		{
+			name:       "NG_GracePeriodExpired",
+			interactor: expiredInteractor,
+			setup:      func() (*model.User, error) { return withdraw("reactivate2@example.com") },
+			wantErr:    true,
+		},
+		{
+			name:       "NG_ActiveUser",
+			interactor: userInteractor,
+			setup: func() (*model.User, error) {
+				created, err := userInteractor.Create(ctx, &port.CreateUserInput{Email: "reactivate3@example.com"})
+				if err != nil {
+					return nil, err
+				}
+				return created.User, nil
+			},
+			wantErr: true,
+		},
+		{
+			name:       "NG_UserNotFound",
+			interactor: userInteractor,
+			setup:      func() (*model.User, error) { return &model.User{ID: uuid.New()}, nil },
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := tt.setup()
+			if err != nil {
+				t.Fatalf("Failed to setup test user: %v", err)
+			}
+			got, err := tt.interactor.Reactivate(ctx, &port.ReactivateUserInput{ID: user.ID})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Reactivate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.User.Status != model.UserStatusActive || got.User.IsDeleted() {
+				t.Errorf("Reactivate() got status = %v, deletedAt = %v", got.User.Status, got.User.DeletedAt)
+			}
+			result, err := userInteractor.Get(ctx, &port.GetUserInput{ID: user.ID})
+			if err != nil || result.User == nil {
+				t.Errorf("Reactivate() user is not readable: %v", err)
+			}
+		})
+	}
+}
+
 func TestUserInteractor_EnqueueUserDeletion(t *testing.T) {
-	ctx := context.Background()
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
 	gw, err := testhelper.Setup(ctx)
 	if err != nil {
 		t.Fatalf("Failed to setup test: %v", err)
@@ -415,14 +659,64 @@ func TestUserInteractor_EnqueueUserDeletion(t *testing.T) {
 	}
 }
 
+func TestUserInteractor_EnqueueExpiredUserDeletions(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	userInteractor := setupTestUserInteractorWithClock(ctx, gw, testUserWithdrawalGracePeriod, clk)
+
+	created, err := userInteractor.Create(ctx, &port.CreateUserInput{Email: "expired@example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	if _, err := userInteractor.Delete(ctx, &port.DeleteUserInput{ID: created.User.ID}); err != nil {
+		t.Fatalf("Failed to delete test user: %v", err)
+	}
+	clk.Advance(testUserWithdrawalGracePeriod)
+
+	// The deletion is enqueued once, and again only when it has not happened after the retry interval
+	steps := []struct {
+		advance time.Duration
+		want    int
+	}{
+		{advance: 0, want: 1},
+		{advance: time.Hour, want: 0},
+		{advance: UserDeletionRetryInterval, want: 1},
+	}
+	for i, step := range steps {
+		clk.Advance(step.advance)
+		got, err := userInteractor.EnqueueExpiredUserDeletions(ctx, &port.EnqueueExpiredUserDeletionsInput{})
+		if err != nil {
+			t.Fatalf("EnqueueExpiredUserDeletions() run %d error = %v", i+1, err)
+		}
+		if len(got.IDs) != step.want {
+			t.Errorf("EnqueueExpiredUserDeletions() run %d enqueued %d users, want %d", i+1, len(got.IDs), step.want)
+		}
+	}
+}
+
 func TestUserInteractor_DequeueAndDeleteUser(t *testing.T) {
-	ctx := context.Background()
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
 	gw, err := testhelper.Setup(ctx)
 	if err != nil {
 		t.Fatalf("Failed to setup test: %v", err)
 	}
 	defer testhelper.Cleanup(ctx, gw)
-	userInteractor := SetupTestUserInteractor(ctx, gw)
+	// The grace period has already passed as soon as the user is withdrawn
+	userInteractor := setupTestUserInteractorWithGracePeriod(ctx, gw, 0)
+	outboxInteractor := SetupTestOutboxInteractor(ctx, gw)
+	// enqueue writes the message to the outbox and relays it to the queue
+	enqueue := func(id uuid.UUID) error {
+		if _, err := userInteractor.EnqueueUserDeletion(ctx, &port.EnqueueUserDeletionInput{ID: id}); err != nil {
+			return err
+		}
+		_, err := outboxInteractor.Relay(ctx, &port.RelayOutboxInput{})
+		return err
+	}
 
 	tests := []struct {
 		name    string
@@ -432,7 +726,7 @@ func TestUserInteractor_DequeueAndDeleteUser(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "OK_ExistingUser",
+			name: "OK_WithdrawnUser",
 			setup: func() error {
 				testUser, err := userInteractor.Create(ctx, &port.CreateUserInput{
 					Email: "process1@example.com",
@@ -440,10 +734,10 @@ func TestUserInteractor_DequeueAndDeleteUser(t *testing.T) {
 				if err != nil {
 					return err
 				}
-				_, err = userInteractor.EnqueueUserDeletion(ctx, &port.EnqueueUserDeletionInput{
-					ID: testUser.User.ID,
-				})
-				return err
+				if _, err := userInteractor.Delete(ctx, &port.DeleteUserInput{ID: testUser.User.ID}); err != nil {
+					return err
+				}
+				return enqueue(testUser.User.ID)
 			},
 			input: &port.DequeueAndDeleteUserInput{
 				BatchSize: 10,
@@ -451,6 +745,39 @@ func TestUserInteractor_DequeueAndDeleteUser(t *testing.T) {
 			want:    1,
 			wantErr: false,
 		},
+		{
+			name: "OK_SkipActiveUser",
+			setup: func() error {
+				testUser, err := userInteractor.Create(ctx, &port.CreateUserInput{
+					Email: "process2@example.com",
+				})
+				if err != nil {
+					return err
+				}
+				return enqueue(testUser.User.ID)
+			},
+			input: &port.DequeueAndDeleteUserInput{
+				BatchSize: 10,
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "OK_DropMessageWithUnknownTenant",
+			setup: func() error {
+				queue := sqsRepo.NewSQSRepository(gw.SQSClient.Client, gw.SQSClient.QueueURLs[sqs.SQSKeySample], clock.New())
+				body, err := json.Marshal(uuid.New())
+				if err != nil {
+					return err
+				}
+				return queue.Send(ctx, model.NewMessage(string(body), model.MessageAttributes{tenant.MessageAttribute: "unknown"}, time.Now()))
+			},
+			input: &port.DequeueAndDeleteUserInput{
+				BatchSize: 10,
+			},
+			want:    0,
+			wantErr: false,
+		},
 		{
 			name: "OK_EmptyQueue",
 			setup: func() error {
@@ -487,3 +814,180 @@ func TestUserInteractor_DequeueAndDeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserInteractor_VerifyEmail(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	userInteractor := SetupTestUserInteractor(ctx, gw)
+	verificationRepo := repository.NewEmailVerificationMySQLRepository(gw.MySQLClient)
+
+	created, err := userInteractor.Create(ctx, &port.CreateUserInput{Email: "verify@example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	if created.User.IsEmailVerified() {
+		t.Fatalf("Create() user is verified")
+	}
+	issue := func(ttl time.Duration) string {
+		verification, err := verificationRepo.Save(ctx, model.NewEmailVerification(created.User, ttl, time.Now()))
+		if err != nil {
+			t.Fatalf("Failed to create test verification: %v", err)
+		}
+		return testEmailVerificationSigner.Sign(model.EmailTokenPayload(model.EmailTokenPurposeVerification, verification.ID))
+	}
+	expired := issue(-time.Second)
+	valid := issue(testEmailVerificationTTL)
+
+	// The cases run in order and share the user
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:    "NG_InvalidSignature",
+			token:   token.NewSigner("another-secret").Sign(uuid.New().String()),
+			wantErr: true,
+		},
+		{
+			name:    "NG_Expired",
+			token:   expired,
+			wantErr: true,
+		},
+		{
+			name:    "OK",
+			token:   valid,
+			wantErr: false,
+		},
+		{
+			name:    "NG_AlreadyUsed",
+			token:   valid,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := userInteractor.VerifyEmail(ctx, &port.VerifyEmailInput{Token: tt.token})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyEmail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.User.IsEmailVerified() {
+				t.Errorf("VerifyEmail() user is not verified")
+			}
+		})
+	}
+
+	t.Run("NG_RequestForVerifiedUser", func(t *testing.T) {
+		if _, err := userInteractor.RequestEmailVerification(ctx, &port.RequestEmailVerificationInput{ID: created.User.ID}); err == nil {
+			t.Errorf("RequestEmailVerification() error = nil, want error")
+		}
+	})
+}
+
+func TestUserInteractor_RequestEmailVerification(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	userInteractor := SetupTestUserInteractor(ctx, gw)
+
+	// Creating the user already sends the first verification mail
+	created, err := userInteractor.Create(ctx, &port.CreateUserInput{Email: "resend@example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	for i := 1; i < testEmailVerificationLimit; i++ {
+		if _, err := userInteractor.RequestEmailVerification(ctx, &port.RequestEmailVerificationInput{ID: created.User.ID}); err != nil {
+			t.Fatalf("RequestEmailVerification() #%d error = %v", i, err)
+		}
+	}
+	_, err = userInteractor.RequestEmailVerification(ctx, &port.RequestEmailVerificationInput{ID: created.User.ID})
+	var domainErr *domainerr.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != domainerr.ResourceExhausted {
+		t.Errorf("RequestEmailVerification() over the limit error = %v, want %s", err, domainerr.ResourceExhausted)
+	}
+}
+
+func TestUserInteractor_EmailChange(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+	gw, err := testhelper.Setup(ctx)
+	if err != nil {
+		t.Fatalf("Failed to setup test: %v", err)
+	}
+	defer testhelper.Cleanup(ctx, gw)
+	userInteractor := SetupTestUserInteractor(ctx, gw)
+
+	created, err := userInteractor.Create(ctx, &port.CreateUserInput{Email: "old@example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	if _, err := userInteractor.Create(ctx, &port.CreateUserInput{Email: "taken@example.com"}); err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+
+	t.Run("NG_RequestTakenEmail", func(t *testing.T) {
+		_, err := userInteractor.RequestEmailChange(ctx, &port.RequestEmailChangeInput{ID: created.User.ID, Email: "taken@example.com"})
+		if err == nil {
+			t.Errorf("RequestEmailChange() error = nil, want error")
+		}
+	})
+
+	requested, err := userInteractor.RequestEmailChange(ctx, &port.RequestEmailChangeInput{ID: created.User.ID, Email: "new@example.com"})
+	if err != nil {
+		t.Fatalf("RequestEmailChange() error = %v", err)
+	}
+	if requested.PendingEmail != "new@example.com" {
+		t.Fatalf("RequestEmailChange() got pending = %v, want %v", requested.PendingEmail, "new@example.com")
+	}
+	confirmToken := testEmailVerificationSigner.Sign(model.EmailTokenPayload(model.EmailTokenPurposeChange, requested.ID))
+	undoToken := testEmailVerificationSigner.Sign(model.EmailTokenPayload(model.EmailTokenPurposeChangeUndo, requested.ID))
+
+	t.Run("NG_ConfirmWithUndoToken", func(t *testing.T) {
+		if _, err := userInteractor.ConfirmEmailChange(ctx, &port.ConfirmEmailChangeInput{Token: undoToken}); err == nil {
+			t.Errorf("ConfirmEmailChange() error = nil, want error")
+		}
+	})
+
+	t.Run("OK_Confirm", func(t *testing.T) {
+		got, err := userInteractor.ConfirmEmailChange(ctx, &port.ConfirmEmailChangeInput{Token: confirmToken})
+		if err != nil {
+			t.Fatalf("ConfirmEmailChange() error = %v", err)
+		}
+		if got.User.Email != "new@example.com" || got.User.PendingEmail != "" {
+			t.Errorf("ConfirmEmailChange() got = %v (pending %v), want %v", got.User.Email, got.User.PendingEmail, "new@example.com")
+		}
+	})
+
+	t.Run("NG_ConfirmTwice", func(t *testing.T) {
+		if _, err := userInteractor.ConfirmEmailChange(ctx, &port.ConfirmEmailChangeInput{Token: confirmToken}); err == nil {
+			t.Errorf("ConfirmEmailChange() error = nil, want error")
+		}
+	})
+
+	t.Run("OK_Undo", func(t *testing.T) {
+		got, err := userInteractor.UndoEmailChange(ctx, &port.UndoEmailChangeInput{Token: undoToken})
+		if err != nil {
+			t.Fatalf("UndoEmailChange() error = %v", err)
+		}
+		if got.User.Email != "old@example.com" {
+			t.Errorf("UndoEmailChange() got = %v, want %v", got.User.Email, "old@example.com")
+		}
+	})
+
+	t.Run("NG_UndoTwice", func(t *testing.T) {
+		if _, err := userInteractor.UndoEmailChange(ctx, &port.UndoEmailChangeInput{Token: undoToken}); err == nil {
+			t.Errorf("UndoEmailChange() error = nil, want error")
+		}
+	})
+}
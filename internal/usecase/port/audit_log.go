@@ -0,0 +1,23 @@
+package port
+
+import (
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type SearchAuditLogsInput struct {
+	// ActorID, TargetType, TargetID, From and To are optional filters.
+	ActorID    uuid.UUID `json:"actor_id"`
+	TargetType string    `json:"target_type"`
+	TargetID   string    `json:"target_id"`
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	Limit      int       `json:"limit"`
+	Offset     int       `json:"offset"`
+}
+
+type SearchAuditLogsOutput struct {
+	AuditLogs []*model.AuditLog `json:"audit_logs"`
+}
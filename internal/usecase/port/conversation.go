@@ -0,0 +1,47 @@
+package port
+
+import (
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type SendChatMessageInput struct {
+	SenderID  uuid.UUID `json:"sender_id"`
+	PartnerID uuid.UUID `json:"partner_id"`
+	Body      string    `json:"body"`
+}
+
+type SendChatMessageOutput struct {
+	Conversation *model.Conversation `json:"conversation"`
+	Message      *model.ChatMessage  `json:"message"`
+}
+
+type ListChatMessagesInput struct {
+	UserID    uuid.UUID `json:"user_id"`
+	PartnerID uuid.UUID `json:"partner_id"`
+	// Cursor is the next cursor of the previous page, and empty for the newest messages.
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+type ListChatMessagesOutput struct {
+	// Conversation is nil until the first message is sent.
+	Conversation *model.Conversation  `json:"conversation"`
+	Messages     []*model.ChatMessage `json:"messages"`
+	// NextCursor pages the older messages, and is empty on the last page.
+	NextCursor string `json:"next_cursor"`
+}
+
+type MarkChatMessagesReadInput struct {
+	UserID    uuid.UUID `json:"user_id"`
+	PartnerID uuid.UUID `json:"partner_id"`
+	// UpToMessageID is the newest message read. Nil marks every message as read.
+	UpToMessageID uuid.UUID `json:"up_to_message_id"`
+}
+
+type MarkChatMessagesReadOutput struct {
+	ReadCount int       `json:"read_count"`
+	ReadAt    time.Time `json:"read_at"`
+}
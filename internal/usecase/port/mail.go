@@ -0,0 +1,9 @@
+package port
+
+type DequeueAndSendMailInput struct {
+	BatchSize int64
+}
+
+type DequeueAndSendMailOutput struct {
+	SentCount int
+}
@@ -1,10 +1,23 @@
 package port
 
 import (
+	"context"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
 )
 
+// MatchingUsecase is the input port of the matching usecases.
+type MatchingUsecase interface {
+	Create(ctx context.Context, input *CreateMatchingInput) (*CreateMatchingOutput, error)
+	Accept(ctx context.Context, input *AcceptMatchingInput) (*AcceptMatchingOutput, error)
+	Reject(ctx context.Context, input *RejectMatchingInput) (*RejectMatchingOutput, error)
+	Cancel(ctx context.Context, input *CancelMatchingInput) (*CancelMatchingOutput, error)
+	Unmatch(ctx context.Context, input *UnmatchMatchingInput) (*UnmatchMatchingOutput, error)
+	ListByMeID(ctx context.Context, input *ListMatchingByMeIDInput) (*ListMatchingByMeIDOutput, error)
+	Timeline(ctx context.Context, input *GetMatchingTimelineInput) (*GetMatchingTimelineOutput, error)
+	ExpireOverdue(ctx context.Context, input *ExpireOverdueMatchingsInput) (*ExpireOverdueMatchingsOutput, error)
+}
+
 type CreateMatchingInput struct {
 	MeID      uuid.UUID `json:"user1_id"`
 	PartnerID uuid.UUID `json:"user2_id"`
@@ -12,11 +25,16 @@ type CreateMatchingInput struct {
 
 type CreateMatchingOutput struct {
 	Matching *model.Matching `json:"matching"`
+	// Mutual is true when the partner had already liked me and the matching became mutual.
+	Mutual bool `json:"mutual"`
+	// Quota is the daily matching quota of me after the request. It is nil when liking back, which is free.
+	Quota *model.MatchingQuota `json:"quota"`
 }
 
 type AcceptMatchingInput struct {
 	MeID      uuid.UUID `json:"me_id"`
 	PartnerID uuid.UUID `json:"partner_id"`
+	Reason    string    `json:"reason"`
 }
 
 type AcceptMatchingOutput struct {
@@ -26,18 +44,58 @@ type AcceptMatchingOutput struct {
 type RejectMatchingInput struct {
 	MeID      uuid.UUID `json:"me_id"`
 	PartnerID uuid.UUID `json:"partner_id"`
+	Reason    string    `json:"reason"`
 }
 
 type RejectMatchingOutput struct {
 	Matching *model.Matching `json:"matching"`
 }
 
+type CancelMatchingInput struct {
+	MeID      uuid.UUID `json:"me_id"`
+	PartnerID uuid.UUID `json:"partner_id"`
+	Reason    string    `json:"reason"`
+}
+
+type CancelMatchingOutput struct {
+	Matching *model.Matching `json:"matching"`
+}
+
+type UnmatchMatchingInput struct {
+	MeID      uuid.UUID `json:"me_id"`
+	PartnerID uuid.UUID `json:"partner_id"`
+	Reason    string    `json:"reason"`
+}
+
+type UnmatchMatchingOutput struct {
+	Matching *model.Matching `json:"matching"`
+}
+
+type GetMatchingTimelineInput struct {
+	MeID      uuid.UUID `json:"me_id"`
+	PartnerID uuid.UUID `json:"partner_id"`
+}
+
+type GetMatchingTimelineOutput struct {
+	Matching  *model.Matching          `json:"matching"`
+	Histories []*model.MatchingHistory `json:"histories"`
+}
+
 type ListMatchingByMeIDInput struct {
-	MeID   uuid.UUID `json:"me_id"`
-	Limit  int       `json:"limit"`
-	Offset int       `json:"offset"`
+	MeID       uuid.UUID `json:"me_id"`
+	Limit      int       `json:"limit"`
+	Offset     int       `json:"offset"`
+	MutualOnly bool      `json:"mutual_only"`
 }
 
 type ListMatchingByMeIDOutput struct {
 	Matchings []*model.Matching `json:"matchings"`
 }
+
+type ExpireOverdueMatchingsInput struct {
+	BatchSize int `json:"batch_size"`
+}
+
+type ExpireOverdueMatchingsOutput struct {
+	ExpiredCount int `json:"expired_count"`
+}
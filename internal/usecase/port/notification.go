@@ -0,0 +1,65 @@
+package port
+
+import (
+	"time"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type ListNotificationsInput struct {
+	UserID     uuid.UUID `json:"user_id"`
+	UnreadOnly bool      `json:"unread_only"`
+	Limit      int       `json:"limit"`
+	Offset     int       `json:"offset"`
+}
+
+type ListNotificationsOutput struct {
+	Notifications []*model.Notification `json:"notifications"`
+	UnreadCount   int                   `json:"unread_count"`
+}
+
+type CountUnreadNotificationsInput struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+type CountUnreadNotificationsOutput struct {
+	UnreadCount int `json:"unread_count"`
+}
+
+type MarkNotificationsReadInput struct {
+	UserID uuid.UUID `json:"user_id"`
+	// NotificationID is the notification read. Nil marks every notification as read.
+	NotificationID uuid.UUID `json:"notification_id"`
+}
+
+type MarkNotificationsReadOutput struct {
+	ReadCount int       `json:"read_count"`
+	ReadAt    time.Time `json:"read_at"`
+}
+
+type GetNotificationPreferenceInput struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+type GetNotificationPreferenceOutput struct {
+	Preference *model.NotificationPreference `json:"preference"`
+}
+
+type UpdateNotificationPreferenceInput struct {
+	UserID uuid.UUID `json:"user_id"`
+	// Channels turns the channels on or off. The channels not in it are left as they are.
+	Channels map[model.NotificationChannel]bool `json:"channels"`
+}
+
+type UpdateNotificationPreferenceOutput struct {
+	Preference *model.NotificationPreference `json:"preference"`
+}
+
+type DequeueAndDispatchNotificationsInput struct {
+	BatchSize int64
+}
+
+type DequeueAndDispatchNotificationsOutput struct {
+	DispatchedCount int
+}
@@ -0,0 +1,10 @@
+package port
+
+type RelayOutboxInput struct {
+	BatchSize int
+}
+
+type RelayOutboxOutput struct {
+	SentCount   int
+	FailedCount int
+}
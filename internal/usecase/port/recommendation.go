@@ -0,0 +1,28 @@
+package port
+
+import (
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+type ListRecommendationsInput struct {
+	UserID uuid.UUID `json:"user_id"`
+	Limit  int       `json:"limit"`
+}
+
+type RecommendedUser struct {
+	User           *model.User           `json:"user"`
+	Recommendation *model.Recommendation `json:"recommendation"`
+}
+
+type ListRecommendationsOutput struct {
+	Recommendations []*RecommendedUser `json:"recommendations"`
+}
+
+type RefreshRecommendationsInput struct {
+	BatchSize int `json:"batch_size"`
+}
+
+type RefreshRecommendationsOutput struct {
+	RefreshedCount int `json:"refreshed_count"`
+}
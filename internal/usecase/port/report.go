@@ -0,0 +1,59 @@
+package port
+
+import (
+	"context"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// ReportUsecase is the input port of the report usecases.
+type ReportUsecase interface {
+	Create(ctx context.Context, input *CreateReportInput) (*CreateReportOutput, error)
+	List(ctx context.Context, input *ListReportsInput) (*ListReportsOutput, error)
+	Assign(ctx context.Context, input *AssignReportInput) (*AssignReportOutput, error)
+	Resolve(ctx context.Context, input *ResolveReportInput) (*ResolveReportOutput, error)
+}
+
+type CreateReportInput struct {
+	ReporterID uuid.UUID `json:"reporter_id"`
+	ReportedID uuid.UUID `json:"reported_id"`
+	Reason     string    `json:"reason"`
+	Comment    string    `json:"comment"`
+}
+
+type CreateReportOutput struct {
+	Report *model.Report `json:"report"`
+}
+
+type ListReportsInput struct {
+	// Status and AssigneeID are optional filters.
+	Status     string    `json:"status"`
+	AssigneeID uuid.UUID `json:"assignee_id"`
+	Limit      int       `json:"limit"`
+	Offset     int       `json:"offset"`
+}
+
+type ListReportsOutput struct {
+	Reports []*model.Report `json:"reports"`
+}
+
+type AssignReportInput struct {
+	ID         uuid.UUID `json:"id"`
+	AssigneeID uuid.UUID `json:"assignee_id"`
+}
+
+type AssignReportOutput struct {
+	Report *model.Report `json:"report"`
+}
+
+type ResolveReportInput struct {
+	ID         uuid.UUID `json:"id"`
+	Resolution string    `json:"resolution"`
+	Note       string    `json:"note"`
+}
+
+type ResolveReportOutput struct {
+	Report *model.Report `json:"report"`
+	// SuspendedUser is the reported user suspended by the resolution, if any.
+	SuspendedUser *model.User `json:"suspended_user"`
+}
@@ -1,12 +1,41 @@
 package port
 
 import (
+	"context"
+	"time"
+
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
 	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
 )
 
+// UserUsecase is the input port of the user usecases.
+type UserUsecase interface {
+	Create(ctx context.Context, input *CreateUserInput) (*CreateUserOutput, error)
+	Get(ctx context.Context, input *GetUserInput) (*GetUserOutput, error)
+	BatchGet(ctx context.Context, input *BatchGetUsersInput) (*BatchGetUsersOutput, error)
+	List(ctx context.Context, input *ListUserInput) (*ListUserOutput, error)
+	Update(ctx context.Context, input *UpdateUserInput) (*UpdateUserOutput, error)
+	Delete(ctx context.Context, input *DeleteUserInput) (*DeleteUserOutput, error)
+	Reactivate(ctx context.Context, input *ReactivateUserInput) (*ReactivateUserOutput, error)
+	RequestEmailVerification(ctx context.Context, input *RequestEmailVerificationInput) (*RequestEmailVerificationOutput, error)
+	VerifyEmail(ctx context.Context, input *VerifyEmailInput) (*VerifyEmailOutput, error)
+	RequestEmailChange(ctx context.Context, input *RequestEmailChangeInput) (*RequestEmailChangeOutput, error)
+	ConfirmEmailChange(ctx context.Context, input *ConfirmEmailChangeInput) (*ConfirmEmailChangeOutput, error)
+	UndoEmailChange(ctx context.Context, input *UndoEmailChangeInput) (*UndoEmailChangeOutput, error)
+	EnqueueUserDeletion(ctx context.Context, input *EnqueueUserDeletionInput) (*EnqueueUserDeletionOutput, error)
+	EnqueueExpiredUserDeletions(ctx context.Context, input *EnqueueExpiredUserDeletionsInput) (*EnqueueExpiredUserDeletionsOutput, error)
+	DequeueAndDeleteUser(ctx context.Context, input *DequeueAndDeleteUserInput) (*DequeueAndDeleteUserOutput, error)
+	DeleteUserPermanently(ctx context.Context, input *DeleteUserPermanentlyInput) (*DeleteUserPermanentlyOutput, error)
+}
+
 type CreateUserInput struct {
-	Email string `json:"email"`
+	Email       string    `json:"email"`
+	DisplayName string    `json:"display_name"`
+	Birthdate   time.Time `json:"birthdate"`
+	Gender      string    `json:"gender"`
+	Bio         string    `json:"bio"`
+	Locale      string    `json:"locale"`
+	Interests   []string  `json:"interests"`
 }
 
 type CreateUserOutput struct {
@@ -21,6 +50,15 @@ type GetUserOutput struct {
 	User *model.User `json:"user"`
 }
 
+type BatchGetUsersInput struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+type BatchGetUsersOutput struct {
+	Users      []*model.User `json:"users"`
+	MissingIDs []uuid.UUID   `json:"missing_ids"`
+}
+
 type ListUserInput struct {
 	Limit  int `json:"limit"`
 	Offset int `json:"offset"`
@@ -31,8 +69,14 @@ type ListUserOutput struct {
 }
 
 type UpdateUserInput struct {
-	ID    uuid.UUID `json:"id"`
-	Email string    `json:"email"`
+	ID          uuid.UUID `json:"id"`
+	Email       string    `json:"email"`
+	DisplayName string    `json:"display_name"`
+	Birthdate   time.Time `json:"birthdate"`
+	Gender      string    `json:"gender"`
+	Bio         string    `json:"bio"`
+	Locale      string    `json:"locale"`
+	Interests   []string  `json:"interests"`
 }
 
 type UpdateUserOutput struct {
@@ -44,7 +88,16 @@ type DeleteUserInput struct {
 }
 
 type DeleteUserOutput struct {
-	ID *uuid.UUID `json:"id"`
+	ID                  *uuid.UUID `json:"id"`
+	PermanentDeletionAt time.Time  `json:"permanent_deletion_at"`
+}
+
+type ReactivateUserInput struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type ReactivateUserOutput struct {
+	User *model.User `json:"user"`
 }
 
 type EnqueueUserDeletionInput struct {
@@ -55,10 +108,76 @@ type EnqueueUserDeletionOutput struct {
 	ID uuid.UUID
 }
 
+type EnqueueExpiredUserDeletionsInput struct {
+	Limit int
+}
+
+type EnqueueExpiredUserDeletionsOutput struct {
+	IDs []uuid.UUID
+}
+
 type DequeueAndDeleteUserInput struct {
 	BatchSize int64
+	// DeleteUser deletes each dequeued user, in the tenant of the message. A decorator sets it to its own DeleteUserPermanently,
+	// and the interactor's is used when it is nil.
+	DeleteUser func(ctx context.Context, input *DeleteUserPermanentlyInput) (*DeleteUserPermanentlyOutput, error)
 }
 
 type DequeueAndDeleteUserOutput struct {
 	DeletedCount int
 }
+
+type DeleteUserPermanentlyInput struct {
+	ID uuid.UUID
+}
+
+type DeleteUserPermanentlyOutput struct {
+	ID uuid.UUID
+	// Deleted is false when the user has reactivated or is already deleted since the deletion was enqueued.
+	Deleted bool
+}
+
+type RequestEmailVerificationInput struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type RequestEmailVerificationOutput struct {
+	ID        uuid.UUID `json:"id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type VerifyEmailInput struct {
+	Token string `json:"token"`
+}
+
+type VerifyEmailOutput struct {
+	User *model.User `json:"user"`
+}
+
+type RequestEmailChangeInput struct {
+	ID    uuid.UUID `json:"id"`
+	Email string    `json:"email"`
+}
+
+type RequestEmailChangeOutput struct {
+	ID            uuid.UUID `json:"id"`
+	PendingEmail  string    `json:"pending_email"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	UndoExpiresAt time.Time `json:"undo_expires_at"`
+}
+
+type ConfirmEmailChangeInput struct {
+	Token string `json:"token"`
+}
+
+type ConfirmEmailChangeOutput struct {
+	User *model.User `json:"user"`
+}
+
+type UndoEmailChangeInput struct {
+	Token string `json:"token"`
+}
+
+type UndoEmailChangeOutput struct {
+	User *model.User `json:"user"`
+}
@@ -0,0 +1,45 @@
+package port
+
+import (
+	"context"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/internal/domain/model"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+// UserBlockUsecase is the input port of the user block usecases.
+type UserBlockUsecase interface {
+	Block(ctx context.Context, input *BlockUserInput) (*BlockUserOutput, error)
+	Unblock(ctx context.Context, input *UnblockUserInput) (*UnblockUserOutput, error)
+	List(ctx context.Context, input *ListUserBlocksInput) (*ListUserBlocksOutput, error)
+}
+
+type BlockUserInput struct {
+	BlockerID uuid.UUID `json:"blocker_id"`
+	BlockedID uuid.UUID `json:"blocked_id"`
+}
+
+type BlockUserOutput struct {
+	Block *model.UserBlock `json:"block"`
+	// RejectedMatching is the pending matching between the users that was rejected by the block, if any.
+	RejectedMatching *model.Matching `json:"rejected_matching"`
+}
+
+type UnblockUserInput struct {
+	BlockerID uuid.UUID `json:"blocker_id"`
+	BlockedID uuid.UUID `json:"blocked_id"`
+}
+
+type UnblockUserOutput struct {
+	BlockerID uuid.UUID `json:"blocker_id"`
+	BlockedID uuid.UUID `json:"blocked_id"`
+}
+
+type ListUserBlocksInput struct {
+	BlockerID uuid.UUID `json:"blocker_id"`
+	Limit     int       `json:"limit"`
+	Offset    int       `json:"offset"`
+}
+
+type ListUserBlocksOutput struct {
+	Blocks []*model.UserBlock `json:"blocks"`
+}
@@ -0,0 +1,44 @@
+// Package auth carries the authenticated user of a request in the context, so that the handlers and the audit log
+// rely on who the token proves the caller to be rather than on what the request claims.
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/tenant"
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+var ErrMissing = errors.New("principal is missing from the context")
+
+// Role is what the authenticated user is allowed to do.
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+// Principal is the user proven by the token of the request.
+type Principal struct {
+	UserID uuid.UUID
+	Role   Role
+	// TenantID is empty when the token names no tenant.
+	TenantID tenant.ID
+}
+
+type contextKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the principal of the context, and fails when the request is not authenticated.
+func FromContext(ctx context.Context) (Principal, error) {
+	p, ok := ctx.Value(contextKey{}).(Principal)
+	if !ok {
+		return Principal{}, ErrMissing
+	}
+	return p, nil
+}
@@ -0,0 +1,20 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MoneyForest/go-clean-architecture-boilerplate/pkg/uuid"
+)
+
+func TestFromContext(t *testing.T) {
+	if _, err := FromContext(context.Background()); !errors.Is(err, ErrMissing) {
+		t.Errorf("FromContext() error = %v, want %v", err, ErrMissing)
+	}
+	want := Principal{UserID: uuid.New(), Role: RoleAdmin, TenantID: "brand-a"}
+	got, err := FromContext(WithPrincipal(context.Background(), want))
+	if err != nil || got != want {
+		t.Errorf("FromContext() = %v, %v, want %v", got, err, want)
+	}
+}
@@ -0,0 +1,23 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := NewFake(start)
+
+	if got := fake.Now(); !got.Equal(start) {
+		t.Errorf("Now() = %v, want %v", got, start)
+	}
+	fake.Advance(time.Hour)
+	if got, want := fake.Now(), start.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("Now() after Advance() = %v, want %v", got, want)
+	}
+	fake.Set(start)
+	if got := fake.Now(); !got.Equal(start) {
+		t.Errorf("Now() after Set() = %v, want %v", got, start)
+	}
+}
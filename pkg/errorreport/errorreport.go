@@ -0,0 +1,60 @@
+// Package errorreport sends the unexpected errors to a crash aggregation service, under the ID the client was given.
+package errorreport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Report is an unexpected error with what is known of where it happened.
+type Report struct {
+	ID    string
+	Err   error
+	Stack []byte
+	// Tags describe the request or job that failed, such as its method and path.
+	Tags map[string]string
+}
+
+type Reporter interface {
+	Report(ctx context.Context, report Report) error
+}
+
+// writerReporter writes every report as a JSON line, for a log collector to aggregate.
+type writerReporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewStdoutReporter returns a reporter writing to the console.
+func NewStdoutReporter() Reporter {
+	return &writerReporter{w: os.Stdout}
+}
+
+type reportLine struct {
+	ErrorID    string            `json:"error_id"`
+	Error      string            `json:"error"`
+	Stack      string            `json:"stack,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	ReportedAt time.Time         `json:"reported_at"`
+}
+
+func (r *writerReporter) Report(ctx context.Context, report Report) error {
+	line, err := json.Marshal(reportLine{
+		ErrorID:    report.ID,
+		Error:      report.Err.Error(),
+		Stack:      string(report.Stack),
+		Tags:       report.Tags,
+		ReportedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err = r.w.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,38 @@
+package errorreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestWriterReporter_Report(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := &writerReporter{w: &buf}
+
+	for _, id := range []string{"first", "second"} {
+		err := reporter.Report(context.Background(), Report{
+			ID:    id,
+			Err:   errors.New("connection refused"),
+			Stack: []byte("goroutine 1"),
+			Tags:  map[string]string{"path": "/api/v1/users"},
+		})
+		if err != nil {
+			t.Fatalf("Report() error = %v", err)
+		}
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("Report() wrote %d lines, want 2", len(lines))
+	}
+	var got reportLine
+	if err := json.Unmarshal(lines[1], &got); err != nil {
+		t.Fatalf("Report() wrote invalid JSON: %v", err)
+	}
+	if got.ErrorID != "second" || got.Error != "connection refused" || got.Stack != "goroutine 1" || got.Tags["path"] != "/api/v1/users" {
+		t.Errorf("Report() wrote %+v", got)
+	}
+}
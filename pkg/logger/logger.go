@@ -39,3 +39,18 @@ func (h *CustomHandler) Handle(ctx context.Context, r slog.Record) error {
 	r.Add("caller", r.PC)
 	return h.Handler.Handle(ctx, r)
 }
+
+type contextKey struct{}
+
+// WithContext puts the logger of a request into the context.
+func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger of the request, or the default logger outside of one.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
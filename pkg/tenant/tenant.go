@@ -0,0 +1,51 @@
+// Package tenant carries the tenant of a request in the context, so that the repositories scope every query to it.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+var (
+	ErrMissing = errors.New("tenant is missing from the context")
+	ErrInvalid = errors.New("tenant ID is invalid")
+)
+
+// ID identifies a white-label app. It is part of keys and queue attributes, so it is limited to a safe set of characters.
+type ID string
+
+// DefaultID is the tenant of the data created before multi-tenancy.
+const DefaultID ID = "default"
+
+// MessageAttribute is the attribute of the queue messages that carries the tenant they were sent in.
+const MessageAttribute = "tenantId"
+
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+func Parse(s string) (ID, error) {
+	if !idPattern.MatchString(s) {
+		return "", ErrInvalid
+	}
+	return ID(s), nil
+}
+
+func (id ID) String() string {
+	return string(id)
+}
+
+type contextKey struct{}
+
+func WithID(ctx context.Context, id ID) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant of the context. There is no fallback to a default,
+// so that code reached without a tenant fails instead of reading the data of another tenant.
+func FromContext(ctx context.Context) (ID, error) {
+	id, ok := ctx.Value(contextKey{}).(ID)
+	if !ok || id == "" {
+		return "", ErrMissing
+	}
+	return id, nil
+}
@@ -0,0 +1,42 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{name: "OK", input: "brand-a"},
+		{name: "OK_Default", input: "default"},
+		{name: "NG_Empty", input: "", wantErr: ErrInvalid},
+		{name: "NG_Upper", input: "Brand", wantErr: ErrInvalid},
+		{name: "NG_Separator", input: "brand:a", wantErr: ErrInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.input {
+				t.Errorf("Parse() = %v, want %v", got, tt.input)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if _, err := FromContext(context.Background()); !errors.Is(err, ErrMissing) {
+		t.Errorf("FromContext() error = %v, want %v", err, ErrMissing)
+	}
+	got, err := FromContext(WithID(context.Background(), "brand-a"))
+	if err != nil || got != "brand-a" {
+		t.Errorf("FromContext() = %v, %v, want brand-a", got, err)
+	}
+}
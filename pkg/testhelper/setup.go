@@ -19,34 +19,40 @@ type Gateway struct {
 }
 
 type TestEnvironment struct {
-	Environment        string
-	DBHost             string
-	DBPort             string
-	DBUser             string
-	DBPassword         string
-	DBDatabase         string
-	RedisHost          string
-	RedisPort          string
-	RedisPassword      string
-	AWSRegion          string
-	AWSEndpoint        string
-	SQSQueueNameSample string
+	Environment              string
+	DBHost                   string
+	DBPort                   string
+	DBUser                   string
+	DBPassword               string
+	DBDatabase               string
+	RedisHost                string
+	RedisPort                string
+	RedisPassword            string
+	AWSRegion                string
+	AWSEndpoint              string
+	SQSQueueNameSample       string
+	SQSQueueNameModeration   string
+	SQSQueueNameMail         string
+	SQSQueueNameNotification string
 }
 
 func Setup(ctx context.Context) (*Gateway, error) {
 	e := &TestEnvironment{
-		Environment:        "test",
-		DBHost:             "localhost",
-		DBPort:             "3306",
-		DBUser:             "root",
-		DBPassword:         "password",
-		DBDatabase:         "testdb",
-		RedisHost:          "localhost",
-		RedisPort:          "6379",
-		RedisPassword:      "password",
-		AWSRegion:          "ap-northeast-1",
-		AWSEndpoint:        "http://localhost:4566",
-		SQSQueueNameSample: "sample_queue",
+		Environment:              "test",
+		DBHost:                   "localhost",
+		DBPort:                   "3306",
+		DBUser:                   "root",
+		DBPassword:               "password",
+		DBDatabase:               "testdb",
+		RedisHost:                "localhost",
+		RedisPort:                "6379",
+		RedisPassword:            "password",
+		AWSRegion:                "ap-northeast-1",
+		AWSEndpoint:              "http://localhost:4566",
+		SQSQueueNameSample:       "sample_queue",
+		SQSQueueNameModeration:   "moderation_queue",
+		SQSQueueNameMail:         "mail_queue",
+		SQSQueueNameNotification: "notification_queue",
 	}
 
 	mysqlClient, err := mysqlgw.InitDB(ctx, mysqlgw.DBConfig{
@@ -74,7 +80,12 @@ func Setup(ctx context.Context) (*Gateway, error) {
 		Region:      e.AWSRegion,
 		Endpoint:    e.AWSEndpoint,
 	}, sqsgw.SQSConfig{
-		QueueNames: map[sqsgw.Key]string{sqsgw.SQSKeySample: e.SQSQueueNameSample},
+		QueueNames: map[sqsgw.Key]string{
+			sqsgw.SQSKeySample:       e.SQSQueueNameSample,
+			sqsgw.SQSKeyModeration:   e.SQSQueueNameModeration,
+			sqsgw.SQSKeyMail:         e.SQSQueueNameMail,
+			sqsgw.SQSKeyNotification: e.SQSQueueNameNotification,
+		},
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,60 @@
+package token
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// VerifyJWT verifies a JWT signed with HS256 by the same secret, and decodes its claims into claims.
+// A token past its "exp" claim is invalid. Other algorithms are rejected, so that an unsigned token cannot pass.
+func (s *Signer) VerifyJWT(token string, claims any, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, s.mac(parts[0]+"."+parts[1])) {
+		return ErrInvalidToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return ErrInvalidToken
+	}
+	var registered struct {
+		Exp *int64 `json:"exp"`
+	}
+	if err := decodeJWTPart(parts[1], &registered); err != nil {
+		return ErrInvalidToken
+	}
+	if registered.Exp != nil && !now.Before(time.Unix(*registered.Exp, 0)) {
+		return ErrInvalidToken
+	}
+	if err := decodeJWTPart(parts[1], claims); err != nil {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// SignJWT returns a JWT of the claims signed with HS256.
+func (s *Signer) SignJWT(claims any) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(s.mac(unsigned)), nil
+}
+
+func decodeJWTPart(part string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,85 @@
+package token
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigner_VerifyJWT(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	signer := NewSigner("secret")
+
+	type claims struct {
+		TenantID string `json:"tenant_id"`
+		Exp      int64  `json:"exp,omitempty"`
+	}
+	sign := func(signer *Signer, c claims) string {
+		signed, err := signer.SignJWT(c)
+		if err != nil {
+			t.Fatalf("SignJWT() error = %v", err)
+		}
+		return signed
+	}
+	valid := sign(signer, claims{TenantID: "acme", Exp: now.Add(time.Hour).Unix()})
+	parts := strings.Split(valid, ".")
+
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "OK",
+			token: valid,
+			want:  "acme",
+		},
+		{
+			name:  "OK_NoExpiry",
+			token: sign(signer, claims{TenantID: "acme"}),
+			want:  "acme",
+		},
+		{
+			name:    "NG_Expired",
+			token:   sign(signer, claims{TenantID: "acme", Exp: now.Unix()}),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "NG_SignedWithAnotherSecret",
+			token:   sign(NewSigner("another"), claims{TenantID: "acme"}),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "NG_ClaimsTampered",
+			token:   parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"tenant_id":"other"}`)) + "." + parts[2],
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "NG_AlgNone",
+			token:   base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + parts[1] + ".",
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "NG_Malformed",
+			token:   "not-a-jwt",
+			wantErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got claims
+			err := signer.VerifyJWT(tt.token, &got, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyJWT() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.TenantID != tt.want {
+				t.Errorf("VerifyJWT() got = %v, want %v", got.TenantID, tt.want)
+			}
+		})
+	}
+}
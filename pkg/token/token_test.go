@@ -0,0 +1,52 @@
+package token
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSigner_Verify(t *testing.T) {
+	signer := NewSigner("secret")
+	signed := signer.Sign("payload")
+
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "OK",
+			token: signed,
+			want:  "payload",
+		},
+		{
+			name:    "NG_SignedWithAnotherSecret",
+			token:   NewSigner("another").Sign("payload"),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "NG_PayloadTampered",
+			token:   NewSigner("secret").Sign("other")[:4] + signed[4:],
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "NG_NoSignature",
+			token:   "cGF5bG9hZA",
+			wantErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := signer.Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Verify() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
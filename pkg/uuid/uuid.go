@@ -1,22 +1,76 @@
+// Package uuid generates the IDs of the entities. They are UUIDv7, so that they sort by creation time
+// and are inserted at the end of the indexes instead of at random places.
 package uuid
 
-import "github.com/google/uuid"
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotUUIDv7 = errors.New("uuid is not a version 7 UUID")
 
 type UUID = uuid.UUID
 
+// New returns a UUIDv7 of the current time. The IDs generated by the process are strictly increasing,
+// even within the same millisecond.
 func New() UUID {
-	return uuid.New()
+	return uuid.Must(uuid.NewV7())
 }
 
-func IsValidUUIDv7(id string) bool {
+// IsValid reports whether id is a UUID of any version. The entities created before UUIDv7 keep their random v4 IDs.
+func IsValid(id string) bool {
 	_, err := uuid.Parse(id)
 	return err == nil
 }
 
+func IsValidUUIDv7(id string) bool {
+	u, err := uuid.Parse(id)
+	return err == nil && isV7(u)
+}
+
+// Timestamp returns the time the UUIDv7 was generated at, to the millisecond.
+func Timestamp(id UUID) (time.Time, error) {
+	if !isV7(id) {
+		return time.Time{}, ErrNotUUIDv7
+	}
+	// The first 48 bits are the milliseconds since the Unix epoch
+	ms := int64(binary.BigEndian.Uint64(append([]byte{0, 0}, id[:6]...)))
+	return time.UnixMilli(ms).UTC(), nil
+}
+
+func isV7(id UUID) bool {
+	return id.Version() == 7 && id.Variant() == uuid.RFC4122
+}
+
+func Parse(id string) (UUID, error) {
+	return uuid.Parse(id)
+}
+
 func MustParse(id string) UUID {
 	return uuid.MustParse(id)
 }
 
+// Bytes returns the 16 bytes of the UUID, as stored in the BINARY(16) columns.
+func Bytes(id UUID) []byte {
+	return id[:]
+}
+
+// FromBytes returns the UUID stored in a BINARY(16) column.
+func FromBytes(b []byte) (UUID, error) {
+	return uuid.FromBytes(b)
+}
+
+func MustFromBytes(b []byte) UUID {
+	id, err := uuid.FromBytes(b)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
 func Nil() UUID {
 	return uuid.Nil
 }
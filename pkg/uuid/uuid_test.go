@@ -0,0 +1,82 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	ids := make([]UUID, 1000)
+	for i := range ids {
+		ids[i] = New()
+	}
+	after := time.Now()
+
+	for i, id := range ids {
+		if !IsValidUUIDv7(id.String()) {
+			t.Fatalf("New() = %v, want a UUIDv7", id)
+		}
+		if i > 0 && bytes.Compare(ids[i-1][:], id[:]) >= 0 {
+			t.Fatalf("New() = %v after %v, want increasing IDs", id, ids[i-1])
+		}
+	}
+	got, err := Timestamp(ids[0])
+	if err != nil {
+		t.Fatalf("Timestamp() error = %v", err)
+	}
+	if got.Before(before) || got.After(after) {
+		t.Errorf("Timestamp() = %v, want between %v and %v", got, before, after)
+	}
+}
+
+func TestIsValidUUIDv7(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want bool
+	}{
+		{name: "OK", id: "01890a5d-ac96-774b-bcce-b302099a8057", want: true},
+		{name: "NG_V4", id: "f47ac10b-58cc-4372-a567-0e02b2c3d479", want: false},
+		{name: "NG_Nil", id: "00000000-0000-0000-0000-000000000000", want: false},
+		{name: "NG_Malformed", id: "not-a-uuid", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidUUIDv7(tt.id); got != tt.want {
+				t.Errorf("IsValidUUIDv7() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	got, err := Timestamp(MustParse("01890a5d-ac96-774b-bcce-b302099a8057"))
+	if err != nil {
+		t.Fatalf("Timestamp() error = %v", err)
+	}
+	if want := time.UnixMilli(0x01890a5dac96).UTC(); !got.Equal(want) {
+		t.Errorf("Timestamp() = %v, want %v", got, want)
+	}
+
+	if _, err := Timestamp(MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")); !errors.Is(err, ErrNotUUIDv7) {
+		t.Errorf("Timestamp() error = %v, want %v", err, ErrNotUUIDv7)
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	id := New()
+	got, err := FromBytes(Bytes(id))
+	if err != nil {
+		t.Fatalf("FromBytes() error = %v", err)
+	}
+	if got != id {
+		t.Errorf("FromBytes() = %v, want %v", got, id)
+	}
+	if _, err := FromBytes([]byte(id.String())); err == nil {
+		t.Error("FromBytes() error = nil, want an error for a textual UUID")
+	}
+}
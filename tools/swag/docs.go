@@ -24,9 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/check": {
+        "/admin/audit_logs": {
             "get": {
-                "description": "Performs a basic health check of the system",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the audit logs from the newest, optionally filtered by actor, target and time range",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,56 +39,106 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
+                ],
+                "summary": "Search the audit log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Actor user ID",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "user",
+                            "matching",
+                            "user_block",
+                            "report"
+                        ],
+                        "type": "string",
+                        "description": "Target type",
+                        "name": "targetType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target ID",
+                        "name": "targetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Inclusive start (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Exclusive end (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Skip items",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get system health status",
                 "responses": {
                     "200": {
-                        "description": "System is healthy",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.HealthResponse"
+                            "$ref": "#/definitions/response.SearchAuditLogsResponse"
                         }
                     },
-                    "503": {
-                        "description": "Service unavailable",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.HealthResponse"
+                            "$ref": "#/definitions/error.DomainError"
                         }
-                    }
-                }
-            }
-        },
-        "/deep_check": {
-            "get": {
-                "description": "Performs a deep health check including dependent services",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Get detailed system health status",
-                "responses": {
-                    "200": {
-                        "description": "System is healthy",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.DeepHealthResponse"
+                            "$ref": "#/definitions/error.DomainError"
                         }
                     },
-                    "503": {
-                        "description": "System is unhealthy",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/response.DeepHealthResponse"
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
                         }
                     }
                 }
             }
         },
-        "/users": {
+        "/admin/reports": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the reports from the oldest, optionally filtered by status and assignee",
                 "consumes": [
                     "application/json"
                 ],
@@ -91,10 +146,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "List all users",
+                "summary": "List the moderation queue",
                 "parameters": [
+                    {
+                        "enum": [
+                            "open",
+                            "assigned",
+                            "resolved"
+                        ],
+                        "type": "string",
+                        "description": "Report status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Assignee ID",
+                        "name": "assigneeId",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 10,
@@ -114,7 +187,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.ListUsersResponse"
+                            "$ref": "#/definitions/response.ListReportsResponse"
                         }
                     },
                     "400": {
@@ -123,45 +196,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/error.DomainError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/error.DomainError"
                         }
-                    }
-                }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "Create a new user",
-                "parameters": [
-                    {
-                        "description": "User data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateUserRequestBody"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/response.CreateUserResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/error.DomainError"
                         }
@@ -175,8 +217,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
-            "get": {
+        "/admin/reports/{reportId}/assign": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -184,15 +231,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Assign a report to the calling moderator",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Report ID",
+                        "name": "reportId",
                         "in": "path",
                         "required": true
                     }
@@ -201,7 +248,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.GetUserResponse"
+                            "$ref": "#/definitions/response.AssignReportResponse"
                         }
                     },
                     "400": {
@@ -210,12 +257,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/error.DomainError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/error.DomainError"
                         }
                     },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -223,8 +288,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/reports/{reportId}/resolve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Closes the report. The suspended resolution also suspends the reported user",
                 "consumes": [
                     "application/json"
                 ],
@@ -232,25 +305,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Update user by ID",
+                "summary": "Resolve an assigned report",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Report ID",
+                        "name": "reportId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User data",
+                        "description": "Resolution",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateUserRequestBody"
+                            "$ref": "#/definitions/request.ResolveReportRequestBody"
                         }
                     }
                 ],
@@ -258,7 +331,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.UpdateUserResponse"
+                            "$ref": "#/definitions/response.ResolveReportResponse"
                         }
                     },
                     "400": {
@@ -267,12 +340,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/error.DomainError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/error.DomainError"
                         }
                     },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -280,8 +371,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/check": {
+            "get": {
+                "description": "Performs a basic health check of the system",
                 "consumes": [
                     "application/json"
                 ],
@@ -289,220 +383,3281 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
-                ],
-                "summary": "Delete user by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "health"
                 ],
+                "summary": "Get system health status",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "System is healthy",
                         "schema": {
-                            "$ref": "#/definitions/response.DeleteUserResponse"
+                            "$ref": "#/definitions/response.HealthResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "503": {
+                        "description": "Service unavailable",
                         "schema": {
-                            "$ref": "#/definitions/error.DomainError"
+                            "$ref": "#/definitions/response.HealthResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    }
+                }
+            }
+        },
+        "/deep_check": {
+            "get": {
+                "description": "Performs a deep health check including dependent services",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Get detailed system health status",
+                "responses": {
+                    "200": {
+                        "description": "System is healthy",
                         "schema": {
-                            "$ref": "#/definitions/error.DomainError"
+                            "$ref": "#/definitions/response.DeepHealthResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "System is unhealthy",
                         "schema": {
-                            "$ref": "#/definitions/error.DomainError"
+                            "$ref": "#/definitions/response.DeepHealthResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "error.DomainError": {
-            "type": "object",
-            "properties": {
-                "cause": {},
+        },
+        "/users": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Skip items",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated user fields to return",
+                        "name": "fields[users]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated related resources to embed, e.g. matchings.partner",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ListUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "User data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateUserRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/email/confirm": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Confirm an email change with the token mailed to the new email",
+                "parameters": [
+                    {
+                        "description": "Confirmation token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.EmailChangeTokenRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ConfirmEmailChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/email/undo": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Undo an email change with the token mailed to the old email",
+                "parameters": [
+                    {
+                        "description": "Undo token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.EmailChangeTokenRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UndoEmailChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/verify": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Verify the email of a user with the mailed token",
+                "parameters": [
+                    {
+                        "description": "Verification token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.VerifyEmailRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.VerifyEmailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated user fields to return",
+                        "name": "fields[users]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated related resources to embed, e.g. matchings.partner",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateUserRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UpdateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.DeleteUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/blocks": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blocks"
+                ],
+                "summary": "List users blocked by a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Blocker user ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Skip items",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ListUserBlocksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Blocks the user in both directions and rejects the pending matching between the users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blocks"
+                ],
+                "summary": "Block a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Blocker user ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User to block",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BlockUserRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.BlockUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/blocks/{blockedId}": {
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blocks"
+                ],
+                "summary": "Unblock a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Blocker user ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Blocked user ID",
+                        "name": "blockedId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UnblockUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/email": {
+            "post": {
+                "description": "The new email stays pending until it is confirmed with the token mailed to it. The old email is notified with a token to undo the change.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Request to change the email of the user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New email",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RequestEmailChangeRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/response.RequestEmailChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/matchings": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matchings"
+                ],
+                "summary": "List matchings of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return only mutual matchings",
+                        "name": "mutual",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Skip items",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated matching fields to return",
+                        "name": "fields[matchings]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated related resources to embed, e.g. partner",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ListMatchingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/matchings/{partnerId}/messages": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the messages with the partner from the newest. Pass nextCursor as cursor to get the older ones",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "conversations"
+                ],
+                "summary": "List messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Partner ID",
+                        "name": "partnerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ListChatMessagesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends a message to the partner of an accepted matching and pushes it to the partner in real time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "conversations"
+                ],
+                "summary": "Send a message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Sender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Partner ID",
+                        "name": "partnerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SendChatMessageRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.SendChatMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/matchings/{partnerId}/messages/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks the messages received from the partner as read up to the given message, or all of them, and pushes the read receipt to the partner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "conversations"
+                ],
+                "summary": "Mark messages as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Reader ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Partner ID",
+                        "name": "partnerId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Newest message read",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.MarkChatMessagesReadRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MarkChatMessagesReadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/matchings/{partnerId}/timeline": {
+            "get": {
+                "description": "Returns every status change of the matching between the users, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "matchings"
+                ],
+                "summary": "Get the timeline of a pair's matching",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Partner ID",
+                        "name": "partnerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MatchingTimelineResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/notification_preference": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns whether the user receives notifications through each channel",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get notification preference",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NotificationPreferenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turns the notification channels of the user on or off, leaving the others as they are",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Update notification preference",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Channels",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateNotificationPreferenceRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NotificationPreferenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the inbox of the user from the newest, with the number of unread notifications",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "List notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only the unread notifications",
+                        "name": "unreadOnly",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ListNotificationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/notifications/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks the given notification of the user as read, or all of them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark notifications as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Notification read",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.MarkNotificationsReadRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MarkNotificationsReadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/notifications/unread_count": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the number of unread notifications in the inbox of the user, for a badge",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Count unread notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CountUnreadNotificationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/reactivate": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Reactivate a withdrawn user within the grace period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ReactivateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/recommendations": {
+            "get": {
+                "description": "Returns the candidates the user can match with, best first. Users already matched, blocked or suspended are excluded",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "List recommended partners",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Max candidates (up to 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ListRecommendationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/reports": {
+            "post": {
+                "description": "Reports the user for a terms of service violation and notifies the moderation team",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Report a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Reporter user ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Report",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateReportRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreateReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/verification": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Mail a new token to verify the email of the user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/response.RequestEmailVerificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users:batchGet": {
+            "post": {
+                "description": "Returns the users in the order of the requested IDs and reports the IDs that were not found",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get users by ID list",
+                "parameters": [
+                    {
+                        "description": "User IDs (up to 100)",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BatchGetUsersRequestBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated user fields to return",
+                        "name": "fields[users]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated related resources to embed, e.g. matchings.partner",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BatchGetUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/error.DomainError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "error.DomainError": {
+            "type": "object",
+            "properties": {
+                "cause": {},
                 "code": {
                     "$ref": "#/definitions/error.ErrorCode"
                 },
-                "details": {
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "message": {
+                    "type": "string"
+                },
+                "reason": {
+                    "$ref": "#/definitions/error.Reason"
+                }
+            }
+        },
+        "error.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "INVALID_ARGUMENT",
+                "NOT_FOUND",
+                "ALREADY_EXISTS",
+                "UNAUTHORIZED",
+                "PERMISSION_DENIED",
+                "PRECONDITION_FAILED",
+                "RESOURCE_EXHAUSTED",
+                "CRITICAL"
+            ],
+            "x-enum-varnames": [
+                "InvalidArgument",
+                "NotFound",
+                "AlreadyExists",
+                "Unauthorized",
+                "PermissionDenied",
+                "PreconditionFailed",
+                "ResourceExhausted",
+                "Critical"
+            ]
+        },
+        "error.Reason": {
+            "type": "string",
+            "enum": [
+                "INTERNAL",
+                "REQUEST_BODY_INVALID",
+                "PARAMETER_INVALID",
+                "PARAMETER_REQUIRED",
+                "INCLUDE_PATH_UNSUPPORTED",
+                "AUTH_REQUIRED",
+                "AUTH_TOKEN_INVALID",
+                "AUTH_ROLE_DENIED",
+                "AUTH_USER_DENIED",
+                "TENANT_REQUIRED",
+                "TENANT_UNKNOWN",
+                "TENANT_MISMATCH",
+                "USER_NOT_FOUND",
+                "USERS_BATCH_SIZE_INVALID",
+                "USER_CANNOT_MATCH",
+                "USER_CANNOT_BE_REACTIVATED",
+                "USER_NOT_ACTIVE",
+                "USER_SUSPENDED",
+                "USER_NOT_SUSPENDED",
+                "USER_WITHDRAWN",
+                "USER_NOT_WITHDRAWN",
+                "USER_GRACE_PERIOD_EXPIRED",
+                "USER_EMAIL_VERIFIED",
+                "USER_EMAIL_EMPTY",
+                "USER_EMAIL_UNCHANGED",
+                "USER_EMAIL_CHANGE_NOT_PENDING",
+                "EMAIL_ALREADY_USED",
+                "EMAIL_CANNOT_BE_CHANGED",
+                "EMAIL_TOKEN_INVALID",
+                "EMAIL_VERIFICATION_NOT_FOUND",
+                "EMAIL_VERIFICATION_UNUSABLE",
+                "EMAIL_VERIFICATION_USED",
+                "EMAIL_VERIFICATION_EXPIRED",
+                "EMAIL_VERIFICATION_EMAIL_MISMATCH",
+                "EMAIL_VERIFICATION_TOO_FREQUENT",
+                "EMAIL_CHANGE_NOT_FOUND",
+                "EMAIL_CHANGE_UNCONFIRMABLE",
+                "EMAIL_CHANGE_UNREVERTABLE",
+                "EMAIL_CHANGE_SUPERSEDED",
+                "EMAIL_CHANGE_CONFIRMED",
+                "EMAIL_CHANGE_REVERTED",
+                "EMAIL_CHANGE_EXPIRED",
+                "EMAIL_CHANGE_UNDO_EXPIRED",
+                "MATCHING_NOT_FOUND",
+                "MATCHING_ALREADY_EXISTS",
+                "MATCHING_NOT_ALLOWED",
+                "MATCHING_ME_OR_PARTNER_ID_REQUIRED",
+                "MATCHING_STATUS_REQUIRED",
+                "MATCHING_STATUS_INVALID",
+                "MATCHING_NOT_PENDING",
+                "MATCHING_EXPIRED",
+                "MATCHING_NOT_OVERDUE",
+                "MATCHING_TRANSITION_NOT_ALLOWED",
+                "MATCHING_ACTOR_NOT_ALLOWED",
+                "MATCHING_QUOTA_EXHAUSTED",
+                "USER_BLOCK_NOT_FOUND",
+                "USER_BLOCK_ALREADY_EXISTS",
+                "USER_BLOCK_SELF",
+                "REPORT_NOT_FOUND",
+                "REPORT_INVALID",
+                "REPORT_NOT_CHANGEABLE",
+                "REPORT_SELF",
+                "REPORT_RESOLVED",
+                "REPORT_NOT_ASSIGNED",
+                "REPORT_ASSIGNEE_INVALID",
+                "REPORT_RESOLUTION_INVALID",
+                "CONVERSATION_NOT_FOUND",
+                "CONVERSATION_ARCHIVED",
+                "CONVERSATION_NOT_MUTUAL",
+                "CONVERSATION_NOT_PARTICIPANT",
+                "MESSAGING_NOT_ALLOWED",
+                "CHAT_MESSAGE_NOT_FOUND",
+                "CHAT_MESSAGE_INVALID",
+                "CHAT_MESSAGE_CURSOR_INVALID",
+                "NOTIFICATION_NOT_FOUND",
+                "NOTIFICATION_TYPE_UNKNOWN",
+                "NOTIFICATION_CHANNEL_UNKNOWN"
+            ],
+            "x-enum-varnames": [
+                "ReasonInternal",
+                "ReasonRequestBodyInvalid",
+                "ReasonParameterInvalid",
+                "ReasonParameterRequired",
+                "ReasonIncludePathUnsupported",
+                "ReasonAuthRequired",
+                "ReasonAuthTokenInvalid",
+                "ReasonAuthRoleDenied",
+                "ReasonAuthUserDenied",
+                "ReasonTenantRequired",
+                "ReasonTenantUnknown",
+                "ReasonTenantMismatch",
+                "ReasonUserNotFound",
+                "ReasonUsersBatchSizeInvalid",
+                "ReasonUserCannotMatch",
+                "ReasonUserCannotBeReactivated",
+                "ReasonUserNotActive",
+                "ReasonUserSuspended",
+                "ReasonUserNotSuspended",
+                "ReasonUserWithdrawn",
+                "ReasonUserNotWithdrawn",
+                "ReasonUserGracePeriodExpired",
+                "ReasonUserEmailVerified",
+                "ReasonUserEmailEmpty",
+                "ReasonUserEmailUnchanged",
+                "ReasonUserEmailChangeNotPending",
+                "ReasonEmailAlreadyUsed",
+                "ReasonEmailCannotBeChanged",
+                "ReasonEmailTokenInvalid",
+                "ReasonEmailVerificationNotFound",
+                "ReasonEmailVerificationUnusable",
+                "ReasonEmailVerificationUsed",
+                "ReasonEmailVerificationExpired",
+                "ReasonEmailVerificationEmailMismatch",
+                "ReasonEmailVerificationTooFrequent",
+                "ReasonEmailChangeNotFound",
+                "ReasonEmailChangeUnconfirmable",
+                "ReasonEmailChangeUnrevertable",
+                "ReasonEmailChangeSuperseded",
+                "ReasonEmailChangeConfirmed",
+                "ReasonEmailChangeReverted",
+                "ReasonEmailChangeExpired",
+                "ReasonEmailChangeUndoExpired",
+                "ReasonMatchingNotFound",
+                "ReasonMatchingAlreadyExists",
+                "ReasonMatchingNotAllowed",
+                "ReasonMatchingMeOrPartnerIDRequired",
+                "ReasonMatchingStatusRequired",
+                "ReasonMatchingStatusInvalid",
+                "ReasonMatchingNotPending",
+                "ReasonMatchingExpired",
+                "ReasonMatchingNotOverdue",
+                "ReasonMatchingTransitionNotAllowed",
+                "ReasonMatchingActorNotAllowed",
+                "ReasonMatchingQuotaExhausted",
+                "ReasonUserBlockNotFound",
+                "ReasonUserBlockAlreadyExists",
+                "ReasonUserBlockSelf",
+                "ReasonReportNotFound",
+                "ReasonReportInvalid",
+                "ReasonReportNotChangeable",
+                "ReasonReportSelf",
+                "ReasonReportResolved",
+                "ReasonReportNotAssigned",
+                "ReasonReportAssigneeInvalid",
+                "ReasonReportResolutionInvalid",
+                "ReasonConversationNotFound",
+                "ReasonConversationArchived",
+                "ReasonConversationNotMutual",
+                "ReasonConversationNotParticipant",
+                "ReasonMessagingNotAllowed",
+                "ReasonChatMessageNotFound",
+                "ReasonChatMessageInvalid",
+                "ReasonChatMessageCursorInvalid",
+                "ReasonNotificationNotFound",
+                "ReasonNotificationTypeUnknown",
+                "ReasonNotificationChannelUnknown"
+            ]
+        },
+        "request.BatchGetUsersRequestBody": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.BlockUserRequestBody": {
+            "type": "object",
+            "properties": {
+                "blockedId": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "request.CreateReportRequestBody": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "spam",
+                        "harassment",
+                        "inappropriate_content",
+                        "fake_profile",
+                        "underage",
+                        "other"
+                    ]
+                },
+                "reportedId": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "request.CreateUserRequestBody": {
+            "type": "object",
+            "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "birthdate": {
+                    "type": "string",
+                    "example": "2000-01-31"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "other"
+                    ]
+                },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "hiking",
+                        "music"
+                    ]
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "ja-JP"
+                }
+            }
+        },
+        "request.EmailChangeTokenRequestBody": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.MarkChatMessagesReadRequestBody": {
+            "type": "object",
+            "properties": {
+                "upToMessageId": {
+                    "description": "UpToMessageID is the newest message read. Empty marks every message as read.",
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "request.MarkNotificationsReadRequestBody": {
+            "type": "object",
+            "properties": {
+                "notificationId": {
+                    "description": "NotificationID is the notification read. Empty marks every notification as read.",
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "request.RequestEmailChangeRequestBody": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ResolveReportRequestBody": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "resolution": {
+                    "type": "string",
+                    "enum": [
+                        "dismissed",
+                        "warned",
+                        "suspended"
+                    ]
+                }
+            }
+        },
+        "request.SendChatMessageRequestBody": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "request.UpdateNotificationPreferenceRequestBody": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "description": "Channels turns the channels on or off by name: in_app, email or push. The channels not in it are left as they are.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                }
+            }
+        },
+        "request.UpdateUserRequestBody": {
+            "type": "object",
+            "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "birthdate": {
+                    "type": "string",
+                    "example": "2000-01-31"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "other"
+                    ]
+                },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "hiking",
+                        "music"
+                    ]
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "ja-JP"
+                }
+            }
+        },
+        "request.VerifyEmailRequestBody": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AssignReportResponse": {
+            "type": "object",
+            "properties": {
+                "assigneeId": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reportedId": {
+                    "type": "string"
+                },
+                "reporterId": {
+                    "type": "string"
+                },
+                "resolution": {
+                    "type": "string"
+                },
+                "resolutionNote": {
+                    "type": "string"
+                },
+                "resolvedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AuditChangeResponse": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {}
+            }
+        },
+        "response.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actorId": {
+                    "description": "ActorID is empty when the change was made by the system, or an anonymous caller.",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "diff": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/response.AuditChangeResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "targetId": {
+                    "type": "string"
+                },
+                "targetType": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.BatchGetUsersResponse": {
+            "type": "object",
+            "properties": {
+                "missingIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.UserResponse"
+                    }
+                }
+            }
+        },
+        "response.BlockUserResponse": {
+            "type": "object",
+            "properties": {
+                "block": {
+                    "$ref": "#/definitions/response.UserBlockResponse"
+                },
+                "rejectedMatching": {
+                    "description": "RejectedMatching is the pending matching rejected by the block, if any.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.MatchingResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "response.ChatMessageResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "conversationId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "readAt": {
+                    "type": "string"
+                },
+                "senderId": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ConfirmEmailChangeResponse": {
+            "type": "object",
+            "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "birthdate": {
+                    "type": "string",
+                    "example": "2000-01-31"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "emailVerifiedAt": {
+                    "description": "EmailVerifiedAt is omitted until the user verifies the email.",
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "pendingEmail": {
+                    "description": "PendingEmail is the new email waiting for confirmation, omitted when no change is pending.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ConversationResponse": {
+            "type": "object",
+            "properties": {
+                "archivedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "matchingId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user1Id": {
+                    "type": "string"
+                },
+                "user2Id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CountUnreadNotificationsResponse": {
+            "type": "object",
+            "properties": {
+                "unreadCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CreateReportResponse": {
+            "type": "object",
+            "properties": {
+                "assigneeId": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reportedId": {
+                    "type": "string"
+                },
+                "reporterId": {
+                    "type": "string"
+                },
+                "resolution": {
+                    "type": "string"
+                },
+                "resolutionNote": {
+                    "type": "string"
+                },
+                "resolvedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CreateUserResponse": {
+            "type": "object",
+            "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "birthdate": {
+                    "type": "string",
+                    "example": "2000-01-31"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "emailVerifiedAt": {
+                    "description": "EmailVerifiedAt is omitted until the user verifies the email.",
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "pendingEmail": {
+                    "description": "PendingEmail is the new email waiting for confirmation, omitted when no change is pending.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.DeepHealthResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.DeleteUserResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "permanentDeletionAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.GetUserResponse": {
+            "type": "object",
+            "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "birthdate": {
+                    "type": "string",
+                    "example": "2000-01-31"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "emailVerifiedAt": {
+                    "description": "EmailVerifiedAt is omitted until the user verifies the email.",
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "pendingEmail": {
+                    "description": "PendingEmail is the new email waiting for confirmation, omitted when no change is pending.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ListChatMessagesResponse": {
+            "type": "object",
+            "properties": {
+                "conversation": {
+                    "description": "Conversation is absent until the first message is sent.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.ConversationResponse"
+                        }
+                    ]
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ChatMessageResponse"
+                    }
+                },
+                "nextCursor": {
+                    "description": "NextCursor pages the older messages, and is absent on the last page.",
+                    "type": "string"
+                }
+            }
+        },
+        "response.ListMatchingsResponse": {
+            "type": "object",
+            "properties": {
+                "matchings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.MatchingResponse"
+                    }
+                }
+            }
+        },
+        "response.ListNotificationsResponse": {
+            "type": "object",
+            "properties": {
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.NotificationResponse"
+                    }
+                },
+                "unreadCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.ListRecommendationsResponse": {
+            "type": "object",
+            "properties": {
+                "recommendations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.RecommendationResponse"
+                    }
+                }
+            }
+        },
+        "response.ListReportsResponse": {
+            "type": "object",
+            "properties": {
+                "reports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ReportResponse"
+                    }
+                }
+            }
+        },
+        "response.ListUserBlocksResponse": {
+            "type": "object",
+            "properties": {
+                "blocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.UserBlockResponse"
+                    }
+                }
+            }
+        },
+        "response.ListUsersResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "totalPage": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.UserResponse"
+                    }
+                }
+            }
+        },
+        "response.MarkChatMessagesReadResponse": {
+            "type": "object",
+            "properties": {
+                "readAt": {
+                    "type": "string"
+                },
+                "readCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.MarkNotificationsReadResponse": {
+            "type": "object",
+            "properties": {
+                "readAt": {
+                    "type": "string"
+                },
+                "readCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.MatchingHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actorId": {
+                    "description": "ActorID is omitted when the system performed the action, e.g. expiration.",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.MatchingResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "meId": {
+                    "type": "string"
+                },
+                "partnerId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.MatchingTimelineResponse": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.MatchingHistoryResponse"
+                    }
+                },
+                "matching": {
+                    "$ref": "#/definitions/response.MatchingResponse"
+                }
+            }
+        },
+        "response.NotificationPreferenceResponse": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "description": "Channels tells whether each channel is on, by name.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "data": {
                     "type": "object",
-                    "additionalProperties": true
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "message": {
+                "id": {
+                    "type": "string"
+                },
+                "readAt": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "error.ErrorCode": {
-            "type": "string",
-            "enum": [
-                "INVALID_ARGUMENT",
-                "NOT_FOUND",
-                "ALREADY_EXISTS",
-                "UNAUTHORIZED",
-                "PERMISSION_DENIED",
-                "PRECONDITION_FAILED",
-                "CRITICAL"
-            ],
-            "x-enum-varnames": [
-                "InvalidArgument",
-                "NotFound",
-                "AlreadyExists",
-                "Unauthorized",
-                "PermissionDenied",
-                "PreconditionFailed",
-                "Critical"
-            ]
-        },
-        "request.CreateUserRequestBody": {
+        "response.ReactivateUserResponse": {
             "type": "object",
             "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "birthdate": {
+                    "type": "string",
+                    "example": "2000-01-31"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
+                },
+                "emailVerifiedAt": {
+                    "description": "EmailVerifiedAt is omitted until the user verifies the email.",
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "pendingEmail": {
+                    "description": "PendingEmail is the new email waiting for confirmation, omitted when no change is pending.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
-        "request.UpdateUserRequestBody": {
+        "response.RecommendationResponse": {
             "type": "object",
             "properties": {
-                "email": {
+                "generatedAt": {
                     "type": "string"
+                },
+                "score": {
+                    "description": "Score is the weighted score from 0 to 1, and Scores are the unweighted scores by scorer.",
+                    "type": "number"
+                },
+                "scores": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/response.UserResponse"
                 }
             }
         },
-        "response.CreateUserResponse": {
+        "response.ReportResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "assigneeId": {
                     "type": "string"
                 },
-                "email": {
+                "comment": {
+                    "type": "string"
+                },
+                "createdAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "reason": {
+                    "type": "string"
+                },
+                "reportedId": {
+                    "type": "string"
+                },
+                "reporterId": {
+                    "type": "string"
+                },
+                "resolution": {
+                    "type": "string"
+                },
+                "resolutionNote": {
+                    "type": "string"
+                },
+                "resolvedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
                 "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "response.DeepHealthResponse": {
+        "response.RequestEmailChangeResponse": {
             "type": "object",
             "properties": {
-                "message": {
+                "expiresAt": {
                     "type": "string"
                 },
-                "status": {
+                "id": {
+                    "type": "string"
+                },
+                "pendingEmail": {
+                    "type": "string"
+                },
+                "undoExpiresAt": {
                     "type": "string"
                 }
             }
         },
-        "response.DeleteUserResponse": {
+        "response.RequestEmailVerificationResponse": {
             "type": "object",
             "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 }
             }
         },
-        "response.GetUserResponse": {
+        "response.ResolveReportResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "report": {
+                    "$ref": "#/definitions/response.ReportResponse"
+                },
+                "suspendedUser": {
+                    "description": "SuspendedUser is the reported user suspended by the resolution, if any.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "response.SearchAuditLogsResponse": {
+            "type": "object",
+            "properties": {
+                "auditLogs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AuditLogResponse"
+                    }
+                }
+            }
+        },
+        "response.SendChatMessageResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
                     "type": "string"
                 },
-                "email": {
+                "conversationId": {
+                    "type": "string"
+                },
+                "createdAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "readAt": {
+                    "type": "string"
+                },
+                "senderId": {
                     "type": "string"
                 }
             }
         },
-        "response.HealthResponse": {
+        "response.UnblockUserResponse": {
             "type": "object",
             "properties": {
-                "status": {
+                "blockedId": {
+                    "type": "string"
+                },
+                "blockerId": {
                     "type": "string"
                 }
             }
         },
-        "response.ListUsersResponse": {
+        "response.UndoEmailChangeResponse": {
             "type": "object",
             "properties": {
-                "page": {
-                    "type": "integer"
+                "bio": {
+                    "type": "string"
                 },
-                "pageSize": {
-                    "type": "integer"
+                "birthdate": {
+                    "type": "string",
+                    "example": "2000-01-31"
                 },
-                "total": {
-                    "type": "integer"
+                "createdAt": {
+                    "type": "string"
                 },
-                "totalPage": {
-                    "type": "integer"
+                "displayName": {
+                    "type": "string"
                 },
-                "users": {
+                "email": {
+                    "type": "string"
+                },
+                "emailVerifiedAt": {
+                    "description": "EmailVerifiedAt is omitted until the user verifies the email.",
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interests": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.UserResponse"
+                        "type": "string"
                     }
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "pendingEmail": {
+                    "description": "PendingEmail is the new email waiting for confirmation, omitted when no change is pending.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
         "response.UpdateUserResponse": {
             "type": "object",
             "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "birthdate": {
+                    "type": "string",
+                    "example": "2000-01-31"
+                },
                 "createdAt": {
                     "type": "string"
                 },
+                "displayName": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
+                "emailVerifiedAt": {
+                    "description": "EmailVerifiedAt is omitted until the user verifies the email.",
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "pendingEmail": {
+                    "description": "PendingEmail is the new email waiting for confirmation, omitted when no change is pending.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
                 "updatedAt": {
                     "type": "string"
                 }
             }
         },
+        "response.UserBlockResponse": {
+            "type": "object",
+            "properties": {
+                "blockedId": {
+                    "type": "string"
+                },
+                "blockerId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                }
+            }
+        },
         "response.UserResponse": {
             "type": "object",
             "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "birthdate": {
+                    "type": "string",
+                    "example": "2000-01-31"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "emailVerifiedAt": {
+                    "description": "EmailVerifiedAt is omitted until the user verifies the email.",
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "pendingEmail": {
+                    "description": "PendingEmail is the new email waiting for confirmation, omitted when no change is pending.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.VerifyEmailResponse": {
+            "type": "object",
+            "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "birthdate": {
+                    "type": "string",
+                    "example": "2000-01-31"
+                },
                 "createdAt": {
                     "type": "string"
                 },
+                "displayName": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
+                "emailVerifiedAt": {
+                    "description": "EmailVerifiedAt is omitted until the user verifies the email.",
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "pendingEmail": {
+                    "description": "PendingEmail is the new email waiting for confirmation, omitted when no change is pending.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
                 "updatedAt": {
                     "type": "string"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "A JWT signed with AUTH_JWT_SECRET, as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 